@@ -0,0 +1,113 @@
+// Package billing provides typed helpers for cost reporting with the OVH
+// API: the details and PDF invoices of the bills of the account, listed and
+// fetched with the me package, the consumption of the current period under
+// /me/consumption/usage/current and the usage of Public Cloud projects under
+// /cloud/project/{serviceName}/usage/current.
+package billing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/me"
+	"github.com/ovh/go-ovh/order"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// BillDetail represents a line of a bill.
+// Visit https://api.ovh.com/console/#/me/bill/%7BbillId%7D/details/%7BbillDetailId%7D#GET for the full definition
+type BillDetail struct {
+	BillDetailID string `json:"billDetailId"`
+	Description  string `json:"description"`
+	// Domain is the service billed
+	Domain      string      `json:"domain"`
+	Quantity    string      `json:"quantity"`
+	UnitPrice   order.Price `json:"unitPrice"`
+	TotalPrice  order.Price `json:"totalPrice"`
+	PeriodStart *time.Time  `json:"periodStart"`
+	PeriodEnd   *time.Time  `json:"periodEnd"`
+}
+
+// Client gives access to the billing routes
+type Client struct {
+	client *ovh.Client
+	me     *me.Client
+}
+
+// New returns a billing client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client, me: me.New(client)}
+}
+
+// billPath returns the path of a route of a bill
+func billPath(billID, format string, args ...interface{}) string {
+	return fmt.Sprintf("/me/bill/%s", url.PathEscape(billID)) + fmt.Sprintf(format, args...)
+}
+
+// BillDetails returns the lines of a bill, with GET /me/bill/{billId}/details
+// and GET /me/bill/{billId}/details/{billDetailId} for each line
+func (c *Client) BillDetails(ctx context.Context, billID string) ([]BillDetail, error) {
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, billPath(billID, "/details"), &ids); err != nil {
+		return nil, err
+	}
+
+	details := make([]BillDetail, len(ids))
+	for i, id := range ids {
+		if err := c.client.GetWithContext(ctx, billPath(billID, "/details/%s", url.PathEscape(id)), &details[i]); err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// DownloadInvoice writes the PDF invoice of a bill to "w". The PDF is served
+// outside of the API, from the pdfUrl of the bill, with the HTTP client of
+// the API client.
+func (c *Client) DownloadInvoice(ctx context.Context, billID string, w io.Writer) error {
+	bill, err := c.me.Bill(ctx, billID)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", bill.PdfURL, nil)
+	if err != nil {
+		return err
+	}
+	response, err := c.client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("billing: unable to download the invoice of bill %s: %s", billID, response.Status)
+	}
+	_, err = io.Copy(w, response.Body)
+	return err
+}
+
+// CostsByService returns the costs without tax of the bills issued between
+// "from" and "to", summed by billed service, from the lines of the bills.
+// Lines without service are summed under an empty name.
+func (c *Client) CostsByService(ctx context.Context, from, to time.Time) (map[string]float64, error) {
+	ids, err := c.me.BillIDs(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := map[string]float64{}
+	for _, id := range ids {
+		details, err := c.BillDetails(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, detail := range details {
+			costs[detail.Domain] += detail.TotalPrice.Value
+		}
+	}
+	return costs, nil
+}
@@ -0,0 +1,76 @@
+package billing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestCostsByService(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/bill", http.StatusOK, `["FR1", "FR2"]`)
+	server.Handle("GET", "/me/bill/FR1/details", http.StatusOK, `["FR1-1", "FR1-2"]`)
+	server.Handle("GET", "/me/bill/FR1/details/FR1-1", http.StatusOK, `{"billDetailId": "FR1-1", "domain": "ns1.example.net", "quantity": "1", "totalPrice": {"value": 30}}`)
+	server.Handle("GET", "/me/bill/FR1/details/FR1-2", http.StatusOK, `{"billDetailId": "FR1-2", "domain": "example.com", "quantity": "1", "totalPrice": {"value": 8.5}}`)
+	server.Handle("GET", "/me/bill/FR2/details", http.StatusOK, `["FR2-1"]`)
+	server.Handle("GET", "/me/bill/FR2/details/FR2-1", http.StatusOK, `{"billDetailId": "FR2-1", "domain": "ns1.example.net", "quantity": "1", "totalPrice": {"value": 30}}`)
+
+	// Test
+	costs, err := client.CostsByService(context.Background(), time.Time{}, time.Time{})
+
+	// Validate
+	if err != nil {
+		t.Fatalf("CostsByService should not return an error. Got %v", err)
+	}
+	if fmt.Sprint(costs) != "map[example.com:8.5 ns1.example.net:60]" {
+		t.Fatalf("CostsByService should sum the costs by service. Got %v", costs)
+	}
+}
+
+func TestDownloadInvoice(t *testing.T) {
+	// Init test: invoices are served outside of the API
+	invoices := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("reference") != "FR123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer invoices.Close()
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/bill/FR123", http.StatusOK, fmt.Sprintf(`{"billId": "FR123", "pdfUrl": "%s/facture.pdf?reference=FR123"}`, invoices.URL))
+	server.Handle("GET", "/me/bill/FR404", http.StatusOK, fmt.Sprintf(`{"billId": "FR404", "pdfUrl": "%s/facture.pdf?reference=FR404"}`, invoices.URL))
+	ctx := context.Background()
+
+	// Test
+	var pdf bytes.Buffer
+	err := client.DownloadInvoice(ctx, "FR123", &pdf)
+	errMissing := client.DownloadInvoice(ctx, "FR404", &bytes.Buffer{})
+
+	// Validate
+	if err != nil || pdf.String() != "%PDF-1.4" {
+		t.Fatalf("DownloadInvoice should write the PDF. Got %q, %v", pdf.String(), err)
+	}
+	if errMissing == nil {
+		t.Fatalf("DownloadInvoice should fail when the PDF can not be downloaded")
+	}
+}
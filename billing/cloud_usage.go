@@ -0,0 +1,66 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// CloudUsage represents the usage of a Public Cloud project over the current
+// period. The hourly and monthly usages are grouped by resource type, like
+// "instance", "storage" or "volume".
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/usage/current#GET for the full definition
+type CloudUsage struct {
+	Period       CloudUsagePeriod            `json:"period"`
+	HourlyUsage  map[string][]CloudUsageItem `json:"hourlyUsage"`
+	MonthlyUsage map[string][]CloudUsageItem `json:"monthlyUsage"`
+	LastUpdate   time.Time                   `json:"lastUpdate"`
+}
+
+// CloudUsagePeriod is the period of a CloudUsage
+type CloudUsagePeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// CloudUsageItem represents the usage of a type of resources in a region
+type CloudUsageItem struct {
+	Region     string  `json:"region"`
+	Reference  string  `json:"reference"`
+	TotalPrice float64 `json:"totalPrice"`
+}
+
+// CloudProjectUsage returns the usage of a Public Cloud project over the
+// current period, with GET /cloud/project/{serviceName}/usage/current
+func (c *Client) CloudProjectUsage(ctx context.Context, serviceName string) (*CloudUsage, error) {
+	usage := &CloudUsage{}
+	path := fmt.Sprintf("/cloud/project/%s/usage/current", url.PathEscape(serviceName))
+	if err := c.client.GetWithContext(ctx, path, usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// Total returns the price of the whole usage
+func (u *CloudUsage) Total() float64 {
+	var total float64
+	for _, costs := range u.ByResourceType() {
+		total += costs
+	}
+	return total
+}
+
+// ByResourceType sums the prices of the hourly and monthly usage by resource
+// type
+func (u *CloudUsage) ByResourceType() map[string]float64 {
+	costs := map[string]float64{}
+	for _, usage := range []map[string][]CloudUsageItem{u.HourlyUsage, u.MonthlyUsage} {
+		for resourceType, items := range usage {
+			for _, item := range items {
+				costs[resourceType] += item.TotalPrice
+			}
+		}
+	}
+	return costs
+}
@@ -0,0 +1,36 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCloudProjectUsage(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/0123abcd/usage/current", http.StatusOK, `{
+		"period": {"from": "2024-03-01T00:00:00+01:00", "to": "2024-03-15T00:00:00+01:00"},
+		"hourlyUsage": {
+			"instance": [{"region": "GRA11", "reference": "b2-7", "totalPrice": 10.25}, {"region": "SBG5", "reference": "b2-7", "totalPrice": 4.75}],
+			"storage": [{"region": "GRA", "totalPrice": 1}]
+		},
+		"monthlyUsage": {"instance": [{"region": "GRA11", "reference": "d2-2", "totalPrice": 3}]}
+	}`)
+
+	// Test
+	usage, err := client.CloudProjectUsage(context.Background(), "0123abcd")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("CloudProjectUsage should not return an error. Got %v", err)
+	}
+	if costs := usage.ByResourceType(); fmt.Sprint(costs) != "map[instance:18 storage:1]" {
+		t.Fatalf("ByResourceType should sum the hourly and monthly costs. Got %v", costs)
+	}
+	if usage.Total() != 19 {
+		t.Fatalf("Total should sum all the costs. Got %v", usage.Total())
+	}
+}
@@ -0,0 +1,52 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/go-ovh/order"
+)
+
+// ConsumptionTransaction represents the consumption of a service over the
+// current period.
+// Visit https://api.ovh.com/console/#/me/consumption/usage/current#GET for the full definition
+type ConsumptionTransaction struct {
+	ID           int64                `json:"id"`
+	ServiceID    int64                `json:"serviceId"`
+	BeginDate    time.Time            `json:"beginDate"`
+	EndDate      *time.Time           `json:"endDate"`
+	CreationDate time.Time            `json:"creationDate"`
+	LastUpdate   time.Time            `json:"lastUpdate"`
+	Price        order.Price          `json:"price"`
+	Elements     []ConsumptionElement `json:"elements"`
+}
+
+// ConsumptionElement represents the consumption of a plan of a service
+type ConsumptionElement struct {
+	PlanCode   string      `json:"planCode"`
+	PlanFamily string      `json:"planFamily"`
+	Quantity   float64     `json:"quantity"`
+	Price      order.Price `json:"price"`
+}
+
+// CurrentConsumption returns the consumption of the services over the
+// current period, with GET /me/consumption/usage/current
+func (c *Client) CurrentConsumption(ctx context.Context) ([]ConsumptionTransaction, error) {
+	transactions := []ConsumptionTransaction{}
+	if err := c.client.GetWithContext(ctx, "/me/consumption/usage/current", &transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// ConsumptionByPlanFamily sums the price of consumption elements by plan
+// family, like "instance" or "storage"
+func ConsumptionByPlanFamily(transactions []ConsumptionTransaction) map[string]float64 {
+	costs := map[string]float64{}
+	for _, transaction := range transactions {
+		for _, element := range transaction.Elements {
+			costs[element.PlanFamily] += element.Price.Value
+		}
+	}
+	return costs
+}
@@ -0,0 +1,38 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCurrentConsumption(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/consumption/usage/current", http.StatusOK, `[
+		{"id": 1, "serviceId": 42, "beginDate": "2024-03-01T00:00:00+01:00", "price": {"value": 12.5}, "elements": [
+			{"planCode": "b2-7.consumption", "planFamily": "instance", "quantity": 100, "price": {"value": 10}},
+			{"planCode": "storage.consumption", "planFamily": "storage", "quantity": 50, "price": {"value": 2.5}}
+		]},
+		{"id": 2, "serviceId": 43, "beginDate": "2024-03-01T00:00:00+01:00", "price": {"value": 5}, "elements": [
+			{"planCode": "d2-2.consumption", "planFamily": "instance", "quantity": 10, "price": {"value": 5}}
+		]}
+	]`)
+
+	// Test
+	transactions, err := client.CurrentConsumption(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentConsumption should not return an error. Got %v", err)
+	}
+	costs := ConsumptionByPlanFamily(transactions)
+
+	// Validate
+	if len(transactions) != 2 || transactions[0].ServiceID != 42 || transactions[0].Elements[1].Quantity != 50 {
+		t.Fatalf("Transactions should be decoded. Got %+v", transactions)
+	}
+	if fmt.Sprint(costs) != "map[instance:15 storage:2.5]" {
+		t.Fatalf("ConsumptionByPlanFamily should sum the costs by plan family. Got %v", costs)
+	}
+}
@@ -0,0 +1,237 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Volume snapshot statuses
+const (
+	VolumeSnapshotStatusCreating  = "creating"
+	VolumeSnapshotStatusAvailable = "available"
+	VolumeSnapshotStatusError     = "error"
+)
+
+// VolumeSnapshot represents a snapshot of a block storage volume. Size is in
+// GB.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/volume/snapshot/%7BsnapshotId%7D#GET for the full definition
+type VolumeSnapshot struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	VolumeID     string    `json:"volumeId"`
+	Region       string    `json:"region"`
+	Size         int       `json:"size"`
+	Status       string    `json:"status"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+// VolumeSnapshotSchedule configures RunVolumeSnapshotSchedule
+type VolumeSnapshotSchedule struct {
+	// Name prefixes the names of the snapshots, followed by their UTC
+	// creation time, like "daily-20200101-100000"
+	Name string
+
+	// Every is the delay between two snapshots
+	Every time.Duration
+
+	// Keep is the number of snapshots kept, the older ones being deleted.
+	// All snapshots are kept when zero.
+	Keep int
+
+	// OnSnapshot, when set, is called after each snapshot, with the error
+	// which occurred, if any. The schedule stops on the first error otherwise.
+	OnSnapshot func(snapshot *VolumeSnapshot, err error)
+}
+
+// Quotas represents the quotas of a project in a region.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/quota#GET for the full definition
+type Quotas struct {
+	Region   string         `json:"region"`
+	Instance InstanceQuotas `json:"instance"`
+	Volume   VolumeQuotas   `json:"volume"`
+}
+
+// InstanceQuotas represents the instance quotas of a project in a region.
+// RAM is in MB.
+type InstanceQuotas struct {
+	MaxCores      int `json:"maxCores"`
+	MaxInstances  int `json:"maxInstances"`
+	MaxRAM        int `json:"maxRam"`
+	UsedCores     int `json:"usedCores"`
+	UsedInstances int `json:"usedInstances"`
+	UsedRAM       int `json:"usedRAM"`
+}
+
+// VolumeQuotas represents the block storage quotas of a project in a
+// region. Sizes are in GB.
+type VolumeQuotas struct {
+	MaxGigabytes         int `json:"maxGigabytes"`
+	UsedGigabytes        int `json:"usedGigabytes"`
+	MaxVolumeCount       int `json:"maxVolumeCount"`
+	VolumeCount          int `json:"volumeCount"`
+	MaxBackupGigabytes   int `json:"maxBackupGigabytes"`
+	UsedBackupGigabytes  int `json:"usedBackupGigabytes"`
+	MaxVolumeBackupCount int `json:"maxVolumeBackupCount"`
+	VolumeBackupCount    int `json:"volumeBackupCount"`
+}
+
+// VolumeSnapshots lists the volume snapshots of a project, with
+// GET /cloud/project/{serviceName}/volume/snapshot
+func (c *Client) VolumeSnapshots(ctx context.Context, serviceName string) ([]VolumeSnapshot, error) {
+	snapshots := []VolumeSnapshot{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/volume/snapshot"), &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// VolumeSnapshot returns a volume snapshot, with
+// GET /cloud/project/{serviceName}/volume/snapshot/{snapshotId}
+func (c *Client) VolumeSnapshot(ctx context.Context, serviceName, snapshotID string) (*VolumeSnapshot, error) {
+	snapshot := &VolumeSnapshot{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/volume/snapshot/%s", url.PathEscape(snapshotID)), snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CreateVolumeSnapshot snapshots a volume, with
+// POST /cloud/project/{serviceName}/volume/{volumeId}/snapshot
+func (c *Client) CreateVolumeSnapshot(ctx context.Context, serviceName, volumeID, name, description string) (*VolumeSnapshot, error) {
+	snapshot := &VolumeSnapshot{}
+	body := map[string]string{"name": name}
+	if description != "" {
+		body["description"] = description
+	}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/volume/%s/snapshot", url.PathEscape(volumeID)), body, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// DeleteVolumeSnapshot deletes a volume snapshot, with
+// DELETE /cloud/project/{serviceName}/volume/snapshot/{snapshotId}
+func (c *Client) DeleteVolumeSnapshot(ctx context.Context, serviceName, snapshotID string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/volume/snapshot/%s", url.PathEscape(snapshotID)), nil)
+}
+
+// WaitVolumeSnapshot polls a volume snapshot every "interval",
+// DefaultPollInterval if not positive, until it is available. It fails if the
+// snapshot reaches the error status instead, or when the context is done.
+func (c *Client) WaitVolumeSnapshot(ctx context.Context, serviceName, snapshotID string, interval time.Duration) (*VolumeSnapshot, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		snapshot, err := c.VolumeSnapshot(ctx, serviceName, snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Status == VolumeSnapshotStatusAvailable {
+			return snapshot, nil
+		}
+		if snapshot.Status == VolumeSnapshotStatusError {
+			return snapshot, fmt.Errorf("volume snapshot %s is in %s status", snapshotID, snapshot.Status)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return snapshot, ctx.Err()
+		}
+	}
+}
+
+// RotateVolumeSnapshots snapshots a volume, waits for the snapshot to be
+// available, polling it every "interval", then deletes the oldest snapshots
+// of the volume named after "name" so that only "keep" of them remain. See
+// VolumeSnapshotSchedule for the naming of the snapshots.
+func (c *Client) RotateVolumeSnapshots(ctx context.Context, serviceName, volumeID, name string, keep int, interval time.Duration) (*VolumeSnapshot, error) {
+	snapshotName := name + "-" + time.Now().UTC().Format("20060102-150405")
+	snapshot, err := c.CreateVolumeSnapshot(ctx, serviceName, volumeID, snapshotName, "")
+	if err != nil {
+		return nil, err
+	}
+	if snapshot, err = c.WaitVolumeSnapshot(ctx, serviceName, snapshot.ID, interval); err != nil {
+		return snapshot, err
+	}
+	if keep <= 0 {
+		return snapshot, nil
+	}
+
+	snapshots, err := c.VolumeSnapshots(ctx, serviceName)
+	if err != nil {
+		return snapshot, err
+	}
+	rotated := []VolumeSnapshot{}
+	for _, s := range snapshots {
+		if s.VolumeID == volumeID && strings.HasPrefix(s.Name, name+"-") {
+			rotated = append(rotated, s)
+		}
+	}
+	sort.Slice(rotated, func(i, j int) bool {
+		return rotated[i].CreationDate.After(rotated[j].CreationDate)
+	})
+	for i := keep; i < len(rotated); i++ {
+		if err := c.DeleteVolumeSnapshot(ctx, serviceName, rotated[i].ID); err != nil {
+			return snapshot, err
+		}
+	}
+	return snapshot, nil
+}
+
+// RunVolumeSnapshotSchedule snapshots a volume right away, then every
+// schedule.Every, rotating its snapshots with RotateVolumeSnapshots, until the
+// context is done or a snapshot fails without schedule.OnSnapshot
+func (c *Client) RunVolumeSnapshotSchedule(ctx context.Context, serviceName, volumeID string, schedule VolumeSnapshotSchedule) error {
+	if schedule.Every <= 0 {
+		return fmt.Errorf("volume snapshot schedule of %s has no period", volumeID)
+	}
+
+	ticker := time.NewTicker(schedule.Every)
+	defer ticker.Stop()
+	for {
+		// The ticker may be ready along with the context, do not snapshot then
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		snapshot, err := c.RotateVolumeSnapshots(ctx, serviceName, volumeID, schedule.Name, schedule.Keep, 0)
+		if schedule.OnSnapshot != nil {
+			schedule.OnSnapshot(snapshot, err)
+		} else if err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Quotas lists the quotas of a project in all its regions, with
+// GET /cloud/project/{serviceName}/quota
+func (c *Client) Quotas(ctx context.Context, serviceName string) ([]Quotas, error) {
+	quotas := []Quotas{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/quota"), &quotas); err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
+
+// RegionQuotas returns the quotas of a project in a region, with
+// GET /cloud/project/{serviceName}/region/{regionName}/quota
+func (c *Client) RegionQuotas(ctx context.Context, serviceName, region string) (*Quotas, error) {
+	quotas := &Quotas{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/region/%s/quota", url.PathEscape(region)), quotas); err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
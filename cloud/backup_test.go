@@ -0,0 +1,137 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVolumeSnapshots(t *testing.T) {
+	// Init test
+	snapshot := `{"id": "vsnap-1", "name": "before-upgrade", "description": "", "volumeId": "vol-1", "region": "GRA7", "size": 10, "status": "%s", "creationDate": "2020-01-01T10:00:00Z"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/cloud/project/abc123/volume/vol-1/snapshot", http.StatusOK, strings.Replace(snapshot, "%s", "creating", 1))
+	server.HandleSequence("GET", "/cloud/project/abc123/volume/snapshot/vsnap-1", http.StatusOK, strings.Replace(snapshot, "%s", "creating", 1), strings.Replace(snapshot, "%s", "available", 1))
+	server.Handle("GET", "/cloud/project/abc123/volume/snapshot", http.StatusOK, "["+strings.Replace(snapshot, "%s", "available", 1)+"]")
+	server.Handle("DELETE", "/cloud/project/abc123/volume/snapshot/vsnap-1", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateVolumeSnapshot(ctx, "abc123", "vol-1", "before-upgrade", "")
+	if err != nil {
+		t.Fatalf("CreateVolumeSnapshot should not return an error. Got %v", err)
+	}
+	available, err := client.WaitVolumeSnapshot(ctx, "abc123", created.ID, time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitVolumeSnapshot should not return an error. Got %v", err)
+	}
+	snapshots, err := client.VolumeSnapshots(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("VolumeSnapshots should not return an error. Got %v", err)
+	}
+	if err := client.DeleteVolumeSnapshot(ctx, "abc123", "vsnap-1"); err != nil {
+		t.Fatalf("DeleteVolumeSnapshot should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if available.Status != VolumeSnapshotStatusAvailable || len(snapshots) != 1 || snapshots[0].VolumeID != "vol-1" || snapshots[0].Size != 10 {
+		t.Fatalf("Volume snapshots should be decoded. Got %+v, %+v", available, snapshots)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/volume/vol-1/snapshot"); string(request.Body) != `{"name":"before-upgrade"}` {
+		t.Fatalf("CreateVolumeSnapshot should send the snapshot name. Got %s", request.Body)
+	}
+}
+
+func TestRotateVolumeSnapshots(t *testing.T) {
+	// Init test: 3 daily snapshots of vol-1 once the new one is created
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/cloud/project/abc123/volume/vol-1/snapshot", http.StatusOK, `{"id": "new", "volumeId": "vol-1", "status": "creating"}`)
+	server.Handle("GET", "/cloud/project/abc123/volume/snapshot/new", http.StatusOK, `{"id": "new", "volumeId": "vol-1", "status": "available"}`)
+	server.Handle("GET", "/cloud/project/abc123/volume/snapshot", http.StatusOK, `[
+			{"id": "old", "name": "daily-20200101-100000", "volumeId": "vol-1", "creationDate": "2020-01-01T10:00:00Z"},
+			{"id": "new", "name": "daily-20200103-100000", "volumeId": "vol-1", "creationDate": "2020-01-03T10:00:00Z"},
+			{"id": "recent", "name": "daily-20200102-100000", "volumeId": "vol-1", "creationDate": "2020-01-02T10:00:00Z"},
+			{"id": "weekly", "name": "weekly-20190101-100000", "volumeId": "vol-1", "creationDate": "2019-01-01T10:00:00Z"},
+			{"id": "other", "name": "daily-20190101-100000", "volumeId": "vol-2", "creationDate": "2019-01-01T10:00:00Z"}
+		]`)
+	server.Handle("DELETE", "/cloud/project/abc123/volume/snapshot/old", http.StatusOK, `null`)
+
+	// Test
+	snapshot, err := client.RotateVolumeSnapshots(context.Background(), "abc123", "vol-1", "daily", 2, time.Millisecond)
+
+	// Validate
+	if err != nil || snapshot.ID != "new" {
+		t.Fatalf("RotateVolumeSnapshots should return the new snapshot. Got %+v, %v", snapshot, err)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/volume/vol-1/snapshot"); !strings.HasPrefix(string(request.Body), `{"name":"daily-`) {
+		t.Fatalf("RotateVolumeSnapshots should name the snapshot after its schedule. Got %s", request.Body)
+	}
+	if _, ok := server.LastRequest("DELETE", "/cloud/project/abc123/volume/snapshot/old"); !ok {
+		t.Fatalf("RotateVolumeSnapshots should delete the oldest snapshot")
+	}
+}
+
+func TestRunVolumeSnapshotSchedule(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/cloud/project/abc123/volume/vol-1/snapshot", http.StatusOK, `{"id": "new", "volumeId": "vol-1", "status": "creating"}`)
+	server.Handle("GET", "/cloud/project/abc123/volume/snapshot/new", http.StatusOK, `{"id": "new", "volumeId": "vol-1", "status": "available"}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Test
+	count := 0
+	err := client.RunVolumeSnapshotSchedule(ctx, "abc123", "vol-1", VolumeSnapshotSchedule{
+		Name:  "hourly",
+		Every: time.Millisecond,
+		OnSnapshot: func(snapshot *VolumeSnapshot, err error) {
+			if err != nil {
+				t.Fatalf("Scheduled snapshots should not fail. Got %v", err)
+			}
+			if count++; count == 2 {
+				cancel()
+			}
+		},
+	})
+
+	// Validate
+	if err != context.Canceled || count != 2 {
+		t.Fatalf("Schedule should run until the context is canceled. Got %v after %d snapshots", err, count)
+	}
+	if err := client.RunVolumeSnapshotSchedule(ctx, "abc123", "vol-1", VolumeSnapshotSchedule{Name: "hourly"}); err == nil {
+		t.Fatalf("Schedule without period should fail")
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	// Init test
+	quotas := `{"region": "GRA7", "instance": {"maxCores": 20, "maxInstances": 10, "maxRam": 40960, "usedCores": 4, "usedInstances": 2, "usedRAM": 8192}, "volume": {"maxGigabytes": 10000, "usedGigabytes": 20, "maxVolumeCount": 100, "volumeCount": 2, "maxBackupGigabytes": 10000, "usedBackupGigabytes": 10, "maxVolumeBackupCount": 100, "volumeBackupCount": 1}}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/quota", http.StatusOK, "["+quotas+"]")
+	server.Handle("GET", "/cloud/project/abc123/region/GRA7/quota", http.StatusOK, quotas)
+	ctx := context.Background()
+
+	// Test
+	all, err := client.Quotas(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("Quotas should not return an error. Got %v", err)
+	}
+	region, err := client.RegionQuotas(ctx, "abc123", "GRA7")
+	if err != nil {
+		t.Fatalf("RegionQuotas should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(all) != 1 || all[0].Instance.MaxRAM != 40960 || all[0].Volume.UsedBackupGigabytes != 10 {
+		t.Fatalf("Quotas should decode the response. Got %+v", all)
+	}
+	if region.Region != "GRA7" || region.Volume.MaxGigabytes != 10000 || region.Volume.VolumeBackupCount != 1 {
+		t.Fatalf("RegionQuotas should decode the response. Got %+v", region)
+	}
+}
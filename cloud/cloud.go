@@ -0,0 +1,59 @@
+// Package cloud provides typed helpers for the OVH Public Cloud API, under
+// /cloud/project: projects, quotas, instances, volumes, snapshots and their
+// schedules, private networks, Managed Kubernetes clusters and object
+// storage, with its S3 and Swift credentials.
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Project represents a Public Cloud project.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D#GET for the full definition
+type Project struct {
+	ProjectID    string    `json:"project_id"`
+	ProjectName  string    `json:"projectName"`
+	Description  string    `json:"description"`
+	Status       string    `json:"status"`
+	CreationDate time.Time `json:"creationDate"`
+	Expiration   time.Time `json:"expiration"`
+	Access       string    `json:"access"`
+}
+
+// Client gives access to the /cloud/project routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a Public Cloud client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// projectPath returns the path of a route of a project
+func projectPath(serviceName, format string, args ...interface{}) string {
+	return fmt.Sprintf("/cloud/project/%s", url.PathEscape(serviceName)) + fmt.Sprintf(format, args...)
+}
+
+// ProjectIDs lists the IDs of the projects, with GET /cloud/project
+func (c *Client) ProjectIDs(ctx context.Context) ([]string, error) {
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, "/cloud/project", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Project returns a project, with GET /cloud/project/{serviceName}
+func (c *Client) Project(ctx context.Context, serviceName string) (*Project, error) {
+	project := &Project{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, ""), project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
@@ -0,0 +1,45 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestProjects(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project", http.StatusOK, `["abc123"]`)
+	server.Handle("GET", "/cloud/project/abc123", http.StatusOK, `{"project_id": "abc123", "projectName": "prod", "description": "Production", "status": "ok", "creationDate": "2020-01-01T10:00:00+01:00", "access": "full"}`)
+
+	// Test
+	ids, err := client.ProjectIDs(context.Background())
+	if err != nil {
+		t.Fatalf("ProjectIDs should not return an error. Got %v", err)
+	}
+	project, err := client.Project(context.Background(), ids[0])
+	if err != nil {
+		t.Fatalf("Project should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if !reflect.DeepEqual(ids, []string{"abc123"}) {
+		t.Fatalf("ProjectIDs should return the project IDs. Got %v", ids)
+	}
+	if project.ProjectID != "abc123" || project.ProjectName != "prod" || project.Status != "ok" || project.CreationDate.Year() != 2020 {
+		t.Fatalf("Project should decode the response. Got %+v", project)
+	}
+}
@@ -0,0 +1,245 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Instance statuses
+const (
+	InstanceStatusActive  = "ACTIVE"
+	InstanceStatusBuild   = "BUILD"
+	InstanceStatusError   = "ERROR"
+	InstanceStatusReboot  = "REBOOT"
+	InstanceStatusResize  = "RESIZE"
+	InstanceStatusShutoff = "SHUTOFF"
+	InstanceStatusDeleted = "DELETED"
+)
+
+// DefaultPollInterval is the delay between two status checks of the Wait
+// helpers when no explicit interval is given
+const DefaultPollInterval = 5 * time.Second
+
+// WaitTimeoutError is returned by the AndWait helpers when an instance does
+// not reach the expected status within their timeout. It wraps
+// context.DeadlineExceeded.
+type WaitTimeoutError struct {
+	InstanceID string
+	Status     string
+	Timeout    time.Duration
+	// Instance is the last state of the instance, if it could be fetched
+	Instance *Instance
+}
+
+func (err *WaitTimeoutError) Error() string {
+	status := "unknown"
+	if err.Instance != nil {
+		status = err.Instance.Status
+	}
+	return fmt.Sprintf("instance %s did not reach %s status within %s (last status %s)", err.InstanceID, err.Status, err.Timeout, status)
+}
+
+// Unwrap returns context.DeadlineExceeded
+func (err *WaitTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// IPAddress represents an IP address of an instance
+type IPAddress struct {
+	IP        string `json:"ip"`
+	Type      string `json:"type"`
+	Version   int    `json:"version"`
+	NetworkID string `json:"networkId"`
+	GatewayIP string `json:"gatewayIp"`
+}
+
+// Instance represents a Public Cloud instance.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/instance/%7BinstanceId%7D#GET for the full definition
+type Instance struct {
+	ID             string      `json:"id"`
+	Name           string      `json:"name"`
+	Status         string      `json:"status"`
+	Region         string      `json:"region"`
+	FlavorID       string      `json:"flavorId"`
+	ImageID        string      `json:"imageId"`
+	SSHKeyID       string      `json:"sshKeyId"`
+	Created        time.Time   `json:"created"`
+	IPAddresses    []IPAddress `json:"ipAddresses"`
+	MonthlyBilling interface{} `json:"monthlyBilling"`
+}
+
+// InstanceNetwork attaches a new instance to a private network
+type InstanceNetwork struct {
+	NetworkID string `json:"networkId"`
+	IP        string `json:"ip,omitempty"`
+}
+
+// InstanceCreation holds the parameters of a new instance
+type InstanceCreation struct {
+	Name           string            `json:"name"`
+	FlavorID       string            `json:"flavorId"`
+	ImageID        string            `json:"imageId,omitempty"`
+	Region         string            `json:"region"`
+	SSHKeyID       string            `json:"sshKeyId,omitempty"`
+	Networks       []InstanceNetwork `json:"networks,omitempty"`
+	UserData       string            `json:"userData,omitempty"`
+	MonthlyBilling bool              `json:"monthlyBilling,omitempty"`
+}
+
+// Instances lists the instances of a project, with
+// GET /cloud/project/{serviceName}/instance. An empty "region" lists all
+// regions.
+func (c *Client) Instances(ctx context.Context, serviceName, region string) ([]Instance, error) {
+	path := projectPath(serviceName, "/instance")
+	if region != "" {
+		path += "?region=" + url.QueryEscape(region)
+	}
+
+	instances := []Instance{}
+	if err := c.client.GetWithContext(ctx, path, &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// Instance returns an instance, with
+// GET /cloud/project/{serviceName}/instance/{instanceId}
+func (c *Client) Instance(ctx context.Context, serviceName, instanceID string) (*Instance, error) {
+	instance := &Instance{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/instance/%s", url.PathEscape(instanceID)), instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// CreateInstance creates an instance, with
+// POST /cloud/project/{serviceName}/instance. The instance is returned while
+// still being built, see WaitInstanceStatus.
+func (c *Client) CreateInstance(ctx context.Context, serviceName string, creation InstanceCreation) (*Instance, error) {
+	instance := &Instance{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/instance"), creation, instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// DeleteInstance deletes an instance, with
+// DELETE /cloud/project/{serviceName}/instance/{instanceId}
+func (c *Client) DeleteInstance(ctx context.Context, serviceName, instanceID string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/instance/%s", url.PathEscape(instanceID)), nil)
+}
+
+// RebootInstance reboots an instance, with
+// POST /cloud/project/{serviceName}/instance/{instanceId}/reboot. A hard
+// reboot is a power cycle, a soft one a clean reboot of the operating system.
+func (c *Client) RebootInstance(ctx context.Context, serviceName, instanceID string, hard bool) error {
+	rebootType := "soft"
+	if hard {
+		rebootType = "hard"
+	}
+	body := map[string]string{"type": rebootType}
+	return c.client.PostWithContext(ctx, projectPath(serviceName, "/instance/%s/reboot", url.PathEscape(instanceID)), body, nil)
+}
+
+// ResizeInstance changes the flavor of an instance, with
+// POST /cloud/project/{serviceName}/instance/{instanceId}/resize
+func (c *Client) ResizeInstance(ctx context.Context, serviceName, instanceID, flavorID string) (*Instance, error) {
+	instance := &Instance{}
+	body := map[string]string{"flavorId": flavorID}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/instance/%s/resize", url.PathEscape(instanceID)), body, instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// WaitInstanceStatus polls an instance every "interval", DefaultPollInterval
+// if not positive, until it reaches "status". It fails if the instance
+// reaches the ERROR status instead, or when the context is done.
+func (c *Client) WaitInstanceStatus(ctx context.Context, serviceName, instanceID, status string, interval time.Duration) (*Instance, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		instance, err := c.Instance(ctx, serviceName, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		if instance.Status == status {
+			return instance, nil
+		}
+		if instance.Status == InstanceStatusError {
+			return instance, fmt.Errorf("instance %s is in %s status", instanceID, instance.Status)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return instance, ctx.Err()
+		}
+	}
+}
+
+// CreateInstanceAndWait creates an instance and waits for it to be active,
+// polling it every "interval" (DefaultPollInterval if not positive). A
+// positive "timeout" bounds the wait, failing with a *WaitTimeoutError.
+func (c *Client) CreateInstanceAndWait(ctx context.Context, serviceName string, creation InstanceCreation, interval, timeout time.Duration) (*Instance, error) {
+	instance, err := c.CreateInstance(ctx, serviceName, creation)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitActive(ctx, serviceName, instance.ID, false, interval, timeout)
+}
+
+// RebootAndWait reboots an instance and waits for it to be active again, see
+// CreateInstanceAndWait. As an instance may still be reported active right
+// after the reboot request, the first check is done after one interval.
+func (c *Client) RebootAndWait(ctx context.Context, serviceName, instanceID string, hard bool, interval, timeout time.Duration) (*Instance, error) {
+	if err := c.RebootInstance(ctx, serviceName, instanceID, hard); err != nil {
+		return nil, err
+	}
+	return c.waitActive(ctx, serviceName, instanceID, true, interval, timeout)
+}
+
+// ResizeAndWait changes the flavor of an instance and waits for it to be
+// active again, see RebootAndWait
+func (c *Client) ResizeAndWait(ctx context.Context, serviceName, instanceID, flavorID string, interval, timeout time.Duration) (*Instance, error) {
+	if _, err := c.ResizeInstance(ctx, serviceName, instanceID, flavorID); err != nil {
+		return nil, err
+	}
+	return c.waitActive(ctx, serviceName, instanceID, true, interval, timeout)
+}
+
+// waitActive waits for an instance to be active, within "timeout" if
+// positive, optionally delaying the first check by one interval
+func (c *Client) waitActive(ctx context.Context, serviceName, instanceID string, delay bool, interval, timeout time.Duration) (*Instance, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	waitCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var instance *Instance
+	err := func() error {
+		if delay {
+			select {
+			case <-time.After(interval):
+			case <-waitCtx.Done():
+				return waitCtx.Err()
+			}
+		}
+		var err error
+		instance, err = c.WaitInstanceStatus(waitCtx, serviceName, instanceID, InstanceStatusActive, interval)
+		return err
+	}()
+	if err != nil && timeout > 0 && ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+		return instance, &WaitTimeoutError{InstanceID: instanceID, Status: InstanceStatusActive, Timeout: timeout, Instance: instance}
+	}
+	return instance, err
+}
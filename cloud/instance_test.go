@@ -0,0 +1,155 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const recordedInstance = `{
+	"id": "inst-1",
+	"name": "web",
+	"status": "%s",
+	"region": "GRA7",
+	"flavorId": "flavor-1",
+	"imageId": "image-1",
+	"sshKeyId": "",
+	"created": "2020-01-01T10:00:00Z",
+	"ipAddresses": [{"ip": "51.0.0.1", "type": "public", "version": 4, "networkId": "net-1", "gatewayIp": "51.0.0.254"}],
+	"monthlyBilling": null
+}`
+
+func instanceWithStatus(status string) string {
+	return fmt.Sprintf(recordedInstance, status)
+}
+
+func TestInstances(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/instance?region=GRA7", http.StatusOK, "["+instanceWithStatus("ACTIVE")+"]")
+	server.Handle("POST", "/cloud/project/abc123/instance", http.StatusOK, instanceWithStatus("BUILD"))
+	server.Handle("POST", "/cloud/project/abc123/instance/inst-1/reboot", http.StatusOK, `null`)
+	server.Handle("DELETE", "/cloud/project/abc123/instance/inst-1", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	instances, err := client.Instances(ctx, "abc123", "GRA7")
+	if err != nil {
+		t.Fatalf("Instances should not return an error. Got %v", err)
+	}
+	instance, err := client.CreateInstance(ctx, "abc123", InstanceCreation{Name: "web", FlavorID: "flavor-1", ImageID: "image-1", Region: "GRA7"})
+	if err != nil {
+		t.Fatalf("CreateInstance should not return an error. Got %v", err)
+	}
+	if err := client.RebootInstance(ctx, "abc123", "inst-1", true); err != nil {
+		t.Fatalf("RebootInstance should not return an error. Got %v", err)
+	}
+	if err := client.DeleteInstance(ctx, "abc123", "inst-1"); err != nil {
+		t.Fatalf("DeleteInstance should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(instances) != 1 || instances[0].IPAddresses[0].IP != "51.0.0.1" {
+		t.Fatalf("Instances should decode the response. Got %+v", instances)
+	}
+	if instance.Status != InstanceStatusBuild {
+		t.Fatalf("CreateInstance should return the new instance. Got %+v", instance)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/instance"); string(request.Body) != `{"name":"web","flavorId":"flavor-1","imageId":"image-1","region":"GRA7"}` {
+		t.Fatalf("CreateInstance should send the creation parameters. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/instance/inst-1/reboot"); string(request.Body) != `{"type":"hard"}` {
+		t.Fatalf("RebootInstance should send the reboot type. Got %s", request.Body)
+	}
+}
+
+func TestWaitInstanceStatus(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.HandleSequence("GET", "/cloud/project/abc123/instance/inst-1", http.StatusOK, instanceWithStatus("BUILD"), instanceWithStatus("BUILD"), instanceWithStatus("ACTIVE"))
+	server.HandleSequence("GET", "/cloud/project/abc123/instance/inst-2", http.StatusOK, instanceWithStatus("BUILD"), instanceWithStatus("ERROR"))
+	ctx := context.Background()
+
+	// Test: the instance becomes active
+	instance, err := client.WaitInstanceStatus(ctx, "abc123", "inst-1", InstanceStatusActive, time.Millisecond)
+	if err != nil || instance.Status != InstanceStatusActive {
+		t.Fatalf("WaitInstanceStatus should return the active instance. Got %+v, %v", instance, err)
+	}
+
+	// Test: the instance fails
+	instance, err = client.WaitInstanceStatus(ctx, "abc123", "inst-2", InstanceStatusActive, time.Millisecond)
+	if err == nil || instance.Status != InstanceStatusError {
+		t.Fatalf("WaitInstanceStatus should fail on ERROR status. Got %+v, %v", instance, err)
+	}
+
+	// Test: the context expires
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitInstanceStatus(ctx, "abc123", "inst-1", InstanceStatusShutoff, time.Millisecond); err == nil || ctx.Err() == nil {
+		t.Fatalf("WaitInstanceStatus should stop with the context. Got %v", err)
+	}
+}
+
+func TestInstanceAndWait(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/cloud/project/abc123/instance", http.StatusOK, instanceWithStatus("BUILD"))
+	server.Handle("POST", "/cloud/project/abc123/instance/inst-1/reboot", http.StatusOK, `null`)
+	server.Handle("POST", "/cloud/project/abc123/instance/inst-1/resize", http.StatusOK, instanceWithStatus("RESIZE"))
+	server.HandleSequence("GET", "/cloud/project/abc123/instance/inst-1", http.StatusOK, instanceWithStatus("BUILD"), instanceWithStatus("ACTIVE"), instanceWithStatus("HARD_REBOOT"), instanceWithStatus("ACTIVE"), instanceWithStatus("RESIZE"), instanceWithStatus("ACTIVE"))
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateInstanceAndWait(ctx, "abc123", InstanceCreation{Name: "web", FlavorID: "flavor-1", Region: "GRA7"}, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("CreateInstanceAndWait should not return an error. Got %v", err)
+	}
+	rebooted, err := client.RebootAndWait(ctx, "abc123", "inst-1", true, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("RebootAndWait should not return an error. Got %v", err)
+	}
+	resized, err := client.ResizeAndWait(ctx, "abc123", "inst-1", "flavor-2", time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("ResizeAndWait should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if created.Status != InstanceStatusActive || rebooted.Status != InstanceStatusActive || resized.Status != InstanceStatusActive {
+		t.Fatalf("AndWait helpers should return active instances. Got %s, %s, %s", created.Status, rebooted.Status, resized.Status)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/instance/inst-1/reboot"); string(request.Body) != `{"type":"hard"}` {
+		t.Fatalf("RebootAndWait should send the reboot type. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/instance/inst-1/resize"); string(request.Body) != `{"flavorId":"flavor-2"}` {
+		t.Fatalf("ResizeAndWait should send the flavor. Got %s", request.Body)
+	}
+}
+
+func TestInstanceAndWaitTimeout(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/cloud/project/abc123/instance/inst-1/reboot", http.StatusOK, `null`)
+	server.Handle("GET", "/cloud/project/abc123/instance/inst-1", http.StatusOK, instanceWithStatus("REBOOT"))
+
+	// Test
+	_, err := client.RebootAndWait(context.Background(), "abc123", "inst-1", false, time.Millisecond, 20*time.Millisecond)
+
+	// Validate
+	var timeoutErr *WaitTimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Timeout != 20*time.Millisecond || timeoutErr.InstanceID != "inst-1" {
+		t.Fatalf("RebootAndWait should fail with a WaitTimeoutError. Got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitTimeoutError should wrap context.DeadlineExceeded")
+	}
+	if timeoutErr.Instance != nil && timeoutErr.Instance.Status != InstanceStatusReboot {
+		t.Fatalf("WaitTimeoutError should hold the last instance state. Got %+v", timeoutErr.Instance)
+	}
+}
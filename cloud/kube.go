@@ -0,0 +1,244 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Kubernetes cluster statuses
+const (
+	KubeStatusInstalling     = "INSTALLING"
+	KubeStatusUpdating       = "UPDATING"
+	KubeStatusResetting      = "RESETTING"
+	KubeStatusDeleting       = "DELETING"
+	KubeStatusReady          = "READY"
+	KubeStatusError          = "ERROR"
+	KubeStatusUserError      = "USER_ERROR"
+	KubeStatusUserQuotaError = "USER_QUOTA_ERROR"
+)
+
+// Kubernetes cluster upgrade strategies, see UpgradeKube
+const (
+	KubeUpgradeLatestPatch = "LATEST_PATCH"
+	KubeUpgradeNextMinor   = "NEXT_MINOR"
+)
+
+// Kube represents a Managed Kubernetes cluster.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/kube/%7BkubeId%7D#GET for the full definition
+type Kube struct {
+	ID                     string    `json:"id"`
+	Name                   string    `json:"name"`
+	Region                 string    `json:"region"`
+	Version                string    `json:"version"`
+	NextUpgradeVersions    []string  `json:"nextUpgradeVersions"`
+	Status                 string    `json:"status"`
+	URL                    string    `json:"url"`
+	NodesURL               string    `json:"nodesUrl"`
+	UpdatePolicy           string    `json:"updatePolicy"`
+	IsUpToDate             bool      `json:"isUpToDate"`
+	ControlPlaneIsUpToDate bool      `json:"controlPlaneIsUpToDate"`
+	PrivateNetworkID       string    `json:"privateNetworkId"`
+	CreatedAt              time.Time `json:"createdAt"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+}
+
+// KubeCreation holds the parameters of a new Kubernetes cluster. An empty
+// version selects the latest one.
+type KubeCreation struct {
+	Name             string            `json:"name"`
+	Region           string            `json:"region"`
+	Version          string            `json:"version,omitempty"`
+	PrivateNetworkID string            `json:"privateNetworkId,omitempty"`
+	UpdatePolicy     string            `json:"updatePolicy,omitempty"`
+	NodePool         *NodePoolCreation `json:"nodepool,omitempty"`
+}
+
+// NodePool represents a node pool of a Kubernetes cluster.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/kube/%7BkubeId%7D/nodepool/%7BnodePoolId%7D#GET for the full definition
+type NodePool struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Flavor         string    `json:"flavor"`
+	Status         string    `json:"status"`
+	SizeStatus     string    `json:"sizeStatus"`
+	Autoscale      bool      `json:"autoscale"`
+	MonthlyBilled  bool      `json:"monthlyBilled"`
+	AntiAffinity   bool      `json:"antiAffinity"`
+	DesiredNodes   int       `json:"desiredNodes"`
+	MinNodes       int       `json:"minNodes"`
+	MaxNodes       int       `json:"maxNodes"`
+	CurrentNodes   int       `json:"currentNodes"`
+	AvailableNodes int       `json:"availableNodes"`
+	UpToDateNodes  int       `json:"upToDateNodes"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// NodePoolCreation holds the parameters of a new node pool
+type NodePoolCreation struct {
+	Name          string `json:"name,omitempty"`
+	FlavorName    string `json:"flavorName"`
+	DesiredNodes  int    `json:"desiredNodes"`
+	MinNodes      int    `json:"minNodes,omitempty"`
+	MaxNodes      int    `json:"maxNodes,omitempty"`
+	Autoscale     bool   `json:"autoscale,omitempty"`
+	MonthlyBilled bool   `json:"monthlyBilled,omitempty"`
+	AntiAffinity  bool   `json:"antiAffinity,omitempty"`
+}
+
+// NodePoolUpdate holds the new size of a node pool
+type NodePoolUpdate struct {
+	DesiredNodes int  `json:"desiredNodes"`
+	MinNodes     int  `json:"minNodes"`
+	MaxNodes     int  `json:"maxNodes"`
+	Autoscale    bool `json:"autoscale"`
+}
+
+// kubePath returns the path of a route of a Kubernetes cluster
+func kubePath(serviceName, kubeID, format string, args ...interface{}) string {
+	return projectPath(serviceName, "/kube/%s", url.PathEscape(kubeID)) + fmt.Sprintf(format, args...)
+}
+
+// KubeIDs lists the IDs of the Kubernetes clusters of a project, with
+// GET /cloud/project/{serviceName}/kube
+func (c *Client) KubeIDs(ctx context.Context, serviceName string) ([]string, error) {
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/kube"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Kube returns a Kubernetes cluster, with
+// GET /cloud/project/{serviceName}/kube/{kubeId}
+func (c *Client) Kube(ctx context.Context, serviceName, kubeID string) (*Kube, error) {
+	kube := &Kube{}
+	if err := c.client.GetWithContext(ctx, kubePath(serviceName, kubeID, ""), kube); err != nil {
+		return nil, err
+	}
+	return kube, nil
+}
+
+// CreateKube creates a Kubernetes cluster, with
+// POST /cloud/project/{serviceName}/kube. The cluster is returned while
+// still being installed, see WaitClusterReady.
+func (c *Client) CreateKube(ctx context.Context, serviceName string, creation KubeCreation) (*Kube, error) {
+	kube := &Kube{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/kube"), creation, kube); err != nil {
+		return nil, err
+	}
+	return kube, nil
+}
+
+// RenameKube renames a Kubernetes cluster, with
+// PUT /cloud/project/{serviceName}/kube/{kubeId}
+func (c *Client) RenameKube(ctx context.Context, serviceName, kubeID, name string) error {
+	body := map[string]string{"name": name}
+	return c.client.PutWithContext(ctx, kubePath(serviceName, kubeID, ""), body, nil)
+}
+
+// DeleteKube deletes a Kubernetes cluster and its nodes, with
+// DELETE /cloud/project/{serviceName}/kube/{kubeId}
+func (c *Client) DeleteKube(ctx context.Context, serviceName, kubeID string) error {
+	return c.client.DeleteWithContext(ctx, kubePath(serviceName, kubeID, ""), nil)
+}
+
+// Kubeconfig returns the kubeconfig file of a Kubernetes cluster, with
+// POST /cloud/project/{serviceName}/kube/{kubeId}/kubeconfig
+func (c *Client) Kubeconfig(ctx context.Context, serviceName, kubeID string) (string, error) {
+	var res struct {
+		Content string `json:"content"`
+	}
+	if err := c.client.PostWithContext(ctx, kubePath(serviceName, kubeID, "/kubeconfig"), nil, &res); err != nil {
+		return "", err
+	}
+	return res.Content, nil
+}
+
+// UpgradeKube upgrades a Kubernetes cluster, with
+// POST /cloud/project/{serviceName}/kube/{kubeId}/update. "strategy" is
+// KubeUpgradeLatestPatch or KubeUpgradeNextMinor.
+func (c *Client) UpgradeKube(ctx context.Context, serviceName, kubeID, strategy string) error {
+	body := map[string]string{"strategy": strategy}
+	return c.client.PostWithContext(ctx, kubePath(serviceName, kubeID, "/update"), body, nil)
+}
+
+// SetKubeUpdatePolicy sets the automatic update policy of a Kubernetes
+// cluster, like "ALWAYS_UPDATE" or "NEVER_UPDATE", with
+// PUT /cloud/project/{serviceName}/kube/{kubeId}/updatePolicy
+func (c *Client) SetKubeUpdatePolicy(ctx context.Context, serviceName, kubeID, policy string) error {
+	body := map[string]string{"updatePolicy": policy}
+	return c.client.PutWithContext(ctx, kubePath(serviceName, kubeID, "/updatePolicy"), body, nil)
+}
+
+// WaitClusterReady polls a Kubernetes cluster every "interval",
+// DefaultPollInterval if not positive, until it is ready. It fails if the
+// cluster reaches an error status instead, or when the context is done.
+func (c *Client) WaitClusterReady(ctx context.Context, serviceName, kubeID string, interval time.Duration) (*Kube, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		kube, err := c.Kube(ctx, serviceName, kubeID)
+		if err != nil {
+			return nil, err
+		}
+		switch kube.Status {
+		case KubeStatusReady:
+			return kube, nil
+		case KubeStatusError, KubeStatusUserError, KubeStatusUserQuotaError:
+			return kube, fmt.Errorf("kubernetes cluster %s is in %s status", kubeID, kube.Status)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return kube, ctx.Err()
+		}
+	}
+}
+
+// NodePools lists the node pools of a Kubernetes cluster, with
+// GET /cloud/project/{serviceName}/kube/{kubeId}/nodepool
+func (c *Client) NodePools(ctx context.Context, serviceName, kubeID string) ([]NodePool, error) {
+	pools := []NodePool{}
+	if err := c.client.GetWithContext(ctx, kubePath(serviceName, kubeID, "/nodepool"), &pools); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// NodePool returns a node pool of a Kubernetes cluster, with
+// GET /cloud/project/{serviceName}/kube/{kubeId}/nodepool/{nodePoolId}
+func (c *Client) NodePool(ctx context.Context, serviceName, kubeID, nodePoolID string) (*NodePool, error) {
+	pool := &NodePool{}
+	if err := c.client.GetWithContext(ctx, kubePath(serviceName, kubeID, "/nodepool/%s", url.PathEscape(nodePoolID)), pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// CreateNodePool creates a node pool in a Kubernetes cluster, with
+// POST /cloud/project/{serviceName}/kube/{kubeId}/nodepool
+func (c *Client) CreateNodePool(ctx context.Context, serviceName, kubeID string, creation NodePoolCreation) (*NodePool, error) {
+	pool := &NodePool{}
+	if err := c.client.PostWithContext(ctx, kubePath(serviceName, kubeID, "/nodepool"), creation, pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// UpdateNodePool resizes a node pool, with
+// PUT /cloud/project/{serviceName}/kube/{kubeId}/nodepool/{nodePoolId}
+func (c *Client) UpdateNodePool(ctx context.Context, serviceName, kubeID, nodePoolID string, update NodePoolUpdate) error {
+	return c.client.PutWithContext(ctx, kubePath(serviceName, kubeID, "/nodepool/%s", url.PathEscape(nodePoolID)), update, nil)
+}
+
+// DeleteNodePool deletes a node pool and its nodes, with
+// DELETE /cloud/project/{serviceName}/kube/{kubeId}/nodepool/{nodePoolId}
+func (c *Client) DeleteNodePool(ctx context.Context, serviceName, kubeID, nodePoolID string) error {
+	return c.client.DeleteWithContext(ctx, kubePath(serviceName, kubeID, "/nodepool/%s", url.PathEscape(nodePoolID)), nil)
+}
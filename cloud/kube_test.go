@@ -0,0 +1,149 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const recordedKube = `{"id": "k-1", "name": "prod", "region": "GRA7", "version": "1.28", "nextUpgradeVersions": ["1.29"], "status": "%s", "url": "xxx.c1.gra7.k8s.ovh.net", "nodesUrl": "xxx.nodes.c1.gra7.k8s.ovh.net", "updatePolicy": "ALWAYS_UPDATE", "isUpToDate": true, "controlPlaneIsUpToDate": true, "createdAt": "2020-01-01T10:00:00Z", "updatedAt": "2020-01-01T10:00:00Z"}`
+
+func kubeWithStatus(status string) string {
+	return fmt.Sprintf(recordedKube, status)
+}
+
+func TestKube(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/kube", http.StatusOK, `["k-1"]`)
+	server.Handle("POST", "/cloud/project/abc123/kube", http.StatusOK, kubeWithStatus("INSTALLING"))
+	server.Handle("GET", "/cloud/project/abc123/kube/k-1", http.StatusOK, kubeWithStatus("READY"))
+	server.Handle("PUT", "/cloud/project/abc123/kube/k-1", http.StatusOK, `null`)
+	server.Handle("DELETE", "/cloud/project/abc123/kube/k-1", http.StatusOK, `null`)
+	server.Handle("POST", "/cloud/project/abc123/kube/k-1/kubeconfig", http.StatusOK, `{"content": "apiVersion: v1\nkind: Config\n"}`)
+	server.Handle("POST", "/cloud/project/abc123/kube/k-1/update", http.StatusOK, `null`)
+	server.Handle("PUT", "/cloud/project/abc123/kube/k-1/updatePolicy", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateKube(ctx, "abc123", KubeCreation{
+		Name:     "prod",
+		Region:   "GRA7",
+		NodePool: &NodePoolCreation{FlavorName: "b2-7", DesiredNodes: 3},
+	})
+	if err != nil {
+		t.Fatalf("CreateKube should not return an error. Got %v", err)
+	}
+	ids, err := client.KubeIDs(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("KubeIDs should not return an error. Got %v", err)
+	}
+	kube, err := client.Kube(ctx, "abc123", ids[0])
+	if err != nil {
+		t.Fatalf("Kube should not return an error. Got %v", err)
+	}
+	kubeconfig, err := client.Kubeconfig(ctx, "abc123", "k-1")
+	if err != nil {
+		t.Fatalf("Kubeconfig should not return an error. Got %v", err)
+	}
+	if err := client.RenameKube(ctx, "abc123", "k-1", "production"); err != nil {
+		t.Fatalf("RenameKube should not return an error. Got %v", err)
+	}
+	if err := client.UpgradeKube(ctx, "abc123", "k-1", KubeUpgradeNextMinor); err != nil {
+		t.Fatalf("UpgradeKube should not return an error. Got %v", err)
+	}
+	if err := client.SetKubeUpdatePolicy(ctx, "abc123", "k-1", "NEVER_UPDATE"); err != nil {
+		t.Fatalf("SetKubeUpdatePolicy should not return an error. Got %v", err)
+	}
+	if err := client.DeleteKube(ctx, "abc123", "k-1"); err != nil {
+		t.Fatalf("DeleteKube should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if created.Status != KubeStatusInstalling || kube.Status != KubeStatusReady || kube.NextUpgradeVersions[0] != "1.29" {
+		t.Fatalf("Kube should decode the response. Got %+v", kube)
+	}
+	if !strings.HasPrefix(kubeconfig, "apiVersion: v1") {
+		t.Fatalf("Kubeconfig should return the file content. Got %s", kubeconfig)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/kube"); string(request.Body) != `{"name":"prod","region":"GRA7","nodepool":{"flavorName":"b2-7","desiredNodes":3}}` {
+		t.Fatalf("CreateKube should send the creation parameters. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/kube/k-1/update"); string(request.Body) != `{"strategy":"NEXT_MINOR"}` {
+		t.Fatalf("UpgradeKube should send the strategy. Got %s", request.Body)
+	}
+}
+
+func TestNodePools(t *testing.T) {
+	// Init test
+	pool := `{"id": "np-1", "name": "default", "flavor": "b2-7", "status": "READY", "sizeStatus": "CAPACITY_OK", "autoscale": false, "desiredNodes": 3, "minNodes": 0, "maxNodes": 100, "currentNodes": 3, "availableNodes": 3, "upToDateNodes": 3, "createdAt": "2020-01-01T10:00:00Z"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/kube/k-1/nodepool", http.StatusOK, "["+pool+"]")
+	server.Handle("POST", "/cloud/project/abc123/kube/k-1/nodepool", http.StatusOK, pool)
+	server.Handle("GET", "/cloud/project/abc123/kube/k-1/nodepool/np-1", http.StatusOK, pool)
+	server.Handle("PUT", "/cloud/project/abc123/kube/k-1/nodepool/np-1", http.StatusOK, `null`)
+	server.Handle("DELETE", "/cloud/project/abc123/kube/k-1/nodepool/np-1", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateNodePool(ctx, "abc123", "k-1", NodePoolCreation{Name: "default", FlavorName: "b2-7", DesiredNodes: 3})
+	if err != nil {
+		t.Fatalf("CreateNodePool should not return an error. Got %v", err)
+	}
+	pools, err := client.NodePools(ctx, "abc123", "k-1")
+	if err != nil {
+		t.Fatalf("NodePools should not return an error. Got %v", err)
+	}
+	fetched, err := client.NodePool(ctx, "abc123", "k-1", pools[0].ID)
+	if err != nil {
+		t.Fatalf("NodePool should not return an error. Got %v", err)
+	}
+	if err := client.UpdateNodePool(ctx, "abc123", "k-1", created.ID, NodePoolUpdate{DesiredNodes: 5, MaxNodes: 10, Autoscale: true}); err != nil {
+		t.Fatalf("UpdateNodePool should not return an error. Got %v", err)
+	}
+	if err := client.DeleteNodePool(ctx, "abc123", "k-1", created.ID); err != nil {
+		t.Fatalf("DeleteNodePool should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if fetched.Flavor != "b2-7" || fetched.CurrentNodes != 3 || fetched.CreatedAt.IsZero() {
+		t.Fatalf("NodePool should decode the response. Got %+v", fetched)
+	}
+	if request, _ := server.LastRequest("PUT", "/cloud/project/abc123/kube/k-1/nodepool/np-1"); string(request.Body) != `{"desiredNodes":5,"minNodes":0,"maxNodes":10,"autoscale":true}` {
+		t.Fatalf("UpdateNodePool should send the new size. Got %s", request.Body)
+	}
+}
+
+func TestWaitClusterReady(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.HandleSequence("GET", "/cloud/project/abc123/kube/k-1", http.StatusOK, kubeWithStatus("INSTALLING"), kubeWithStatus("INSTALLING"), kubeWithStatus("READY"))
+	server.HandleSequence("GET", "/cloud/project/abc123/kube/k-2", http.StatusOK, kubeWithStatus("INSTALLING"), kubeWithStatus("USER_QUOTA_ERROR"))
+	server.Handle("GET", "/cloud/project/abc123/kube/k-3", http.StatusOK, kubeWithStatus("UPDATING"))
+	ctx := context.Background()
+
+	// Test: the cluster becomes ready
+	kube, err := client.WaitClusterReady(ctx, "abc123", "k-1", time.Millisecond)
+	if err != nil || kube.Status != KubeStatusReady {
+		t.Fatalf("WaitClusterReady should return the ready cluster. Got %+v, %v", kube, err)
+	}
+
+	// Test: the cluster fails
+	kube, err = client.WaitClusterReady(ctx, "abc123", "k-2", time.Millisecond)
+	if err == nil || kube.Status != KubeStatusUserQuotaError {
+		t.Fatalf("WaitClusterReady should fail on error statuses. Got %+v, %v", kube, err)
+	}
+
+	// Test: the context expires
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitClusterReady(ctx, "abc123", "k-3", time.Millisecond); err == nil || ctx.Err() == nil {
+		t.Fatalf("WaitClusterReady should stop with the context. Got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package cloud
+
+import (
+	"context"
+	"net/url"
+)
+
+// NetworkRegion represents the status of a network in a region
+type NetworkRegion struct {
+	Region string `json:"region"`
+	Status string `json:"status"`
+}
+
+// Network represents a private network of a project.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/network/private/%7BnetworkId%7D#GET for the full definition
+type Network struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	VlanID  int             `json:"vlanId"`
+	Status  string          `json:"status"`
+	Type    string          `json:"type"`
+	Regions []NetworkRegion `json:"regions"`
+}
+
+// NetworkCreation holds the parameters of a new private network. The network
+// is created in all regions if none is given.
+type NetworkCreation struct {
+	Name    string   `json:"name"`
+	VlanID  int      `json:"vlanId,omitempty"`
+	Regions []string `json:"regions,omitempty"`
+}
+
+// PrivateNetworks lists the private networks of a project, with
+// GET /cloud/project/{serviceName}/network/private
+func (c *Client) PrivateNetworks(ctx context.Context, serviceName string) ([]Network, error) {
+	networks := []Network{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/network/private"), &networks); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
+
+// PrivateNetwork returns a private network, with
+// GET /cloud/project/{serviceName}/network/private/{networkId}
+func (c *Client) PrivateNetwork(ctx context.Context, serviceName, networkID string) (*Network, error) {
+	network := &Network{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/network/private/%s", url.PathEscape(networkID)), network); err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+// CreatePrivateNetwork creates a private network, with
+// POST /cloud/project/{serviceName}/network/private
+func (c *Client) CreatePrivateNetwork(ctx context.Context, serviceName string, creation NetworkCreation) (*Network, error) {
+	network := &Network{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/network/private"), creation, network); err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+// DeletePrivateNetwork deletes a private network, with
+// DELETE /cloud/project/{serviceName}/network/private/{networkId}
+func (c *Client) DeletePrivateNetwork(ctx context.Context, serviceName, networkID string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/network/private/%s", url.PathEscape(networkID)), nil)
+}
+
+// PublicNetworks lists the public networks of a project, with
+// GET /cloud/project/{serviceName}/network/public
+func (c *Client) PublicNetworks(ctx context.Context, serviceName string) ([]Network, error) {
+	networks := []Network{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/network/public"), &networks); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
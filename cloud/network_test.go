@@ -0,0 +1,51 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPrivateNetworks(t *testing.T) {
+	// Init test
+	network := `{"id": "pn-1_10", "name": "backend", "vlanId": 10, "status": "ACTIVE", "type": "private", "regions": [{"region": "GRA7", "status": "ACTIVE"}]}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/network/private", http.StatusOK, "["+network+"]")
+	server.Handle("GET", "/cloud/project/abc123/network/private/pn-1_10", http.StatusOK, network)
+	server.Handle("POST", "/cloud/project/abc123/network/private", http.StatusOK, network)
+	server.Handle("DELETE", "/cloud/project/abc123/network/private/pn-1_10", http.StatusOK, `null`)
+	server.Handle("GET", "/cloud/project/abc123/network/public", http.StatusOK, `[{"id": "ext-net", "name": "Ext-Net", "vlanId": 0, "status": "ACTIVE", "type": "public", "regions": []}]`)
+	ctx := context.Background()
+
+	// Test
+	networks, err := client.PrivateNetworks(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("PrivateNetworks should not return an error. Got %v", err)
+	}
+	network2, err := client.PrivateNetwork(ctx, "abc123", "pn-1_10")
+	if err != nil {
+		t.Fatalf("PrivateNetwork should not return an error. Got %v", err)
+	}
+	if _, err := client.CreatePrivateNetwork(ctx, "abc123", NetworkCreation{Name: "backend", VlanID: 10, Regions: []string{"GRA7"}}); err != nil {
+		t.Fatalf("CreatePrivateNetwork should not return an error. Got %v", err)
+	}
+	if err := client.DeletePrivateNetwork(ctx, "abc123", "pn-1_10"); err != nil {
+		t.Fatalf("DeletePrivateNetwork should not return an error. Got %v", err)
+	}
+	public, err := client.PublicNetworks(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("PublicNetworks should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(networks) != 1 || network2.VlanID != 10 || network2.Regions[0].Region != "GRA7" {
+		t.Fatalf("PrivateNetwork should decode the response. Got %+v", network2)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/network/private"); string(request.Body) != `{"name":"backend","vlanId":10,"regions":["GRA7"]}` {
+		t.Fatalf("CreatePrivateNetwork should send the creation parameters. Got %s", request.Body)
+	}
+	if len(public) != 1 || public[0].Type != "public" {
+		t.Fatalf("PublicNetworks should decode the response. Got %+v", public)
+	}
+}
@@ -0,0 +1,184 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RoleObjectStoreOperator is the role of users allowed to use the object
+// storage, see CreateUser
+const RoleObjectStoreOperator = "objectstore_operator"
+
+// User represents a Public Cloud user, used to access the OpenStack and S3
+// APIs.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/user/%7BuserId%7D#GET for the full definition
+type User struct {
+	ID           int64     `json:"id"`
+	Username     string    `json:"username"`
+	Description  string    `json:"description"`
+	Status       string    `json:"status"`
+	Roles        []Role    `json:"roles"`
+	CreationDate time.Time `json:"creationDate"`
+	// Password is only returned on creation
+	Password string `json:"password"`
+}
+
+// Role represents a role of a Public Cloud user
+type Role struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// S3Credential represents an S3 access key of a user. Its secret is only
+// returned on creation, see S3CredentialSecret.
+type S3Credential struct {
+	Access   string `json:"access"`
+	Secret   string `json:"secret"`
+	TenantID string `json:"tenantId"`
+	UserID   string `json:"userId"`
+}
+
+// S3Config holds everything needed to configure an S3 client, like
+// aws-sdk-go, for the object storage of a region:
+//
+//	config := aws.Config{
+//		Region:      aws.String(s3.Region),
+//		Endpoint:    aws.String(s3.Endpoint),
+//		Credentials: credentials.NewStaticCredentials(s3.AccessKeyID, s3.SecretAccessKey, ""),
+//	}
+type S3Config struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Endpoint        string
+}
+
+// StorageEndpoint represents the Swift endpoint of a region
+type StorageEndpoint struct {
+	Region string `json:"region"`
+	URL    string `json:"url"`
+}
+
+// StorageAccess holds a Swift token and the Swift endpoints of a project, as
+// used by Swift clients like github.com/ncw/swift with their storage URL and
+// auth token
+type StorageAccess struct {
+	Token     string            `json:"token"`
+	Endpoints []StorageEndpoint `json:"endpoints"`
+}
+
+// Endpoint returns the Swift URL of a region, or an empty string if the
+// project has no storage in this region
+func (a *StorageAccess) Endpoint(region string) string {
+	for _, endpoint := range a.Endpoints {
+		if strings.EqualFold(endpoint.Region, region) {
+			return endpoint.URL
+		}
+	}
+	return ""
+}
+
+// S3Endpoint returns the S3 endpoint URL of a region. Public Cloud
+// region names, like "GRA11", are converted to their S3 region, like "gra".
+func S3Endpoint(region string) string {
+	return fmt.Sprintf("https://s3.%s.io.cloud.ovh.net", S3Region(region))
+}
+
+// S3Region returns the S3 region of a Public Cloud region, its lower cased
+// name without the datacenter number: "gra" for "GRA" or "GRA11"
+func S3Region(region string) string {
+	return strings.ToLower(strings.TrimRight(region, "0123456789"))
+}
+
+// Users lists the users of a project, with GET /cloud/project/{serviceName}/user
+func (c *Client) Users(ctx context.Context, serviceName string) ([]User, error) {
+	users := []User{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/user"), &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// CreateUser creates a user with a role, like RoleObjectStoreOperator, with
+// POST /cloud/project/{serviceName}/user. The returned user holds its
+// password, which can not be retrieved later.
+func (c *Client) CreateUser(ctx context.Context, serviceName, description, role string) (*User, error) {
+	body := map[string]string{"description": description, "role": role}
+	user := &User{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/user"), body, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser deletes a user, with DELETE /cloud/project/{serviceName}/user/{userId}
+func (c *Client) DeleteUser(ctx context.Context, serviceName string, userID int64) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/user/%d", userID), nil)
+}
+
+// S3Credentials lists the S3 access keys of a user, with
+// GET /cloud/project/{serviceName}/user/{userId}/s3Credentials
+func (c *Client) S3Credentials(ctx context.Context, serviceName string, userID int64) ([]S3Credential, error) {
+	credentials := []S3Credential{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/user/%d/s3Credentials", userID), &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// CreateS3Credential creates an S3 access key for a user, with
+// POST /cloud/project/{serviceName}/user/{userId}/s3Credentials
+func (c *Client) CreateS3Credential(ctx context.Context, serviceName string, userID int64) (*S3Credential, error) {
+	credential := &S3Credential{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/user/%d/s3Credentials", userID), nil, credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// S3CredentialSecret returns the secret of an S3 access key, with
+// POST /cloud/project/{serviceName}/user/{userId}/s3Credentials/{access}/secret
+func (c *Client) S3CredentialSecret(ctx context.Context, serviceName string, userID int64, access string) (string, error) {
+	var res struct {
+		Secret string `json:"secret"`
+	}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/user/%d/s3Credentials/%s/secret", userID, url.PathEscape(access)), nil, &res); err != nil {
+		return "", err
+	}
+	return res.Secret, nil
+}
+
+// DeleteS3Credential deletes an S3 access key, with
+// DELETE /cloud/project/{serviceName}/user/{userId}/s3Credentials/{access}
+func (c *Client) DeleteS3Credential(ctx context.Context, serviceName string, userID int64, access string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/user/%d/s3Credentials/%s", userID, url.PathEscape(access)), nil)
+}
+
+// NewS3Config creates an S3 access key for a user and returns the S3
+// configuration of a region using it
+func (c *Client) NewS3Config(ctx context.Context, serviceName string, userID int64, region string) (*S3Config, error) {
+	credential, err := c.CreateS3Credential(ctx, serviceName, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Config{
+		AccessKeyID:     credential.Access,
+		SecretAccessKey: credential.Secret,
+		Region:          S3Region(region),
+		Endpoint:        S3Endpoint(region),
+	}, nil
+}
+
+// StorageAccess returns a Swift token and the Swift endpoints of a project,
+// with POST /cloud/project/{serviceName}/storage/access
+func (c *Client) StorageAccess(ctx context.Context, serviceName string) (*StorageAccess, error) {
+	access := &StorageAccess{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/storage/access"), nil, access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
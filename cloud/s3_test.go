@@ -0,0 +1,91 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestS3Credentials(t *testing.T) {
+	// Init test
+	credential := `{"access": "a1b2", "secret": "s3cr3t", "tenantId": "abc123", "userId": "42"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/user", http.StatusOK, `[{"id": 42, "username": "user-a1", "description": "backup", "status": "ok", "roles": [{"id": "r1", "name": "objectstore_operator"}], "creationDate": "2020-01-01T10:00:00+01:00"}]`)
+	server.Handle("POST", "/cloud/project/abc123/user", http.StatusOK, `{"id": 42, "username": "user-a1", "description": "backup", "status": "creating", "password": "p4ss"}`)
+	server.Handle("DELETE", "/cloud/project/abc123/user/42", http.StatusOK, `null`)
+	server.Handle("GET", "/cloud/project/abc123/user/42/s3Credentials", http.StatusOK, `[{"access": "a1b2", "tenantId": "abc123", "userId": "42"}]`)
+	server.Handle("POST", "/cloud/project/abc123/user/42/s3Credentials", http.StatusOK, credential)
+	server.Handle("POST", "/cloud/project/abc123/user/42/s3Credentials/a1b2/secret", http.StatusOK, `{"secret": "s3cr3t"}`)
+	server.Handle("DELETE", "/cloud/project/abc123/user/42/s3Credentials/a1b2", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	user, err := client.CreateUser(ctx, "abc123", "backup", RoleObjectStoreOperator)
+	if err != nil {
+		t.Fatalf("CreateUser should not return an error. Got %v", err)
+	}
+	users, err := client.Users(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("Users should not return an error. Got %v", err)
+	}
+	config, err := client.NewS3Config(ctx, "abc123", user.ID, "GRA11")
+	if err != nil {
+		t.Fatalf("NewS3Config should not return an error. Got %v", err)
+	}
+	credentials, err := client.S3Credentials(ctx, "abc123", user.ID)
+	if err != nil {
+		t.Fatalf("S3Credentials should not return an error. Got %v", err)
+	}
+	secret, err := client.S3CredentialSecret(ctx, "abc123", user.ID, credentials[0].Access)
+	if err != nil {
+		t.Fatalf("S3CredentialSecret should not return an error. Got %v", err)
+	}
+	if err := client.DeleteS3Credential(ctx, "abc123", user.ID, "a1b2"); err != nil {
+		t.Fatalf("DeleteS3Credential should not return an error. Got %v", err)
+	}
+	if err := client.DeleteUser(ctx, "abc123", user.ID); err != nil {
+		t.Fatalf("DeleteUser should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if user.Password != "p4ss" || users[0].Roles[0].Name != RoleObjectStoreOperator {
+		t.Fatalf("Users should be decoded. Got %+v", users)
+	}
+	expected := S3Config{AccessKeyID: "a1b2", SecretAccessKey: "s3cr3t", Region: "gra", Endpoint: "https://s3.gra.io.cloud.ovh.net"}
+	if *config != expected {
+		t.Fatalf("NewS3Config should return %+v. Got %+v", expected, *config)
+	}
+	if secret != "s3cr3t" {
+		t.Fatalf("S3CredentialSecret should return the secret. Got %s", secret)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/user"); string(request.Body) != `{"description":"backup","role":"objectstore_operator"}` {
+		t.Fatalf("CreateUser should send the role. Got %s", request.Body)
+	}
+}
+
+func TestStorageAccess(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/cloud/project/abc123/storage/access", http.StatusOK, `{"token": "gAAAA", "endpoints": [{"region": "GRA", "url": "https://storage.gra.cloud.ovh.net/v1/AUTH_abc123"}, {"region": "SBG", "url": "https://storage.sbg.cloud.ovh.net/v1/AUTH_abc123"}]}`)
+
+	// Test
+	access, err := client.StorageAccess(context.Background(), "abc123")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("StorageAccess should not return an error. Got %v", err)
+	}
+	if access.Token != "gAAAA" || access.Endpoint("sbg") != "https://storage.sbg.cloud.ovh.net/v1/AUTH_abc123" || access.Endpoint("BHS") != "" {
+		t.Fatalf("StorageAccess should return the token and endpoints. Got %+v", access)
+	}
+}
+
+func TestS3Region(t *testing.T) {
+	for region, expected := range map[string]string{"GRA": "gra", "GRA11": "gra", "uk": "uk", "DE1": "de"} {
+		if got := S3Region(region); got != expected {
+			t.Fatalf("S3Region(%s) should be %s. Got %s", region, expected, got)
+		}
+	}
+}
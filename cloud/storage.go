@@ -0,0 +1,75 @@
+package cloud
+
+import (
+	"context"
+	"net/url"
+)
+
+// Container represents an object storage container
+type Container struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Region        string `json:"region"`
+	StoredBytes   int64  `json:"storedBytes"`
+	StoredObjects int64  `json:"storedObjects"`
+}
+
+// ContainerObject represents an object of a container
+type ContainerObject struct {
+	Name           string `json:"name"`
+	Size           int64  `json:"size"`
+	ContentType    string `json:"contentType"`
+	LastModified   string `json:"lastModified"`
+	RetrievalState string `json:"retrievalState"`
+}
+
+// ContainerDetail represents an object storage container and its objects.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/storage/%7BcontainerId%7D#GET for the full definition
+type ContainerDetail struct {
+	Name          string            `json:"name"`
+	Region        string            `json:"region"`
+	Public        bool              `json:"public"`
+	StaticURL     string            `json:"staticUrl"`
+	ContainerType string            `json:"containerType"`
+	StoredBytes   int64             `json:"storedBytes"`
+	StoredObjects int64             `json:"storedObjects"`
+	Objects       []ContainerObject `json:"objects"`
+}
+
+// Containers lists the object storage containers of a project, with
+// GET /cloud/project/{serviceName}/storage
+func (c *Client) Containers(ctx context.Context, serviceName string) ([]Container, error) {
+	containers := []Container{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/storage"), &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// Container returns an object storage container, with
+// GET /cloud/project/{serviceName}/storage/{containerId}
+func (c *Client) Container(ctx context.Context, serviceName, containerID string) (*ContainerDetail, error) {
+	container := &ContainerDetail{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/storage/%s", url.PathEscape(containerID)), container); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// CreateContainer creates an object storage container, with
+// POST /cloud/project/{serviceName}/storage. Archive containers are cheaper
+// but their objects must be unfrozen before being read.
+func (c *Client) CreateContainer(ctx context.Context, serviceName, name, region string, archive bool) (*Container, error) {
+	container := &Container{}
+	body := map[string]interface{}{"containerName": name, "region": region, "archive": archive}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/storage"), body, container); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// DeleteContainer deletes an empty object storage container, with
+// DELETE /cloud/project/{serviceName}/storage/{containerId}
+func (c *Client) DeleteContainer(ctx context.Context, serviceName, containerID string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/storage/%s", url.PathEscape(containerID)), nil)
+}
@@ -0,0 +1,43 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestContainers(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/storage", http.StatusOK, `[{"id": "c-1", "name": "assets", "region": "GRA", "storedBytes": 2048, "storedObjects": 2}]`)
+	server.Handle("GET", "/cloud/project/abc123/storage/c-1", http.StatusOK, `{"name": "assets", "region": "GRA", "public": false, "staticUrl": "https://storage.gra.cloud.ovh.net/v1/AUTH_abc123/assets", "containerType": "private", "storedBytes": 2048, "storedObjects": 2, "objects": [{"name": "logo.png", "size": 1024, "contentType": "image/png", "lastModified": "2020-01-01T10:00:00Z", "retrievalState": "unsealed"}]}`)
+	server.Handle("POST", "/cloud/project/abc123/storage", http.StatusOK, `{"id": "c-2", "name": "archive", "region": "GRA", "storedBytes": 0, "storedObjects": 0}`)
+	server.Handle("DELETE", "/cloud/project/abc123/storage/c-2", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	containers, err := client.Containers(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("Containers should not return an error. Got %v", err)
+	}
+	container, err := client.Container(ctx, "abc123", containers[0].ID)
+	if err != nil {
+		t.Fatalf("Container should not return an error. Got %v", err)
+	}
+	created, err := client.CreateContainer(ctx, "abc123", "archive", "GRA", true)
+	if err != nil {
+		t.Fatalf("CreateContainer should not return an error. Got %v", err)
+	}
+	if err := client.DeleteContainer(ctx, "abc123", created.ID); err != nil {
+		t.Fatalf("DeleteContainer should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if containers[0].StoredBytes != 2048 || len(container.Objects) != 1 || container.Objects[0].Size != 1024 {
+		t.Fatalf("Container should decode the response. Got %+v", container)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/storage"); string(request.Body) != `{"archive":true,"containerName":"archive","region":"GRA"}` {
+		t.Fatalf("CreateContainer should send the creation parameters. Got %s", request.Body)
+	}
+}
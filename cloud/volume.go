@@ -0,0 +1,125 @@
+package cloud
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Volume represents a block storage volume.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/volume/%7BvolumeId%7D#GET for the full definition
+type Volume struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Size         int       `json:"size"`
+	Type         string    `json:"type"`
+	Status       string    `json:"status"`
+	Region       string    `json:"region"`
+	Bootable     bool      `json:"bootable"`
+	AttachedTo   []string  `json:"attachedTo"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+// VolumeCreation holds the parameters of a new volume. Size is in GB.
+type VolumeCreation struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Size        int    `json:"size"`
+	Type        string `json:"type,omitempty"`
+	Region      string `json:"region"`
+	SnapshotID  string `json:"snapshotId,omitempty"`
+}
+
+// Snapshot represents an instance snapshot.
+// Visit https://api.ovh.com/console/#/cloud/project/%7BserviceName%7D/snapshot/%7BimageId%7D#GET for the full definition
+type Snapshot struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	Region       string    `json:"region"`
+	Size         float64   `json:"size"`
+	MinDisk      int       `json:"minDisk"`
+	Visibility   string    `json:"visibility"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+// Volumes lists the volumes of a project, with
+// GET /cloud/project/{serviceName}/volume
+func (c *Client) Volumes(ctx context.Context, serviceName string) ([]Volume, error) {
+	volumes := []Volume{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/volume"), &volumes); err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
+
+// Volume returns a volume, with
+// GET /cloud/project/{serviceName}/volume/{volumeId}
+func (c *Client) Volume(ctx context.Context, serviceName, volumeID string) (*Volume, error) {
+	volume := &Volume{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/volume/%s", url.PathEscape(volumeID)), volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// CreateVolume creates a volume, with POST /cloud/project/{serviceName}/volume
+func (c *Client) CreateVolume(ctx context.Context, serviceName string, creation VolumeCreation) (*Volume, error) {
+	volume := &Volume{}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/volume"), creation, volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// DeleteVolume deletes a volume, with
+// DELETE /cloud/project/{serviceName}/volume/{volumeId}
+func (c *Client) DeleteVolume(ctx context.Context, serviceName, volumeID string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/volume/%s", url.PathEscape(volumeID)), nil)
+}
+
+// AttachVolume attaches a volume to an instance, with
+// POST /cloud/project/{serviceName}/volume/{volumeId}/attach
+func (c *Client) AttachVolume(ctx context.Context, serviceName, volumeID, instanceID string) (*Volume, error) {
+	volume := &Volume{}
+	body := map[string]string{"instanceId": instanceID}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/volume/%s/attach", url.PathEscape(volumeID)), body, volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// DetachVolume detaches a volume from an instance, with
+// POST /cloud/project/{serviceName}/volume/{volumeId}/detach
+func (c *Client) DetachVolume(ctx context.Context, serviceName, volumeID, instanceID string) (*Volume, error) {
+	volume := &Volume{}
+	body := map[string]string{"instanceId": instanceID}
+	if err := c.client.PostWithContext(ctx, projectPath(serviceName, "/volume/%s/detach", url.PathEscape(volumeID)), body, volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// Snapshots lists the instance snapshots of a project, with
+// GET /cloud/project/{serviceName}/snapshot
+func (c *Client) Snapshots(ctx context.Context, serviceName string) ([]Snapshot, error) {
+	snapshots := []Snapshot{}
+	if err := c.client.GetWithContext(ctx, projectPath(serviceName, "/snapshot"), &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// CreateSnapshot snapshots an instance, with
+// POST /cloud/project/{serviceName}/instance/{instanceId}/snapshot
+func (c *Client) CreateSnapshot(ctx context.Context, serviceName, instanceID, name string) error {
+	body := map[string]string{"snapshotName": name}
+	return c.client.PostWithContext(ctx, projectPath(serviceName, "/instance/%s/snapshot", url.PathEscape(instanceID)), body, nil)
+}
+
+// DeleteSnapshot deletes a snapshot, with
+// DELETE /cloud/project/{serviceName}/snapshot/{imageId}
+func (c *Client) DeleteSnapshot(ctx context.Context, serviceName, snapshotID string) error {
+	return c.client.DeleteWithContext(ctx, projectPath(serviceName, "/snapshot/%s", url.PathEscape(snapshotID)), nil)
+}
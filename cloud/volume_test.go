@@ -0,0 +1,79 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestVolumes(t *testing.T) {
+	// Init test
+	volume := `{"id": "vol-1", "name": "data", "description": "", "size": 10, "type": "classic", "status": "in-use", "region": "GRA7", "bootable": false, "attachedTo": ["inst-1"], "creationDate": "2020-01-01T10:00:00Z"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/volume", http.StatusOK, "["+volume+"]")
+	server.Handle("POST", "/cloud/project/abc123/volume", http.StatusOK, volume)
+	server.Handle("POST", "/cloud/project/abc123/volume/vol-1/attach", http.StatusOK, volume)
+	server.Handle("POST", "/cloud/project/abc123/volume/vol-1/detach", http.StatusOK, volume)
+	server.Handle("DELETE", "/cloud/project/abc123/volume/vol-1", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	volumes, err := client.Volumes(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("Volumes should not return an error. Got %v", err)
+	}
+	if _, err := client.CreateVolume(ctx, "abc123", VolumeCreation{Name: "data", Size: 10, Region: "GRA7"}); err != nil {
+		t.Fatalf("CreateVolume should not return an error. Got %v", err)
+	}
+	if _, err := client.AttachVolume(ctx, "abc123", "vol-1", "inst-1"); err != nil {
+		t.Fatalf("AttachVolume should not return an error. Got %v", err)
+	}
+	if _, err := client.DetachVolume(ctx, "abc123", "vol-1", "inst-1"); err != nil {
+		t.Fatalf("DetachVolume should not return an error. Got %v", err)
+	}
+	if err := client.DeleteVolume(ctx, "abc123", "vol-1"); err != nil {
+		t.Fatalf("DeleteVolume should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(volumes) != 1 || volumes[0].Size != 10 || volumes[0].AttachedTo[0] != "inst-1" {
+		t.Fatalf("Volumes should decode the response. Got %+v", volumes)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/volume"); string(request.Body) != `{"name":"data","size":10,"region":"GRA7"}` {
+		t.Fatalf("CreateVolume should send the creation parameters. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/volume/vol-1/attach"); string(request.Body) != `{"instanceId":"inst-1"}` {
+		t.Fatalf("AttachVolume should send the instance. Got %s", request.Body)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/cloud/project/abc123/snapshot", http.StatusOK, `[{"id": "snap-1", "name": "backup", "status": "active", "region": "GRA7", "size": 1.5, "minDisk": 10, "visibility": "private", "creationDate": "2020-01-01T10:00:00Z"}]`)
+	server.Handle("POST", "/cloud/project/abc123/instance/inst-1/snapshot", http.StatusOK, `null`)
+	server.Handle("DELETE", "/cloud/project/abc123/snapshot/snap-1", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	if err := client.CreateSnapshot(ctx, "abc123", "inst-1", "backup"); err != nil {
+		t.Fatalf("CreateSnapshot should not return an error. Got %v", err)
+	}
+	snapshots, err := client.Snapshots(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("Snapshots should not return an error. Got %v", err)
+	}
+	if err := client.DeleteSnapshot(ctx, "abc123", snapshots[0].ID); err != nil {
+		t.Fatalf("DeleteSnapshot should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if snapshots[0].Size != 1.5 || snapshots[0].MinDisk != 10 {
+		t.Fatalf("Snapshots should decode the response. Got %+v", snapshots)
+	}
+	if request, _ := server.LastRequest("POST", "/cloud/project/abc123/instance/inst-1/snapshot"); string(request.Body) != `{"snapshotName":"backup"}` {
+		t.Fatalf("CreateSnapshot should send the snapshot name. Got %s", request.Body)
+	}
+}
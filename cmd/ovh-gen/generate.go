@@ -0,0 +1,448 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ovh/go-ovh/schema"
+)
+
+// primitiveTypes maps the API primitive types to Go types. Types which are
+// neither primitive nor models, like "ip" or "password", are strings.
+var primitiveTypes = map[string]string{
+	"boolean":  "bool",
+	"long":     "int64",
+	"int":      "int64",
+	"double":   "float64",
+	"float":    "float64",
+	"string":   "string",
+	"datetime": "time.Time",
+	"time":     "string",
+	"date":     "string",
+}
+
+// generator generates the bindings of a set of section schemas
+type generator struct {
+	pkg    string
+	models map[string]schema.Model
+	// Go type names of the models
+	names map[string]string
+	// Imports of the file being generated
+	imports map[string]bool
+}
+
+// generate returns the generated files of a package, by name: a file with
+// the client, a file with all the models and a file per section
+func generate(pkg string, schemas []*schema.Schema) (map[string][]byte, error) {
+	g := &generator{pkg: pkg, models: map[string]schema.Model{}, names: map[string]string{}, imports: map[string]bool{}}
+	for _, s := range schemas {
+		for name, model := range s.Models {
+			// Models shared by several sections are identical
+			g.models[genericBase(name)] = model
+		}
+	}
+
+	// Name the models in a stable order, as different names may give the
+	// same identifier
+	modelNames := make([]string, 0, len(g.models))
+	for name := range g.models {
+		modelNames = append(modelNames, name)
+	}
+	sort.Strings(modelNames)
+	used := map[string]bool{"Client": true}
+	for _, name := range modelNames {
+		g.names[name] = uniqueName(exportedName(name), used)
+	}
+
+	files := map[string][]byte{}
+	var err error
+	g.imports["github.com/ovh/go-ovh/ovh"] = true
+	if files["client.go"], err = g.format(g.client()); err != nil {
+		return nil, err
+	}
+	if files["models.go"], err = g.format(g.modelsFile()); err != nil {
+		return nil, err
+	}
+	for _, s := range schemas {
+		name := strings.Replace(strings.Trim(s.ResourcePath, "/"), "/", "_", -1) + ".go"
+		if files[name], err = g.format(g.section(s)); err != nil {
+			return nil, fmt.Errorf("%s: %v", s.ResourcePath, err)
+		}
+	}
+	return files, nil
+}
+
+// format adds the file header, with the imports used, and formats the
+// generated code
+func (g *generator) format(body string) ([]byte, error) {
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	g.imports = map[string]bool{}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by ovh-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+	if len(imports) > 0 {
+		// Standard library first
+		b.WriteString("import (\n")
+		for _, external := range []bool{false, true} {
+			for i, imp := range imports {
+				if strings.Contains(imp, ".") != external {
+					continue
+				}
+				if external && i > 0 && !strings.Contains(imports[i-1], ".") {
+					b.WriteString("\n")
+				}
+				fmt.Fprintf(&b, "\t%q\n", imp)
+			}
+		}
+		b.WriteString(")\n\n")
+	}
+	b.WriteString(body)
+
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %v\n%s", err, b.Bytes())
+	}
+	return out, nil
+}
+
+// client returns the Client type of the package
+func (g *generator) client() string {
+	return `// Client gives access to the generated routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+`
+}
+
+// modelsFile returns the types of all the models, sorted by name
+func (g *generator) modelsFile() string {
+	names := make([]string, 0, len(g.models))
+	for name := range g.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		g.model(&b, name, g.models[name])
+	}
+	return b.String()
+}
+
+// model writes the type of a model: a string type with constants for
+// enumerations, a struct otherwise
+func (g *generator) model(b *strings.Builder, name string, model schema.Model) {
+	typeName := g.names[name]
+	writeComment(b, typeName, "is", name, model.Description)
+	if len(model.Enum) > 0 {
+		enumType := g.goType(model.EnumType, nil)
+		fmt.Fprintf(b, "type %s %s\n\n", typeName, enumType)
+		b.WriteString("const (\n")
+		used := map[string]bool{}
+		for _, value := range model.Enum {
+			constant := uniqueName(typeName+exportedName(value), used)
+			if enumType == "string" {
+				fmt.Fprintf(b, "\t%s %s = %q\n", constant, typeName, value)
+			} else {
+				fmt.Fprintf(b, "\t%s %s = %s\n", constant, typeName, value)
+			}
+		}
+		b.WriteString(")\n\n")
+		return
+	}
+
+	fmt.Fprintf(b, "type %s struct {\n", typeName)
+	properties := make([]string, 0, len(model.Properties))
+	for property := range model.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	for _, property := range properties {
+		p := model.Properties[property]
+		if p.Description != "" {
+			fmt.Fprintf(b, "\t// %s\n", oneLine(p.Description))
+		}
+		goType := g.goType(p.FullType, model.Generics)
+		if p.CanBeNull && !strings.HasPrefix(goType, "[]") && goType != "json.RawMessage" {
+			goType = "*" + goType
+		}
+		// Writable values are always sent, so that false or zero values
+		// can be set
+		tag := property
+		if p.CanBeNull || p.ReadOnly {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:\"%s\"`\n", exportedName(property), goType, tag)
+	}
+	b.WriteString("}\n\n")
+}
+
+// section returns the methods of the operations of a section
+func (g *generator) section(s *schema.Schema) string {
+	var b strings.Builder
+	for _, api := range s.APIs {
+		for _, operation := range api.Operations {
+			g.operation(&b, api, operation)
+		}
+	}
+	return b.String()
+}
+
+// operation writes the method calling an operation, along with the type of
+// its query and body parameters, if any
+func (g *generator) operation(b *strings.Builder, api schema.API, operation schema.Operation) {
+	name := exportedName(strings.ToLower(operation.HTTPMethod) + " " + api.Path)
+
+	var pathParams, queryParams, bodyParams []schema.Parameter
+	for _, param := range operation.Parameters {
+		switch param.ParamType {
+		case "path":
+			pathParams = append(pathParams, param)
+		case "query":
+			queryParams = append(queryParams, param)
+		case "body":
+			bodyParams = append(bodyParams, param)
+		}
+	}
+
+	// A single unnamed body parameter is the whole body
+	wholeBody := len(bodyParams) == 1 && bodyParams[0].Name == ""
+	paramsType := name + "Params"
+	if len(queryParams) > 0 || (len(bodyParams) > 0 && !wholeBody) {
+		fmt.Fprintf(b, "// %s are the parameters of %s\n", paramsType, name)
+		fmt.Fprintf(b, "type %s struct {\n", paramsType)
+		for _, param := range queryParams {
+			g.paramField(b, param, "-")
+		}
+		for _, param := range bodyParams {
+			g.paramField(b, param, param.Name)
+		}
+		b.WriteString("}\n\n")
+	} else {
+		paramsType = ""
+	}
+
+	// Signature
+	writeComment(b, name, "calls", operation.HTTPMethod+" "+api.Path, operation.Description)
+	if operation.APIStatus.Value == "DEPRECATED" {
+		b.WriteString("//\n// Deprecated: this operation is deprecated by the API")
+		if operation.APIStatus.Replacement != "" {
+			fmt.Fprintf(b, ", use %s instead", operation.APIStatus.Replacement)
+		}
+		b.WriteString(".\n")
+	}
+	g.imports["context"] = true
+	args := []string{"ctx context.Context"}
+	for _, param := range pathParams {
+		args = append(args, fmt.Sprintf("%s %s", unexportedName(param.Name), g.goType(param.FullType, nil)))
+	}
+	if wholeBody {
+		args = append(args, "body "+g.goType(bodyParams[0].FullType, nil))
+	} else if paramsType != "" {
+		args = append(args, "params *"+paramsType)
+	}
+	resType := ""
+	if operation.ResponseType != "" && operation.ResponseType != "void" {
+		resType = g.goType(operation.ResponseType, nil)
+	}
+	if resType != "" {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(args, ", "), resType)
+	} else {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+	}
+
+	// Path, with the path parameters escaped
+	path := api.Path
+	var pathArgs []string
+	for _, param := range pathParams {
+		path = strings.Replace(path, "{"+param.Name+"}", "%s", -1)
+		pathArgs = append(pathArgs, fmt.Sprintf("url.PathEscape(fmt.Sprint(%s))", unexportedName(param.Name)))
+	}
+	if len(pathArgs) > 0 {
+		g.imports["fmt"] = true
+		g.imports["net/url"] = true
+		fmt.Fprintf(b, "\tpath := fmt.Sprintf(%q, %s)\n", path, strings.Join(pathArgs, ", "))
+	} else {
+		fmt.Fprintf(b, "\tpath := %q\n", path)
+	}
+	if len(queryParams) > 0 {
+		g.imports["net/url"] = true
+		b.WriteString("\tquery := url.Values{}\n")
+		b.WriteString("\tif params != nil {\n")
+		for _, param := range queryParams {
+			field := "params." + exportedName(param.Name)
+			value := field
+			if !param.Required {
+				value = "(*" + field + ")"
+			}
+			if param.FullType == "datetime" {
+				value += ".Format(time.RFC3339)"
+			} else {
+				g.imports["fmt"] = true
+				value = "fmt.Sprint(" + value + ")"
+			}
+			if param.Required {
+				fmt.Fprintf(b, "\t\tquery.Set(%q, %s)\n", param.Name, value)
+			} else {
+				fmt.Fprintf(b, "\t\tif %s != nil {\n\t\t\tquery.Set(%q, %s)\n\t\t}\n", field, param.Name, value)
+			}
+		}
+		b.WriteString("\t}\n")
+		b.WriteString("\tif len(query) > 0 {\n\t\tpath += \"?\" + query.Encode()\n\t}\n")
+	}
+
+	// Call
+	body := "nil"
+	if wholeBody {
+		body = "body"
+	} else if len(bodyParams) > 0 {
+		body = "params"
+	}
+	needAuth := !operation.NoAuthentication
+	if resType != "" {
+		fmt.Fprintf(b, "\tvar res %s\n", resType)
+		fmt.Fprintf(b, "\terr := c.client.CallAPIWithContext(ctx, %q, path, %s, &res, %v)\n", operation.HTTPMethod, body, needAuth)
+		b.WriteString("\treturn res, err\n")
+	} else {
+		fmt.Fprintf(b, "\treturn c.client.CallAPIWithContext(ctx, %q, path, %s, nil, %v)\n", operation.HTTPMethod, body, needAuth)
+	}
+	b.WriteString("}\n\n")
+}
+
+// paramField writes the field of a query or body parameter. Optional
+// parameters are pointers, so that they can be omitted.
+func (g *generator) paramField(b *strings.Builder, param schema.Parameter, jsonName string) {
+	if param.Description != "" {
+		fmt.Fprintf(b, "\t// %s\n", oneLine(param.Description))
+	}
+	goType := g.goType(param.FullType, nil)
+	tag := jsonName
+	if !param.Required {
+		if !strings.HasPrefix(goType, "[]") && goType != "json.RawMessage" {
+			goType = "*" + goType
+		}
+		if tag != "-" {
+			tag += ",omitempty"
+		}
+	}
+	fmt.Fprintf(b, "\t%s %s `json:\"%s\"`\n", exportedName(param.Name), goType, tag)
+}
+
+// goType returns the Go type of an API type. "generics" are the type
+// parameters of the model being generated, if any, which are raw JSON.
+func (g *generator) goType(apiType string, generics []string) string {
+	if strings.HasSuffix(apiType, "[]") {
+		return "[]" + g.goType(strings.TrimSuffix(apiType, "[]"), generics)
+	}
+	if strings.HasPrefix(apiType, "map[") {
+		if i := strings.Index(apiType, "]"); i > 0 {
+			return "map[string]" + g.goType(apiType[i+1:], generics)
+		}
+	}
+	for _, generic := range generics {
+		if apiType == generic {
+			g.imports["encoding/json"] = true
+			return "json.RawMessage"
+		}
+	}
+	if goType, ok := primitiveTypes[apiType]; ok {
+		if goType == "time.Time" {
+			g.imports["time"] = true
+		}
+		return goType
+	}
+	if name, ok := g.names[genericBase(apiType)]; ok {
+		return name
+	}
+	if strings.Contains(apiType, ".") {
+		// Model of another section
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+	return "string"
+}
+
+// genericBase returns the name of a generic model without its type
+// parameters, for instance "complexType.UnitAndValue" for
+// "complexType.UnitAndValue<long>"
+func genericBase(name string) string {
+	if i := strings.Index(name, "<"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// exportedName converts a name, like "billId", "dedicated.server.Task" or
+// "get /me/bill/{billId}", to an exported Go identifier
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" || unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
+
+// uniqueName returns "name", with a numeric suffix if it is already used,
+// and marks it as used
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// unexportedName converts a name to an unexported Go identifier, avoiding
+// the Go keywords and the names used by the generated methods
+func unexportedName(name string) string {
+	out := exportedName(name)
+	out = strings.ToLower(out[:1]) + out[1:]
+	switch out {
+	case "ctx", "path", "query", "body", "params", "res", "err", "type", "func", "range", "default", "interface", "map", "select", "go", "package":
+		out += "Param"
+	}
+	return out
+}
+
+// writeComment writes the doc comment of a generated identifier, "name verb
+// origin: description"
+func writeComment(b *strings.Builder, name, verb, origin, description string) {
+	if description == "" {
+		fmt.Fprintf(b, "// %s %s %s\n", name, verb, origin)
+		return
+	}
+	fmt.Fprintf(b, "// %s %s %s: %s\n", name, verb, origin, oneLine(description))
+}
+
+// oneLine removes the line breaks of a description
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ovh/go-ovh/schema"
+)
+
+// ovhStub declares the part of the ovh package used by the generated code
+const ovhStub = `package ovh
+
+import "context"
+
+type Client struct{}
+
+func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, reqBody, resType interface{}, needAuth bool) error {
+	return nil
+}
+`
+
+// stubImporter imports the standard library, and the ovh stub
+type stubImporter struct {
+	std types.Importer
+	ovh *types.Package
+}
+
+func (i stubImporter) Import(path string) (*types.Package, error) {
+	if path == "github.com/ovh/go-ovh/ovh" {
+		return i.ovh, nil
+	}
+	return i.std.Import(path)
+}
+
+// typeCheck checks that the generated files compile
+func typeCheck(t *testing.T, files map[string][]byte) *types.Package {
+	fset := token.NewFileSet()
+	stub, err := parser.ParseFile(fset, "ovh.go", ovhStub, 0)
+	if err != nil {
+		t.Fatalf("Stub should parse. Got %v", err)
+	}
+	std := importer.Default()
+	ovh, err := (&types.Config{Importer: std}).Check("github.com/ovh/go-ovh/ovh", fset, []*ast.File{stub}, nil)
+	if err != nil {
+		t.Fatalf("Stub should type check. Got %v", err)
+	}
+
+	var parsed []*ast.File
+	for name, content := range files {
+		file, err := parser.ParseFile(fset, name, content, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("%s should parse. Got %v", name, err)
+		}
+		parsed = append(parsed, file)
+	}
+	pkg, err := (&types.Config{Importer: stubImporter{std, ovh}}).Check("me", fset, parsed, nil)
+	if err != nil {
+		t.Fatalf("Generated code should type check. Got %v", err)
+	}
+	return pkg
+}
+
+func loadTestSchemas(t *testing.T) map[string][]byte {
+	s, err := readSchema("testdata")(nil, "/me")
+	if err != nil {
+		t.Fatalf("readSchema should not fail. Got %v", err)
+	}
+	files, err := generate("me", []*schema.Schema{s})
+	if err != nil {
+		t.Fatalf("generate should not fail. Got %v", err)
+	}
+	return files
+}
+
+func TestGenerate(t *testing.T) {
+	// Test
+	files := loadTestSchemas(t)
+	pkg := typeCheck(t, files)
+
+	// Validate
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "client.go,me.go,models.go" {
+		t.Fatalf("Generated files should be client.go, me.go and models.go. Got %v", names)
+	}
+
+	for name, signature := range map[string]string{
+		"GetMeBill":             "func(ctx context.Context, params *me.GetMeBillParams) ([]string, error)",
+		"GetMeBillBillId":       "func(ctx context.Context, billId string) (me.BillingBill, error)",
+		"PutMeSshKeyKeyName":    "func(ctx context.Context, keyName string, body me.NichandleSshKey) error",
+		"DeleteMeSshKeyKeyName": "func(ctx context.Context, keyName string) error",
+		"PostMeSshKey":          "func(ctx context.Context, params *me.PostMeSshKeyParams) error",
+		"PostMeGeolocation":     "func(ctx context.Context) (encoding/json.RawMessage, error)",
+	} {
+		method, _, _ := types.LookupFieldOrMethod(types.NewPointer(pkg.Scope().Lookup("Client").Type()), true, pkg, name)
+		if method == nil {
+			t.Fatalf("Method %s should be generated", name)
+		}
+		got := types.TypeString(method.Type(), func(p *types.Package) string { return p.Path() })
+		if got != signature {
+			t.Fatalf("%s signature should be '%s'. Got '%s'", name, signature, got)
+		}
+	}
+
+	models := string(files["models.go"])
+	for _, expected := range []string{
+		"type BillingCategoryEnum string",
+		`BillingCategoryEnumPurchaseCloud BillingCategoryEnum = "purchase-cloud"`,
+		"Category *BillingCategoryEnum `json:\"category,omitempty\"`",
+		"Date time.Time `json:\"date,omitempty\"`",
+		"PriceWithTax json.RawMessage `json:\"priceWithTax,omitempty\"`",
+		"Tags []string `json:\"tags,omitempty\"`",
+		"Volume ComplexTypeUnitAndValue `json:\"volume,omitempty\"`",
+		"Value json.RawMessage `json:\"value\"`",
+		"Default bool `json:\"default\"`",
+	} {
+		if !strings.Contains(squeeze(models), expected) {
+			t.Fatalf("models.go should contain:\n%s\nGot:\n%s", expected, models)
+		}
+	}
+
+	me := squeeze(string(files["me.go"]))
+	for _, expected := range []string{
+		`Deprecated: this operation is deprecated by the API, use /me/bill/{billId}/details instead.`,
+		`path := fmt.Sprintf("/me/bill/%s", url.PathEscape(fmt.Sprint(billId)))`,
+		`query.Set("category", fmt.Sprint(params.Category))`,
+		`query.Set("date.from", (*params.DateFrom).Format(time.RFC3339))`,
+		"DateFrom *time.Time `json:\"-\"`",
+		"Default *bool `json:\"default,omitempty\"`",
+		`c.client.CallAPIWithContext(ctx, "POST", path, nil, &res, false)`,
+		`c.client.CallAPIWithContext(ctx, "PUT", path, body, nil, true)`,
+	} {
+		if !strings.Contains(me, expected) {
+			t.Fatalf("me.go should contain:\n%s\nGot:\n%s", expected, me)
+		}
+	}
+}
+
+func TestGeneratePackage(t *testing.T) {
+	// Init test
+	out, err := ioutil.TempDir("", "ovh-gen")
+	if err != nil {
+		t.Fatalf("TempDir should not fail. Got %v", err)
+	}
+	defer os.RemoveAll(out)
+
+	// Test
+	var stderr bytes.Buffer
+	code := run([]string{"-schemas", "testdata", "-out", filepath.Join(out, "my-me"), "/me"}, &stderr)
+
+	// Validate
+	if code != 0 {
+		t.Fatalf("ovh-gen should succeed. Got %d: %s", code, stderr.String())
+	}
+	content, err := ioutil.ReadFile(filepath.Join(out, "my-me", "client.go"))
+	if err != nil || !strings.Contains(string(content), "package myme\n") {
+		t.Fatalf("client.go should be written in package myme. Got %v:\n%s", err, content)
+	}
+
+	if code := run([]string{"-schemas", "testdata", "-out", out, "/unknown"}, &stderr); code != 1 {
+		t.Fatalf("ovh-gen should fail with unknown sections. Got %d", code)
+	}
+	if code := run([]string{"/me"}, &stderr); code != 2 {
+		t.Fatalf("ovh-gen should require -out. Got %d", code)
+	}
+}
+
+func TestNames(t *testing.T) {
+	for name, expected := range map[string]string{
+		"billId":                "BillId",
+		"dedicated.server.Task": "DedicatedServerTask",
+		"get /me/bill/{billId}": "GetMeBillBillId",
+		"purchase-cloud":        "PurchaseCloud",
+		"2048":                  "X2048",
+	} {
+		if got := exportedName(name); got != expected {
+			t.Fatalf("exportedName(%q) should be '%s'. Got '%s'", name, expected, got)
+		}
+	}
+	if got := unexportedName("type"); got != "typeParam" {
+		t.Fatalf("Keywords should be suffixed. Got '%s'", got)
+	}
+	used := map[string]bool{}
+	if uniqueName("A", used) != "A" || uniqueName("A", used) != "A2" {
+		t.Fatalf("uniqueName should suffix used names")
+	}
+}
+
+// squeeze collapses the whitespace of generated code, aligned by gofmt
+func squeeze(s string) string {
+	return regexp.MustCompile(`[ \t]+`).ReplaceAllString(s, " ")
+}
@@ -0,0 +1,126 @@
+// Command ovh-gen generates typed Go bindings for sections of the OVH API,
+// from the schemas served by the API: a struct or enumeration type per model
+// and a method per operation, on top of the github.com/ovh/go-ovh/ovh client.
+//
+// Usage:
+//
+//	ovh-gen [-endpoint NAME] [-schemas DIR] -out DIR [-package NAME] SECTION...
+//
+// Sections are API paths, like /me or /domain/zone. Schemas are downloaded
+// with the client configuration, see github.com/ovh/go-ovh/ovh, or read from
+// the "-schemas" directory, where the schema of /domain/zone is
+// domain_zone.json. The generated package is used as:
+//
+//	client, _ := ovh.NewDefaultClient()
+//	api := me.New(client)
+//	bill, err := api.GetMeBillBillId(ctx, "FR1234")
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ovh/go-ovh/ovh"
+	"github.com/ovh/go-ovh/schema"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run runs the command line "args" and returns the exit code
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ovh-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	endpoint := flags.String("endpoint", "", "endpoint name or URL to download the schemas from, from the configuration by default")
+	schemaDir := flags.String("schemas", "", "directory to read the schemas from, instead of downloading them")
+	out := flags.String("out", "", "output directory of the generated package")
+	pkg := flags.String("package", "", "name of the generated package, the output directory name by default")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: ovh-gen [-endpoint NAME] [-schemas DIR] -out DIR [-package NAME] SECTION...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *out == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	if *pkg == "" {
+		abs, err := filepath.Abs(*out)
+		if err != nil {
+			fmt.Fprintf(stderr, "ovh-gen: %v\n", err)
+			return 1
+		}
+		*pkg = strings.ToLower(strings.Replace(filepath.Base(abs), "-", "", -1))
+	}
+
+	if err := generatePackage(*endpoint, *schemaDir, *out, *pkg, flags.Args()); err != nil {
+		fmt.Fprintf(stderr, "ovh-gen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generatePackage loads the schemas of the sections and writes the generated
+// files in "out"
+func generatePackage(endpoint, schemaDir, out, pkg string, sections []string) error {
+	loader := readSchema(schemaDir)
+	if schemaDir == "" {
+		client, err := ovh.NewEndpointClient(endpoint)
+		if err != nil {
+			return err
+		}
+		loader = schema.New(client).GetSchema
+	}
+
+	schemas := make([]*schema.Schema, 0, len(sections))
+	for _, section := range sections {
+		s, err := loader(context.Background(), section)
+		if err != nil {
+			return fmt.Errorf("unable to load the schema of %s: %v", section, err)
+		}
+		if s.ResourcePath == "" {
+			s.ResourcePath = "/" + strings.Trim(section, "/")
+		}
+		schemas = append(schemas, s)
+	}
+
+	files, err := generate(pkg, schemas)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(out, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSchema returns a loader of the schemas stored in "dir"
+func readSchema(dir string) func(context.Context, string) (*schema.Schema, error) {
+	return func(ctx context.Context, section string) (*schema.Schema, error) {
+		name := strings.Replace(strings.Trim(section, "/"), "/", "_", -1) + ".json"
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		s := &schema.Schema{}
+		if err := json.Unmarshal(data, s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// callCommand returns the command calling the API with "method"
+func callCommand(method string) command {
+	return func(opts *globalOptions, args []string, stdout, stderr io.Writer) error {
+		flags := flag.NewFlagSet(method, flag.ContinueOnError)
+		flags.SetOutput(stderr)
+		data := flags.String("data", "", "JSON request body")
+		unauth := flags.Bool("unauth", false, "send the request without authentication")
+		positional, err := parseCommand(flags, args)
+		if err != nil {
+			return err
+		}
+		if len(positional) != 1 {
+			return fmt.Errorf("expected a single PATH argument, got %d", len(positional))
+		}
+
+		var reqBody interface{}
+		if *data != "" {
+			var body json.RawMessage
+			if err := json.Unmarshal([]byte(*data), &body); err != nil {
+				return fmt.Errorf("invalid JSON data: %v", err)
+			}
+			reqBody = body
+		}
+
+		client, err := opts.newClient(stderr)
+		if err != nil {
+			return err
+		}
+		request := client.Request(method, positional[0]).Body(reqBody)
+		if *unauth {
+			request = request.UnAuth()
+		}
+		response, err := request.DoFull(context.Background())
+		if err != nil {
+			return err
+		}
+		return printBody(stdout, opts.output, response.Body)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// configCommand prints the resolved configuration, with masked secrets, and
+// the time delta with the API, to debug configuration and signature issues.
+// With -diagnose, it prints where each value was read from instead, without
+// calling the API, even when the configuration is invalid.
+func configCommand(opts *globalOptions, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	diagnose := flags.Bool("diagnose", false, "report the configuration files and the source of each value")
+	positional, err := parseCommand(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("unexpected arguments %q", positional)
+	}
+	if *diagnose {
+		return diagnoseCommand(opts, stdout)
+	}
+
+	client, err := opts.newClient(stderr)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "endpoint\t%s\n", client.Endpoint())
+	if client.ClientID != "" {
+		fmt.Fprintf(w, "client_id\t%s\n", client.ClientID)
+		fmt.Fprintf(w, "client_secret\t%s\n", mask(client.ClientSecret))
+	} else {
+		fmt.Fprintf(w, "application_key\t%s\n", client.AppKey)
+		fmt.Fprintf(w, "application_secret\t%s\n", mask(client.AppSecret))
+		fmt.Fprintf(w, "consumer_key\t%s\n", mask(client.ConsumerKey))
+	}
+	if delta, err := client.TimeDeltaWithContext(context.Background()); err != nil {
+		fmt.Fprintf(w, "time_delta\tunavailable: %v\n", err)
+	} else {
+		fmt.Fprintf(w, "time_delta\t%v\n", delta)
+	}
+	return w.Flush()
+}
+
+// diagnoseCommand prints the configuration report of ovh.DiagnoseConfig
+func diagnoseCommand(opts *globalOptions, stdout io.Writer) error {
+	var options []ovh.Option
+	if opts.profile != "" {
+		options = append(options, ovh.WithProfile(opts.profile))
+	}
+	report, err := ovh.DiagnoseConfig(opts.endpoint, options...)
+	if report != nil {
+		fmt.Fprint(stdout, report)
+	}
+	return err
+}
+
+// mask hides all but the first characters of a secret, so that the right one
+// can be recognized
+func mask(secret string) string {
+	if secret == "" {
+		return "(none)"
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return secret[:4] + "****"
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// accessLevels maps the -access values to their methods
+var accessLevels = map[string][]string{
+	"ro":  ovh.ReadOnly,
+	"rw":  ovh.ReadWrite,
+	"rws": ovh.ReadWriteSafe,
+}
+
+// loginCommand requests a new consumer key and prints its validation URL
+func loginCommand(opts *globalOptions, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("login", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	access := flags.String("access", "rw", `access level, "ro", "rw" or "rws" (read, write, no delete)`)
+	path := flags.String("path", "/", "path the consumer key is restricted to, along with its sub-paths")
+	redirection := flags.String("redirection", "", "URL the user is redirected to after the validation")
+	positional, err := parseCommand(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("unexpected arguments %q", positional)
+	}
+	methods, ok := accessLevels[*access]
+	if !ok {
+		return fmt.Errorf("unknown access level %q", *access)
+	}
+
+	client, err := opts.newClient(stderr)
+	if err != nil {
+		return err
+	}
+	request := client.NewCkRequestWithRedirection(*redirection)
+	request.AddRecursiveRules(methods, *path)
+	state, err := request.DoWithContext(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Visit %s to validate the consumer key, then add it to your configuration:\n\n", state.ValidationURL)
+	fmt.Fprintf(stdout, "consumer_key=%s\n", state.ConsumerKey)
+	return nil
+}
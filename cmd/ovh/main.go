@@ -0,0 +1,123 @@
+// Command ovh calls the OVH API from the command line. It reads the same
+// configuration as the library, see github.com/ovh/go-ovh/ovh.
+//
+// Usage:
+//
+//	ovh [flags] get|post|put|delete PATH [-data JSON] [-unauth]
+//	ovh [flags] login [-access ro|rw|rws] [-path PATH] [-redirection URL]
+//	ovh [flags] config [-diagnose]
+//
+// Flags:
+//
+//	-endpoint NAME   endpoint name or URL, from the configuration by default
+//	-profile NAME    configuration profile
+//	-output FORMAT   "json", the default, or "table"
+//	-debug           log the requests and responses on stderr
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// globalOptions are the flags shared by all commands
+type globalOptions struct {
+	endpoint string
+	profile  string
+	output   string
+	debug    bool
+}
+
+// command runs a command with its arguments
+type command func(opts *globalOptions, args []string, stdout, stderr io.Writer) error
+
+var commands = map[string]command{
+	"get":    callCommand("GET"),
+	"post":   callCommand("POST"),
+	"put":    callCommand("PUT"),
+	"delete": callCommand("DELETE"),
+	"login":  loginCommand,
+	"config": configCommand,
+}
+
+const usage = `Usage:
+  ovh [flags] get|post|put|delete PATH [-data JSON] [-unauth]
+  ovh [flags] login [-access ro|rw|rws] [-path PATH] [-redirection URL]
+  ovh [flags] config [-diagnose]
+
+Flags:
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command line "args" and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	opts := &globalOptions{}
+	flags := flag.NewFlagSet("ovh", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.endpoint, "endpoint", "", "endpoint name or URL, from the configuration by default")
+	flags.StringVar(&opts.profile, "profile", "", "configuration profile")
+	flags.StringVar(&opts.output, "output", "json", `output format, "json" or "table"`)
+	flags.BoolVar(&opts.debug, "debug", false, "log the requests and responses on stderr")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if opts.output != "json" && opts.output != "table" {
+		fmt.Fprintf(stderr, "ovh: unknown output format %q\n", opts.output)
+		return 2
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		flags.Usage()
+		return 2
+	}
+	if err := cmd(opts, flags.Args()[1:], stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "ovh: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newClient creates a client from the configuration
+func (opts *globalOptions) newClient(stderr io.Writer) (*ovh.Client, error) {
+	var options []ovh.Option
+	if opts.profile != "" {
+		options = append(options, ovh.WithProfile(opts.profile))
+	}
+	client, err := ovh.NewClientWithOptions(opts.endpoint, options...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.debug {
+		client.EnableDebugLog(stderr)
+	}
+	return client, nil
+}
+
+// parseCommand parses the flags of a command, which may be given before or
+// after its positional arguments
+func parseCommand(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
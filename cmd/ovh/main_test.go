@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+// initServer starts a fake API and points the configuration to it
+func initServer(t *testing.T) (*govhtest.Server, func()) {
+	server := govhtest.NewServer()
+	dir, err := ioutil.TempDir("", "ovh-cli")
+	if err != nil {
+		t.Fatalf("TempDir should not fail. Got %v", err)
+	}
+	config := filepath.Join(dir, "ovh.conf")
+	ioutil.WriteFile(config, []byte(`
+[default]
+endpoint=`+server.URL+`
+
+[`+server.URL+`]
+application_key=`+server.AppKey+`
+application_secret=`+server.AppSecret+`
+consumer_key=`+server.ConsumerKey+`
+`), 0600)
+	os.Setenv("OVH_CONFIG", config)
+
+	return server, func() {
+		os.Unsetenv("OVH_CONFIG")
+		os.RemoveAll(dir)
+		server.Close()
+	}
+}
+
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGet(t *testing.T) {
+	// Init test
+	server, teardown := initServer(t)
+	defer teardown()
+	server.Handle("GET", "/me", http.StatusOK, `{"nichandle":"xx1111-ovh","customerCode":12345678901234}`)
+
+	// Test
+	code, stdout, stderr := runCommand("get", "/me")
+
+	// Validate
+	if code != 0 {
+		t.Fatalf("get should succeed. Got %d: %s", code, stderr)
+	}
+	expected := "{\n  \"customerCode\": 12345678901234,\n  \"nichandle\": \"xx1111-ovh\"\n}\n"
+	if stdout != expected {
+		t.Fatalf("get should print indented JSON. Got %q", stdout)
+	}
+	if requests := server.Requests(); len(requests) != 1 || !requests[0].Authenticated {
+		t.Fatalf("get should send a signed request. Got %+v", requests)
+	}
+}
+
+func TestPostData(t *testing.T) {
+	// Init test
+	server, teardown := initServer(t)
+	defer teardown()
+	server.Handle("POST", "/domain/zone/example.com/refresh", http.StatusOK, "null")
+
+	// Test
+	code, _, stderr := runCommand("-output", "table", "post", "/domain/zone/example.com/refresh", "-data", `{"force": true}`)
+
+	// Validate
+	if code != 0 {
+		t.Fatalf("post should succeed. Got %d: %s", code, stderr)
+	}
+	if requests := server.Requests(); len(requests) != 1 || string(requests[0].Body) != `{"force":true}` {
+		t.Fatalf("post should send the data. Got %+v", requests)
+	}
+
+	if code, _, _ := runCommand("post", "/domain/zone/example.com/refresh", "-data", `{`); code != 1 {
+		t.Fatalf("post should fail with invalid JSON data. Got %d", code)
+	}
+}
+
+func TestCallError(t *testing.T) {
+	// Init test
+	_, teardown := initServer(t)
+	defer teardown()
+
+	// Test
+	code, _, stderr := runCommand("delete", "/me/sshKey/unknown")
+
+	// Validate
+	if code != 1 || !strings.Contains(stderr, "404") {
+		t.Fatalf("API errors should be reported. Got %d: %s", code, stderr)
+	}
+}
+
+func TestTableOutput(t *testing.T) {
+	// Init test
+	server, teardown := initServer(t)
+	defer teardown()
+	server.Handle("GET", "/dedicated/server", http.StatusOK, `["ns1.example.net","ns2.example.net"]`)
+	server.Handle("GET", "/me/api/credential/details", http.StatusOK, `[{"credentialId":1,"status":"validated"},{"credentialId":2,"rules":[{"method":"GET"}]}]`)
+
+	// Test
+	_, ids, _ := runCommand("-output", "table", "get", "/dedicated/server")
+	_, objects, _ := runCommand("-output", "table", "get", "/me/api/credential/details")
+
+	// Validate
+	if ids != "ns1.example.net\nns2.example.net\n" {
+		t.Fatalf("Lists of scalars should be printed one per line. Got %q", ids)
+	}
+	expected := "credentialId  rules               status\n" +
+		"1                                 validated\n" +
+		"2             [{\"method\":\"GET\"}]\n"
+	if objects != expected {
+		t.Fatalf("Lists of objects should be printed as a table. Got %q", objects)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	// Init test
+	server, teardown := initServer(t)
+	defer teardown()
+	server.Handle("POST", "/auth/credential", http.StatusOK, `{"consumerKey":"new-ck","state":"pendingValidation","validationUrl":"https://eu.api.ovh.com/auth/?credentialToken=x"}`)
+
+	// Test
+	code, stdout, stderr := runCommand("login", "-access", "ro", "-path", "/me")
+
+	// Validate
+	if code != 0 {
+		t.Fatalf("login should succeed. Got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "https://eu.api.ovh.com/auth/?credentialToken=x") || !strings.Contains(stdout, "consumer_key=new-ck") {
+		t.Fatalf("login should print the validation URL and consumer key. Got %q", stdout)
+	}
+	body := string(server.Requests()[0].Body)
+	if body != `{"accessRules":[{"method":"GET","path":"/me"},{"method":"GET","path":"/me/*"}]}` {
+		t.Fatalf("login should request the access rules. Got %s", body)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	// Init test
+	server, teardown := initServer(t)
+	defer teardown()
+
+	// Test
+	code, stdout, stderr := runCommand("config")
+
+	// Validate
+	if code != 0 {
+		t.Fatalf("config should succeed. Got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, server.URL) || !strings.Contains(stdout, server.AppKey) {
+		t.Fatalf("config should print the endpoint and application key. Got %q", stdout)
+	}
+	if strings.Contains(stdout, server.AppSecret) || strings.Contains(stdout, server.ConsumerKey) {
+		t.Fatalf("config should mask the secrets. Got %q", stdout)
+	}
+}
+
+func TestConfigDiagnose(t *testing.T) {
+	// Init test
+	server, teardown := initServer(t)
+	defer teardown()
+
+	// Test
+	code, stdout, stderr := runCommand("config", "-diagnose")
+
+	// Validate
+	if code != 0 {
+		t.Fatalf("config -diagnose should succeed. Got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "endpoint: "+server.URL) || !strings.Contains(stdout, "application_key="+server.AppKey+" (file ["+server.URL+"] application_key)") {
+		t.Fatalf("config -diagnose should print the sources of the values. Got %q", stdout)
+	}
+	if strings.Contains(stdout, server.AppSecret) || strings.Contains(stdout, server.ConsumerKey) {
+		t.Fatalf("config -diagnose should mask the secrets. Got %q", stdout)
+	}
+	if len(server.Requests()) != 0 {
+		t.Fatalf("config -diagnose should not call the API. Got %d requests", len(server.Requests()))
+	}
+}
+
+func TestUsage(t *testing.T) {
+	if code, _, stderr := runCommand("unknown"); code != 2 || !strings.Contains(stderr, "Usage:") {
+		t.Fatalf("Unknown commands should print the usage. Got %d: %s", code, stderr)
+	}
+	if code, _, _ := runCommand("-output", "xml", "get", "/me"); code != 2 {
+		t.Fatalf("Unknown output formats should be rejected. Got %d", code)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// printBody prints a JSON response body in the requested format
+func printBody(w io.Writer, format string, body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	// Keep numbers as is, large IDs would be altered by float64
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		// Not a JSON document, print as is
+		_, err := w.Write(body)
+		return err
+	}
+
+	if format == "table" {
+		return printTable(w, value)
+	}
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
+
+// printTable prints lists of objects with a column per key, objects with a
+// line per key, and other values one per line
+func printTable(w io.Writer, value interface{}) error {
+	var buffer bytes.Buffer
+	tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	switch v := value.(type) {
+	case []interface{}:
+		columns := tableColumns(v)
+		if len(columns) == 0 {
+			for _, item := range v {
+				fmt.Fprintln(tw, cell(item))
+			}
+			break
+		}
+		fmt.Fprintln(tw, strings.Join(columns, "\t"))
+		for _, item := range v {
+			object, _ := item.(map[string]interface{})
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				cells[i] = cell(object[column])
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(tw, "%s\t%s\n", key, cell(v[key]))
+		}
+	default:
+		fmt.Fprintln(tw, cell(v))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Empty last cells are padded, trim them
+	for _, line := range strings.SplitAfter(buffer.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, strings.TrimRight(line, " \n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableColumns returns the sorted keys of the objects of a list, if any
+func tableColumns(items []interface{}) []string {
+	seen := map[string]bool{}
+	columns := []string{}
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range object {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// cell formats a value for a table cell, nested values as compact JSON
+func cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		out, _ := json.Marshal(v)
+		return string(out)
+	default:
+		return fmt.Sprint(v)
+	}
+}
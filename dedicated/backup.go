@@ -0,0 +1,145 @@
+package dedicated
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Quantity is a value with its unit, like {"unit": "GB", "value": 500}
+type Quantity struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// BackupFTP represents the backup storage of a dedicated server.
+// Visit https://api.ovh.com/console/#/dedicated/server/%7BserviceName%7D/features/backupFTP#GET for the full definition
+type BackupFTP struct {
+	FTPBackupName string `json:"ftpBackupName"`
+	Type          string `json:"type"`
+	// Quota is the size of the storage, and Usage its used part, usually in %
+	Quota Quantity `json:"quota"`
+	Usage Quantity `json:"usage"`
+	// ReadOnlyDate is set when the storage is read-only, for instance after
+	// its quota was exceeded
+	ReadOnlyDate *time.Time `json:"readOnlyDate"`
+}
+
+// BackupFTPAccess represents the protocols allowed to an IP block on the
+// backup storage of a dedicated server
+type BackupFTPAccess struct {
+	IPBlock      string    `json:"ipBlock"`
+	FTP          bool      `json:"ftp"`
+	NFS          bool      `json:"nfs"`
+	CIFS         bool      `json:"cifs"`
+	IsApplied    bool      `json:"isApplied"`
+	LastUpdate   time.Time `json:"lastUpdate"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+// BackupFTPAccessCreation holds the parameters of a new backup storage
+// access
+type BackupFTPAccessCreation struct {
+	IPBlock string `json:"ipBlock"`
+	FTP     bool   `json:"ftp"`
+	NFS     bool   `json:"nfs"`
+	CIFS    bool   `json:"cifs"`
+}
+
+// BackupFTP returns the backup storage of a dedicated server, with
+// GET /dedicated/server/{serviceName}/features/backupFTP
+func (c *Client) BackupFTP(ctx context.Context, serviceName string) (*BackupFTP, error) {
+	backup := &BackupFTP{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/features/backupFTP"), backup); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// ActivateBackupFTP orders the backup storage of a dedicated server, with
+// POST /dedicated/server/{serviceName}/features/backupFTP
+func (c *Client) ActivateBackupFTP(ctx context.Context, serviceName string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, serverPath(serviceName, "/features/backupFTP"), nil, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ActivateBackupFTPAndWait orders the backup storage of a dedicated server,
+// waits for the activation task to be done, see WaitTask, and returns the
+// storage
+func (c *Client) ActivateBackupFTPAndWait(ctx context.Context, serviceName string, opts *ovh.WaitTaskOptions) (*BackupFTP, error) {
+	task, err := c.ActivateBackupFTP(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.WaitTask(ctx, serviceName, task.TaskID, opts); err != nil {
+		return nil, err
+	}
+	return c.BackupFTP(ctx, serviceName)
+}
+
+// DeleteBackupFTP terminates the backup storage of a dedicated server, with
+// DELETE /dedicated/server/{serviceName}/features/backupFTP. Its content is
+// lost.
+func (c *Client) DeleteBackupFTP(ctx context.Context, serviceName string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.DeleteWithContext(ctx, serverPath(serviceName, "/features/backupFTP"), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ResetBackupFTPPassword sends a new password of the backup storage by
+// email, with POST /dedicated/server/{serviceName}/features/backupFTP/password
+func (c *Client) ResetBackupFTPPassword(ctx context.Context, serviceName string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, serverPath(serviceName, "/features/backupFTP/password"), nil, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// BackupFTPAccessBlocks lists the IP blocks allowed on the backup storage,
+// with GET /dedicated/server/{serviceName}/features/backupFTP/access
+func (c *Client) BackupFTPAccessBlocks(ctx context.Context, serviceName string) ([]string, error) {
+	blocks := []string{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/features/backupFTP/access"), &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// BackupFTPAccess returns the access of an IP block to the backup storage,
+// with GET /dedicated/server/{serviceName}/features/backupFTP/access/{ipBlock}
+func (c *Client) BackupFTPAccess(ctx context.Context, serviceName, ipBlock string) (*BackupFTPAccess, error) {
+	access := &BackupFTPAccess{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/features/backupFTP/access/%s", url.PathEscape(ipBlock)), access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// CreateBackupFTPAccess allows an IP block on the backup storage, with
+// POST /dedicated/server/{serviceName}/features/backupFTP/access
+func (c *Client) CreateBackupFTPAccess(ctx context.Context, serviceName string, creation BackupFTPAccessCreation) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, serverPath(serviceName, "/features/backupFTP/access"), creation, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// DeleteBackupFTPAccess revokes the access of an IP block to the backup
+// storage, with
+// DELETE /dedicated/server/{serviceName}/features/backupFTP/access/{ipBlock}
+func (c *Client) DeleteBackupFTPAccess(ctx context.Context, serviceName, ipBlock string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.DeleteWithContext(ctx, serverPath(serviceName, "/features/backupFTP/access/%s", url.PathEscape(ipBlock)), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
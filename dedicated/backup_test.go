@@ -0,0 +1,64 @@
+package dedicated
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestBackupFTP(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/dedicated/server/ns1.example.net/features/backupFTP", http.StatusOK, `{"taskId": 11, "function": "createBackupFTP", "status": "init"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/task/11", http.StatusOK, `{"taskId": 11, "function": "createBackupFTP", "status": "done"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/features/backupFTP", http.StatusOK, `{"ftpBackupName": "ftpback-rbx1-1.ovh.net", "type": "included", "quota": {"unit": "GB", "value": 500}, "usage": {"unit": "%", "value": 12.5}, "readOnlyDate": null}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/features/backupFTP/access", http.StatusOK, `["198.51.100.1/32"]`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/features/backupFTP/access/198.51.100.1%2F32", http.StatusOK, `{"ipBlock": "198.51.100.1/32", "ftp": true, "nfs": false, "cifs": false, "isApplied": true}`)
+	server.Handle("POST", "/dedicated/server/ns1.example.net/features/backupFTP/access", http.StatusOK, `{"taskId": 12, "function": "applyBackupFtpAcls", "status": "init"}`)
+	server.Handle("DELETE", "/dedicated/server/ns1.example.net/features/backupFTP/access/198.51.100.1%2F32", http.StatusOK, `{"taskId": 13, "function": "applyBackupFtpAcls", "status": "init"}`)
+	server.Handle("POST", "/dedicated/server/ns1.example.net/features/backupFTP/password", http.StatusOK, `{"taskId": 14, "function": "changePasswordBackupFTP", "status": "init"}`)
+	server.Handle("DELETE", "/dedicated/server/ns1.example.net/features/backupFTP", http.StatusOK, `{"taskId": 15, "function": "removeBackupFTP", "status": "init"}`)
+	ctx := context.Background()
+
+	// Test
+	backup, err := client.ActivateBackupFTPAndWait(ctx, "ns1.example.net", nil)
+	if err != nil {
+		t.Fatalf("ActivateBackupFTPAndWait should not return an error. Got %v", err)
+	}
+	blocks, err := client.BackupFTPAccessBlocks(ctx, "ns1.example.net")
+	if err != nil {
+		t.Fatalf("BackupFTPAccessBlocks should not return an error. Got %v", err)
+	}
+	access, err := client.BackupFTPAccess(ctx, "ns1.example.net", blocks[0])
+	if err != nil {
+		t.Fatalf("BackupFTPAccess should not return an error. Got %v", err)
+	}
+	if _, err := client.CreateBackupFTPAccess(ctx, "ns1.example.net", BackupFTPAccessCreation{IPBlock: "198.51.100.2/32", NFS: true}); err != nil {
+		t.Fatalf("CreateBackupFTPAccess should not return an error. Got %v", err)
+	}
+	if _, err := client.DeleteBackupFTPAccess(ctx, "ns1.example.net", blocks[0]); err != nil {
+		t.Fatalf("DeleteBackupFTPAccess should not return an error. Got %v", err)
+	}
+	if _, err := client.ResetBackupFTPPassword(ctx, "ns1.example.net"); err != nil {
+		t.Fatalf("ResetBackupFTPPassword should not return an error. Got %v", err)
+	}
+	task, err := client.DeleteBackupFTP(ctx, "ns1.example.net")
+	if err != nil {
+		t.Fatalf("DeleteBackupFTP should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if backup.FTPBackupName != "ftpback-rbx1-1.ovh.net" || backup.Quota.Value != 500 || backup.Quota.Unit != "GB" || backup.Usage.Value != 12.5 || backup.ReadOnlyDate != nil {
+		t.Fatalf("BackupFTP should decode the response. Got %+v", backup)
+	}
+	if !access.FTP || access.NFS || !access.IsApplied {
+		t.Fatalf("BackupFTPAccess should decode the response. Got %+v", access)
+	}
+	if request, _ := server.LastRequest("POST", "/dedicated/server/ns1.example.net/features/backupFTP/access"); string(request.Body) != `{"ipBlock":"198.51.100.2/32","ftp":false,"nfs":true,"cifs":false}` {
+		t.Fatalf("CreateBackupFTPAccess should send the access. Got %s", request.Body)
+	}
+	if task.TaskID != 15 {
+		t.Fatalf("DeleteBackupFTP should return the task. Got %+v", task)
+	}
+}
@@ -0,0 +1,125 @@
+// Package dedicated provides typed helpers for the OVH dedicated server API,
+// under /dedicated/server: servers, reboots, tasks, netboot, interventions,
+// IPMI sessions and backup storage.
+package dedicated
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Task statuses
+const (
+	TaskStatusInit          = "init"
+	TaskStatusTodo          = "todo"
+	TaskStatusDoing         = "doing"
+	TaskStatusDone          = "done"
+	TaskStatusCancelled     = "cancelled"
+	TaskStatusCustomerError = "customerError"
+	TaskStatusOVHError      = "ovhError"
+)
+
+// Server represents a dedicated server.
+// Visit https://api.ovh.com/console/#/dedicated/server/%7BserviceName%7D#GET for the full definition
+type Server struct {
+	ServerID        int64  `json:"serverId"`
+	Name            string `json:"name"`
+	Reverse         string `json:"reverse"`
+	IP              string `json:"ip"`
+	Datacenter      string `json:"datacenter"`
+	Rack            string `json:"rack"`
+	OS              string `json:"os"`
+	State           string `json:"state"`
+	PowerState      string `json:"powerState"`
+	BootID          int64  `json:"bootId"`
+	RescueMail      string `json:"rescueMail"`
+	Monitoring      bool   `json:"monitoring"`
+	NoIntervention  bool   `json:"noIntervention"`
+	ProfessionalUse bool   `json:"professionalUse"`
+	CommercialRange string `json:"commercialRange"`
+	LinkSpeed       int    `json:"linkSpeed"`
+	SupportLevel    string `json:"supportLevel"`
+}
+
+// Task represents an asynchronous operation on a dedicated server.
+// Visit https://api.ovh.com/console/#/dedicated/server/%7BserviceName%7D/task/%7BtaskId%7D#GET for the full definition
+type Task struct {
+	TaskID     int64     `json:"taskId"`
+	Function   string    `json:"function"`
+	Status     string    `json:"status"`
+	Comment    string    `json:"comment"`
+	StartDate  time.Time `json:"startDate"`
+	DoneDate   time.Time `json:"doneDate"`
+	LastUpdate time.Time `json:"lastUpdate"`
+}
+
+// Client gives access to the /dedicated/server routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a dedicated server client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// serverPath returns the path of a route of a server
+func serverPath(serviceName, format string, args ...interface{}) string {
+	return fmt.Sprintf("/dedicated/server/%s", url.PathEscape(serviceName)) + fmt.Sprintf(format, args...)
+}
+
+// Servers lists the names of the dedicated servers, with GET /dedicated/server
+func (c *Client) Servers(ctx context.Context) ([]string, error) {
+	names := []string{}
+	if err := c.client.GetWithContext(ctx, "/dedicated/server", &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Server returns a dedicated server, with GET /dedicated/server/{serviceName}
+func (c *Client) Server(ctx context.Context, serviceName string) (*Server, error) {
+	server := &Server{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, ""), server); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// Reboot hard reboots a dedicated server, with
+// POST /dedicated/server/{serviceName}/reboot
+func (c *Client) Reboot(ctx context.Context, serviceName string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, serverPath(serviceName, "/reboot"), nil, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Task returns a task of a dedicated server, with
+// GET /dedicated/server/{serviceName}/task/{taskId}
+func (c *Client) Task(ctx context.Context, serviceName string, taskID int64) (*Task, error) {
+	task := &Task{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/task/%d", taskID), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// WaitTask waits until a task of a dedicated server is done, see
+// ovh.Client.WaitTask
+func (c *Client) WaitTask(ctx context.Context, serviceName string, taskID int64, opts *ovh.WaitTaskOptions) (*Task, error) {
+	response, err := c.client.WaitTask(ctx, serverPath(serviceName, "/task/{taskId}"), taskID, opts)
+	if err != nil {
+		return nil, err
+	}
+	task := &Task{}
+	if err := response.Unmarshal(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
@@ -0,0 +1,91 @@
+package dedicated
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/govhtest"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestServers(t *testing.T) {
+	// Init test
+	task := `{"taskId": 7, "function": "hardReboot", "status": "init", "comment": "Reboot asked", "startDate": "2020-01-01T10:00:00+01:00", "doneDate": null, "lastUpdate": "2020-01-01T10:00:00+01:00"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dedicated/server", http.StatusOK, `["ns1.example.net"]`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net", http.StatusOK, `{"serverId": 1, "name": "ns1.example.net", "ip": "198.51.100.1", "datacenter": "rbx8", "os": "debian11_64", "state": "ok", "powerState": "poweron", "bootId": 1, "monitoring": true, "linkSpeed": 1000}`)
+	server.Handle("POST", "/dedicated/server/ns1.example.net/reboot", http.StatusOK, task)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/task/7", http.StatusOK, task)
+	ctx := context.Background()
+
+	// Test
+	names, err := client.Servers(ctx)
+	if err != nil {
+		t.Fatalf("Servers should not return an error. Got %v", err)
+	}
+	details, err := client.Server(ctx, names[0])
+	if err != nil {
+		t.Fatalf("Server should not return an error. Got %v", err)
+	}
+	rebootTask, err := client.Reboot(ctx, details.Name)
+	if err != nil {
+		t.Fatalf("Reboot should not return an error. Got %v", err)
+	}
+	fetched, err := client.Task(ctx, details.Name, rebootTask.TaskID)
+	if err != nil {
+		t.Fatalf("Task should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if details.IP != "198.51.100.1" || details.Datacenter != "rbx8" || !details.Monitoring || details.LinkSpeed != 1000 {
+		t.Fatalf("Server should decode the response. Got %+v", details)
+	}
+	if fetched.Function != "hardReboot" || !fetched.DoneDate.IsZero() {
+		t.Fatalf("Task should decode the response. Got %+v", fetched)
+	}
+}
+
+func TestWaitTask(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dedicated/server/ns1.example.net/task/7", http.StatusOK, `{"taskId": 7, "function": "hardReboot", "status": "done"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/task/8", http.StatusOK, `{"taskId": 8, "function": "hardReboot", "status": "ovhError", "comment": "Reboot failed"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/task/9", http.StatusOK, `{"taskId": 9, "function": "hardReboot", "status": "doing"}`)
+	ctx := context.Background()
+
+	// Test
+	done, err := client.WaitTask(ctx, "ns1.example.net", 7, nil)
+	if err != nil {
+		t.Fatalf("WaitTask should not return an error. Got %v", err)
+	}
+	_, failedErr := client.WaitTask(ctx, "ns1.example.net", 8, nil)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, timeoutErr := client.WaitTask(timeoutCtx, "ns1.example.net", 9, &ovh.WaitTaskOptions{Interval: 10 * time.Millisecond, MaxInterval: 10 * time.Millisecond})
+
+	// Validate
+	if done.Status != TaskStatusDone {
+		t.Fatalf("WaitTask should return the done task. Got %+v", done)
+	}
+	var taskErr *ovh.TaskError
+	if !errors.As(failedErr, &taskErr) || taskErr.Status != TaskStatusOVHError || taskErr.Comment != "Reboot failed" {
+		t.Fatalf("WaitTask should fail on task errors. Got %v", failedErr)
+	}
+	if timeoutErr != context.DeadlineExceeded {
+		t.Fatalf("WaitTask should stop with its context. Got %v", timeoutErr)
+	}
+}
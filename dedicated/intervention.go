@@ -0,0 +1,34 @@
+package dedicated
+
+import (
+	"context"
+	"time"
+)
+
+// Intervention represents a technical intervention of the datacenter team on
+// a dedicated server
+type Intervention struct {
+	InterventionID int64     `json:"interventionId"`
+	Date           time.Time `json:"date"`
+	Type           string    `json:"type"`
+}
+
+// InterventionIDs lists the interventions on a dedicated server, with
+// GET /dedicated/server/{serviceName}/intervention
+func (c *Client) InterventionIDs(ctx context.Context, serviceName string) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/intervention"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Intervention returns an intervention, with
+// GET /dedicated/server/{serviceName}/intervention/{interventionId}
+func (c *Client) Intervention(ctx context.Context, serviceName string, interventionID int64) (*Intervention, error) {
+	intervention := &Intervention{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/intervention/%d", interventionID), intervention); err != nil {
+		return nil, err
+	}
+	return intervention, nil
+}
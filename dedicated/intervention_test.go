@@ -0,0 +1,31 @@
+package dedicated
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestInterventions(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dedicated/server/ns1.example.net/intervention", http.StatusOK, `[3]`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/intervention/3", http.StatusOK, `{"interventionId": 3, "date": "2020-01-01T10:00:00+01:00", "type": "Disk replacement"}`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.InterventionIDs(ctx, "ns1.example.net")
+	if err != nil {
+		t.Fatalf("InterventionIDs should not return an error. Got %v", err)
+	}
+	intervention, err := client.Intervention(ctx, "ns1.example.net", ids[0])
+	if err != nil {
+		t.Fatalf("Intervention should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if intervention.InterventionID != 3 || intervention.Type != "Disk replacement" {
+		t.Fatalf("Intervention should decode the response. Got %+v", intervention)
+	}
+}
@@ -0,0 +1,106 @@
+package dedicated
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// IPMI access types
+const (
+	IPMIAccessKVMIPHTML5     = "kvmipHtml5URL"
+	IPMIAccessKVMIPJnlp      = "kvmipJnlp"
+	IPMIAccessSerialOverSSH  = "serialOverLanSshKey"
+	IPMIAccessSerialOverHTTP = "serialOverLanURL"
+)
+
+// IPMI represents the IPMI features of a dedicated server
+type IPMI struct {
+	Activated         bool            `json:"activated"`
+	SupportedFeatures map[string]bool `json:"supportedFeatures"`
+}
+
+// IPMIAccessRequest holds the parameters of an IPMI access request. TTL is in
+// minutes. SSHKey is only used by serial over SSH accesses.
+type IPMIAccessRequest struct {
+	Type      string `json:"type"`
+	TTL       int    `json:"ttl"`
+	IPToAllow string `json:"ipToAllow,omitempty"`
+	SSHKey    string `json:"sshKey,omitempty"`
+}
+
+// IPMIAccess represents an IPMI access, like a KVM URL or JNLP file
+type IPMIAccess struct {
+	Value      string    `json:"value"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// IPMISession is a ready to use IPMI access. Value is an URL for the
+// IPMIAccessKVMIPHTML5 and IPMIAccessSerialOverHTTP types, the content of a
+// JNLP file for IPMIAccessKVMIPJnlp and a SSH command line for
+// IPMIAccessSerialOverSSH.
+type IPMISession struct {
+	ServiceName string
+	Type        string
+	Value       string
+	Expiration  time.Time
+	Task        *Task
+}
+
+// IPMI returns the IPMI features of a dedicated server, with
+// GET /dedicated/server/{serviceName}/features/ipmi
+func (c *Client) IPMI(ctx context.Context, serviceName string) (*IPMI, error) {
+	ipmi := &IPMI{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/features/ipmi"), ipmi); err != nil {
+		return nil, err
+	}
+	return ipmi, nil
+}
+
+// RequestIPMIAccess requests an IPMI access, with
+// POST /dedicated/server/{serviceName}/features/ipmi/access. The access is
+// available with IPMIAccess once the task is done.
+func (c *Client) RequestIPMIAccess(ctx context.Context, serviceName string, request IPMIAccessRequest) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, serverPath(serviceName, "/features/ipmi/access"), request, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// IPMIAccess returns a previously requested IPMI access, with
+// GET /dedicated/server/{serviceName}/features/ipmi/access
+func (c *Client) IPMIAccess(ctx context.Context, serviceName, accessType string) (*IPMIAccess, error) {
+	access := &IPMIAccess{}
+	path := serverPath(serviceName, "/features/ipmi/access") + "?type=" + url.QueryEscape(accessType)
+	if err := c.client.GetWithContext(ctx, path, access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// OpenIPMISession requests an IPMI access, waits for the access task to be
+// done and returns the access. See RequestIPMIAccess, WaitTask and
+// IPMIAccess.
+func (c *Client) OpenIPMISession(ctx context.Context, serviceName string, request IPMIAccessRequest, opts *ovh.WaitTaskOptions) (*IPMISession, error) {
+	task, err := c.RequestIPMIAccess(ctx, serviceName, request)
+	if err != nil {
+		return nil, err
+	}
+	if task, err = c.WaitTask(ctx, serviceName, task.TaskID, opts); err != nil {
+		return nil, err
+	}
+	access, err := c.IPMIAccess(ctx, serviceName, request.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &IPMISession{
+		ServiceName: serviceName,
+		Type:        request.Type,
+		Value:       access.Value,
+		Expiration:  access.Expiration,
+		Task:        task,
+	}, nil
+}
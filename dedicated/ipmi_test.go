@@ -0,0 +1,74 @@
+package dedicated
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIPMI(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dedicated/server/ns1.example.net/features/ipmi", http.StatusOK, `{"activated": true, "supportedFeatures": {"kvmipHtml5URL": true, "kvmipJnlp": true, "serialOverLanSshKey": false, "serialOverLanURL": true}}`)
+	server.Handle("POST", "/dedicated/server/ns1.example.net/features/ipmi/access", http.StatusOK, `{"taskId": 9, "function": "ipmi/configureAccess", "status": "init"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/features/ipmi/access?type=kvmipHtml5URL", http.StatusOK, `{"value": "https://kvm.example.net/session", "expiration": "2020-01-01T11:00:00+01:00"}`)
+	ctx := context.Background()
+
+	// Test
+	ipmi, err := client.IPMI(ctx, "ns1.example.net")
+	if err != nil {
+		t.Fatalf("IPMI should not return an error. Got %v", err)
+	}
+	task, err := client.RequestIPMIAccess(ctx, "ns1.example.net", IPMIAccessRequest{Type: IPMIAccessKVMIPHTML5, TTL: 15, IPToAllow: "203.0.113.1"})
+	if err != nil {
+		t.Fatalf("RequestIPMIAccess should not return an error. Got %v", err)
+	}
+	access, err := client.IPMIAccess(ctx, "ns1.example.net", IPMIAccessKVMIPHTML5)
+	if err != nil {
+		t.Fatalf("IPMIAccess should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if !ipmi.Activated || !ipmi.SupportedFeatures[IPMIAccessKVMIPHTML5] || ipmi.SupportedFeatures[IPMIAccessSerialOverSSH] {
+		t.Fatalf("IPMI should decode the response. Got %+v", ipmi)
+	}
+	if task.TaskID != 9 || access.Value != "https://kvm.example.net/session" {
+		t.Fatalf("IPMI access should be decoded. Got %+v, %+v", task, access)
+	}
+	if request, _ := server.LastRequest("POST", "/dedicated/server/ns1.example.net/features/ipmi/access"); string(request.Body) != `{"type":"kvmipHtml5URL","ttl":15,"ipToAllow":"203.0.113.1"}` {
+		t.Fatalf("RequestIPMIAccess should send the request. Got %s", request.Body)
+	}
+}
+
+func TestOpenIPMISession(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/dedicated/server/ns1.example.net/features/ipmi/access", http.StatusOK, `{"taskId": 9, "function": "ipmi/configureAccess", "status": "init"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/task/9", http.StatusOK, `{"taskId": 9, "function": "ipmi/configureAccess", "status": "done"}`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/features/ipmi/access?type=serialOverLanURL", http.StatusOK, `{"value": "https://sol.example.net/session", "expiration": "2020-01-01T11:00:00+01:00"}`)
+	server.Handle("POST", "/dedicated/server/ns2.example.net/features/ipmi/access", http.StatusOK, `{"taskId": 10, "function": "ipmi/configureAccess", "status": "init"}`)
+	server.Handle("GET", "/dedicated/server/ns2.example.net/task/10", http.StatusOK, `{"taskId": 10, "function": "ipmi/configureAccess", "status": "customerError", "comment": "IP not allowed"}`)
+	ctx := context.Background()
+	request := IPMIAccessRequest{Type: IPMIAccessSerialOverHTTP, TTL: 5}
+
+	// Test
+	session, err := client.OpenIPMISession(ctx, "ns1.example.net", request, nil)
+	if err != nil {
+		t.Fatalf("OpenIPMISession should not return an error. Got %v", err)
+	}
+	_, failedErr := client.OpenIPMISession(ctx, "ns2.example.net", request, nil)
+
+	// Validate
+	if session.Type != IPMIAccessSerialOverHTTP || session.Value != "https://sol.example.net/session" || session.Expiration.IsZero() {
+		t.Fatalf("OpenIPMISession should return the access. Got %+v", session)
+	}
+	if session.ServiceName != "ns1.example.net" || session.Task.Status != TaskStatusDone {
+		t.Fatalf("OpenIPMISession should return the done task. Got %+v", session.Task)
+	}
+	if failedErr == nil || !strings.Contains(failedErr.Error(), "IP not allowed") {
+		t.Fatalf("OpenIPMISession should fail when the access task fails. Got %v", failedErr)
+	}
+}
@@ -0,0 +1,57 @@
+package dedicated
+
+import (
+	"context"
+	"net/url"
+)
+
+// Boot types
+const (
+	BootTypeHarddisk = "harddisk"
+	BootTypeRescue   = "rescue"
+	BootTypeNetwork  = "network"
+	BootTypeInternal = "internal"
+)
+
+// Netboot represents a boot option of a dedicated server.
+// Visit https://api.ovh.com/console/#/dedicated/server/%7BserviceName%7D/boot/%7BbootId%7D#GET for the full definition
+type Netboot struct {
+	BootID      int64  `json:"bootId"`
+	BootType    string `json:"bootType"`
+	Kernel      string `json:"kernel"`
+	Description string `json:"description"`
+}
+
+// NetbootIDs lists the boot options available on a dedicated server, with
+// GET /dedicated/server/{serviceName}/boot. An empty "bootType" is not used as
+// a filter.
+func (c *Client) NetbootIDs(ctx context.Context, serviceName, bootType string) ([]int64, error) {
+	path := serverPath(serviceName, "/boot")
+	if bootType != "" {
+		path += "?bootType=" + url.QueryEscape(bootType)
+	}
+
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Netboot returns a boot option, with
+// GET /dedicated/server/{serviceName}/boot/{bootId}
+func (c *Client) Netboot(ctx context.Context, serviceName string, bootID int64) (*Netboot, error) {
+	netboot := &Netboot{}
+	if err := c.client.GetWithContext(ctx, serverPath(serviceName, "/boot/%d", bootID), netboot); err != nil {
+		return nil, err
+	}
+	return netboot, nil
+}
+
+// SetNetboot selects the boot option used on the next reboots, with
+// PUT /dedicated/server/{serviceName}. The server must be rebooted for the
+// change to take effect, see Reboot.
+func (c *Client) SetNetboot(ctx context.Context, serviceName string, bootID int64) error {
+	body := map[string]int64{"bootId": bootID}
+	return c.client.PutWithContext(ctx, serverPath(serviceName, ""), body, nil)
+}
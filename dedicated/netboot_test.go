@@ -0,0 +1,38 @@
+package dedicated
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestNetboot(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dedicated/server/ns1.example.net/boot?bootType=rescue", http.StatusOK, `[22]`)
+	server.Handle("GET", "/dedicated/server/ns1.example.net/boot/22", http.StatusOK, `{"bootId": 22, "bootType": "rescue", "kernel": "rescue64-pro", "description": "Rescue"}`)
+	server.Handle("PUT", "/dedicated/server/ns1.example.net", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.NetbootIDs(ctx, "ns1.example.net", BootTypeRescue)
+	if err != nil {
+		t.Fatalf("NetbootIDs should not return an error. Got %v", err)
+	}
+	netboot, err := client.Netboot(ctx, "ns1.example.net", ids[0])
+	if err != nil {
+		t.Fatalf("Netboot should not return an error. Got %v", err)
+	}
+	if err := client.SetNetboot(ctx, "ns1.example.net", netboot.BootID); err != nil {
+		t.Fatalf("SetNetboot should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if netboot.Kernel != "rescue64-pro" || netboot.BootType != BootTypeRescue {
+		t.Fatalf("Netboot should decode the response. Got %+v", netboot)
+	}
+	if request, _ := server.LastRequest("PUT", "/dedicated/server/ns1.example.net"); string(request.Body) != `{"bootId":22}` {
+		t.Fatalf("SetNetboot should send the boot ID. Got %s", request.Body)
+	}
+}
@@ -0,0 +1,272 @@
+// Package dns01 solves ACME DNS-01 challenges with OVH DNS zones: it
+// creates and removes the "_acme-challenge" TXT records, refreshes the zone
+// and waits for the records to be served by its name servers.
+//
+// Provider implements the DNS provider interface of lego, and may back
+// certmagic or any other ACME client:
+//
+//	provider := dns01.NewProvider(client)
+//	err := provider.Present("example.com", token, keyAuth)
+package dns01
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/go-ovh/domain"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Provider defaults, used when the corresponding Provider field is not set
+const (
+	DefaultTTL                = 60
+	DefaultPropagationTimeout = 2 * time.Minute
+	DefaultPollingInterval    = 5 * time.Second
+)
+
+// challengeLabel is the label of the challenge records
+const challengeLabel = "_acme-challenge"
+
+// lookupTXT queries the TXT records of "name" on a name server. It is a
+// variable to be overwritten during the tests.
+var lookupTXT = func(ctx context.Context, nameServer, name string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, net.JoinHostPort(strings.TrimSuffix(nameServer, "."), "53"))
+		},
+	}
+	return resolver.LookupTXT(ctx, name)
+}
+
+// ChallengeValue returns the value of the TXT record of a DNS-01 challenge,
+// from its key authorization
+func ChallengeValue(keyAuth string) string {
+	sum := sha256.Sum256([]byte(keyAuth))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Provider solves DNS-01 challenges in the DNS zones of an account
+type Provider struct {
+	client *domain.Client
+
+	// Zone is the DNS zone of the challenged domains. When empty, the
+	// longest zone of the account containing each domain is used.
+	Zone string
+
+	// TTL of the challenge records, DefaultTTL when zero
+	TTL int
+
+	// PropagationTimeout is the maximum delay waiting for the records to be
+	// served by the zone name servers, DefaultPropagationTimeout when zero.
+	// The propagation is not checked when negative.
+	PropagationTimeout time.Duration
+
+	// PollingInterval is the delay between two propagation checks,
+	// DefaultPollingInterval when zero
+	PollingInterval time.Duration
+}
+
+// NewProvider returns a DNS-01 provider using the given OVH API client
+func NewProvider(client *ovh.Client) *Provider {
+	return &Provider{client: domain.New(client)}
+}
+
+// Present creates the TXT record of the challenge of "domainName", refreshes
+// the zone and waits for its propagation, with the default settings of
+// NewProvider
+func Present(ctx context.Context, client *ovh.Client, domainName, keyAuth string) error {
+	return NewProvider(client).PresentWithContext(ctx, domainName, keyAuth)
+}
+
+// CleanUp removes the TXT record of the challenge of "domainName", see
+// Present
+func CleanUp(ctx context.Context, client *ovh.Client, domainName, keyAuth string) error {
+	return NewProvider(client).CleanUpWithContext(ctx, domainName, keyAuth)
+}
+
+// Present creates the TXT record of a challenge, as required by lego. The
+// token is not used.
+func (p *Provider) Present(domainName, token, keyAuth string) error {
+	return p.PresentWithContext(context.Background(), domainName, keyAuth)
+}
+
+// CleanUp removes the TXT record of a challenge, as required by lego. The
+// token is not used.
+func (p *Provider) CleanUp(domainName, token, keyAuth string) error {
+	return p.CleanUpWithContext(context.Background(), domainName, keyAuth)
+}
+
+// Timeout returns the maximum delay of Present and the polling interval of
+// the propagation checks, as used by lego
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.propagationTimeout(), p.pollingInterval()
+}
+
+// PresentWithContext creates the TXT record of the challenge of
+// "domainName", refreshes the zone and waits for the record to be served by
+// all the zone name servers
+func (p *Provider) PresentWithContext(ctx context.Context, domainName, keyAuth string) error {
+	zone, subDomain, err := p.challengeRecord(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	value := ChallengeValue(keyAuth)
+
+	ttl := p.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	creation := domain.RecordCreation{FieldType: "TXT", SubDomain: subDomain, Target: strconv.Quote(value), TTL: ttl}
+	if _, err := p.client.CreateRecord(ctx, zone, creation); err != nil {
+		return err
+	}
+	if err := p.client.RefreshZone(ctx, zone); err != nil {
+		return err
+	}
+	if p.PropagationTimeout < 0 {
+		return nil
+	}
+	return p.waitPropagation(ctx, zone, challengeName(subDomain, zone), value)
+}
+
+// CleanUpWithContext removes the TXT records of the challenge of
+// "domainName" and refreshes the zone
+func (p *Provider) CleanUpWithContext(ctx context.Context, domainName, keyAuth string) error {
+	zone, subDomain, err := p.challengeRecord(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	target := strconv.Quote(ChallengeValue(keyAuth))
+
+	ids, err := p.client.RecordIDs(ctx, zone, "TXT", subDomain)
+	if err != nil {
+		return err
+	}
+	deleted := false
+	for _, id := range ids {
+		record, err := p.client.Record(ctx, zone, id)
+		if err != nil {
+			return err
+		}
+		if record.Target != target {
+			continue
+		}
+		if err := p.client.DeleteRecord(ctx, zone, id); err != nil {
+			return err
+		}
+		deleted = true
+	}
+	if !deleted {
+		return nil
+	}
+	return p.client.RefreshZone(ctx, zone)
+}
+
+// challengeRecord returns the zone and the sub-domain of the challenge
+// record of a domain
+func (p *Provider) challengeRecord(ctx context.Context, domainName string) (string, string, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(domainName, "*."), "."))
+
+	zones := []string{p.Zone}
+	if p.Zone == "" {
+		var err error
+		if zones, err = p.client.Zones(ctx); err != nil {
+			return "", "", err
+		}
+	}
+	zone := ""
+	for _, candidate := range zones {
+		candidate = strings.ToLower(strings.TrimSuffix(candidate, "."))
+		if (domainName == candidate || strings.HasSuffix(domainName, "."+candidate)) && len(candidate) > len(zone) {
+			zone = candidate
+		}
+	}
+	if zone == "" {
+		return "", "", fmt.Errorf("dns01: no DNS zone found for %s", domainName)
+	}
+
+	subDomain := challengeLabel
+	if domainName != zone {
+		subDomain += "." + strings.TrimSuffix(domainName, "."+zone)
+	}
+	return zone, subDomain, nil
+}
+
+// challengeName returns the fully qualified name of a challenge record
+func challengeName(subDomain, zone string) string {
+	return subDomain + "." + zone + "."
+}
+
+// waitPropagation polls the name servers of the zone until they all serve
+// the TXT record "name" with "value"
+func (p *Provider) waitPropagation(ctx context.Context, zone, name, value string) error {
+	z, err := p.client.Zone(ctx, zone)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.propagationTimeout())
+	defer cancel()
+
+	pending := z.NameServers
+	for {
+		var lastErr error
+		var remaining []string
+		for _, nameServer := range pending {
+			if ok, err := serves(ctx, nameServer, name, value); !ok {
+				remaining = append(remaining, nameServer)
+				lastErr = err
+			}
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		pending = remaining
+
+		select {
+		case <-time.After(p.pollingInterval()):
+		case <-ctx.Done():
+			if lastErr == nil {
+				lastErr = ctx.Err()
+			}
+			return fmt.Errorf("dns01: %s is not served by %s yet: %v", name, strings.Join(pending, ", "), lastErr)
+		}
+	}
+}
+
+// serves checks if a name server serves the TXT record "name" with "value"
+func serves(ctx context.Context, nameServer, name, value string) (bool, error) {
+	values, err := lookupTXT(ctx, nameServer, name)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range values {
+		if v == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// propagationTimeout returns the maximum delay of the propagation checks
+func (p *Provider) propagationTimeout() time.Duration {
+	if p.PropagationTimeout <= 0 {
+		return DefaultPropagationTimeout
+	}
+	return p.PropagationTimeout
+}
+
+// pollingInterval returns the delay between two propagation checks
+func (p *Provider) pollingInterval() time.Duration {
+	if p.PollingInterval <= 0 {
+		return DefaultPollingInterval
+	}
+	return p.PollingInterval
+}
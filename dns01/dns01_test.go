@@ -0,0 +1,178 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Provider) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	provider := NewProvider(client)
+	provider.PollingInterval = time.Millisecond
+	return server, provider
+}
+
+// mockLookupTXT replaces lookupTXT, and returns a function restoring it
+func mockLookupTXT(lookup func(nameServer, name string) ([]string, error)) func() {
+	previous := lookupTXT
+	lookupTXT = func(ctx context.Context, nameServer, name string) ([]string, error) {
+		return lookup(nameServer, name)
+	}
+	return func() { lookupTXT = previous }
+}
+
+func TestChallengeValue(t *testing.T) {
+	// Test
+	value := ChallengeValue("token.thumbprint")
+
+	// Validate
+	if value != "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I" {
+		t.Fatalf("ChallengeValue should be an unpadded base64url sha256. Got %s", value)
+	}
+}
+
+func TestPresent(t *testing.T) {
+	// Init test
+	server, provider := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone", http.StatusOK, `["example.com", "dev.example.com", "example.org"]`)
+	server.Handle("POST", "/domain/zone/dev.example.com/record", http.StatusOK, `{"id": 1}`)
+	server.Handle("POST", "/domain/zone/dev.example.com/refresh", http.StatusOK, `null`)
+	server.Handle("GET", "/domain/zone/dev.example.com", http.StatusOK, `{"name": "dev.example.com", "nameServers": ["dns1.ovh.net", "ns1.ovh.net"]}`)
+
+	value := ChallengeValue("keyAuth")
+	lookups := map[string]int{}
+	defer mockLookupTXT(func(nameServer, name string) ([]string, error) {
+		if name != "_acme-challenge.www.dev.example.com." {
+			t.Fatalf("Challenge name should be looked up. Got %s", name)
+		}
+		lookups[nameServer]++
+		if nameServer == "ns1.ovh.net" && lookups[nameServer] < 3 {
+			return []string{"stale"}, nil
+		}
+		return []string{value}, nil
+	})()
+
+	// Test
+	err := provider.Present("*.www.dev.example.com", "token", "keyAuth")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Present should not return an error. Got %v", err)
+	}
+	requests := server.Requests()
+	body := string(requests[1].Body)
+	if !strings.Contains(body, `"fieldType":"TXT"`) || !strings.Contains(body, `"subDomain":"_acme-challenge.www"`) || !strings.Contains(body, `"ttl":60`) {
+		t.Fatalf("Present should create the challenge record. Got %s", body)
+	}
+	if !strings.Contains(body, `"target":"\"`+value+`\""`) {
+		t.Fatalf("Present should quote the challenge value. Got %s", body)
+	}
+	if requests[2].Path != "/domain/zone/dev.example.com/refresh" {
+		t.Fatalf("Present should refresh the zone. Got %s", requests[2].Path)
+	}
+	if lookups["dns1.ovh.net"] != 1 || lookups["ns1.ovh.net"] != 3 {
+		t.Fatalf("Present should poll each name server until propagation. Got %v", lookups)
+	}
+}
+
+func TestPresentPropagationTimeout(t *testing.T) {
+	// Init test
+	server, provider := initServer(t)
+	defer server.Close()
+	provider.Zone = "example.com"
+	provider.PropagationTimeout = 20 * time.Millisecond
+	server.Handle("POST", "/domain/zone/example.com/record", http.StatusOK, `{"id": 1}`)
+	server.Handle("POST", "/domain/zone/example.com/refresh", http.StatusOK, `null`)
+	server.Handle("GET", "/domain/zone/example.com", http.StatusOK, `{"name": "example.com", "nameServers": ["ns1.ovh.net"]}`)
+	defer mockLookupTXT(func(nameServer, name string) ([]string, error) {
+		return nil, errors.New("no such host")
+	})()
+
+	// Test
+	err := provider.PresentWithContext(context.Background(), "example.com", "keyAuth")
+
+	// Validate
+	if err == nil || !strings.Contains(err.Error(), "_acme-challenge.example.com. is not served by ns1.ovh.net") {
+		t.Fatalf("Present should fail when the record does not propagate. Got %v", err)
+	}
+	if !strings.Contains(err.Error(), "no such host") {
+		t.Fatalf("Propagation error should include the last lookup error. Got %v", err)
+	}
+}
+
+func TestPresentUnknownZone(t *testing.T) {
+	// Init test
+	server, provider := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone", http.StatusOK, `["example.com"]`)
+
+	// Test
+	err := provider.Present("www.notexample.com", "token", "keyAuth")
+
+	// Validate
+	if err == nil || err.Error() != "dns01: no DNS zone found for www.notexample.com" {
+		t.Fatalf("Present should fail without a matching zone. Got %v", err)
+	}
+	if len(server.Requests()) != 1 {
+		t.Fatalf("Present should not create any record. Got %d requests", len(server.Requests()))
+	}
+}
+
+func TestCleanUp(t *testing.T) {
+	// Init test
+	server := govhtest.NewServer()
+	defer server.Close()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	target := `"` + ChallengeValue("keyAuth") + `"`
+	server.Handle("GET", "/domain/zone", http.StatusOK, `["example.com"]`)
+	server.Handle("GET", "/domain/zone/example.com/record?fieldType=TXT&subDomain=_acme-challenge", http.StatusOK, `[1, 2]`)
+	server.Handle("GET", "/domain/zone/example.com/record/1", http.StatusOK, `{"id": 1, "fieldType": "TXT", "subDomain": "_acme-challenge", "target": "\"other\""}`)
+	server.Handle("GET", "/domain/zone/example.com/record/2", http.StatusOK, map[string]interface{}{"id": 2, "fieldType": "TXT", "subDomain": "_acme-challenge", "target": target})
+	server.Handle("DELETE", "/domain/zone/example.com/record/2", http.StatusOK, `null`)
+	server.Handle("POST", "/domain/zone/example.com/refresh", http.StatusOK, `null`)
+
+	// Test
+	err = CleanUp(context.Background(), client, "example.com.", "keyAuth")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("CleanUp should not return an error. Got %v", err)
+	}
+	var paths []string
+	for _, request := range server.Requests() {
+		if request.Method != "GET" {
+			paths = append(paths, request.Method+" "+request.Path)
+		}
+	}
+	expected := "DELETE /domain/zone/example.com/record/2, POST /domain/zone/example.com/refresh"
+	if strings.Join(paths, ", ") != expected {
+		t.Fatalf("CleanUp should only delete the challenge record. Expected %s. Got %v", expected, paths)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	// Init test
+	provider := &Provider{}
+
+	// Test
+	timeout, interval := provider.Timeout()
+
+	// Validate
+	if timeout != DefaultPropagationTimeout || interval != DefaultPollingInterval {
+		t.Fatalf("Timeout should return the defaults. Got %v, %v", timeout, interval)
+	}
+}
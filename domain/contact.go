@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Contact change states
+const (
+	ContactChangeStateCheckValidity         = "checkValidity"
+	ContactChangeStateValidatingByCustomers = "validatingByCustomers"
+	ContactChangeStateTodo                  = "todo"
+	ContactChangeStateDoing                 = "doing"
+	ContactChangeStateDone                  = "done"
+	ContactChangeStateRefused               = "refused"
+	ContactChangeStateAborted               = "aborted"
+)
+
+// ContactChange holds the NIC handles of the new contacts of a domain. Empty
+// contacts are not changed.
+type ContactChange struct {
+	ContactAdmin   string `json:"contactAdmin,omitempty"`
+	ContactBilling string `json:"contactBilling,omitempty"`
+	ContactTech    string `json:"contactTech,omitempty"`
+}
+
+// ContactChangeTask represents a contact change request. It is done once
+// accepted by both the current and the new contacts, from the links sent by
+// email or with AcceptContactChange.
+// Visit https://api.ovh.com/console/#/me/task/contactChange/%7Bid%7D#GET for the full definition
+type ContactChangeTask struct {
+	ID            int64      `json:"id"`
+	ServiceDomain string     `json:"serviceDomain"`
+	State         string     `json:"state"`
+	ContactTypes  []string   `json:"contactTypes"`
+	FromAccount   string     `json:"fromAccount"`
+	ToAccount     string     `json:"toAccount"`
+	AskingAccount string     `json:"askingAccount"`
+	DateRequest   time.Time  `json:"dateRequest"`
+	DateDone      *time.Time `json:"dateDone"`
+}
+
+// contactChangePath returns the path of a contact change task
+func contactChangePath(taskID int64, format string, args ...interface{}) string {
+	return fmt.Sprintf("/me/task/contactChange/%d", taskID) + fmt.Sprintf(format, args...)
+}
+
+// ChangeContact requests a change of the contacts of a domain, with
+// POST /domain/{serviceName}/changeContact. It returns the IDs of the
+// contact change tasks, see WaitContactChange.
+func (c *Client) ChangeContact(ctx context.Context, domain string, change ContactChange) ([]int64, error) {
+	taskIDs := []int64{}
+	if err := c.client.PostWithContext(ctx, domainPath(domain, "/changeContact"), change, &taskIDs); err != nil {
+		return nil, err
+	}
+	return taskIDs, nil
+}
+
+// ContactChange returns a contact change task, with
+// GET /me/task/contactChange/{id}
+func (c *Client) ContactChange(ctx context.Context, taskID int64) (*ContactChangeTask, error) {
+	task := &ContactChangeTask{}
+	if err := c.client.GetWithContext(ctx, contactChangePath(taskID, ""), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// AcceptContactChange accepts a contact change with the token received by
+// email, with POST /me/task/contactChange/{id}/accept
+func (c *Client) AcceptContactChange(ctx context.Context, taskID int64, token string) error {
+	body := map[string]string{"token": token}
+	return c.client.PostWithContext(ctx, contactChangePath(taskID, "/accept"), body, nil)
+}
+
+// RefuseContactChange refuses a contact change with the token received by
+// email, with POST /me/task/contactChange/{id}/refuse
+func (c *Client) RefuseContactChange(ctx context.Context, taskID int64, token string) error {
+	body := map[string]string{"token": token}
+	return c.client.PostWithContext(ctx, contactChangePath(taskID, "/refuse"), body, nil)
+}
+
+// ResendContactChangeEmail sends the validation emails of a contact change
+// again, with POST /me/task/contactChange/{id}/resendEmail
+func (c *Client) ResendContactChangeEmail(ctx context.Context, taskID int64) error {
+	return c.client.PostWithContext(ctx, contactChangePath(taskID, "/resendEmail"), nil, nil)
+}
+
+// WaitContactChange polls a contact change task every "interval",
+// DefaultPollInterval if not positive, until it is done. It fails if the
+// change is refused or aborted, or when the context is done. As contacts may
+// take days to validate a change, the context should bound the wait.
+func (c *Client) WaitContactChange(ctx context.Context, taskID int64, interval time.Duration) (*ContactChangeTask, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		task, err := c.ContactChange(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		switch task.State {
+		case ContactChangeStateDone:
+			return task, nil
+		case ContactChangeStateRefused, ContactChangeStateAborted:
+			return task, fmt.Errorf("domain: contact change %d of %s is %s", taskID, task.ServiceDomain, task.State)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return task, ctx.Err()
+		}
+	}
+}
+
+// ChangeContactAndWait requests a change of the contacts of a domain and
+// waits for all the resulting contact change tasks to be done, see
+// ChangeContact and WaitContactChange
+func (c *Client) ChangeContactAndWait(ctx context.Context, domain string, change ContactChange, interval time.Duration) ([]ContactChangeTask, error) {
+	taskIDs, err := c.ChangeContact(ctx, domain, change)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]ContactChangeTask, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, err := c.WaitContactChange(ctx, taskID, interval)
+		if err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
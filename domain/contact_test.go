@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChangeContact(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/domain/example.com/changeContact", http.StatusOK, `[41, 42]`)
+	server.Handle("GET", "/me/task/contactChange/41", http.StatusOK, `{"id": 41, "serviceDomain": "example.com", "state": "done", "contactTypes": ["contactAdmin"], "fromAccount": "aa1-ovh", "toAccount": "bb2-ovh", "dateRequest": "2020-01-01T10:00:00+01:00", "dateDone": "2020-01-02T10:00:00+01:00"}`)
+	server.Handle("GET", "/me/task/contactChange/42", http.StatusOK, `{"id": 42, "serviceDomain": "example.com", "state": "done", "contactTypes": ["contactTech"], "fromAccount": "aa1-ovh", "toAccount": "bb2-ovh"}`)
+	server.Handle("POST", "/me/task/contactChange/41/accept", http.StatusOK, `null`)
+	server.Handle("POST", "/me/task/contactChange/41/refuse", http.StatusOK, `null`)
+	server.Handle("POST", "/me/task/contactChange/41/resendEmail", http.StatusOK, `null`)
+	server.Handle("POST", "/domain/example.org/changeContact", http.StatusOK, `[43]`)
+	server.Handle("GET", "/me/task/contactChange/43", http.StatusOK, `{"id": 43, "serviceDomain": "example.org", "state": "refused"}`)
+	ctx := context.Background()
+	change := ContactChange{ContactAdmin: "bb2-ovh", ContactTech: "bb2-ovh"}
+
+	// Test
+	tasks, err := client.ChangeContactAndWait(ctx, "example.com", change, time.Millisecond)
+	if err != nil {
+		t.Fatalf("ChangeContactAndWait should not return an error. Got %v", err)
+	}
+	if err := client.AcceptContactChange(ctx, 41, "token"); err != nil {
+		t.Fatalf("AcceptContactChange should not return an error. Got %v", err)
+	}
+	if err := client.RefuseContactChange(ctx, 41, "token"); err != nil {
+		t.Fatalf("RefuseContactChange should not return an error. Got %v", err)
+	}
+	if err := client.ResendContactChangeEmail(ctx, 41); err != nil {
+		t.Fatalf("ResendContactChangeEmail should not return an error. Got %v", err)
+	}
+	_, refusedErr := client.ChangeContactAndWait(ctx, "example.org", change, time.Millisecond)
+
+	// Validate
+	if len(tasks) != 2 || tasks[0].ToAccount != "bb2-ovh" || tasks[0].DateDone == nil || tasks[1].ContactTypes[0] != "contactTech" {
+		t.Fatalf("ChangeContactAndWait should return the done tasks. Got %+v", tasks)
+	}
+	if request, _ := server.LastRequest("POST", "/domain/example.com/changeContact"); string(request.Body) != `{"contactAdmin":"bb2-ovh","contactTech":"bb2-ovh"}` {
+		t.Fatalf("ChangeContact should send the new contacts. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("POST", "/me/task/contactChange/41/accept"); string(request.Body) != `{"token":"token"}` {
+		t.Fatalf("AcceptContactChange should send the token. Got %s", request.Body)
+	}
+	if refusedErr == nil {
+		t.Fatalf("ChangeContactAndWait should fail for refused changes")
+	}
+}
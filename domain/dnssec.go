@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DNSSEC statuses
+const (
+	DNSSECStatusDisabled          = "disabled"
+	DNSSECStatusEnabled           = "enabled"
+	DNSSECStatusEnableInProgress  = "enableInProgress"
+	DNSSECStatusDisableInProgress = "disableInProgress"
+)
+
+// DSKey is the public key of a DNSSEC key signing key, published as a DS
+// record in the parent zone of a domain
+type DSKey struct {
+	Algorithm int    `json:"algorithm"`
+	Flags     int    `json:"flags"`
+	PublicKey string `json:"publicKey"`
+	Tag       int    `json:"tag"`
+}
+
+// DSRecord represents a DS record of a domain.
+// Visit https://api.ovh.com/console/#/domain/%7BserviceName%7D/dsRecord/%7Bid%7D#GET for the full definition
+type DSRecord struct {
+	ID        int64  `json:"id"`
+	Algorithm int    `json:"algorithm"`
+	Flags     int    `json:"flags"`
+	PublicKey string `json:"publicKey"`
+	Tag       int    `json:"tag"`
+	Status    string `json:"status"`
+}
+
+// DNSSECStatus returns the DNSSEC status of a zone, with
+// GET /domain/zone/{zoneName}/dnssec
+func (c *Client) DNSSECStatus(ctx context.Context, zone string) (string, error) {
+	var dnssec struct {
+		Status string `json:"status"`
+	}
+	if err := c.client.GetWithContext(ctx, zonePath(zone, "/dnssec"), &dnssec); err != nil {
+		return "", err
+	}
+	return dnssec.Status, nil
+}
+
+// EnableDNSSEC enables DNSSEC on a zone, with
+// POST /domain/zone/{zoneName}/dnssec
+func (c *Client) EnableDNSSEC(ctx context.Context, zone string) error {
+	return c.client.PostWithContext(ctx, zonePath(zone, "/dnssec"), nil, nil)
+}
+
+// DisableDNSSEC disables DNSSEC on a zone, with
+// DELETE /domain/zone/{zoneName}/dnssec
+func (c *Client) DisableDNSSEC(ctx context.Context, zone string) error {
+	return c.client.DeleteWithContext(ctx, zonePath(zone, "/dnssec"), nil)
+}
+
+// DSRecordIDs lists the IDs of the DS records of a domain, with
+// GET /domain/{serviceName}/dsRecord
+func (c *Client) DSRecordIDs(ctx context.Context, domain string) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/dsRecord"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DSRecord returns a DS record of a domain, with
+// GET /domain/{serviceName}/dsRecord/{id}
+func (c *Client) DSRecord(ctx context.Context, domain string, id int64) (*DSRecord, error) {
+	record := &DSRecord{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/dsRecord/%d", id), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// DSRecords returns all the DS records of a domain, see DSRecordIDs and
+// DSRecord
+func (c *Client) DSRecords(ctx context.Context, domain string) ([]DSRecord, error) {
+	ids, err := c.DSRecordIDs(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]DSRecord, 0, len(ids))
+	for _, id := range ids {
+		record, err := c.DSRecord(ctx, domain, id)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, nil
+}
+
+// SetDSRecords replaces the DS records of a domain, with
+// POST /domain/{serviceName}/dsRecord. An empty list removes them all.
+func (c *Client) SetDSRecords(ctx context.Context, domain string, keys []DSKey) (*DomainTask, error) {
+	task := &DomainTask{}
+	if keys == nil {
+		keys = []DSKey{}
+	}
+	body := map[string][]DSKey{"keys": keys}
+	if err := c.client.PostWithContext(ctx, domainPath(domain, "/dsRecord"), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// SetDSRecordsAndWait replaces the DS records of a domain and waits for the
+// update task to be done, see SetDSRecords and WaitDomainTask
+func (c *Client) SetDSRecordsAndWait(ctx context.Context, domain string, keys []DSKey, interval time.Duration) (*DomainTask, error) {
+	task, err := c.SetDSRecords(ctx, domain, keys)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitDomainTask(ctx, domain, task.ID, interval)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDNSSEC(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone/example.com/dnssec", http.StatusOK, `{"status": "enableInProgress"}`)
+	server.Handle("POST", "/domain/zone/example.com/dnssec", http.StatusOK, `null`)
+	server.Handle("DELETE", "/domain/zone/example.com/dnssec", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	if err := client.EnableDNSSEC(ctx, "example.com"); err != nil {
+		t.Fatalf("EnableDNSSEC should not return an error. Got %v", err)
+	}
+	status, err := client.DNSSECStatus(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("DNSSECStatus should not return an error. Got %v", err)
+	}
+	if err := client.DisableDNSSEC(ctx, "example.com"); err != nil {
+		t.Fatalf("DisableDNSSEC should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if status != DNSSECStatusEnableInProgress {
+		t.Fatalf("DNSSECStatus should be '%s'. Got '%s'", DNSSECStatusEnableInProgress, status)
+	}
+}
+
+func TestDSRecords(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/example.com/dsRecord", http.StatusOK, `[7]`)
+	server.Handle("GET", "/domain/example.com/dsRecord/7", http.StatusOK, `{"id": 7, "algorithm": 13, "flags": 257, "publicKey": "mdsswUyr3DPW132mOi8V9xESWE8jTo0d", "tag": 2371, "status": "enabled"}`)
+	server.Handle("POST", "/domain/example.com/dsRecord", http.StatusOK, `{"id": 99, "function": "DomainDsUpdate", "status": "todo"}`)
+	server.Handle("GET", "/domain/example.com/task/99", http.StatusOK, `{"id": 99, "function": "DomainDsUpdate", "status": "done"}`)
+	ctx := context.Background()
+
+	// Test
+	records, err := client.DSRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("DSRecords should not return an error. Got %v", err)
+	}
+	keys := []DSKey{{Algorithm: 13, Flags: 257, PublicKey: "newkey", Tag: 4242}}
+	task, err := client.SetDSRecordsAndWait(ctx, "example.com", keys, 0)
+	if err != nil {
+		t.Fatalf("SetDSRecordsAndWait should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(records) != 1 || records[0].Tag != 2371 || records[0].Flags != 257 || records[0].Status != "enabled" {
+		t.Fatalf("DSRecords should decode the records. Got %+v", records)
+	}
+	if request, _ := server.LastRequest("POST", "/domain/example.com/dsRecord"); string(request.Body) != `{"keys":[{"algorithm":13,"flags":257,"publicKey":"newkey","tag":4242}]}` {
+		t.Fatalf("SetDSRecords should send the keys. Got %s", request.Body)
+	}
+	if task.Status != TaskStatusDone {
+		t.Fatalf("SetDSRecordsAndWait should return the done task. Got %+v", task)
+	}
+}
@@ -0,0 +1,67 @@
+// Package domain provides typed helpers for the OVH domain API: DNS zones,
+// under /domain/zone, with records management, zone refresh and DNSSEC, and
+// domain services, under /domain, with their contacts, WHOIS obfuscation, DS
+// records and tasks.
+//
+// Zones may be exported and imported as bind zone files. SyncZone applies a
+// zone file to a zone, only changing the records which differ.
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Zone represents a DNS zone.
+// Visit https://api.ovh.com/console/#/domain/zone/%7BzoneName%7D#GET for the full definition
+type Zone struct {
+	Name            string     `json:"name"`
+	NameServers     []string   `json:"nameServers"`
+	DNSSECSupported bool       `json:"dnssecSupported"`
+	HasDNSAnycast   bool       `json:"hasDnsAnycast"`
+	LastUpdate      *time.Time `json:"lastUpdate"`
+}
+
+// Client gives access to the /domain routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a domain client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// zonePath returns the path of a route of a zone
+func zonePath(zone, format string, args ...interface{}) string {
+	return fmt.Sprintf("/domain/zone/%s", url.PathEscape(zone)) + fmt.Sprintf(format, args...)
+}
+
+// Zones lists the DNS zones of the account, with GET /domain/zone
+func (c *Client) Zones(ctx context.Context) ([]string, error) {
+	zones := []string{}
+	if err := c.client.GetWithContext(ctx, "/domain/zone", &zones); err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// Zone returns a zone, with GET /domain/zone/{zoneName}
+func (c *Client) Zone(ctx context.Context, zone string) (*Zone, error) {
+	z := &Zone{}
+	if err := c.client.GetWithContext(ctx, zonePath(zone, ""), z); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// RefreshZone applies the pending record changes of a zone, with
+// POST /domain/zone/{zoneName}/refresh. Record changes are not served until
+// the zone is refreshed.
+func (c *Client) RefreshZone(ctx context.Context, zone string) error {
+	return c.client.PostWithContext(ctx, zonePath(zone, "/refresh"), nil, nil)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestZones(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone", http.StatusOK, `["example.com", "example.org"]`)
+	server.Handle("POST", "/domain/zone/example.com/refresh", http.StatusOK, `null`)
+	server.Handle("GET", "/domain/zone/example.com", http.StatusOK, `{"name": "example.com", "nameServers": ["dns200.anycast.me", "ns200.anycast.me"], "hasDnsAnycast": true}`)
+
+	// Test
+	zones, err := client.Zones(context.Background())
+	if err != nil {
+		t.Fatalf("Zones should not return an error. Got %v", err)
+	}
+	zone, err := client.Zone(context.Background(), zones[0])
+	if err != nil {
+		t.Fatalf("Zone should not return an error. Got %v", err)
+	}
+	if err := client.RefreshZone(context.Background(), "example.com"); err != nil {
+		t.Fatalf("RefreshZone should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if !reflect.DeepEqual(zones, []string{"example.com", "example.org"}) {
+		t.Fatalf("Zones should return the zone names. Got %v", zones)
+	}
+	if len(zone.NameServers) != 2 || !zone.HasDNSAnycast {
+		t.Fatalf("Zone should decode the response. Got %+v", zone)
+	}
+}
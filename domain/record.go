@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+)
+
+// Record represents a DNS record.
+// Visit https://api.ovh.com/console/#/domain/zone/%7BzoneName%7D/record/%7Bid%7D#GET for the full definition
+type Record struct {
+	ID        int64  `json:"id"`
+	Zone      string `json:"zone"`
+	SubDomain string `json:"subDomain"`
+	FieldType string `json:"fieldType"`
+	Target    string `json:"target"`
+	TTL       int    `json:"ttl"`
+}
+
+// RecordCreation holds the parameters of a new DNS record. The zone default
+// TTL is used when TTL is zero.
+type RecordCreation struct {
+	FieldType string `json:"fieldType"`
+	SubDomain string `json:"subDomain"`
+	Target    string `json:"target"`
+	TTL       int    `json:"ttl,omitempty"`
+}
+
+// RecordUpdate holds the new values of a DNS record
+type RecordUpdate struct {
+	SubDomain string `json:"subDomain"`
+	Target    string `json:"target"`
+	TTL       int    `json:"ttl,omitempty"`
+}
+
+// RecordIDs lists the IDs of the records of a zone, with
+// GET /domain/zone/{zoneName}/record. Empty "fieldType" and "subDomain" are
+// not used as filters.
+func (c *Client) RecordIDs(ctx context.Context, zone, fieldType, subDomain string) ([]int64, error) {
+	query := url.Values{}
+	if fieldType != "" {
+		query.Set("fieldType", fieldType)
+	}
+	if subDomain != "" {
+		query.Set("subDomain", subDomain)
+	}
+	path := zonePath(zone, "/record")
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Record returns a record, with GET /domain/zone/{zoneName}/record/{id}
+func (c *Client) Record(ctx context.Context, zone string, id int64) (*Record, error) {
+	record := &Record{}
+	if err := c.client.GetWithContext(ctx, zonePath(zone, "/record/%d", id), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// CreateRecord creates a record, with POST /domain/zone/{zoneName}/record.
+// The zone must be refreshed for the record to be served, see RefreshZone.
+func (c *Client) CreateRecord(ctx context.Context, zone string, creation RecordCreation) (*Record, error) {
+	record := &Record{}
+	if err := c.client.PostWithContext(ctx, zonePath(zone, "/record"), creation, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// UpdateRecord updates a record, with PUT /domain/zone/{zoneName}/record/{id}.
+// The zone must be refreshed for the change to be served, see RefreshZone.
+func (c *Client) UpdateRecord(ctx context.Context, zone string, id int64, update RecordUpdate) error {
+	return c.client.PutWithContext(ctx, zonePath(zone, "/record/%d", id), update, nil)
+}
+
+// DeleteRecord deletes a record, with DELETE /domain/zone/{zoneName}/record/{id}.
+// The zone must be refreshed for the change to be served, see RefreshZone.
+func (c *Client) DeleteRecord(ctx context.Context, zone string, id int64) error {
+	return c.client.DeleteWithContext(ctx, zonePath(zone, "/record/%d", id), nil)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRecords(t *testing.T) {
+	// Init test
+	record := `{"id": 42, "zone": "example.com", "subDomain": "_acme-challenge", "fieldType": "TXT", "target": "\"token\"", "ttl": 60}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone/example.com/record?fieldType=TXT&subDomain=_acme-challenge", http.StatusOK, `[42]`)
+	server.Handle("GET", "/domain/zone/example.com/record/42", http.StatusOK, record)
+	server.Handle("POST", "/domain/zone/example.com/record", http.StatusOK, record)
+	server.Handle("PUT", "/domain/zone/example.com/record/42", http.StatusOK, `null`)
+	server.Handle("DELETE", "/domain/zone/example.com/record/42", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateRecord(ctx, "example.com", RecordCreation{FieldType: "TXT", SubDomain: "_acme-challenge", Target: `"token"`, TTL: 60})
+	if err != nil {
+		t.Fatalf("CreateRecord should not return an error. Got %v", err)
+	}
+	ids, err := client.RecordIDs(ctx, "example.com", "TXT", "_acme-challenge")
+	if err != nil {
+		t.Fatalf("RecordIDs should not return an error. Got %v", err)
+	}
+	fetched, err := client.Record(ctx, "example.com", ids[0])
+	if err != nil {
+		t.Fatalf("Record should not return an error. Got %v", err)
+	}
+	if err := client.UpdateRecord(ctx, "example.com", 42, RecordUpdate{SubDomain: "_acme-challenge", Target: `"other"`}); err != nil {
+		t.Fatalf("UpdateRecord should not return an error. Got %v", err)
+	}
+	if err := client.DeleteRecord(ctx, "example.com", 42); err != nil {
+		t.Fatalf("DeleteRecord should not return an error. Got %v", err)
+	}
+
+	// Validate
+	expected := &Record{ID: 42, Zone: "example.com", SubDomain: "_acme-challenge", FieldType: "TXT", Target: `"token"`, TTL: 60}
+	if !reflect.DeepEqual(created, expected) || !reflect.DeepEqual(fetched, expected) {
+		t.Fatalf("Records should be decoded as %+v. Got %+v and %+v", expected, created, fetched)
+	}
+	if request, _ := server.LastRequest("POST", "/domain/zone/example.com/record"); string(request.Body) != `{"fieldType":"TXT","subDomain":"_acme-challenge","target":"\"token\"","ttl":60}` {
+		t.Fatalf("CreateRecord should send the record. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("PUT", "/domain/zone/example.com/record/42"); string(request.Body) != `{"subDomain":"_acme-challenge","target":"\"other\""}` {
+		t.Fatalf("UpdateRecord should send the new values. Got %s", request.Body)
+	}
+}
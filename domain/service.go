@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Domain task statuses
+const (
+	TaskStatusTodo      = "todo"
+	TaskStatusDoing     = "doing"
+	TaskStatusDone      = "done"
+	TaskStatusError     = "error"
+	TaskStatusCancelled = "cancelled"
+)
+
+// WHOIS fields which may be obfuscated, see ObfuscateWhois
+const (
+	WhoisFieldAddress = "address"
+	WhoisFieldEmail   = "email"
+	WhoisFieldPhone   = "phone"
+)
+
+// DefaultPollInterval is the delay between two status checks of the Wait
+// helpers when no explicit interval is given
+const DefaultPollInterval = 5 * time.Second
+
+// Domain represents a domain name service.
+// Visit https://api.ovh.com/console/#/domain/%7BserviceName%7D#GET for the full definition
+type Domain struct {
+	Domain             string     `json:"domain"`
+	Offer              string     `json:"offer"`
+	NameServerType     string     `json:"nameServerType"`
+	TransferLockStatus string     `json:"transferLockStatus"`
+	WhoisOwner         string     `json:"whoisOwner"`
+	OwoSupported       bool       `json:"owoSupported"`
+	DNSSECSupported    bool       `json:"dnssecSupported"`
+	LastUpdate         *time.Time `json:"lastUpdate"`
+}
+
+// DomainTask represents an asynchronous operation on a domain service.
+// Visit https://api.ovh.com/console/#/domain/%7BserviceName%7D/task/%7Bid%7D#GET for the full definition
+type DomainTask struct {
+	ID           int64      `json:"id"`
+	Function     string     `json:"function"`
+	Status       string     `json:"status"`
+	Comment      string     `json:"comment"`
+	CreationDate time.Time  `json:"creationDate"`
+	DoneDate     *time.Time `json:"doneDate"`
+	LastUpdate   time.Time  `json:"lastUpdate"`
+}
+
+// domainPath returns the path of a route of a domain service
+func domainPath(domain, format string, args ...interface{}) string {
+	return fmt.Sprintf("/domain/%s", url.PathEscape(domain)) + fmt.Sprintf(format, args...)
+}
+
+// Domains lists the domain names of the account, with GET /domain
+func (c *Client) Domains(ctx context.Context) ([]string, error) {
+	domains := []string{}
+	if err := c.client.GetWithContext(ctx, "/domain", &domains); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// Domain returns a domain name service, with GET /domain/{serviceName}
+func (c *Client) Domain(ctx context.Context, domain string) (*Domain, error) {
+	service := &Domain{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, ""), service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// DomainTask returns a domain task, with GET /domain/{serviceName}/task/{id}
+func (c *Client) DomainTask(ctx context.Context, domain string, taskID int64) (*DomainTask, error) {
+	task := &DomainTask{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/task/%d", taskID), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// WaitDomainTask polls a domain task every "interval", DefaultPollInterval if
+// not positive, until it is done. It fails if the task ends in error or is
+// cancelled, or when the context is done.
+func (c *Client) WaitDomainTask(ctx context.Context, domain string, taskID int64, interval time.Duration) (*DomainTask, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		task, err := c.DomainTask(ctx, domain, taskID)
+		if err != nil {
+			return nil, err
+		}
+		switch task.Status {
+		case TaskStatusDone:
+			return task, nil
+		case TaskStatusError, TaskStatusCancelled:
+			return task, fmt.Errorf("domain: task %d of %s is in %s status: %s", taskID, domain, task.Status, task.Comment)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return task, ctx.Err()
+		}
+	}
+}
+
+// ObfuscatedWhoisFields lists the WHOIS fields of the owner which are
+// obfuscated, with GET /domain/{serviceName}/owo
+func (c *Client) ObfuscatedWhoisFields(ctx context.Context, domain string) ([]string, error) {
+	fields := []string{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/owo"), &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// ObfuscateWhois obfuscates WHOIS fields of the owner, like WhoisFieldEmail,
+// with POST /domain/{serviceName}/owo. It returns the newly obfuscated fields.
+func (c *Client) ObfuscateWhois(ctx context.Context, domain string, fields ...string) ([]string, error) {
+	obfuscated := []string{}
+	body := map[string][]string{"fields": fields}
+	if err := c.client.PostWithContext(ctx, domainPath(domain, "/owo"), body, &obfuscated); err != nil {
+		return nil, err
+	}
+	return obfuscated, nil
+}
+
+// UnobfuscateWhois publishes a WHOIS field of the owner again, with
+// DELETE /domain/{serviceName}/owo/{field}
+func (c *Client) UnobfuscateWhois(ctx context.Context, domain, field string) error {
+	return c.client.DeleteWithContext(ctx, domainPath(domain, "/owo/%s", url.PathEscape(field)), nil)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDomain(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain", http.StatusOK, `["example.com"]`)
+	server.Handle("GET", "/domain/example.com", http.StatusOK, `{"domain": "example.com", "offer": "gold", "nameServerType": "hosted", "transferLockStatus": "locked", "whoisOwner": "12345", "owoSupported": true, "dnssecSupported": true, "lastUpdate": "2020-01-01T10:00:00+01:00"}`)
+	server.Handle("GET", "/domain/example.com/owo", http.StatusOK, `["email"]`)
+	server.Handle("POST", "/domain/example.com/owo", http.StatusOK, `["address", "phone"]`)
+	server.Handle("DELETE", "/domain/example.com/owo/email", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	domains, err := client.Domains(ctx)
+	if err != nil {
+		t.Fatalf("Domains should not return an error. Got %v", err)
+	}
+	domain, err := client.Domain(ctx, domains[0])
+	if err != nil {
+		t.Fatalf("Domain should not return an error. Got %v", err)
+	}
+	fields, err := client.ObfuscatedWhoisFields(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("ObfuscatedWhoisFields should not return an error. Got %v", err)
+	}
+	obfuscated, err := client.ObfuscateWhois(ctx, "example.com", WhoisFieldAddress, WhoisFieldPhone)
+	if err != nil {
+		t.Fatalf("ObfuscateWhois should not return an error. Got %v", err)
+	}
+	if err := client.UnobfuscateWhois(ctx, "example.com", WhoisFieldEmail); err != nil {
+		t.Fatalf("UnobfuscateWhois should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if domain.Domain != "example.com" || domain.TransferLockStatus != "locked" || domain.WhoisOwner != "12345" || !domain.OwoSupported || domain.LastUpdate == nil {
+		t.Fatalf("Domain should decode the response. Got %+v", domain)
+	}
+	if len(fields) != 1 || fields[0] != WhoisFieldEmail || len(obfuscated) != 2 {
+		t.Fatalf("WHOIS fields should be decoded. Got %v, %v", fields, obfuscated)
+	}
+	if request, _ := server.LastRequest("POST", "/domain/example.com/owo"); string(request.Body) != `{"fields":["address","phone"]}` {
+		t.Fatalf("ObfuscateWhois should send the fields. Got %s", request.Body)
+	}
+}
+
+func TestWaitDomainTask(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/example.com/task/1", http.StatusOK, `{"id": 1, "function": "DomainDnsUpdate", "status": "done"}`)
+	server.Handle("GET", "/domain/example.com/task/2", http.StatusOK, `{"id": 2, "function": "DomainDnsUpdate", "status": "error", "comment": "Invalid name server"}`)
+	ctx := context.Background()
+
+	// Test
+	task, err := client.WaitDomainTask(ctx, "example.com", 1, 0)
+	if err != nil || task.Status != TaskStatusDone {
+		t.Fatalf("WaitDomainTask should return the done task. Got %+v, %v", task, err)
+	}
+	task, err = client.WaitDomainTask(ctx, "example.com", 2, 0)
+
+	// Validate
+	if err == nil || !strings.Contains(err.Error(), "Invalid name server") || task.Status != TaskStatusError {
+		t.Fatalf("WaitDomainTask should fail for tasks in error. Got %+v, %v", task, err)
+	}
+}
@@ -0,0 +1,315 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Task represents a task of a zone, like a zone import.
+// Visit https://api.ovh.com/console/#/domain/zone/%7BzoneName%7D/task/%7Bid%7D#GET for the full definition
+type Task struct {
+	ID           int64      `json:"id"`
+	Function     string     `json:"function"`
+	Status       string     `json:"status"`
+	Comment      string     `json:"comment"`
+	CreationDate *time.Time `json:"creationDate"`
+	DoneDate     *time.Time `json:"doneDate"`
+}
+
+// ExportZone returns the zone file of a zone, in bind format, with
+// GET /domain/zone/{zoneName}/export
+func (c *Client) ExportZone(ctx context.Context, zone string) (string, error) {
+	var zoneFile string
+	if err := c.client.GetWithContext(ctx, zonePath(zone, "/export"), &zoneFile); err != nil {
+		return "", err
+	}
+	return zoneFile, nil
+}
+
+// ImportZone replaces all the records of a zone with those of a zone file,
+// in bind format, with POST /domain/zone/{zoneName}/import. The import is
+// done by the returned task.
+func (c *Client) ImportZone(ctx context.Context, zone, zoneFile string) (*Task, error) {
+	body := map[string]string{"zoneFile": zoneFile}
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, zonePath(zone, "/import"), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// RecordChange is an update of the TTL of a record, see ZoneDiff
+type RecordChange struct {
+	Record Record
+	TTL    int
+}
+
+// ZoneDiff holds the changes turning the records of a zone into the desired
+// ones, see DiffRecords
+type ZoneDiff struct {
+	Create []RecordCreation
+	Update []RecordChange
+	Delete []Record
+}
+
+// Empty checks if there is no change
+func (d *ZoneDiff) Empty() bool {
+	return len(d.Create) == 0 && len(d.Update) == 0 && len(d.Delete) == 0
+}
+
+// recordKey identifies a record by its name, type and target
+func recordKey(subDomain, fieldType, target string) string {
+	return strings.ToLower(subDomain) + " " + strings.ToUpper(fieldType) + " " + target
+}
+
+// DiffRecords compares the current records of a zone with the desired ones.
+// Records are identified by their sub-domain, type and target: desired
+// records which do not exist are created, current ones which are not desired
+// are deleted, and the TTL of the others is updated when it differs.
+func DiffRecords(current []Record, desired []RecordCreation) *ZoneDiff {
+	remaining := map[string][]Record{}
+	for _, record := range current {
+		key := recordKey(record.SubDomain, record.FieldType, record.Target)
+		remaining[key] = append(remaining[key], record)
+	}
+
+	diff := &ZoneDiff{}
+	for _, record := range desired {
+		key := recordKey(record.SubDomain, record.FieldType, record.Target)
+		matches := remaining[key]
+		if len(matches) == 0 {
+			diff.Create = append(diff.Create, record)
+			continue
+		}
+		remaining[key] = matches[1:]
+		if matches[0].TTL != record.TTL {
+			diff.Update = append(diff.Update, RecordChange{Record: matches[0], TTL: record.TTL})
+		}
+	}
+	for _, record := range current {
+		key := recordKey(record.SubDomain, record.FieldType, record.Target)
+		for _, extra := range remaining[key] {
+			if extra.ID == record.ID {
+				diff.Delete = append(diff.Delete, record)
+			}
+		}
+	}
+	return diff
+}
+
+// Records returns all the records of a zone
+func (c *Client) Records(ctx context.Context, zone string) ([]Record, error) {
+	ids, err := c.RecordIDs(ctx, zone, "", "")
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, 0, len(ids))
+	for _, id := range ids {
+		record, err := c.Record(ctx, zone, id)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, nil
+}
+
+// SyncZone applies the records of a zone file, in bind format, to a zone,
+// only creating, updating and deleting the records which differ, then
+// refreshes the zone if anything changed. SOA records, managed by OVH, are
+// ignored. The applied changes are returned, with nothing applied when
+// "dryRun" is set.
+func (c *Client) SyncZone(ctx context.Context, zone, zoneFile string, dryRun bool) (*ZoneDiff, error) {
+	desired, err := ParseZoneFile(zone, zoneFile)
+	if err != nil {
+		return nil, err
+	}
+	current, err := c.Records(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	var managed []Record
+	for _, record := range current {
+		if !strings.EqualFold(record.FieldType, "SOA") {
+			managed = append(managed, record)
+		}
+	}
+
+	diff := DiffRecords(managed, desired)
+	if dryRun || diff.Empty() {
+		return diff, nil
+	}
+	for _, record := range diff.Delete {
+		if err := c.DeleteRecord(ctx, zone, record.ID); err != nil {
+			return diff, err
+		}
+	}
+	for _, change := range diff.Update {
+		update := RecordUpdate{SubDomain: change.Record.SubDomain, Target: change.Record.Target, TTL: change.TTL}
+		if err := c.UpdateRecord(ctx, zone, change.Record.ID, update); err != nil {
+			return diff, err
+		}
+	}
+	for _, creation := range diff.Create {
+		if _, err := c.CreateRecord(ctx, zone, creation); err != nil {
+			return diff, err
+		}
+	}
+	return diff, c.RefreshZone(ctx, zone)
+}
+
+// ParseZoneFile returns the records of a zone file, in bind format, as
+// exported by ExportZone. Owner names are made relative to "zone", records
+// without TTL use the zone default one, and SOA records are ignored.
+func ParseZoneFile(zone, zoneFile string) ([]RecordCreation, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	origin := zone
+	owner := ""
+	var records []RecordCreation
+
+	lines := strings.Split(strings.Replace(zoneFile, "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		fields, open := zoneFields(lines[i])
+		indented := strings.HasPrefix(lines[i], " ") || strings.HasPrefix(lines[i], "\t")
+
+		// Parentheses group the fields of several lines
+		for open && i+1 < len(lines) {
+			i++
+			var more []string
+			more, open = zoneFields(lines[i])
+			fields = append(fields, more...)
+		}
+		if open {
+			return nil, fmt.Errorf("domain: unbalanced parentheses on line %d", lineNumber)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("domain: missing $ORIGIN value on line %d", lineNumber)
+			}
+			origin = strings.ToLower(strings.TrimSuffix(absoluteName(fields[1], origin), "."))
+			continue
+		case "$TTL", "$INCLUDE", "$GENERATE":
+			continue
+		}
+
+		if !indented {
+			name := absoluteName(fields[0], origin)
+			subDomain, ok := relativeName(name, zone)
+			if !ok {
+				return nil, fmt.Errorf("domain: %s is out of zone %s on line %d", fields[0], zone, lineNumber)
+			}
+			owner = subDomain
+			fields = fields[1:]
+		}
+
+		record := RecordCreation{SubDomain: owner}
+		for len(fields) > 0 {
+			if ttl, err := strconv.Atoi(fields[0]); err == nil {
+				record.TTL = ttl
+			} else if !isZoneClass(fields[0]) {
+				break
+			}
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("domain: missing record type on line %d", lineNumber)
+		}
+		record.FieldType = strings.ToUpper(fields[0])
+		record.Target = strings.Join(fields[1:], " ")
+		if record.FieldType == "SOA" {
+			continue
+		}
+		if record.Target == "" {
+			return nil, fmt.Errorf("domain: missing %s record data on line %d", record.FieldType, lineNumber)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// zoneFields splits a zone file line into fields, keeping quoted strings
+// whole and dropping comments. It reports whether a parenthesis is left open.
+func zoneFields(line string) ([]string, bool) {
+	var fields []string
+	var field strings.Builder
+	quoted, escaped, open := false, false, false
+	flush := func() {
+		if field.Len() > 0 {
+			fields = append(fields, field.String())
+			field.Reset()
+		}
+	}
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			field.WriteRune(r)
+			escaped = true
+		case r == '"':
+			field.WriteRune(r)
+			quoted = !quoted
+		case quoted:
+			field.WriteRune(r)
+		case r == ';':
+			flush()
+			return fields, open
+		case r == '(':
+			flush()
+			open = true
+		case r == ')':
+			flush()
+			open = false
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	flush()
+	return fields, open
+}
+
+// absoluteName returns the fully qualified name of a zone file name
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin + "."
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + origin + "."
+}
+
+// relativeName returns the sub-domain of "zone" named "name", a fully
+// qualified name
+func relativeName(name, zone string) (string, bool) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == zone {
+		return "", true
+	}
+	if strings.HasSuffix(name, "."+zone) {
+		return strings.TrimSuffix(name, "."+zone), true
+	}
+	return "", false
+}
+
+// isZoneClass checks if a zone file field is a record class
+func isZoneClass(field string) bool {
+	switch strings.ToUpper(field) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
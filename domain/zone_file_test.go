@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// exampleZoneFile is a zone file, as exported by the API
+const exampleZoneFile = `$TTL 3600
+@	IN SOA dns200.anycast.me. tech.ovh.net. (2024010100 86400 3600 3600000 300)
+	IN NS     dns200.anycast.me.
+	IN MX     1 mx1.mail.ovh.net.
+	IN TXT    "v=spf1 include:mx.ovh.com ~all" ; SPF
+www	300 IN A  203.0.113.10
+$ORIGIN sub.example.com.
+api	IN CNAME  www.example.com.
+`
+
+func TestParseZoneFile(t *testing.T) {
+	// Test
+	records, err := ParseZoneFile("example.com", exampleZoneFile)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ParseZoneFile should not return an error. Got %v", err)
+	}
+	expected := []RecordCreation{
+		{FieldType: "NS", SubDomain: "", Target: "dns200.anycast.me."},
+		{FieldType: "MX", SubDomain: "", Target: "1 mx1.mail.ovh.net."},
+		{FieldType: "TXT", SubDomain: "", Target: `"v=spf1 include:mx.ovh.com ~all"`},
+		{FieldType: "A", SubDomain: "www", Target: "203.0.113.10", TTL: 300},
+		{FieldType: "CNAME", SubDomain: "api.sub", Target: "www.example.com."},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("ParseZoneFile should return the records. Expected %+v. Got %+v", expected, records)
+	}
+
+	// Test: invalid zone files
+	for _, zoneFile := range []string{"www.example.org. IN A 203.0.113.10", "www IN A", "@ IN SOA ns. tech. (1 2"} {
+		if _, err := ParseZoneFile("example.com", zoneFile); err == nil || !strings.HasPrefix(err.Error(), "domain: ") {
+			t.Fatalf("ParseZoneFile should reject %q. Got %v", zoneFile, err)
+		}
+	}
+}
+
+func TestExportImportZone(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone/example.com/export", http.StatusOK, `"$TTL 3600\nwww IN A 203.0.113.10\n"`)
+	server.Handle("POST", "/domain/zone/example.com/import", http.StatusOK, `{"id": 42, "function": "DnsImport", "status": "todo"}`)
+	ctx := context.Background()
+
+	// Test
+	zoneFile, err := client.ExportZone(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("ExportZone should not return an error. Got %v", err)
+	}
+	task, err := client.ImportZone(ctx, "example.com", zoneFile)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ImportZone should not return an error. Got %v", err)
+	}
+	if zoneFile != "$TTL 3600\nwww IN A 203.0.113.10\n" || task.ID != 42 {
+		t.Fatalf("Zone export and import should be decoded. Got %q, %+v", zoneFile, task)
+	}
+	if request, _ := server.LastRequest("POST", "/domain/zone/example.com/import"); string(request.Body) != `{"zoneFile":"$TTL 3600\nwww IN A 203.0.113.10\n"}` {
+		t.Fatalf("ImportZone should send the zone file. Got %s", request.Body)
+	}
+}
+
+func TestSyncZone(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/zone/example.com/record", http.StatusOK, `[1, 2, 3, 4]`)
+	server.Handle("GET", "/domain/zone/example.com/record/1", http.StatusOK, `{"id": 1, "subDomain": "www", "fieldType": "A", "target": "203.0.113.10", "ttl": 0}`)
+	server.Handle("GET", "/domain/zone/example.com/record/2", http.StatusOK, `{"id": 2, "subDomain": "", "fieldType": "TXT", "target": "\"old\"", "ttl": 0}`)
+	server.Handle("GET", "/domain/zone/example.com/record/3", http.StatusOK, `{"id": 3, "subDomain": "", "fieldType": "MX", "target": "1 mx1.mail.ovh.net.", "ttl": 0}`)
+	server.Handle("GET", "/domain/zone/example.com/record/4", http.StatusOK, `{"id": 4, "subDomain": "", "fieldType": "SOA", "target": "dns200.anycast.me. tech.ovh.net. 1 2 3 4 5", "ttl": 0}`)
+	server.Handle("PUT", "/domain/zone/example.com/record/1", http.StatusOK, `null`)
+	server.Handle("DELETE", "/domain/zone/example.com/record/2", http.StatusOK, `null`)
+	server.Handle("POST", "/domain/zone/example.com/record", http.StatusOK, `{"id": 5}`)
+	server.Handle("POST", "/domain/zone/example.com/refresh", http.StatusOK, `null`)
+	zoneFile := `@ IN MX 1 mx1.mail.ovh.net.
+@ IN TXT "new"
+www 300 IN A 203.0.113.10
+`
+
+	// Test
+	diff, err := client.SyncZone(context.Background(), "example.com", zoneFile, false)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("SyncZone should not return an error. Got %v", err)
+	}
+	if len(diff.Create) != 1 || len(diff.Update) != 1 || len(diff.Delete) != 1 || diff.Delete[0].ID != 2 {
+		t.Fatalf("SyncZone should only apply the changes. Got %+v", diff)
+	}
+	if request, _ := server.LastRequest("POST", "/domain/zone/example.com/record"); string(request.Body) != `{"fieldType":"TXT","subDomain":"","target":"\"new\""}` {
+		t.Fatalf("SyncZone should create the missing records. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("PUT", "/domain/zone/example.com/record/1"); string(request.Body) != `{"subDomain":"www","target":"203.0.113.10","ttl":300}` {
+		t.Fatalf("SyncZone should update the TTLs. Got %s", request.Body)
+	}
+	_, refreshed := server.LastRequest("POST", "/domain/zone/example.com/refresh")
+	if !refreshed {
+		t.Fatalf("SyncZone should refresh the zone")
+	}
+}
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Account represents a mailbox of an email domain.
+// Visit https://api.ovh.com/console/#/email/domain/%7Bdomain%7D/account/%7BaccountName%7D#GET for the full definition
+type Account struct {
+	AccountName string `json:"accountName"`
+	Domain      string `json:"domain"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+	// Size is the size of the mailbox, in bytes
+	Size      int64  `json:"size"`
+	IsBlocked bool   `json:"isBlocked"`
+	State     string `json:"state"`
+}
+
+// AccountCreation holds the parameters of a new mailbox. AccountName is the
+// part of the email address before the "@". Size, in bytes, must be one of
+// the allowed sizes of the domain, the default size is used when zero.
+type AccountCreation struct {
+	AccountName string `json:"accountName"`
+	Password    string `json:"password"`
+	Description string `json:"description,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// AccountUsage represents the usage of a mailbox
+type AccountUsage struct {
+	// Quota is the used size of the mailbox, in bytes
+	Quota      int64     `json:"quota"`
+	EmailCount int64     `json:"emailCount"`
+	Date       time.Time `json:"date"`
+}
+
+// Accounts lists the names of the mailboxes of a domain, with
+// GET /email/domain/{domain}/account
+func (c *Client) Accounts(ctx context.Context, domain string) ([]string, error) {
+	names := []string{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/account"), &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Account returns a mailbox, with GET /email/domain/{domain}/account/{accountName}
+func (c *Client) Account(ctx context.Context, domain, name string) (*Account, error) {
+	account := &Account{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/account/%s", url.PathEscape(name)), account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// CreateAccount creates a mailbox, with POST /email/domain/{domain}/account.
+// It returns a ConflictError if the mailbox already exists and a QuotaError
+// if the domain has no mailbox left.
+func (c *Client) CreateAccount(ctx context.Context, domain string, creation AccountCreation) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, domainPath(domain, "/account"), creation, task); err != nil {
+		return nil, creationError(err, "account", domain, creation.AccountName+"@"+domain)
+	}
+	return task, nil
+}
+
+// UpdateAccount updates the description and size of a mailbox, with
+// PUT /email/domain/{domain}/account/{accountName}. Empty values are not
+// updated.
+func (c *Client) UpdateAccount(ctx context.Context, domain, name, description string, size int64) error {
+	body := map[string]interface{}{}
+	if description != "" {
+		body["description"] = description
+	}
+	if size != 0 {
+		body["size"] = size
+	}
+	return c.client.PutWithContext(ctx, domainPath(domain, "/account/%s", url.PathEscape(name)), body, nil)
+}
+
+// DeleteAccount deletes a mailbox and its emails, with
+// DELETE /email/domain/{domain}/account/{accountName}
+func (c *Client) DeleteAccount(ctx context.Context, domain, name string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.DeleteWithContext(ctx, domainPath(domain, "/account/%s", url.PathEscape(name)), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ChangePassword changes the password of a mailbox, with
+// POST /email/domain/{domain}/account/{accountName}/changePassword
+func (c *Client) ChangePassword(ctx context.Context, domain, name, password string) (*Task, error) {
+	task := &Task{}
+	body := map[string]string{"password": password}
+	if err := c.client.PostWithContext(ctx, domainPath(domain, "/account/%s/changePassword", url.PathEscape(name)), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// AccountUsage returns the usage of a mailbox, with
+// GET /email/domain/{domain}/account/{accountName}/usage
+func (c *Client) AccountUsage(ctx context.Context, domain, name string) (*AccountUsage, error) {
+	usage := &AccountUsage{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/account/%s/usage", url.PathEscape(name)), usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
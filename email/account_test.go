@@ -0,0 +1,67 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestAccounts(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	task := `{"id": 7, "action": "addAccount", "account": "john", "domain": "example.com", "date": "2020-01-01T10:00:00+01:00"}`
+	server.Handle("GET", "/email/domain/example.com/account", http.StatusOK, `["john"]`)
+	server.Handle("POST", "/email/domain/example.com/account", http.StatusOK, task)
+	server.Handle("GET", "/email/domain/example.com/account/john", http.StatusOK, `{"accountName": "john", "domain": "example.com", "email": "john@example.com", "description": "John", "size": 5000000000, "isBlocked": false, "state": "ok"}`)
+	server.Handle("PUT", "/email/domain/example.com/account/john", http.StatusOK, nil)
+	server.Handle("POST", "/email/domain/example.com/account/john/changePassword", http.StatusOK, task)
+	server.Handle("GET", "/email/domain/example.com/account/john/usage", http.StatusOK, `{"quota": 1024, "emailCount": 3, "date": "2020-01-01T10:00:00+01:00"}`)
+	server.Handle("DELETE", "/email/domain/example.com/account/john", http.StatusOK, task)
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateAccount(ctx, "example.com", AccountCreation{AccountName: "john", Password: "p4ssw0rd!", Size: 5000000000})
+	if err != nil {
+		t.Fatalf("CreateAccount should not return an error. Got %v", err)
+	}
+	names, err := client.Accounts(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("Accounts should not return an error. Got %v", err)
+	}
+	account, err := client.Account(ctx, "example.com", names[0])
+	if err != nil {
+		t.Fatalf("Account should not return an error. Got %v", err)
+	}
+	if err := client.UpdateAccount(ctx, "example.com", "john", "", 2500000000); err != nil {
+		t.Fatalf("UpdateAccount should not return an error. Got %v", err)
+	}
+	if _, err := client.ChangePassword(ctx, "example.com", "john", "n3w-p4ss!"); err != nil {
+		t.Fatalf("ChangePassword should not return an error. Got %v", err)
+	}
+	usage, err := client.AccountUsage(ctx, "example.com", "john")
+	if err != nil {
+		t.Fatalf("AccountUsage should not return an error. Got %v", err)
+	}
+	if _, err := client.DeleteAccount(ctx, "example.com", "john"); err != nil {
+		t.Fatalf("DeleteAccount should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if created.ID != 7 || created.Action != "addAccount" {
+		t.Fatalf("Task should be decoded. Got %+v", created)
+	}
+	if account.Email != "john@example.com" || account.Size != 5000000000 {
+		t.Fatalf("Account should be decoded. Got %+v", account)
+	}
+	if usage.Quota != 1024 || usage.EmailCount != 3 || usage.Date.IsZero() {
+		t.Fatalf("AccountUsage should be decoded. Got %+v", usage)
+	}
+	requests := server.Requests()
+	if body := string(requests[0].Body); body != `{"accountName":"john","password":"p4ssw0rd!","size":5000000000}` {
+		t.Fatalf("CreateAccount should send the account creation. Got %s", body)
+	}
+	if body := string(requests[3].Body); body != `{"size":2500000000}` {
+		t.Fatalf("UpdateAccount should only send the updated values. Got %s", body)
+	}
+}
@@ -0,0 +1,152 @@
+// Package email provides typed helpers for the OVH MX Plan email API, under
+// /email/domain: mailboxes, redirections and quotas of email domains.
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Domain represents an email domain.
+// Visit https://api.ovh.com/console/#/email/domain/%7Bdomain%7D#GET for the full definition
+type Domain struct {
+	Domain             string  `json:"domain"`
+	Status             string  `json:"status"`
+	Offer              string  `json:"offer"`
+	AllowedAccountSize []int64 `json:"allowedAccountSize"`
+	IsMXValid          bool    `json:"isMXValid"`
+	IsSPFValid         bool    `json:"isSPFValid"`
+}
+
+// Quota represents the maximum number of resources of an email domain
+type Quota struct {
+	Account     int `json:"account"`
+	Redirection int `json:"redirection"`
+	Responder   int `json:"responder"`
+	MailingList int `json:"mailingList"`
+}
+
+// Task represents an asynchronous operation on an email domain, like an
+// account creation
+type Task struct {
+	ID      int64     `json:"id"`
+	Action  string    `json:"action"`
+	Account string    `json:"account"`
+	Domain  string    `json:"domain"`
+	Date    time.Time `json:"date"`
+}
+
+// ConflictError is returned when creating an account or a redirection which
+// already exists
+type ConflictError struct {
+	// Resource is "account" or "redirection"
+	Resource string
+	// Name of the resource, like the email address of an account
+	Name string
+	Err  *ovh.APIError
+}
+
+func (err *ConflictError) Error() string {
+	return fmt.Sprintf("email: %s %s already exists: %s", err.Resource, err.Name, err.Err.Message)
+}
+
+// Unwrap returns the API error
+func (err *ConflictError) Unwrap() error {
+	return err.Err
+}
+
+// QuotaError is returned when creating an account or a redirection would
+// exceed the quota of the domain, see Quota
+type QuotaError struct {
+	// Resource is "account" or "redirection"
+	Resource string
+	Domain   string
+	Err      *ovh.APIError
+}
+
+func (err *QuotaError) Error() string {
+	return fmt.Sprintf("email: %s quota of %s exceeded: %s", err.Resource, err.Domain, err.Err.Message)
+}
+
+// Unwrap returns the API error
+func (err *QuotaError) Unwrap() error {
+	return err.Err
+}
+
+// IsConflict checks if an error is a ConflictError
+func IsConflict(err error) bool {
+	var conflict *ConflictError
+	return errors.As(err, &conflict)
+}
+
+// IsQuotaExceeded checks if an error is a QuotaError
+func IsQuotaExceeded(err error) bool {
+	var quota *QuotaError
+	return errors.As(err, &quota)
+}
+
+// creationError converts the API errors of a creation to a ConflictError or
+// a QuotaError, when they can be identified
+func creationError(err error, resource, domain, name string) error {
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.StatusCode == http.StatusConflict || strings.Contains(strings.ToLower(apiErr.Message), "already exist") {
+		return &ConflictError{Resource: resource, Name: name, Err: apiErr}
+	}
+	if strings.Contains(strings.ToLower(apiErr.Message), "quota") {
+		return &QuotaError{Resource: resource, Domain: domain, Err: apiErr}
+	}
+	return err
+}
+
+// Client gives access to the /email/domain routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns an email client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// domainPath returns the path of a route of an email domain
+func domainPath(domain, format string, args ...interface{}) string {
+	return fmt.Sprintf("/email/domain/%s", url.PathEscape(domain)) + fmt.Sprintf(format, args...)
+}
+
+// Domains lists the email domains, with GET /email/domain
+func (c *Client) Domains(ctx context.Context) ([]string, error) {
+	domains := []string{}
+	if err := c.client.GetWithContext(ctx, "/email/domain", &domains); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// Domain returns an email domain, with GET /email/domain/{domain}
+func (c *Client) Domain(ctx context.Context, domain string) (*Domain, error) {
+	res := &Domain{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, ""), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Quota returns the quota of an email domain, with
+// GET /email/domain/{domain}/quota
+func (c *Client) Quota(ctx context.Context, domain string) (*Quota, error) {
+	quota := &Quota{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/quota"), quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
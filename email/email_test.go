@@ -0,0 +1,87 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestDomain(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/email/domain", http.StatusOK, `["example.com"]`)
+	server.Handle("GET", "/email/domain/example.com", http.StatusOK, `{"domain": "example.com", "status": "ok", "offer": "MX Plan 100", "allowedAccountSize": [5000000000], "isMXValid": true}`)
+	server.Handle("GET", "/email/domain/example.com/quota", http.StatusOK, `{"account": 100, "redirection": 1000, "responder": 1000, "mailingList": 0}`)
+	ctx := context.Background()
+
+	// Test
+	domains, err := client.Domains(ctx)
+	if err != nil {
+		t.Fatalf("Domains should not return an error. Got %v", err)
+	}
+	domain, err := client.Domain(ctx, domains[0])
+	if err != nil {
+		t.Fatalf("Domain should not return an error. Got %v", err)
+	}
+	quota, err := client.Quota(ctx, domains[0])
+	if err != nil {
+		t.Fatalf("Quota should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if domain.Offer != "MX Plan 100" || domain.AllowedAccountSize[0] != 5000000000 || !domain.IsMXValid {
+		t.Fatalf("Domain should be decoded. Got %+v", domain)
+	}
+	if quota.Account != 100 || quota.Redirection != 1000 {
+		t.Fatalf("Quota should be decoded. Got %+v", quota)
+	}
+}
+
+func TestCreationErrors(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/email/domain/example.com/account", http.StatusConflict, `{"message": "This account already exists"}`)
+	server.Handle("POST", "/email/domain/example.com/redirection", http.StatusForbidden, `{"message": "Redirection quota reached"}`)
+	server.Handle("POST", "/email/domain/other.com/account", http.StatusBadRequest, `{"message": "Invalid password"}`)
+	ctx := context.Background()
+
+	// Test: conflict
+	_, err := client.CreateAccount(ctx, "example.com", AccountCreation{AccountName: "john", Password: "p4ssw0rd!"})
+	if !IsConflict(err) || IsQuotaExceeded(err) {
+		t.Fatalf("CreateAccount should return a ConflictError. Got %v", err)
+	}
+	if err.Error() != "email: account john@example.com already exists: This account already exists" {
+		t.Fatalf("ConflictError should name the account. Got %s", err)
+	}
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusConflict {
+		t.Fatalf("ConflictError should wrap the API error. Got %v", err)
+	}
+
+	// Test: quota
+	_, err = client.CreateRedirection(ctx, "example.com", "john@example.com", "john@example.net", false)
+	if !IsQuotaExceeded(err) || IsConflict(err) {
+		t.Fatalf("CreateRedirection should return a QuotaError. Got %v", err)
+	}
+
+	// Test: other errors
+	_, err = client.CreateAccount(ctx, "other.com", AccountCreation{AccountName: "john", Password: "p"})
+	if _, ok := err.(*ovh.APIError); !ok {
+		t.Fatalf("CreateAccount should return other API errors as is. Got %#v", err)
+	}
+}
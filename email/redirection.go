@@ -0,0 +1,71 @@
+package email
+
+import (
+	"context"
+	"net/url"
+)
+
+// Redirection represents an email redirection of a domain.
+// Visit https://api.ovh.com/console/#/email/domain/%7Bdomain%7D/redirection/%7Bid%7D#GET for the full definition
+type Redirection struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Redirections lists the IDs of the redirections of a domain, with
+// GET /email/domain/{domain}/redirection. Empty "from" and "to" addresses
+// are not used as filters.
+func (c *Client) Redirections(ctx context.Context, domain, from, to string) ([]string, error) {
+	query := url.Values{}
+	if from != "" {
+		query.Set("from", from)
+	}
+	if to != "" {
+		query.Set("to", to)
+	}
+	path := domainPath(domain, "/redirection")
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Redirection returns a redirection, with
+// GET /email/domain/{domain}/redirection/{id}
+func (c *Client) Redirection(ctx context.Context, domain, id string) (*Redirection, error) {
+	redirection := &Redirection{}
+	if err := c.client.GetWithContext(ctx, domainPath(domain, "/redirection/%s", url.PathEscape(id)), redirection); err != nil {
+		return nil, err
+	}
+	return redirection, nil
+}
+
+// CreateRedirection redirects the emails sent to "from" to "to", with
+// POST /email/domain/{domain}/redirection. With "localCopy", the emails are
+// also kept in the "from" mailbox. It returns a ConflictError if the
+// redirection already exists and a QuotaError if the domain has no
+// redirection left.
+func (c *Client) CreateRedirection(ctx context.Context, domain, from, to string, localCopy bool) (*Task, error) {
+	task := &Task{}
+	body := map[string]interface{}{"from": from, "to": to, "localCopy": localCopy}
+	if err := c.client.PostWithContext(ctx, domainPath(domain, "/redirection"), body, task); err != nil {
+		return nil, creationError(err, "redirection", domain, from+" to "+to)
+	}
+	return task, nil
+}
+
+// DeleteRedirection deletes a redirection, with
+// DELETE /email/domain/{domain}/redirection/{id}
+func (c *Client) DeleteRedirection(ctx context.Context, domain, id string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.DeleteWithContext(ctx, domainPath(domain, "/redirection/%s", url.PathEscape(id)), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
@@ -0,0 +1,43 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRedirections(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	task := `{"id": 8, "action": "addRedirection", "domain": "example.com", "date": "2020-01-01T10:00:00+01:00"}`
+	server.Handle("GET", "/email/domain/example.com/redirection?from=john%40example.com", http.StatusOK, `["r-1"]`)
+	server.Handle("GET", "/email/domain/example.com/redirection/r-1", http.StatusOK, `{"id": "r-1", "from": "john@example.com", "to": "john@example.net"}`)
+	server.Handle("POST", "/email/domain/example.com/redirection", http.StatusOK, task)
+	server.Handle("DELETE", "/email/domain/example.com/redirection/r-1", http.StatusOK, task)
+	ctx := context.Background()
+
+	// Test
+	if _, err := client.CreateRedirection(ctx, "example.com", "john@example.com", "john@example.net", true); err != nil {
+		t.Fatalf("CreateRedirection should not return an error. Got %v", err)
+	}
+	ids, err := client.Redirections(ctx, "example.com", "john@example.com", "")
+	if err != nil {
+		t.Fatalf("Redirections should not return an error. Got %v", err)
+	}
+	redirection, err := client.Redirection(ctx, "example.com", ids[0])
+	if err != nil {
+		t.Fatalf("Redirection should not return an error. Got %v", err)
+	}
+	if _, err := client.DeleteRedirection(ctx, "example.com", "r-1"); err != nil {
+		t.Fatalf("DeleteRedirection should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if redirection.To != "john@example.net" {
+		t.Fatalf("Redirection should be decoded. Got %+v", redirection)
+	}
+	if body := string(server.Requests()[0].Body); body != `{"from":"john@example.com","localCopy":true,"to":"john@example.net"}` {
+		t.Fatalf("CreateRedirection should send the redirection. Got %s", body)
+	}
+}
@@ -0,0 +1,105 @@
+// Package govh provides a package-level default OVH API client and top-level
+// call functions, like net/http's DefaultClient, for quick scripts:
+//
+//	var me map[string]interface{}
+//	if err := govh.Get("/me", &me); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The default client is created on first use with ovh.NewDefaultClient, from
+// the environment and configuration files. A client with other options may
+// be set with SetDefaultClient, for instance in tests. All functions are safe
+// for concurrent use.
+package govh
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+var (
+	// Guards defaultClient. sync.Once would consider the creation done, even
+	// in case of error, hence a mutex.
+	mutex         sync.Mutex
+	defaultClient *ovh.Client
+
+	// newDefaultClient creates the default client, overridden by the tests
+	newDefaultClient = ovh.NewDefaultClient
+)
+
+// DefaultClient returns the default client, creating it from the environment
+// and configuration files on first use. A failed creation is tried again by
+// the next call.
+func DefaultClient() (*ovh.Client, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if defaultClient == nil {
+		client, err := newDefaultClient()
+		if err != nil {
+			return nil, err
+		}
+		defaultClient = client
+	}
+	return defaultClient, nil
+}
+
+// SetDefaultClient replaces the default client. With a nil client, the next
+// call creates a new one from the environment and configuration files.
+func SetDefaultClient(client *ovh.Client) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	defaultClient = client
+}
+
+// Get is a wrapper for the GET method of the default client
+func Get(url string, resType interface{}) error {
+	return GetWithContext(context.Background(), url, resType)
+}
+
+// Post is a wrapper for the POST method of the default client
+func Post(url string, reqBody, resType interface{}) error {
+	return PostWithContext(context.Background(), url, reqBody, resType)
+}
+
+// Put is a wrapper for the PUT method of the default client
+func Put(url string, reqBody, resType interface{}) error {
+	return PutWithContext(context.Background(), url, reqBody, resType)
+}
+
+// Delete is a wrapper for the DELETE method of the default client
+func Delete(url string, resType interface{}) error {
+	return DeleteWithContext(context.Background(), url, resType)
+}
+
+// GetWithContext is a wrapper for the GET method of the default client
+func GetWithContext(ctx context.Context, url string, resType interface{}) error {
+	return CallAPIWithContext(ctx, "GET", url, nil, resType, true)
+}
+
+// PostWithContext is a wrapper for the POST method of the default client
+func PostWithContext(ctx context.Context, url string, reqBody, resType interface{}) error {
+	return CallAPIWithContext(ctx, "POST", url, reqBody, resType, true)
+}
+
+// PutWithContext is a wrapper for the PUT method of the default client
+func PutWithContext(ctx context.Context, url string, reqBody, resType interface{}) error {
+	return CallAPIWithContext(ctx, "PUT", url, reqBody, resType, true)
+}
+
+// DeleteWithContext is a wrapper for the DELETE method of the default client
+func DeleteWithContext(ctx context.Context, url string, resType interface{}) error {
+	return CallAPIWithContext(ctx, "DELETE", url, nil, resType, true)
+}
+
+// CallAPIWithContext calls the API with the default client, see
+// ovh.Client.CallAPIWithContext
+func CallAPIWithContext(ctx context.Context, method, url string, reqBody, resType interface{}, needAuth bool) error {
+	client, err := DefaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CallAPIWithContext(ctx, method, url, reqBody, resType, needAuth)
+}
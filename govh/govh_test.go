@@ -0,0 +1,94 @@
+package govh
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func TestDefaultClient(t *testing.T) {
+	// Init test
+	server := govhtest.NewServer()
+	defer server.Close()
+	server.Handle("GET", "/me", http.StatusOK, map[string]string{"nichandle": "xx1111-ovh"})
+	server.Handle("POST", "/me/contact", http.StatusOK, `{"id":42}`)
+	server.Handle("DELETE", "/me/contact/42", http.StatusOK, `null`)
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	SetDefaultClient(client)
+	defer SetDefaultClient(nil)
+
+	// Test
+	var me struct {
+		Nichandle string `json:"nichandle"`
+	}
+	if err := Get("/me", &me); err != nil {
+		t.Fatalf("Get should not fail. Got %v", err)
+	}
+	var contact struct {
+		ID int64 `json:"id"`
+	}
+	if err := Post("/me/contact", map[string]string{"email": "john@example.com"}, &contact); err != nil {
+		t.Fatalf("Post should not fail. Got %v", err)
+	}
+	if err := Delete("/me/contact/42", nil); err != nil {
+		t.Fatalf("Delete should not fail. Got %v", err)
+	}
+
+	// Validate
+	if me.Nichandle != "xx1111-ovh" || contact.ID != 42 {
+		t.Fatalf("Calls should decode the responses. Got %+v, %+v", me, contact)
+	}
+	if requests := server.Requests(); len(requests) != 3 || !requests[0].Authenticated || string(requests[1].Body) != `{"email":"john@example.com"}` {
+		t.Fatalf("Calls should be sent by the default client. Got %+v", requests)
+	}
+}
+
+func TestDefaultClientLazy(t *testing.T) {
+	// Init test: the first creation fails
+	defer func() { newDefaultClient = ovh.NewDefaultClient }()
+	SetDefaultClient(nil)
+	defer SetDefaultClient(nil)
+
+	server := govhtest.NewServer()
+	defer server.Close()
+	creations := 0
+	newDefaultClient = func() (*ovh.Client, error) {
+		if creations++; creations == 1 {
+			return nil, errors.New("missing credentials")
+		}
+		return server.Client()
+	}
+
+	// Test
+	if err := Get("/me", nil); err == nil || err.Error() != "missing credentials" {
+		t.Fatalf("Calls should fail when the default client cannot be created. Got %v", err)
+	}
+	var wg sync.WaitGroup
+	clients := make([]*ovh.Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = DefaultClient()
+		}(i)
+	}
+	wg.Wait()
+
+	// Validate
+	if creations != 2 {
+		t.Fatalf("Default client should be created once after a failure. Got %d creations", creations)
+	}
+	for _, client := range clients {
+		if client == nil || client != clients[0] {
+			t.Fatalf("Concurrent calls should share the default client. Got %v", clients)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package govhtest provides an in-process fake OVH API server to test code
+// using the github.com/ovh/go-ovh/ovh client.
+//
+// The server answers /auth/time, checks the application key and request
+// signatures like the real API, records all requests and serves canned
+// responses registered per method and path:
+//
+//	server := govhtest.NewServer()
+//	defer server.Close()
+//	server.Handle("GET", "/me", http.StatusOK, map[string]string{"nichandle": "xx1111-ovh"})
+//
+//	client, _ := server.Client()
+//	// ... run the code under test with client
+//
+//	requests := server.Requests()
+//
+// Recorder is an HTTP transport recording the responses of the real API to
+// fixture files, and replaying them without network access.
+package govhtest
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Default credentials accepted by the server
+const (
+	DefaultAppKey      = "govhtest-application-key"
+	DefaultAppSecret   = "govhtest-application-secret"
+	DefaultConsumerKey = "govhtest-consumer-key"
+)
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	// Path of the request, including its query string
+	Path   string
+	Header http.Header
+	Body   []byte
+	// Authenticated is set for signed requests
+	Authenticated bool
+}
+
+// Server is a fake OVH API server
+type Server struct {
+	*httptest.Server
+
+	// Credentials accepted by the server. They may be changed before the
+	// first request.
+	AppKey      string
+	AppSecret   string
+	ConsumerKey string
+
+	mutex    sync.Mutex
+	routes   map[string]http.HandlerFunc
+	requests []Request
+}
+
+// NewServer starts a fake OVH API server accepting the default credentials.
+// It must be closed when done.
+func NewServer() *Server {
+	s := &Server{
+		AppKey:      DefaultAppKey,
+		AppSecret:   DefaultAppSecret,
+		ConsumerKey: DefaultConsumerKey,
+		routes:      map[string]http.HandlerFunc{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a client of the server, using its credentials. It never
+// reads the environment nor the configuration files, see
+// ovh.NewClientForTesting.
+func (s *Server) Client() (*ovh.Client, error) {
+	return ovh.NewClientForTesting(s.URL, ovh.WithAppKey(s.AppKey, s.AppSecret), ovh.WithConsumerKey(s.ConsumerKey))
+}
+
+// Handle registers a canned response for "method" on "path". "body" is sent
+// as is if it is a string or []byte, serialized as JSON otherwise. "path" may
+// include a query string, to only match requests with this exact query.
+// Routes registered without query string match any query.
+func (s *Server) Handle(method, path string, status int, body interface{}) {
+	s.HandleSequence(method, path, status, body)
+}
+
+// HandleSequence registers canned responses for "method" on "path", served in
+// turn, the last one being repeated, for instance to follow the status of a
+// task. See Handle.
+func (s *Server) HandleSequence(method, path string, status int, bodies ...interface{}) {
+	responses := [][]byte{nil}
+	if len(bodies) > 0 {
+		responses = make([][]byte, len(bodies))
+	}
+	for i, body := range bodies {
+		responses[i] = responseBody(body)
+	}
+
+	var mutex sync.Mutex
+	s.HandleFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		data := responses[0]
+		if len(responses) > 1 {
+			responses = responses[1:]
+		}
+		mutex.Unlock()
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write(data)
+	})
+}
+
+// responseBody returns "body" as is if it is a string or []byte, serialized
+// as JSON otherwise
+func responseBody(body interface{}) []byte {
+	switch b := body.(type) {
+	case string:
+		return []byte(b)
+	case []byte:
+		return b
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		panic(fmt.Sprintf("govhtest: can not serialize response body: %v", err))
+	}
+	return data
+}
+
+// HandleFunc registers a handler for "method" on "path", see Handle. The
+// handler is only called for requests with valid credentials.
+func (s *Server) HandleFunc(method, path string, handler http.HandlerFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.routes[strings.ToUpper(method)+" "+path] = handler
+}
+
+// Requests returns the requests received by the server, in order, except the
+// /auth/time ones
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// LastRequest returns the last request received for "method" on "path",
+// which includes the query string, if any
+func (s *Server) LastRequest(method, path string) (Request, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		if request := s.requests[i]; request.Method == strings.ToUpper(method) && request.Path == path {
+			return request, true
+		}
+	}
+	return Request{}, false
+}
+
+// Reset forgets the recorded requests
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = nil
+}
+
+// writeError writes an error formatted like the API ones
+func writeError(w http.ResponseWriter, status int, errorCode, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"class":     "Client::" + http.StatusText(status),
+		"errorCode": errorCode,
+		"message":   message,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/auth/time" {
+		fmt.Fprint(w, time.Now().Unix())
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	request := Request{
+		Method:        r.Method,
+		Path:          r.URL.RequestURI(),
+		Header:        r.Header.Clone(),
+		Body:          body,
+		Authenticated: r.Header.Get("X-Ovh-Signature") != "",
+	}
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, request)
+	handler, ok := s.routes[r.Method+" "+request.Path]
+	if !ok {
+		handler, ok = s.routes[r.Method+" "+r.URL.Path]
+	}
+	s.mutex.Unlock()
+
+	if r.Header.Get("X-Ovh-Application") != s.AppKey {
+		writeError(w, http.StatusForbidden, "INVALID_KEY", "Invalid application key")
+		return
+	}
+	if request.Authenticated {
+		if r.Header.Get("X-Ovh-Consumer") != s.ConsumerKey {
+			writeError(w, http.StatusForbidden, "INVALID_CREDENTIAL", "This credential does not exist")
+			return
+		}
+		if r.Header.Get("X-Ovh-Signature") != s.signature(r, body) {
+			writeError(w, http.StatusBadRequest, "INVALID_SIGNATURE", "Invalid signature")
+			return
+		}
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "", fmt.Sprintf("govhtest: no handler for %s %s", r.Method, request.Path))
+		return
+	}
+	handler(w, r)
+}
+
+// signature computes the expected signature of a request
+func (s *Server) signature(r *http.Request, body []byte) string {
+	h := sha1.New()
+	h.Write([]byte(fmt.Sprintf("%s+%s+%s+%s%s+%s+%s",
+		s.AppSecret,
+		s.ConsumerKey,
+		r.Method,
+		s.URL,
+		r.URL.RequestURI(),
+		body,
+		r.Header.Get("X-Ovh-Timestamp"),
+	)))
+	return fmt.Sprintf("$1$%x", h.Sum(nil))
+}
@@ -0,0 +1,141 @@
+package govhtest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func TestServer(t *testing.T) {
+	// Init test
+	server := NewServer()
+	defer server.Close()
+	server.Handle("GET", "/me", http.StatusOK, map[string]string{"nichandle": "xx1111-ovh"})
+	server.Handle("POST", "/domain/zone/example.com/record", http.StatusOK, `{"id":42}`)
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+
+	// Test
+	var me struct {
+		Nichandle string `json:"nichandle"`
+	}
+	if err := client.Get("/me?fields=all", &me); err != nil {
+		t.Fatalf("Signed GET should be accepted. Got %v", err)
+	}
+	var record struct {
+		ID int64 `json:"id"`
+	}
+	if err := client.Post("/domain/zone/example.com/record", map[string]string{"fieldType": "TXT"}, &record); err != nil {
+		t.Fatalf("Signed POST should be accepted. Got %v", err)
+	}
+
+	// Validate
+	if me.Nichandle != "xx1111-ovh" || record.ID != 42 {
+		t.Fatalf("Canned responses should be served. Got %+v, %+v", me, record)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("Requests should be recorded. Got %d", len(requests))
+	}
+	if requests[0].Path != "/me?fields=all" || !requests[0].Authenticated {
+		t.Fatalf("First request should be a signed GET /me. Got %+v", requests[0])
+	}
+	if requests[1].Method != "POST" || string(requests[1].Body) != `{"fieldType":"TXT"}` {
+		t.Fatalf("Second request should be the POST. Got %+v", requests[1])
+	}
+
+	server.Reset()
+	if len(server.Requests()) != 0 {
+		t.Fatalf("Reset should forget the requests")
+	}
+}
+
+func TestServerSequence(t *testing.T) {
+	// Init test
+	server := NewServer()
+	defer server.Close()
+	server.HandleSequence("GET", "/me/task/1", http.StatusOK, `{"status":"todo"}`, `{"status":"done"}`)
+	server.Handle("POST", "/me/task/1/accept", http.StatusOK, `null`)
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+
+	// Test
+	statuses := []string{}
+	for i := 0; i < 3; i++ {
+		var task struct {
+			Status string `json:"status"`
+		}
+		if err := client.Get("/me/task/1", &task); err != nil {
+			t.Fatalf("GET should be accepted. Got %v", err)
+		}
+		statuses = append(statuses, task.Status)
+	}
+	client.Post("/me/task/1/accept", map[string]string{"token": "first"}, nil)
+	client.Post("/me/task/1/accept", map[string]string{"token": "second"}, nil)
+
+	// Validate
+	if strings.Join(statuses, ",") != "todo,done,done" {
+		t.Fatalf("Responses should be served in turn, the last one being repeated. Got %v", statuses)
+	}
+	if request, ok := server.LastRequest("POST", "/me/task/1/accept"); !ok || string(request.Body) != `{"token":"second"}` {
+		t.Fatalf("LastRequest should return the last matching request. Got %+v", request)
+	}
+	if _, ok := server.LastRequest("DELETE", "/me/task/1"); ok {
+		t.Fatalf("LastRequest should not match other requests")
+	}
+}
+
+func TestServerRejectsInvalidCredentials(t *testing.T) {
+	// Init test
+	server := NewServer()
+	defer server.Close()
+	server.Handle("GET", "/me", http.StatusOK, "{}")
+
+	for name, client := range map[string]*ovh.Client{
+		"secret":       mustClient(t, server.URL, DefaultAppKey, "wrong", DefaultConsumerKey),
+		"consumer key": mustClient(t, server.URL, DefaultAppKey, DefaultAppSecret, "wrong"),
+		"app key":      mustClient(t, server.URL, "wrong", DefaultAppSecret, DefaultConsumerKey),
+	} {
+		// Test
+		err := client.Get("/me", nil)
+
+		// Validate
+		var apiErr *ovh.APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode == "" {
+			t.Fatalf("Request with invalid %s should be rejected. Got %v", name, err)
+		}
+	}
+}
+
+func TestServerUnknownRoute(t *testing.T) {
+	// Init test
+	server := NewServer()
+	defer server.Close()
+	client, _ := server.Client()
+
+	// Test
+	err := client.GetUnAuth("/unknown", nil)
+
+	// Validate
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		t.Fatalf("Unknown routes should return a 404. Got %v", err)
+	}
+}
+
+func mustClient(t *testing.T, endpoint, appKey, appSecret, consumerKey string) *ovh.Client {
+	client, err := ovh.NewClient(endpoint, appKey, appSecret, consumerKey)
+	if err != nil {
+		t.Fatalf("NewClient should not fail. Got %v", err)
+	}
+	return client
+}
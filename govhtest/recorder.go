@@ -0,0 +1,308 @@
+package govhtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode is the mode of a Recorder
+type Mode string
+
+// Recorder modes
+const (
+	// ModeReplay serves the recorded responses, without network access
+	ModeReplay Mode = "replay"
+	// ModeRecord sends the requests to the API and records the responses
+	ModeRecord Mode = "record"
+)
+
+// ModeEnv is the environment variable selecting the mode of the recorders
+// created without explicit mode, like "GOVHTEST_MODE=record go test ./..."
+const ModeEnv = "GOVHTEST_MODE"
+
+// redacted replaces the secrets in the fixtures
+const redacted = "****"
+
+// redactedFieldNames are the parts of the JSON field names whose values are
+// redacted from the fixtures, matched case-insensitively
+var redactedFieldNames = []string{"password", "secret", "token", "consumerkey"}
+
+// Interaction is a request and its response, as stored in the fixtures
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in the fixtures. Its headers are not
+// stored, as they hold the credentials and the signature.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// Path of the request, including the API version and the query string
+	Path string `json:"path"`
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response stored in the fixtures
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorder is an HTTP transport recording the API responses to a fixture
+// file, with secrets redacted, and serving them back without network access,
+// for deterministic integration tests:
+//
+//	recorder, err := govhtest.NewRecorder("testdata/zone.json", "")
+//	defer recorder.Save()
+//	client.SetTransport(recorder)
+//
+// Requests are matched on their method, path and body, in the order they
+// were recorded. /auth/time is never recorded, replays answer it with the
+// current time.
+type Recorder struct {
+	// Transport sends the requests in record mode, http.DefaultTransport when
+	// nil
+	Transport http.RoundTripper
+
+	// Secrets are redacted from the fixtures, for instance the consumer key
+	// of the recording client. Values of JSON fields like "password" or
+	// "consumerKey" are always redacted.
+	Secrets []string
+
+	path         string
+	mode         Mode
+	mutex        sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder returns a recorder of the fixture file at "path". Without
+// mode, ModeEnv selects it, ModeReplay by default. The fixture file must
+// exist in replay mode.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	if mode == "" {
+		mode = Mode(os.Getenv(ModeEnv))
+	}
+	if mode == "" {
+		mode = ModeReplay
+	}
+	r := &Recorder{path: path, mode: mode}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("govhtest: unable to read fixtures: %v", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("govhtest: invalid fixtures in %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	default:
+		return nil, fmt.Errorf("govhtest: unknown recorder mode %q", mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Interactions returns the recorded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the fixture file. It is a no-op
+// in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mutex.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// RoundTrip records or replays a request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   r.redact(req.URL.RequestURI()),
+		Body:   r.redactBody(body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	response, err := transport.RoundTrip(req)
+	if err != nil || strings.HasSuffix(req.URL.Path, "/auth/time") {
+		return response, err
+	}
+	data, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+
+	header := response.Header.Clone()
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+	r.mutex.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: response.StatusCode,
+			Header: header,
+			Body:   r.redactBody(data),
+		},
+	})
+	r.mutex.Unlock()
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(data))
+	response.ContentLength = int64(len(data))
+	response.Header = header
+	response.Uncompressed = true
+	return response, nil
+}
+
+// replay serves the first unused interaction matching the request
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/auth/time") {
+		return newResponse(req, http.StatusOK, nil, strconv.FormatInt(time.Now().Unix(), 10)), nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		return newResponse(req, interaction.Response.Status, interaction.Response.Header, interaction.Response.Body), nil
+	}
+	return nil, fmt.Errorf("govhtest: no recorded response for %s %s in %s", recorded.Method, recorded.Path, r.path)
+}
+
+// newResponse builds a response to a request
+func newResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// readResponseBody reads a whole response body, decompressing it if needed
+func readResponseBody(response *http.Response) ([]byte, error) {
+	defer response.Body.Close()
+	var reader io.Reader = response.Body
+	if response.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		reader = gzipReader
+	}
+	return ioutil.ReadAll(reader)
+}
+
+// redact replaces the secrets of the recorder in "s"
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.Secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+	}
+	return s
+}
+
+// redactBody returns a body with the secrets and the sensitive JSON fields
+// redacted
+func (r *Recorder) redactBody(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil || !redactFields(value) {
+		return r.redact(string(body))
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return r.redact(string(body))
+	}
+	return r.redact(string(data))
+}
+
+// redactFields replaces the values of the sensitive fields of a decoded JSON
+// value, reporting whether any was found
+func redactFields(value interface{}) bool {
+	found := false
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, field := range value {
+			if isRedactedField(name) {
+				value[name] = redacted
+				found = true
+			} else if redactFields(field) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if redactFields(item) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// isRedactedField checks if a JSON field holds a secret
+func isRedactedField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range redactedFieldNames {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
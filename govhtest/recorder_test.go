@@ -0,0 +1,107 @@
+package govhtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func TestRecorder(t *testing.T) {
+	// Init test: record the responses of a server
+	dir, err := ioutil.TempDir("", "govhtest-fixtures")
+	if err != nil {
+		t.Fatalf("TempDir should not fail. Got %v", err)
+	}
+	defer os.RemoveAll(dir)
+	fixtures := filepath.Join(dir, "fixtures.json")
+
+	server := NewServer()
+	server.Handle("GET", "/me", http.StatusOK, map[string]string{"nichandle": "xx1111-ovh"})
+	server.Handle("POST", "/auth/credential", http.StatusOK, map[string]string{"consumerKey": "new-consumer-key", "state": "pendingValidation"})
+	server.Handle("DELETE", "/me/sshKey/laptop", http.StatusNotFound, `{"message": "not found"}`)
+
+	recorder, err := NewRecorder(fixtures, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder should not fail. Got %v", err)
+	}
+	recorder.Secrets = []string{"xx1111-ovh"}
+	client, _ := server.Client()
+	client.SetTransport(recorder)
+
+	// Test
+	var me map[string]string
+	var credential map[string]string
+	if err := client.Get("/me", &me); err != nil {
+		t.Fatalf("Recorded GET should not fail. Got %v", err)
+	}
+	if err := client.Post("/auth/credential", map[string]string{"redirection": "https://example.com"}, &credential); err != nil {
+		t.Fatalf("Recorded POST should not fail. Got %v", err)
+	}
+	client.Delete("/me/sshKey/laptop", nil)
+	server.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save should not fail. Got %v", err)
+	}
+
+	// Validate: responses are served as is, secrets are redacted from fixtures
+	if me["nichandle"] != "xx1111-ovh" || credential["consumerKey"] != "new-consumer-key" {
+		t.Fatalf("Recorded responses should be served. Got %v, %v", me, credential)
+	}
+	data, _ := ioutil.ReadFile(fixtures)
+	for _, secret := range []string{"xx1111-ovh", "new-consumer-key", DefaultConsumerKey, DefaultAppSecret, "/auth/time"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("Fixtures should not contain %s. Got %s", secret, data)
+		}
+	}
+
+	// Test: replay without network
+	replayer, err := NewRecorder(fixtures, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder should load the fixtures. Got %v", err)
+	}
+	if len(replayer.Interactions()) != 3 {
+		t.Fatalf("Fixtures should hold 3 interactions. Got %+v", replayer.Interactions())
+	}
+	client, _ = ovh.NewClient(server.URL, DefaultAppKey, DefaultAppSecret, DefaultConsumerKey)
+	client.SetTransport(replayer)
+	me = nil
+	credential = nil
+	if err := client.Get("/me", &me); err != nil {
+		t.Fatalf("Replayed GET should not fail. Got %v", err)
+	}
+	if err := client.Post("/auth/credential", map[string]string{"redirection": "https://example.com"}, &credential); err != nil {
+		t.Fatalf("Replayed POST should not fail. Got %v", err)
+	}
+	err = client.Delete("/me/sshKey/laptop", nil)
+
+	// Validate
+	if me["nichandle"] != "****" || credential["state"] != "pendingValidation" {
+		t.Fatalf("Recorded responses should be replayed. Got %v, %v", me, credential)
+	}
+	if apiErr, ok := err.(*ovh.APIError); !ok || apiErr.Code != http.StatusNotFound {
+		t.Fatalf("Recorded errors should be replayed. Got %v", err)
+	}
+	if err := client.Get("/me", nil); err == nil || !strings.Contains(err.Error(), "no recorded response for GET") {
+		t.Fatalf("Requests should only be replayed once. Got %v", err)
+	}
+}
+
+func TestRecorderMode(t *testing.T) {
+	// Test: replay by default, the fixtures must exist
+	if _, err := NewRecorder(filepath.Join(os.TempDir(), "govhtest-missing.json"), ""); err == nil {
+		t.Fatalf("NewRecorder should fail on missing fixtures")
+	}
+
+	// Test: mode from the environment
+	os.Setenv(ModeEnv, "record")
+	defer os.Unsetenv(ModeEnv)
+	recorder, err := NewRecorder("unused.json", "")
+	if err != nil || recorder.Mode() != ModeRecord {
+		t.Fatalf("NewRecorder should read the mode from %s. Got %v", ModeEnv, err)
+	}
+}
@@ -0,0 +1,139 @@
+package ip
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Firewall rule actions
+const (
+	ActionPermit = "permit"
+	ActionDeny   = "deny"
+)
+
+// Firewall represents the network firewall of an IP.
+// Visit https://api.ovh.com/console/#/ip/%7Bip%7D/firewall/%7BipOnFirewall%7D#GET for the full definition
+type Firewall struct {
+	IPOnFirewall string `json:"ipOnFirewall"`
+	Enabled      bool   `json:"enabled"`
+	State        string `json:"state"`
+}
+
+// TCPOption holds the TCP matching options of a firewall rule
+type TCPOption struct {
+	// Option is "established" or "syn"
+	Option    string `json:"option,omitempty"`
+	Fragments bool   `json:"fragments,omitempty"`
+}
+
+// FirewallRule represents a rule of the network firewall of an IP.
+// Visit https://api.ovh.com/console/#/ip/%7Bip%7D/firewall/%7BipOnFirewall%7D/rule/%7Bsequence%7D#GET for the full definition
+type FirewallRule struct {
+	Sequence        int       `json:"sequence"`
+	Action          string    `json:"action"`
+	Protocol        string    `json:"protocol"`
+	Source          string    `json:"source"`
+	Destination     string    `json:"destination"`
+	SourcePort      string    `json:"sourcePort"`
+	DestinationPort string    `json:"destinationPort"`
+	TCPOption       string    `json:"tcpOption"`
+	Fragments       bool      `json:"fragments"`
+	Rule            string    `json:"rule"`
+	State           string    `json:"state"`
+	CreationDate    time.Time `json:"creationDate"`
+}
+
+// FirewallRuleCreation holds the parameters of a new firewall rule. Rules
+// are evaluated by increasing sequence, from 0 to 19.
+type FirewallRuleCreation struct {
+	Sequence        int        `json:"sequence"`
+	Action          string     `json:"action"`
+	Protocol        string     `json:"protocol"`
+	Source          string     `json:"source,omitempty"`
+	SourcePort      int        `json:"sourcePort,omitempty"`
+	DestinationPort int        `json:"destinationPort,omitempty"`
+	TCPOption       *TCPOption `json:"tcpOption,omitempty"`
+	Fragments       bool       `json:"fragments,omitempty"`
+}
+
+// FirewallIPs lists the IPs of a block on the network firewall, with
+// GET /ip/{ip}/firewall
+func (c *Client) FirewallIPs(ctx context.Context, block string) ([]string, error) {
+	ips := []string{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/firewall"), &ips); err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
+
+// Firewall returns the network firewall of an IP, with
+// GET /ip/{ip}/firewall/{ipOnFirewall}
+func (c *Client) Firewall(ctx context.Context, block, ip string) (*Firewall, error) {
+	firewall := &Firewall{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/firewall/%s", url.PathEscape(ip)), firewall); err != nil {
+		return nil, err
+	}
+	return firewall, nil
+}
+
+// AddFirewallIP adds an IP to the network firewall, with
+// POST /ip/{ip}/firewall. The firewall is disabled until enabled with
+// EnableFirewall.
+func (c *Client) AddFirewallIP(ctx context.Context, block, ip string) (*Firewall, error) {
+	body := map[string]string{"ipOnFirewall": ip}
+	firewall := &Firewall{}
+	if err := c.client.PostWithContext(ctx, ipPath(block, "/firewall"), body, firewall); err != nil {
+		return nil, err
+	}
+	return firewall, nil
+}
+
+// EnableFirewall enables or disables the network firewall of an IP, with
+// PUT /ip/{ip}/firewall/{ipOnFirewall}
+func (c *Client) EnableFirewall(ctx context.Context, block, ip string, enabled bool) error {
+	body := map[string]bool{"enabled": enabled}
+	return c.client.PutWithContext(ctx, ipPath(block, "/firewall/%s", url.PathEscape(ip)), body, nil)
+}
+
+// RemoveFirewallIP removes an IP from the network firewall, with
+// DELETE /ip/{ip}/firewall/{ipOnFirewall}
+func (c *Client) RemoveFirewallIP(ctx context.Context, block, ip string) error {
+	return c.client.DeleteWithContext(ctx, ipPath(block, "/firewall/%s", url.PathEscape(ip)), nil)
+}
+
+// FirewallRules lists the sequences of the firewall rules of an IP, with
+// GET /ip/{ip}/firewall/{ipOnFirewall}/rule
+func (c *Client) FirewallRules(ctx context.Context, block, ip string) ([]int, error) {
+	sequences := []int{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/firewall/%s/rule", url.PathEscape(ip)), &sequences); err != nil {
+		return nil, err
+	}
+	return sequences, nil
+}
+
+// FirewallRule returns a firewall rule of an IP, with
+// GET /ip/{ip}/firewall/{ipOnFirewall}/rule/{sequence}
+func (c *Client) FirewallRule(ctx context.Context, block, ip string, sequence int) (*FirewallRule, error) {
+	rule := &FirewallRule{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/firewall/%s/rule/%d", url.PathEscape(ip), sequence), rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// AddFirewallRule adds a firewall rule to an IP, with
+// POST /ip/{ip}/firewall/{ipOnFirewall}/rule
+func (c *Client) AddFirewallRule(ctx context.Context, block, ip string, creation *FirewallRuleCreation) (*FirewallRule, error) {
+	rule := &FirewallRule{}
+	if err := c.client.PostWithContext(ctx, ipPath(block, "/firewall/%s/rule", url.PathEscape(ip)), creation, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteFirewallRule deletes a firewall rule of an IP, with
+// DELETE /ip/{ip}/firewall/{ipOnFirewall}/rule/{sequence}
+func (c *Client) DeleteFirewallRule(ctx context.Context, block, ip string, sequence int) error {
+	return c.client.DeleteWithContext(ctx, ipPath(block, "/firewall/%s/rule/%d", url.PathEscape(ip), sequence), nil)
+}
@@ -0,0 +1,81 @@
+package ip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFirewall(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	firewall := `{"ipOnFirewall": "192.0.2.1", "enabled": false, "state": "ok"}`
+	rule := `{"sequence": 0, "action": "permit", "protocol": "tcp", "source": "any", "destination": "192.0.2.1/32", "destinationPort": "eq 22", "tcpOption": "established", "fragments": false, "rule": "permit tcp any 192.0.2.1/32 eq 22 established", "state": "creationPending", "creationDate": "2020-01-01T10:00:00+01:00"}`
+	server.Handle("GET", "/ip/192.0.2.1%2F32/firewall", http.StatusOK, `["192.0.2.1"]`)
+	server.Handle("POST", "/ip/192.0.2.1%2F32/firewall", http.StatusOK, firewall)
+	server.Handle("GET", "/ip/192.0.2.1%2F32/firewall/192.0.2.1", http.StatusOK, firewall)
+	server.Handle("PUT", "/ip/192.0.2.1%2F32/firewall/192.0.2.1", http.StatusOK, nil)
+	server.Handle("DELETE", "/ip/192.0.2.1%2F32/firewall/192.0.2.1", http.StatusOK, nil)
+	server.Handle("GET", "/ip/192.0.2.1%2F32/firewall/192.0.2.1/rule", http.StatusOK, `[0]`)
+	server.Handle("POST", "/ip/192.0.2.1%2F32/firewall/192.0.2.1/rule", http.StatusOK, rule)
+	server.Handle("GET", "/ip/192.0.2.1%2F32/firewall/192.0.2.1/rule/0", http.StatusOK, rule)
+	server.Handle("DELETE", "/ip/192.0.2.1%2F32/firewall/192.0.2.1/rule/0", http.StatusOK, nil)
+	ctx := context.Background()
+	block, ip := "192.0.2.1/32", "192.0.2.1"
+
+	// Test
+	if _, err := client.AddFirewallIP(ctx, block, ip); err != nil {
+		t.Fatalf("AddFirewallIP should not return an error. Got %v", err)
+	}
+	if err := client.EnableFirewall(ctx, block, ip, true); err != nil {
+		t.Fatalf("EnableFirewall should not return an error. Got %v", err)
+	}
+	ips, err := client.FirewallIPs(ctx, block)
+	if err != nil || len(ips) != 1 {
+		t.Fatalf("FirewallIPs should list the IP. Got %v %v", ips, err)
+	}
+	fetched, err := client.Firewall(ctx, block, ips[0])
+	if err != nil {
+		t.Fatalf("Firewall should not return an error. Got %v", err)
+	}
+	created, err := client.AddFirewallRule(ctx, block, ip, &FirewallRuleCreation{
+		Sequence:        0,
+		Action:          ActionPermit,
+		Protocol:        "tcp",
+		DestinationPort: 22,
+		TCPOption:       &TCPOption{Option: "established"},
+	})
+	if err != nil {
+		t.Fatalf("AddFirewallRule should not return an error. Got %v", err)
+	}
+	sequences, err := client.FirewallRules(ctx, block, ip)
+	if err != nil {
+		t.Fatalf("FirewallRules should not return an error. Got %v", err)
+	}
+	fetchedRule, err := client.FirewallRule(ctx, block, ip, sequences[0])
+	if err != nil {
+		t.Fatalf("FirewallRule should not return an error. Got %v", err)
+	}
+	if err := client.DeleteFirewallRule(ctx, block, ip, 0); err != nil {
+		t.Fatalf("DeleteFirewallRule should not return an error. Got %v", err)
+	}
+	if err := client.RemoveFirewallIP(ctx, block, ip); err != nil {
+		t.Fatalf("RemoveFirewallIP should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if fetched.IPOnFirewall != ip || fetched.State != "ok" {
+		t.Fatalf("Firewall should be decoded. Got %+v", fetched)
+	}
+	if created.DestinationPort != "eq 22" || fetchedRule.CreationDate.IsZero() {
+		t.Fatalf("Firewall rule should be decoded. Got %+v", fetchedRule)
+	}
+	requests := server.Requests()
+	if body := string(requests[1].Body); body != `{"enabled":true}` {
+		t.Fatalf("EnableFirewall should send the state. Got %s", body)
+	}
+	if body := string(requests[4].Body); body != `{"sequence":0,"action":"permit","protocol":"tcp","destinationPort":22,"tcpOption":{"option":"established"}}` {
+		t.Fatalf("AddFirewallRule should send the rule. Got %s", body)
+	}
+}
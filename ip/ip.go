@@ -0,0 +1,195 @@
+// Package ip provides typed helpers for the OVH IP API, under /ip: IP blocks
+// and failover IPs, moving them between services, reverse DNS, the network
+// firewall and the anti-DDoS mitigation.
+//
+// IP blocks are given in CIDR notation, like "192.0.2.0/28" or
+// "192.0.2.1/32", and IPs without prefix, like "192.0.2.1".
+package ip
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// IP block types
+const (
+	TypeCDN           = "cdn"
+	TypeCloud         = "cloud"
+	TypeDedicated     = "dedicated"
+	TypeFailover      = "failover"
+	TypeHostedSSL     = "hosted_ssl"
+	TypeHousing       = "housing"
+	TypeLoadBalancing = "loadBalancing"
+	TypeMail          = "mail"
+	TypeOverTheBox    = "overthebox"
+	TypePCC           = "pcc"
+	TypePCI           = "pci"
+	TypePrivate       = "private"
+	TypeVPN           = "vpn"
+	TypeVPS           = "vps"
+	TypeVRack         = "vrack"
+	TypeXDSL          = "xdsl"
+)
+
+// RoutedTo represents the service an IP block is routed to
+type RoutedTo struct {
+	ServiceName string `json:"serviceName"`
+}
+
+// IP represents an IP block.
+// Visit https://api.ovh.com/console/#/ip/%7Bip%7D#GET for the full definition
+type IP struct {
+	IP              string   `json:"ip"`
+	Type            string   `json:"type"`
+	Description     string   `json:"description"`
+	Country         string   `json:"country"`
+	OrganisationID  string   `json:"organisationId"`
+	CanBeTerminated bool     `json:"canBeTerminated"`
+	RoutedTo        RoutedTo `json:"routedTo"`
+}
+
+// Filter filters the IP blocks listed by IPs. Empty fields are not used as
+// filters.
+type Filter struct {
+	// Type of the IP blocks, like TypeFailover
+	Type string
+	// Description of the IP blocks
+	Description string
+	// RoutedTo is the service name the IP blocks are routed to
+	RoutedTo string
+}
+
+// Destination represents a service an IP block may be moved to
+type Destination struct {
+	Service string   `json:"service"`
+	Nexthop []string `json:"nexthop"`
+}
+
+// Task represents an asynchronous operation on an IP block, like a move.
+// Visit https://api.ovh.com/console/#/ip/%7Bip%7D/task/%7BtaskId%7D#GET for the full definition
+type Task struct {
+	TaskID      int64     `json:"taskId"`
+	Function    string    `json:"function"`
+	Status      string    `json:"status"`
+	Comment     string    `json:"comment"`
+	Destination RoutedTo  `json:"destination"`
+	StartDate   time.Time `json:"startDate"`
+	DoneDate    time.Time `json:"doneDate"`
+	LastUpdate  time.Time `json:"lastUpdate"`
+}
+
+// Client gives access to the /ip routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns an IP client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// ipPath returns the path of a route of an IP block
+func ipPath(block, format string, args ...interface{}) string {
+	return fmt.Sprintf("/ip/%s", url.PathEscape(block)) + fmt.Sprintf(format, args...)
+}
+
+// IPs lists the IP blocks, with GET /ip. The filter may be nil.
+func (c *Client) IPs(ctx context.Context, filter *Filter) ([]string, error) {
+	query := url.Values{}
+	if filter != nil {
+		if filter.Type != "" {
+			query.Set("type", filter.Type)
+		}
+		if filter.Description != "" {
+			query.Set("description", filter.Description)
+		}
+		if filter.RoutedTo != "" {
+			query.Set("routedTo.serviceName", filter.RoutedTo)
+		}
+	}
+	path := "/ip"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	blocks := []string{}
+	if err := c.client.GetWithContext(ctx, path, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// IP returns an IP block, with GET /ip/{ip}
+func (c *Client) IP(ctx context.Context, block string) (*IP, error) {
+	ip := &IP{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, ""), ip); err != nil {
+		return nil, err
+	}
+	return ip, nil
+}
+
+// SetDescription updates the description of an IP block, with PUT /ip/{ip}
+func (c *Client) SetDescription(ctx context.Context, block, description string) error {
+	body := map[string]string{"description": description}
+	return c.client.PutWithContext(ctx, ipPath(block, ""), body, nil)
+}
+
+// Destinations lists the services an IP block may be moved to, by service
+// type, with GET /ip/{ip}/move
+func (c *Client) Destinations(ctx context.Context, block string) (map[string][]Destination, error) {
+	destinations := map[string][]Destination{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/move"), &destinations); err != nil {
+		return nil, err
+	}
+	return destinations, nil
+}
+
+// Move moves an IP block to another service, like a dedicated server, with
+// POST /ip/{ip}/move. "nexthop" is the IP of the destination service to
+// route the block to, it may be empty for most services.
+func (c *Client) Move(ctx context.Context, block, to, nexthop string) (*Task, error) {
+	body := map[string]string{"to": to}
+	if nexthop != "" {
+		body["nexthop"] = nexthop
+	}
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, ipPath(block, "/move"), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Park parks an IP block, routing it to no service, with POST /ip/{ip}/park
+func (c *Client) Park(ctx context.Context, block string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, ipPath(block, "/park"), nil, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Task returns a task of an IP block, with GET /ip/{ip}/task/{taskId}
+func (c *Client) Task(ctx context.Context, block string, taskID int64) (*Task, error) {
+	task := &Task{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/task/%d", taskID), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// WaitTask waits until a task of an IP block is done, see ovh.Client.WaitTask
+func (c *Client) WaitTask(ctx context.Context, block string, taskID int64, opts *ovh.WaitTaskOptions) (*Task, error) {
+	response, err := c.client.WaitTask(ctx, ipPath(block, "/task/{taskId}"), taskID, opts)
+	if err != nil {
+		return nil, err
+	}
+	task := &Task{}
+	if err := response.Unmarshal(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
@@ -0,0 +1,96 @@
+package ip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/govhtest"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestIP(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ip?routedTo.serviceName=ns1.example.net&type=failover", http.StatusOK, `["192.0.2.0/28"]`)
+	server.Handle("GET", "/ip/192.0.2.0%2F28", http.StatusOK, `{"ip": "192.0.2.0/28", "type": "failover", "description": "web", "country": "fr", "canBeTerminated": true, "routedTo": {"serviceName": "ns1.example.net"}}`)
+	server.Handle("PUT", "/ip/192.0.2.0%2F28", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	blocks, err := client.IPs(ctx, &Filter{Type: TypeFailover, RoutedTo: "ns1.example.net"})
+	if err != nil {
+		t.Fatalf("IPs should not return an error. Got %v", err)
+	}
+	ip, err := client.IP(ctx, blocks[0])
+	if err != nil {
+		t.Fatalf("IP should not return an error. Got %v", err)
+	}
+	if err := client.SetDescription(ctx, blocks[0], "api"); err != nil {
+		t.Fatalf("SetDescription should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if ip.IP != "192.0.2.0/28" || ip.Type != TypeFailover || ip.RoutedTo.ServiceName != "ns1.example.net" {
+		t.Fatalf("IP should be decoded. Got %+v", ip)
+	}
+	if body := string(server.Requests()[2].Body); body != `{"description":"api"}` {
+		t.Fatalf("SetDescription should send the description. Got %s", body)
+	}
+}
+
+func TestMove(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ip/192.0.2.1%2F32/move", http.StatusOK, `{"dedicatedServer": [{"service": "ns2.example.net", "nexthop": ["198.51.100.1"]}], "vps": []}`)
+	server.Handle("POST", "/ip/192.0.2.1%2F32/move", http.StatusOK, `{"taskId": 12, "function": "genericMoveFloatingIp", "status": "todo", "destination": {"serviceName": "ns2.example.net"}, "startDate": "2020-01-01T10:00:00+01:00"}`)
+	server.Handle("POST", "/ip/192.0.2.1%2F32/park", http.StatusOK, `{"taskId": 13, "function": "parkingIp", "status": "todo"}`)
+	server.Handle("GET", "/ip/192.0.2.1%2F32/task/12", http.StatusOK, `{"taskId": 12, "function": "genericMoveFloatingIp", "status": "done"}`)
+	ctx := context.Background()
+
+	// Test
+	destinations, err := client.Destinations(ctx, "192.0.2.1/32")
+	if err != nil {
+		t.Fatalf("Destinations should not return an error. Got %v", err)
+	}
+	destination := destinations["dedicatedServer"][0]
+	task, err := client.Move(ctx, "192.0.2.1/32", destination.Service, destination.Nexthop[0])
+	if err != nil {
+		t.Fatalf("Move should not return an error. Got %v", err)
+	}
+	parked, err := client.Park(ctx, "192.0.2.1/32")
+	if err != nil {
+		t.Fatalf("Park should not return an error. Got %v", err)
+	}
+	fetched, err := client.Task(ctx, "192.0.2.1/32", task.TaskID)
+	if err != nil {
+		t.Fatalf("Task should not return an error. Got %v", err)
+	}
+	done, err := client.WaitTask(ctx, "192.0.2.1/32", task.TaskID, &ovh.WaitTaskOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitTask should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if task.TaskID != 12 || task.Destination.ServiceName != "ns2.example.net" || parked.TaskID != 13 {
+		t.Fatalf("Tasks should be decoded. Got %+v and %+v", task, parked)
+	}
+	if fetched.Status != "done" || done.Status != "done" {
+		t.Fatalf("Task should be done. Got %+v", done)
+	}
+	if body := string(server.Requests()[1].Body); body != `{"nexthop":"198.51.100.1","to":"ns2.example.net"}` {
+		t.Fatalf("Move should send the destination. Got %s", body)
+	}
+}
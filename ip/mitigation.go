@@ -0,0 +1,55 @@
+package ip
+
+import (
+	"context"
+	"net/url"
+)
+
+// Mitigation represents the anti-DDoS mitigation of an IP.
+// Visit https://api.ovh.com/console/#/ip/%7Bip%7D/mitigation/%7BipOnMitigation%7D#GET for the full definition
+type Mitigation struct {
+	IPOnMitigation string `json:"ipOnMitigation"`
+	// Auto is set when the mitigation was triggered by an attack
+	Auto bool `json:"auto"`
+	// Permanent is set when the mitigation is always enabled
+	Permanent bool   `json:"permanent"`
+	State     string `json:"state"`
+}
+
+// MitigationIPs lists the IPs of a block under mitigation, with
+// GET /ip/{ip}/mitigation
+func (c *Client) MitigationIPs(ctx context.Context, block string) ([]string, error) {
+	ips := []string{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/mitigation"), &ips); err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
+
+// Mitigation returns the mitigation status of an IP, with
+// GET /ip/{ip}/mitigation/{ipOnMitigation}
+func (c *Client) Mitigation(ctx context.Context, block, ip string) (*Mitigation, error) {
+	mitigation := &Mitigation{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/mitigation/%s", url.PathEscape(ip)), mitigation); err != nil {
+		return nil, err
+	}
+	return mitigation, nil
+}
+
+// EnablePermanentMitigation enables the permanent mitigation of an IP, with
+// POST /ip/{ip}/mitigation
+func (c *Client) EnablePermanentMitigation(ctx context.Context, block, ip string) (*Mitigation, error) {
+	body := map[string]string{"ipOnMitigation": ip}
+	mitigation := &Mitigation{}
+	if err := c.client.PostWithContext(ctx, ipPath(block, "/mitigation"), body, mitigation); err != nil {
+		return nil, err
+	}
+	return mitigation, nil
+}
+
+// DisablePermanentMitigation disables the permanent mitigation of an IP, with
+// DELETE /ip/{ip}/mitigation/{ipOnMitigation}. The mitigation is still
+// triggered automatically by attacks.
+func (c *Client) DisablePermanentMitigation(ctx context.Context, block, ip string) error {
+	return c.client.DeleteWithContext(ctx, ipPath(block, "/mitigation/%s", url.PathEscape(ip)), nil)
+}
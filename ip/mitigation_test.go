@@ -0,0 +1,43 @@
+package ip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestMitigation(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	mitigation := `{"ipOnMitigation": "192.0.2.1", "auto": false, "permanent": true, "state": "ok"}`
+	server.Handle("GET", "/ip/192.0.2.1%2F32/mitigation", http.StatusOK, `["192.0.2.1"]`)
+	server.Handle("GET", "/ip/192.0.2.1%2F32/mitigation/192.0.2.1", http.StatusOK, mitigation)
+	server.Handle("POST", "/ip/192.0.2.1%2F32/mitigation", http.StatusOK, mitigation)
+	server.Handle("DELETE", "/ip/192.0.2.1%2F32/mitigation/192.0.2.1", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	ips, err := client.MitigationIPs(ctx, "192.0.2.1/32")
+	if err != nil {
+		t.Fatalf("MitigationIPs should not return an error. Got %v", err)
+	}
+	fetched, err := client.Mitigation(ctx, "192.0.2.1/32", ips[0])
+	if err != nil {
+		t.Fatalf("Mitigation should not return an error. Got %v", err)
+	}
+	if _, err := client.EnablePermanentMitigation(ctx, "192.0.2.1/32", "192.0.2.1"); err != nil {
+		t.Fatalf("EnablePermanentMitigation should not return an error. Got %v", err)
+	}
+	if err := client.DisablePermanentMitigation(ctx, "192.0.2.1/32", "192.0.2.1"); err != nil {
+		t.Fatalf("DisablePermanentMitigation should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if !fetched.Permanent || fetched.Auto || fetched.State != "ok" {
+		t.Fatalf("Mitigation should be decoded. Got %+v", fetched)
+	}
+	if body := string(server.Requests()[2].Body); body != `{"ipOnMitigation":"192.0.2.1"}` {
+		t.Fatalf("EnablePermanentMitigation should send the IP. Got %s", body)
+	}
+}
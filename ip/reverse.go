@@ -0,0 +1,49 @@
+package ip
+
+import (
+	"context"
+	"net/url"
+)
+
+// Reverse represents the reverse DNS of an IP
+type Reverse struct {
+	IPReverse string `json:"ipReverse"`
+	Reverse   string `json:"reverse"`
+}
+
+// Reverses lists the IPs of a block with a reverse DNS, with
+// GET /ip/{ip}/reverse
+func (c *Client) Reverses(ctx context.Context, block string) ([]string, error) {
+	ips := []string{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/reverse"), &ips); err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
+
+// Reverse returns the reverse DNS of an IP, with
+// GET /ip/{ip}/reverse/{ipReverse}
+func (c *Client) Reverse(ctx context.Context, block, ip string) (*Reverse, error) {
+	reverse := &Reverse{}
+	if err := c.client.GetWithContext(ctx, ipPath(block, "/reverse/%s", url.PathEscape(ip)), reverse); err != nil {
+		return nil, err
+	}
+	return reverse, nil
+}
+
+// SetReverse sets the reverse DNS of an IP, with POST /ip/{ip}/reverse. The
+// API checks that "reverse" resolves to "ip".
+func (c *Client) SetReverse(ctx context.Context, block, ip, reverse string) (*Reverse, error) {
+	body := &Reverse{IPReverse: ip, Reverse: reverse}
+	res := &Reverse{}
+	if err := c.client.PostWithContext(ctx, ipPath(block, "/reverse"), body, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteReverse deletes the reverse DNS of an IP, with
+// DELETE /ip/{ip}/reverse/{ipReverse}
+func (c *Client) DeleteReverse(ctx context.Context, block, ip string) error {
+	return c.client.DeleteWithContext(ctx, ipPath(block, "/reverse/%s", url.PathEscape(ip)), nil)
+}
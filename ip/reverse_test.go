@@ -0,0 +1,44 @@
+package ip
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	reverse := `{"ipReverse": "192.0.2.1", "reverse": "www.example.com."}`
+	server.Handle("GET", "/ip/192.0.2.0%2F28/reverse", http.StatusOK, `["192.0.2.1"]`)
+	server.Handle("GET", "/ip/192.0.2.0%2F28/reverse/192.0.2.1", http.StatusOK, reverse)
+	server.Handle("POST", "/ip/192.0.2.0%2F28/reverse", http.StatusOK, reverse)
+	server.Handle("DELETE", "/ip/192.0.2.0%2F28/reverse/192.0.2.1", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	ips, err := client.Reverses(ctx, "192.0.2.0/28")
+	if err != nil {
+		t.Fatalf("Reverses should not return an error. Got %v", err)
+	}
+	fetched, err := client.Reverse(ctx, "192.0.2.0/28", ips[0])
+	if err != nil {
+		t.Fatalf("Reverse should not return an error. Got %v", err)
+	}
+	set, err := client.SetReverse(ctx, "192.0.2.0/28", "192.0.2.1", "www.example.com.")
+	if err != nil {
+		t.Fatalf("SetReverse should not return an error. Got %v", err)
+	}
+	if err := client.DeleteReverse(ctx, "192.0.2.0/28", "192.0.2.1"); err != nil {
+		t.Fatalf("DeleteReverse should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if fetched.Reverse != "www.example.com." || set.IPReverse != "192.0.2.1" {
+		t.Fatalf("Reverse should be decoded. Got %+v", fetched)
+	}
+	if body := string(server.Requests()[2].Body); body != `{"ipReverse":"192.0.2.1","reverse":"www.example.com."}` {
+		t.Fatalf("SetReverse should send the reverse. Got %s", body)
+	}
+}
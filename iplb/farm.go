@@ -0,0 +1,154 @@
+package iplb
+
+import (
+	"context"
+)
+
+// Server statuses
+const (
+	ServerStatusActive   = "active"
+	ServerStatusInactive = "inactive"
+)
+
+// Farm represents a farm of a load balancer: the servers a frontend
+// balances the traffic to.
+// Visit https://api.ovh.com/console/#/ipLoadbalancing/%7BserviceName%7D/http/farm/%7BfarmId%7D#GET for the full definition
+type Farm struct {
+	FarmID      int64  `json:"farmId"`
+	DisplayName string `json:"displayName"`
+	Zone        string `json:"zone"`
+	Port        int    `json:"port"`
+	// Balance is the balancing algorithm, like "roundrobin" or "leastconn"
+	Balance    string `json:"balance"`
+	Stickiness string `json:"stickiness"`
+	VRackID    *int64 `json:"vrackNetworkId"`
+}
+
+// FarmCreation holds the parameters of a new farm, or the new parameters of
+// a farm
+type FarmCreation struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Zone        string `json:"zone"`
+	Port        int    `json:"port,omitempty"`
+	Balance     string `json:"balance,omitempty"`
+	Stickiness  string `json:"stickiness,omitempty"`
+	VRackID     *int64 `json:"vrackNetworkId,omitempty"`
+}
+
+// Server represents a server of a farm.
+// Visit https://api.ovh.com/console/#/ipLoadbalancing/%7BserviceName%7D/http/farm/%7BfarmId%7D/server/%7BserverId%7D#GET for the full definition
+type Server struct {
+	ServerID    int64  `json:"serverId"`
+	DisplayName string `json:"displayName"`
+	Address     string `json:"address"`
+	Port        *int   `json:"port"`
+	Status      string `json:"status"`
+	Weight      int    `json:"weight"`
+	SSL         bool   `json:"ssl"`
+	Backup      bool   `json:"backup"`
+	Probe       bool   `json:"probe"`
+}
+
+// ServerCreation holds the parameters of a new server, or the new parameters
+// of a server. The farm port is used when Port is nil.
+type ServerCreation struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Address     string `json:"address"`
+	Port        *int   `json:"port,omitempty"`
+	Status      string `json:"status"`
+	Weight      int    `json:"weight,omitempty"`
+	SSL         bool   `json:"ssl,omitempty"`
+	Backup      bool   `json:"backup,omitempty"`
+	Probe       bool   `json:"probe,omitempty"`
+}
+
+// Farms lists the IDs of the farms of a protocol, like ProtocolHTTP, with
+// GET /ipLoadbalancing/{serviceName}/{protocol}/farm
+func (c *Client) Farms(ctx context.Context, serviceName, protocol string) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/%s/farm", protocol), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Farm returns a farm, with
+// GET /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}
+func (c *Client) Farm(ctx context.Context, serviceName, protocol string, id int64) (*Farm, error) {
+	farm := &Farm{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/%s/farm/%d", protocol, id), farm); err != nil {
+		return nil, err
+	}
+	return farm, nil
+}
+
+// CreateFarm creates a farm, with
+// POST /ipLoadbalancing/{serviceName}/{protocol}/farm
+func (c *Client) CreateFarm(ctx context.Context, serviceName, protocol string, creation FarmCreation) (*Farm, error) {
+	farm := &Farm{}
+	if err := c.client.PostWithContext(ctx, lbPath(serviceName, "/%s/farm", protocol), creation, farm); err != nil {
+		return nil, err
+	}
+	return farm, nil
+}
+
+// UpdateFarm updates a farm, with
+// PUT /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}
+func (c *Client) UpdateFarm(ctx context.Context, serviceName, protocol string, id int64, update FarmCreation) error {
+	return c.client.PutWithContext(ctx, lbPath(serviceName, "/%s/farm/%d", protocol, id), update, nil)
+}
+
+// DeleteFarm deletes a farm and its servers, with
+// DELETE /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}
+func (c *Client) DeleteFarm(ctx context.Context, serviceName, protocol string, id int64) error {
+	return c.client.DeleteWithContext(ctx, lbPath(serviceName, "/%s/farm/%d", protocol, id), nil)
+}
+
+// Servers lists the IDs of the servers of a farm, with
+// GET /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}/server
+func (c *Client) Servers(ctx context.Context, serviceName, protocol string, farmID int64) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/%s/farm/%d/server", protocol, farmID), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Server returns a server of a farm, with
+// GET /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}/server/{serverId}
+func (c *Client) Server(ctx context.Context, serviceName, protocol string, farmID, id int64) (*Server, error) {
+	server := &Server{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/%s/farm/%d/server/%d", protocol, farmID, id), server); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// CreateServer adds a server to a farm, with
+// POST /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}/server
+func (c *Client) CreateServer(ctx context.Context, serviceName, protocol string, farmID int64, creation ServerCreation) (*Server, error) {
+	server := &Server{}
+	if err := c.client.PostWithContext(ctx, lbPath(serviceName, "/%s/farm/%d/server", protocol, farmID), creation, server); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// UpdateServer updates a server of a farm, with
+// PUT /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}/server/{serverId}
+func (c *Client) UpdateServer(ctx context.Context, serviceName, protocol string, farmID, id int64, update ServerCreation) error {
+	return c.client.PutWithContext(ctx, lbPath(serviceName, "/%s/farm/%d/server/%d", protocol, farmID, id), update, nil)
+}
+
+// SetServerStatus enables or disables a server of a farm, with
+// PUT /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}/server/{serverId}
+func (c *Client) SetServerStatus(ctx context.Context, serviceName, protocol string, farmID, id int64, status string) error {
+	body := map[string]string{"status": status}
+	return c.client.PutWithContext(ctx, lbPath(serviceName, "/%s/farm/%d/server/%d", protocol, farmID, id), body, nil)
+}
+
+// DeleteServer removes a server from a farm, with
+// DELETE /ipLoadbalancing/{serviceName}/{protocol}/farm/{farmId}/server/{serverId}
+func (c *Client) DeleteServer(ctx context.Context, serviceName, protocol string, farmID, id int64) error {
+	return c.client.DeleteWithContext(ctx, lbPath(serviceName, "/%s/farm/%d/server/%d", protocol, farmID, id), nil)
+}
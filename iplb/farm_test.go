@@ -0,0 +1,87 @@
+package iplb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFarm(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/http/farm", http.StatusOK, `[3]`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3", http.StatusOK, `{"farmId": 3, "zone": "all", "port": 8080, "balance": "roundrobin"}`)
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/http/farm", http.StatusOK, `{"farmId": 4, "zone": "gra", "balance": "leastconn"}`)
+	server.Handle("PUT", "/ipLoadbalancing/loadbalancer-ab12/http/farm/4", http.StatusOK, `null`)
+	server.Handle("DELETE", "/ipLoadbalancing/loadbalancer-ab12/http/farm/4", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.Farms(ctx, "loadbalancer-ab12", ProtocolHTTP)
+	if err != nil {
+		t.Fatalf("Farms should not return an error. Got %v", err)
+	}
+	farm, err := client.Farm(ctx, "loadbalancer-ab12", ProtocolHTTP, ids[0])
+	if err != nil {
+		t.Fatalf("Farm should not return an error. Got %v", err)
+	}
+	created, err := client.CreateFarm(ctx, "loadbalancer-ab12", ProtocolHTTP, FarmCreation{Zone: "gra", Balance: "leastconn"})
+	if err != nil {
+		t.Fatalf("CreateFarm should not return an error. Got %v", err)
+	}
+	if err := client.UpdateFarm(ctx, "loadbalancer-ab12", ProtocolHTTP, created.FarmID, FarmCreation{Zone: "gra", DisplayName: "blue"}); err != nil {
+		t.Fatalf("UpdateFarm should not return an error. Got %v", err)
+	}
+	if err := client.DeleteFarm(ctx, "loadbalancer-ab12", ProtocolHTTP, created.FarmID); err != nil {
+		t.Fatalf("DeleteFarm should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if farm.Port != 8080 || farm.Balance != "roundrobin" {
+		t.Fatalf("Farm should be decoded. Got %+v", farm)
+	}
+	if body := string(server.Requests()[2].Body); body != `{"zone":"gra","balance":"leastconn"}` {
+		t.Fatalf("CreateFarm should only send the set parameters. Got %s", body)
+	}
+}
+
+func TestServer(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server", http.StatusOK, `[20]`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server/20", http.StatusOK, `{"serverId": 20, "address": "10.0.0.20", "port": null, "status": "active", "weight": 1}`)
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server", http.StatusOK, `{"serverId": 21, "address": "10.0.0.21", "status": "inactive"}`)
+	server.Handle("PUT", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server/21", http.StatusOK, `null`)
+	server.Handle("DELETE", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server/21", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.Servers(ctx, "loadbalancer-ab12", ProtocolHTTP, 3)
+	if err != nil {
+		t.Fatalf("Servers should not return an error. Got %v", err)
+	}
+	srv, err := client.Server(ctx, "loadbalancer-ab12", ProtocolHTTP, 3, ids[0])
+	if err != nil {
+		t.Fatalf("Server should not return an error. Got %v", err)
+	}
+	created, err := client.CreateServer(ctx, "loadbalancer-ab12", ProtocolHTTP, 3, ServerCreation{Address: "10.0.0.21", Status: ServerStatusInactive})
+	if err != nil {
+		t.Fatalf("CreateServer should not return an error. Got %v", err)
+	}
+	if err := client.UpdateServer(ctx, "loadbalancer-ab12", ProtocolHTTP, 3, created.ServerID, ServerCreation{Address: "10.0.0.21", Status: ServerStatusActive, Weight: 2}); err != nil {
+		t.Fatalf("UpdateServer should not return an error. Got %v", err)
+	}
+	if err := client.DeleteServer(ctx, "loadbalancer-ab12", ProtocolHTTP, 3, created.ServerID); err != nil {
+		t.Fatalf("DeleteServer should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if srv.Address != "10.0.0.20" || srv.Port != nil || srv.Status != ServerStatusActive {
+		t.Fatalf("Server should be decoded. Got %+v", srv)
+	}
+	if body := string(server.Requests()[2].Body); body != `{"address":"10.0.0.21","status":"inactive"}` {
+		t.Fatalf("CreateServer should only send the set parameters. Got %s", body)
+	}
+}
@@ -0,0 +1,75 @@
+package iplb
+
+import (
+	"context"
+)
+
+// Frontend represents a frontend of a load balancer, listening on a port.
+// Visit https://api.ovh.com/console/#/ipLoadbalancing/%7BserviceName%7D/http/frontend/%7BfrontendId%7D#GET for the full definition
+type Frontend struct {
+	FrontendID    int64    `json:"frontendId"`
+	DisplayName   string   `json:"displayName"`
+	Zone          string   `json:"zone"`
+	Port          string   `json:"port"`
+	DefaultFarmID *int64   `json:"defaultFarmId"`
+	SSL           bool     `json:"ssl"`
+	HSTS          bool     `json:"hsts"`
+	Disabled      bool     `json:"disabled"`
+	AllowedSource []string `json:"allowedSource"`
+}
+
+// FrontendCreation holds the parameters of a new frontend, or the new
+// parameters of a frontend. Port may be a port, a range or a list of ports,
+// like "80", "8000-8010" or "80,443".
+type FrontendCreation struct {
+	DisplayName   string   `json:"displayName,omitempty"`
+	Zone          string   `json:"zone"`
+	Port          string   `json:"port"`
+	DefaultFarmID *int64   `json:"defaultFarmId,omitempty"`
+	SSL           bool     `json:"ssl,omitempty"`
+	HSTS          bool     `json:"hsts,omitempty"`
+	Disabled      bool     `json:"disabled,omitempty"`
+	AllowedSource []string `json:"allowedSource,omitempty"`
+}
+
+// Frontends lists the IDs of the frontends of a protocol, like ProtocolHTTP,
+// with GET /ipLoadbalancing/{serviceName}/{protocol}/frontend
+func (c *Client) Frontends(ctx context.Context, serviceName, protocol string) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/%s/frontend", protocol), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Frontend returns a frontend, with
+// GET /ipLoadbalancing/{serviceName}/{protocol}/frontend/{frontendId}
+func (c *Client) Frontend(ctx context.Context, serviceName, protocol string, id int64) (*Frontend, error) {
+	frontend := &Frontend{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/%s/frontend/%d", protocol, id), frontend); err != nil {
+		return nil, err
+	}
+	return frontend, nil
+}
+
+// CreateFrontend creates a frontend, with
+// POST /ipLoadbalancing/{serviceName}/{protocol}/frontend
+func (c *Client) CreateFrontend(ctx context.Context, serviceName, protocol string, creation FrontendCreation) (*Frontend, error) {
+	frontend := &Frontend{}
+	if err := c.client.PostWithContext(ctx, lbPath(serviceName, "/%s/frontend", protocol), creation, frontend); err != nil {
+		return nil, err
+	}
+	return frontend, nil
+}
+
+// UpdateFrontend updates a frontend, with
+// PUT /ipLoadbalancing/{serviceName}/{protocol}/frontend/{frontendId}
+func (c *Client) UpdateFrontend(ctx context.Context, serviceName, protocol string, id int64, update FrontendCreation) error {
+	return c.client.PutWithContext(ctx, lbPath(serviceName, "/%s/frontend/%d", protocol, id), update, nil)
+}
+
+// DeleteFrontend deletes a frontend, with
+// DELETE /ipLoadbalancing/{serviceName}/{protocol}/frontend/{frontendId}
+func (c *Client) DeleteFrontend(ctx context.Context, serviceName, protocol string, id int64) error {
+	return c.client.DeleteWithContext(ctx, lbPath(serviceName, "/%s/frontend/%d", protocol, id), nil)
+}
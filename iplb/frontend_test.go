@@ -0,0 +1,50 @@
+package iplb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFrontend(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/tcp/frontend", http.StatusOK, `[5]`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/tcp/frontend/5", http.StatusOK, `{"frontendId": 5, "zone": "all", "port": "443", "defaultFarmId": 3, "ssl": true}`)
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/tcp/frontend", http.StatusOK, `{"frontendId": 6, "zone": "gra", "port": "80,8080"}`)
+	server.Handle("PUT", "/ipLoadbalancing/loadbalancer-ab12/tcp/frontend/6", http.StatusOK, `null`)
+	server.Handle("DELETE", "/ipLoadbalancing/loadbalancer-ab12/tcp/frontend/6", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.Frontends(ctx, "loadbalancer-ab12", ProtocolTCP)
+	if err != nil {
+		t.Fatalf("Frontends should not return an error. Got %v", err)
+	}
+	frontend, err := client.Frontend(ctx, "loadbalancer-ab12", ProtocolTCP, ids[0])
+	if err != nil {
+		t.Fatalf("Frontend should not return an error. Got %v", err)
+	}
+	created, err := client.CreateFrontend(ctx, "loadbalancer-ab12", ProtocolTCP, FrontendCreation{Zone: "gra", Port: "80,8080"})
+	if err != nil {
+		t.Fatalf("CreateFrontend should not return an error. Got %v", err)
+	}
+	if err := client.UpdateFrontend(ctx, "loadbalancer-ab12", ProtocolTCP, created.FrontendID, FrontendCreation{Zone: "gra", Port: "80", Disabled: true}); err != nil {
+		t.Fatalf("UpdateFrontend should not return an error. Got %v", err)
+	}
+	if err := client.DeleteFrontend(ctx, "loadbalancer-ab12", ProtocolTCP, created.FrontendID); err != nil {
+		t.Fatalf("DeleteFrontend should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if frontend.DefaultFarmID == nil || *frontend.DefaultFarmID != 3 || !frontend.SSL {
+		t.Fatalf("Frontend should be decoded. Got %+v", frontend)
+	}
+	if created.FrontendID != 6 {
+		t.Fatalf("CreateFrontend should return the frontend. Got %+v", created)
+	}
+	if body := string(server.Requests()[2].Body); body != `{"zone":"gra","port":"80,8080"}` {
+		t.Fatalf("CreateFrontend should only send the set parameters. Got %s", body)
+	}
+}
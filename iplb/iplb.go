@@ -0,0 +1,160 @@
+// Package iplb provides typed helpers for the OVH Load Balancer API, under
+// /ipLoadbalancing: frontends, farms, their servers, the refresh task
+// applying the changes to the load balancer, and log subscriptions.
+//
+// Changes are only applied by a refresh. A blue/green switch, enabling the
+// new servers before disabling the old ones, is:
+//
+//	task, err := iplb.New(client).Apply(ctx, "loadbalancer-ab12", func(ctx context.Context) error {
+//		...
+//	}, nil)
+package iplb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Protocols of the frontends and farms
+const (
+	ProtocolHTTP = "http"
+	ProtocolTCP  = "tcp"
+	ProtocolUDP  = "udp"
+)
+
+// LoadBalancer represents a load balancer.
+// Visit https://api.ovh.com/console/#/ipLoadbalancing/%7BserviceName%7D#GET for the full definition
+type LoadBalancer struct {
+	ServiceName     string   `json:"serviceName"`
+	DisplayName     string   `json:"displayName"`
+	IPLoadbalancing string   `json:"ipLoadbalancing"`
+	IPv4            string   `json:"ipv4"`
+	IPv6            string   `json:"ipv6"`
+	State           string   `json:"state"`
+	Offer           string   `json:"offer"`
+	Zone            []string `json:"zone"`
+}
+
+// Task represents an asynchronous operation on a load balancer, like a
+// refresh.
+// Visit https://api.ovh.com/console/#/ipLoadbalancing/%7BserviceName%7D/task/%7Bid%7D#GET for the full definition
+type Task struct {
+	ID           int64     `json:"id"`
+	Action       string    `json:"action"`
+	Status       string    `json:"status"`
+	Progress     int       `json:"progress"`
+	Zones        []string  `json:"zones"`
+	CreationDate time.Time `json:"creationDate"`
+	DoneDate     time.Time `json:"doneDate"`
+}
+
+// PendingChanges represents the number of changes of a zone waiting for a
+// refresh
+type PendingChanges struct {
+	Zone   string `json:"zone"`
+	Number int    `json:"number"`
+}
+
+// Client gives access to the /ipLoadbalancing routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a load balancer client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// lbPath returns the path of a route of a load balancer
+func lbPath(serviceName, format string, args ...interface{}) string {
+	return fmt.Sprintf("/ipLoadbalancing/%s", url.PathEscape(serviceName)) + fmt.Sprintf(format, args...)
+}
+
+// LoadBalancers lists the names of the load balancers, with GET /ipLoadbalancing
+func (c *Client) LoadBalancers(ctx context.Context) ([]string, error) {
+	names := []string{}
+	if err := c.client.GetWithContext(ctx, "/ipLoadbalancing", &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// LoadBalancer returns a load balancer, with GET /ipLoadbalancing/{serviceName}
+func (c *Client) LoadBalancer(ctx context.Context, serviceName string) (*LoadBalancer, error) {
+	lb := &LoadBalancer{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, ""), lb); err != nil {
+		return nil, err
+	}
+	return lb, nil
+}
+
+// PendingChanges lists the changes waiting for a refresh, by zone, with
+// GET /ipLoadbalancing/{serviceName}/pendingChanges
+func (c *Client) PendingChanges(ctx context.Context, serviceName string) ([]PendingChanges, error) {
+	changes := []PendingChanges{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/pendingChanges"), &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Refresh applies the pending changes of a zone, or of all zones when empty,
+// with POST /ipLoadbalancing/{serviceName}/refresh
+func (c *Client) Refresh(ctx context.Context, serviceName, zone string) (*Task, error) {
+	var body interface{}
+	if zone != "" {
+		body = map[string]string{"zone": zone}
+	}
+	task := &Task{}
+	if err := c.client.PostWithContext(ctx, lbPath(serviceName, "/refresh"), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Task returns a task of a load balancer, with
+// GET /ipLoadbalancing/{serviceName}/task/{id}
+func (c *Client) Task(ctx context.Context, serviceName string, id int64) (*Task, error) {
+	task := &Task{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/task/%d", id), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// WaitTask waits until a task of a load balancer is done, see
+// ovh.Client.WaitTask
+func (c *Client) WaitTask(ctx context.Context, serviceName string, id int64, opts *ovh.WaitTaskOptions) (*Task, error) {
+	response, err := c.client.WaitTask(ctx, lbPath(serviceName, "/task/{taskId}"), id, opts)
+	if err != nil {
+		return nil, err
+	}
+	task := &Task{}
+	if err := response.Unmarshal(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Apply runs "changes", which updates the frontends, farms or servers of a
+// load balancer, then refreshes all its zones and waits for the refresh task
+// to complete. Nothing is refreshed if "changes" fails, the changes already
+// made are still pending.
+//
+// Load balancers are refreshed without dropping the established
+// connections, so that servers can be enabled then disabled without
+// downtime.
+func (c *Client) Apply(ctx context.Context, serviceName string, changes func(ctx context.Context) error, opts *ovh.WaitTaskOptions) (*Task, error) {
+	if err := changes(ctx); err != nil {
+		return nil, err
+	}
+	task, err := c.Refresh(ctx, serviceName, "")
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitTask(ctx, serviceName, task.ID, opts)
+}
@@ -0,0 +1,134 @@
+package iplb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/govhtest"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestLoadBalancer(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ipLoadbalancing", http.StatusOK, `["loadbalancer-ab12"]`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12", http.StatusOK, `{"serviceName": "loadbalancer-ab12", "displayName": "front", "ipv4": "192.0.2.10", "state": "ok", "zone": ["gra", "rbx"]}`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/pendingChanges", http.StatusOK, `[{"zone": "gra", "number": 2}]`)
+	ctx := context.Background()
+
+	// Test
+	names, err := client.LoadBalancers(ctx)
+	if err != nil {
+		t.Fatalf("LoadBalancers should not return an error. Got %v", err)
+	}
+	lb, err := client.LoadBalancer(ctx, names[0])
+	if err != nil {
+		t.Fatalf("LoadBalancer should not return an error. Got %v", err)
+	}
+	changes, err := client.PendingChanges(ctx, names[0])
+	if err != nil {
+		t.Fatalf("PendingChanges should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if lb.IPv4 != "192.0.2.10" || len(lb.Zone) != 2 {
+		t.Fatalf("LoadBalancer should be decoded. Got %+v", lb)
+	}
+	if len(changes) != 1 || changes[0].Zone != "gra" || changes[0].Number != 2 {
+		t.Fatalf("PendingChanges should be decoded. Got %+v", changes)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/refresh", http.StatusOK, `{"id": 7, "action": "refreshIplb", "status": "todo"}`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/task/7", http.StatusOK, `{"id": 7, "action": "refreshIplb", "status": "done", "progress": 100}`)
+	ctx := context.Background()
+
+	// Test
+	task, err := client.Refresh(ctx, "loadbalancer-ab12", "gra")
+	if err != nil {
+		t.Fatalf("Refresh should not return an error. Got %v", err)
+	}
+	fetched, err := client.Task(ctx, "loadbalancer-ab12", task.ID)
+	if err != nil {
+		t.Fatalf("Task should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if task.ID != 7 || task.Status != "todo" || fetched.Progress != 100 {
+		t.Fatalf("Tasks should be decoded. Got %+v and %+v", task, fetched)
+	}
+	if body := string(server.Requests()[0].Body); body != `{"zone":"gra"}` {
+		t.Fatalf("Refresh should send the zone. Got %s", body)
+	}
+}
+
+func TestApply(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("PUT", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server/21", http.StatusOK, `null`)
+	server.Handle("PUT", "/ipLoadbalancing/loadbalancer-ab12/http/farm/3/server/20", http.StatusOK, `null`)
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/refresh", http.StatusOK, `{"id": 7, "status": "todo"}`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/task/7", http.StatusOK, `{"id": 7, "status": "done"}`)
+	ctx := context.Background()
+
+	// Test
+	task, err := client.Apply(ctx, "loadbalancer-ab12", func(ctx context.Context) error {
+		if err := client.SetServerStatus(ctx, "loadbalancer-ab12", ProtocolHTTP, 3, 21, ServerStatusActive); err != nil {
+			return err
+		}
+		return client.SetServerStatus(ctx, "loadbalancer-ab12", ProtocolHTTP, 3, 20, ServerStatusInactive)
+	}, &ovh.WaitTaskOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Apply should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if task.ID != 7 || task.Status != "done" {
+		t.Fatalf("Apply should return the done refresh task. Got %+v", task)
+	}
+	requests := server.Requests()
+	if len(requests) != 4 || requests[2].Method != "POST" || requests[3].Method != "GET" {
+		t.Fatalf("Apply should make the changes, then refresh and wait. Got %d requests", len(requests))
+	}
+	if body := string(requests[0].Body); body != `{"status":"active"}` {
+		t.Fatalf("SetServerStatus should send the status. Got %s", body)
+	}
+}
+
+func TestApplyFailedChanges(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	failure := errors.New("failed")
+
+	// Test
+	_, err := client.Apply(context.Background(), "loadbalancer-ab12", func(ctx context.Context) error {
+		return failure
+	}, nil)
+
+	// Validate
+	if err != failure {
+		t.Fatalf("Apply should return the changes error. Got %v", err)
+	}
+	if len(server.Requests()) != 0 {
+		t.Fatalf("Apply should not refresh when the changes fail. Got %d requests", len(server.Requests()))
+	}
+}
@@ -0,0 +1,115 @@
+package iplb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// LogSubscription forwards a kind of logs of a load balancer to a Logs Data
+// Platform stream.
+// Visit https://api.ovh.com/console/#/ipLoadbalancing/%7BserviceName%7D/log/subscription/%7BsubscriptionId%7D#GET for the full definition
+type LogSubscription struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	Kind           string    `json:"kind"`
+	StreamID       string    `json:"streamId"`
+	ServiceName    string    `json:"serviceName"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// LogOperation is the Logs Data Platform operation applying a change of the
+// log subscriptions. It may be waited for with the logs package, from the
+// Logs Data Platform service name and the operation ID.
+type LogOperation struct {
+	OperationID string `json:"operationId"`
+	ServiceName string `json:"serviceName"`
+}
+
+// TemporaryLogURL is an address streaming the logs of a load balancer, until
+// its expiration date
+type TemporaryLogURL struct {
+	URL            string    `json:"url"`
+	ExpirationDate time.Time `json:"expirationDate"`
+}
+
+// LogKinds lists the kinds of logs of a load balancer, like "haproxy", with
+// GET /ipLoadbalancing/{serviceName}/log/kind
+func (c *Client) LogKinds(ctx context.Context, serviceName string) ([]string, error) {
+	kinds := []string{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/log/kind"), &kinds); err != nil {
+		return nil, err
+	}
+	return kinds, nil
+}
+
+// LogSubscriptions lists the IDs of the log subscriptions of a kind, or of
+// all kinds when empty, with GET /ipLoadbalancing/{serviceName}/log/subscription
+func (c *Client) LogSubscriptions(ctx context.Context, serviceName, kind string) ([]string, error) {
+	path := lbPath(serviceName, "/log/subscription")
+	if kind != "" {
+		path += "?kind=" + url.QueryEscape(kind)
+	}
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// LogSubscription returns a log subscription, with
+// GET /ipLoadbalancing/{serviceName}/log/subscription/{subscriptionId}
+func (c *Client) LogSubscription(ctx context.Context, serviceName, subscriptionID string) (*LogSubscription, error) {
+	subscription := &LogSubscription{}
+	if err := c.client.GetWithContext(ctx, lbPath(serviceName, "/log/subscription/%s", url.PathEscape(subscriptionID)), subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// SubscribeLogs forwards a kind of logs to a Logs Data Platform stream, with
+// POST /ipLoadbalancing/{serviceName}/log/subscription
+func (c *Client) SubscribeLogs(ctx context.Context, serviceName, kind, streamID string) (*LogOperation, error) {
+	operation := &LogOperation{}
+	body := map[string]string{"kind": kind, "streamId": streamID}
+	if err := c.client.PostWithContext(ctx, lbPath(serviceName, "/log/subscription"), body, operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// UnsubscribeLogs stops forwarding logs to a stream, with
+// DELETE /ipLoadbalancing/{serviceName}/log/subscription/{subscriptionId}
+func (c *Client) UnsubscribeLogs(ctx context.Context, serviceName, subscriptionID string) (*LogOperation, error) {
+	operation := &LogOperation{}
+	if err := c.client.DeleteWithContext(ctx, lbPath(serviceName, "/log/subscription/%s", url.PathEscape(subscriptionID)), operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// TemporaryLogURL generates an address streaming a kind of logs, with
+// POST /ipLoadbalancing/{serviceName}/log/url
+func (c *Client) TemporaryLogURL(ctx context.Context, serviceName, kind string) (*TemporaryLogURL, error) {
+	logURL := &TemporaryLogURL{}
+	body := map[string]string{"kind": kind}
+	if err := c.client.PostWithContext(ctx, lbPath(serviceName, "/log/url"), body, logURL); err != nil {
+		return nil, err
+	}
+	return logURL, nil
+}
+
+// LogURLFunc returns a function generating a new temporary log address on
+// each call, to follow a kind of logs with logs.Tail:
+//
+//	err := logs.Tail(ctx, client.LogURLFunc("loadbalancer-ab12", "haproxy"), os.Stdout, nil)
+func (c *Client) LogURLFunc(serviceName, kind string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		logURL, err := c.TemporaryLogURL(ctx, serviceName, kind)
+		if err != nil {
+			return "", fmt.Errorf("iplb: cannot generate a log address for %s: %w", serviceName, err)
+		}
+		return logURL.URL, nil
+	}
+}
@@ -0,0 +1,81 @@
+package iplb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLogSubscriptions(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/log/kind", http.StatusOK, `["haproxy"]`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/log/subscription?kind=haproxy", http.StatusOK, `["sub-1"]`)
+	server.Handle("GET", "/ipLoadbalancing/loadbalancer-ab12/log/subscription/sub-1", http.StatusOK, `{"subscriptionId": "sub-1", "kind": "haproxy", "streamId": "stream-1", "serviceName": "ldp-ab-12345"}`)
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/log/subscription", http.StatusOK, `{"operationId": "op-1", "serviceName": "ldp-ab-12345"}`)
+	server.Handle("DELETE", "/ipLoadbalancing/loadbalancer-ab12/log/subscription/sub-1", http.StatusOK, `{"operationId": "op-2", "serviceName": "ldp-ab-12345"}`)
+	ctx := context.Background()
+
+	// Test
+	kinds, err := client.LogKinds(ctx, "loadbalancer-ab12")
+	if err != nil {
+		t.Fatalf("LogKinds should not return an error. Got %v", err)
+	}
+	ids, err := client.LogSubscriptions(ctx, "loadbalancer-ab12", kinds[0])
+	if err != nil {
+		t.Fatalf("LogSubscriptions should not return an error. Got %v", err)
+	}
+	subscription, err := client.LogSubscription(ctx, "loadbalancer-ab12", ids[0])
+	if err != nil {
+		t.Fatalf("LogSubscription should not return an error. Got %v", err)
+	}
+	subscribed, err := client.SubscribeLogs(ctx, "loadbalancer-ab12", "haproxy", "stream-1")
+	if err != nil {
+		t.Fatalf("SubscribeLogs should not return an error. Got %v", err)
+	}
+	unsubscribed, err := client.UnsubscribeLogs(ctx, "loadbalancer-ab12", "sub-1")
+	if err != nil {
+		t.Fatalf("UnsubscribeLogs should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if subscription.StreamID != "stream-1" || subscription.ServiceName != "ldp-ab-12345" {
+		t.Fatalf("LogSubscription should be decoded. Got %+v", subscription)
+	}
+	if subscribed.OperationID != "op-1" || unsubscribed.OperationID != "op-2" || subscribed.ServiceName != "ldp-ab-12345" {
+		t.Fatalf("Log operations should be decoded. Got %+v, %+v", subscribed, unsubscribed)
+	}
+	requests := server.Requests()
+	if body := string(requests[3].Body); body != `{"kind":"haproxy","streamId":"stream-1"}` {
+		t.Fatalf("SubscribeLogs should send the kind and stream. Got %s", body)
+	}
+}
+
+func TestLogURLFunc(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/ipLoadbalancing/loadbalancer-ab12/log/url", http.StatusOK, `{"url": "https://gra1.logs.ovh.com/tail/?tk=secret", "expirationDate": "2026-10-14T12:00:00Z"}`)
+	source := client.LogURLFunc("loadbalancer-ab12", "haproxy")
+	ctx := context.Background()
+
+	// Test
+	first, err := source(ctx)
+	if err != nil {
+		t.Fatalf("LogURLFunc should not return an error. Got %v", err)
+	}
+	second, err := source(ctx)
+	if err != nil {
+		t.Fatalf("LogURLFunc should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if first != "https://gra1.logs.ovh.com/tail/?tk=secret" || second != first {
+		t.Fatalf("LogURLFunc should return the temporary address. Got %q, %q", first, second)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 || string(requests[0].Body) != `{"kind":"haproxy"}` {
+		t.Fatalf("LogURLFunc should generate an address on each call. Got %+v", requests)
+	}
+}
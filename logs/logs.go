@@ -0,0 +1,246 @@
+// Package logs provides typed helpers for the OVH Logs Data Platform API,
+// under /dbaas/logs: streams, inputs and their operations, and Tail, which
+// streams live logs into an io.Writer or a channel, reconnecting as needed.
+//
+// Logs of a stream are followed from its WebSocket address:
+//
+//	client := logs.New(ovhClient)
+//	err := logs.Tail(ctx, client.StreamURLFunc("ldp-ab-12345", streamID), os.Stdout, nil)
+package logs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Operation states
+const (
+	OperationStatePending  = "PENDING"
+	OperationStateReceived = "RECEIVED"
+	OperationStateStarted  = "STARTED"
+	OperationStateRetry    = "RETRY"
+	OperationStateSuccess  = "SUCCESS"
+	OperationStateFailure  = "FAILURE"
+	OperationStateRevoked  = "REVOKED"
+)
+
+// Stream URL types
+const (
+	StreamURLTypeWebSocket  = "WEB_SOCKET"
+	StreamURLTypeGraylogAPI = "GRAYLOG_API"
+	StreamURLTypeGraylogWeb = "GRAYLOG_WEBUI"
+)
+
+// DefaultPollInterval is the delay between two status checks of the Wait
+// helpers when no explicit interval is given
+const DefaultPollInterval = 5 * time.Second
+
+// Stream represents a Graylog stream of a Logs Data Platform service.
+// Visit https://api.ovh.com/console/#/dbaas/logs/%7BserviceName%7D/output/graylog/stream/%7BstreamId%7D#GET for the full definition
+type Stream struct {
+	StreamID    string    `json:"streamId"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	IsEditable  bool      `json:"isEditable"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// StreamURL is an address of a stream, like its WebSocket address
+type StreamURL struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// Input represents a Logstash or Flowgger input, receiving logs from the
+// outside.
+// Visit https://api.ovh.com/console/#/dbaas/logs/%7BserviceName%7D/input/%7BinputId%7D#GET for the full definition
+type Input struct {
+	InputID       string    `json:"inputId"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	EngineID      string    `json:"engineId"`
+	StreamID      string    `json:"streamId"`
+	Status        string    `json:"status"`
+	Hostname      string    `json:"hostname"`
+	PublicAddress string    `json:"publicAddress"`
+	ExposedPort   string    `json:"exposedPort"`
+	NbInstance    int       `json:"nbInstance"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// InputCreation holds the parameters of a new input
+type InputCreation struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	EngineID    string `json:"engineId"`
+	StreamID    string `json:"streamId"`
+	ExposedPort string `json:"exposedPort,omitempty"`
+	NbInstance  int    `json:"nbInstance,omitempty"`
+}
+
+// Operation represents an asynchronous operation of a Logs Data Platform
+// service, like an input creation.
+// Visit https://api.ovh.com/console/#/dbaas/logs/%7BserviceName%7D/operation/%7BoperationId%7D#GET for the full definition
+type Operation struct {
+	OperationID string    `json:"operationId"`
+	State       string    `json:"state"`
+	StreamID    string    `json:"streamId"`
+	InputID     string    `json:"inputId"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Client gives access to the /dbaas/logs routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a Logs Data Platform client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// servicePath returns the path of a route of a Logs Data Platform service
+func servicePath(serviceName, format string, args ...interface{}) string {
+	return fmt.Sprintf("/dbaas/logs/%s", url.PathEscape(serviceName)) + fmt.Sprintf(format, args...)
+}
+
+// Streams lists the IDs of the streams of a service, with
+// GET /dbaas/logs/{serviceName}/output/graylog/stream
+func (c *Client) Streams(ctx context.Context, serviceName string) ([]string, error) {
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceName, "/output/graylog/stream"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Stream returns a stream, with
+// GET /dbaas/logs/{serviceName}/output/graylog/stream/{streamId}
+func (c *Client) Stream(ctx context.Context, serviceName, streamID string) (*Stream, error) {
+	stream := &Stream{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceName, "/output/graylog/stream/%s", url.PathEscape(streamID)), stream); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// StreamURLs lists the addresses of a stream, with
+// GET /dbaas/logs/{serviceName}/output/graylog/stream/{streamId}/url
+func (c *Client) StreamURLs(ctx context.Context, serviceName, streamID string) ([]StreamURL, error) {
+	urls := []StreamURL{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceName, "/output/graylog/stream/%s/url", url.PathEscape(streamID)), &urls); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// StreamURLFunc returns a URLFunc giving the WebSocket address of a stream,
+// to follow its logs with Tail
+func (c *Client) StreamURLFunc(serviceName, streamID string) URLFunc {
+	return func(ctx context.Context) (string, error) {
+		urls, err := c.StreamURLs(ctx, serviceName, streamID)
+		if err != nil {
+			return "", err
+		}
+		for _, u := range urls {
+			if u.Type == StreamURLTypeWebSocket {
+				return u.Address, nil
+			}
+		}
+		return "", fmt.Errorf("logs: stream %s of %s has no WebSocket address", streamID, serviceName)
+	}
+}
+
+// Inputs lists the IDs of the inputs of a service, with
+// GET /dbaas/logs/{serviceName}/input
+func (c *Client) Inputs(ctx context.Context, serviceName string) ([]string, error) {
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceName, "/input"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Input returns an input, with GET /dbaas/logs/{serviceName}/input/{inputId}
+func (c *Client) Input(ctx context.Context, serviceName, inputID string) (*Input, error) {
+	input := &Input{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceName, "/input/%s", url.PathEscape(inputID)), input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// CreateInput registers an input, with POST /dbaas/logs/{serviceName}/input.
+// The input is created by the returned operation, see WaitOperation.
+func (c *Client) CreateInput(ctx context.Context, serviceName string, creation InputCreation) (*Operation, error) {
+	operation := &Operation{}
+	if err := c.client.PostWithContext(ctx, servicePath(serviceName, "/input"), creation, operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// StartInput starts an input, with
+// POST /dbaas/logs/{serviceName}/input/{inputId}/start
+func (c *Client) StartInput(ctx context.Context, serviceName, inputID string) (*Operation, error) {
+	operation := &Operation{}
+	if err := c.client.PostWithContext(ctx, servicePath(serviceName, "/input/%s/start", url.PathEscape(inputID)), nil, operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// DeleteInput deletes an input, with
+// DELETE /dbaas/logs/{serviceName}/input/{inputId}
+func (c *Client) DeleteInput(ctx context.Context, serviceName, inputID string) (*Operation, error) {
+	operation := &Operation{}
+	if err := c.client.DeleteWithContext(ctx, servicePath(serviceName, "/input/%s", url.PathEscape(inputID)), operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// Operation returns an operation, with
+// GET /dbaas/logs/{serviceName}/operation/{operationId}
+func (c *Client) Operation(ctx context.Context, serviceName, operationID string) (*Operation, error) {
+	operation := &Operation{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceName, "/operation/%s", url.PathEscape(operationID)), operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// WaitOperation polls an operation every "interval", DefaultPollInterval if
+// not positive, until it succeeds. It fails if the operation fails or is
+// revoked, or when the context is done.
+func (c *Client) WaitOperation(ctx context.Context, serviceName, operationID string, interval time.Duration) (*Operation, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		operation, err := c.Operation(ctx, serviceName, operationID)
+		if err != nil {
+			return nil, err
+		}
+		switch operation.State {
+		case OperationStateSuccess:
+			return operation, nil
+		case OperationStateFailure, OperationStateRevoked:
+			return operation, fmt.Errorf("logs: operation %s of %s is in %s state", operationID, serviceName, operation.State)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return operation, ctx.Err()
+		}
+	}
+}
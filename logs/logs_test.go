@@ -0,0 +1,116 @@
+package logs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestStreams(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/output/graylog/stream", http.StatusOK, `["stream-1"]`)
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/output/graylog/stream/stream-1", http.StatusOK, `{"streamId": "stream-1", "title": "iplb"}`)
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/output/graylog/stream/stream-1/url", http.StatusOK, `[{"type": "GRAYLOG_WEBUI", "address": "https://gra1.logs.ovh.com/streams/stream-1"}, {"type": "WEB_SOCKET", "address": "wss://gra1.logs.ovh.com/tail/?tk=secret"}]`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.Streams(ctx, "ldp-ab-12345")
+	if err != nil {
+		t.Fatalf("Streams should not return an error. Got %v", err)
+	}
+	stream, err := client.Stream(ctx, "ldp-ab-12345", ids[0])
+	if err != nil {
+		t.Fatalf("Stream should not return an error. Got %v", err)
+	}
+	address, err := client.StreamURLFunc("ldp-ab-12345", ids[0])(ctx)
+	if err != nil {
+		t.Fatalf("StreamURLFunc should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if stream.Title != "iplb" {
+		t.Fatalf("Stream should be decoded. Got %+v", stream)
+	}
+	if address != "wss://gra1.logs.ovh.com/tail/?tk=secret" {
+		t.Fatalf("StreamURLFunc should return the WebSocket address. Got %q", address)
+	}
+}
+
+func TestStreamURLFuncNoWebSocket(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/output/graylog/stream/stream-1/url", http.StatusOK, `[{"type": "GRAYLOG_API", "address": "https://gra1.logs.ovh.com/api"}]`)
+
+	// Test
+	_, err := client.StreamURLFunc("ldp-ab-12345", "stream-1")(context.Background())
+
+	// Validate
+	if err == nil || err.Error() != "logs: stream stream-1 of ldp-ab-12345 has no WebSocket address" {
+		t.Fatalf("StreamURLFunc should fail without a WebSocket address. Got %v", err)
+	}
+}
+
+func TestCreateInput(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/dbaas/logs/ldp-ab-12345/input", http.StatusOK, `{"operationId": "op-1", "state": "PENDING"}`)
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/operation/op-1", http.StatusOK, `{"operationId": "op-1", "state": "SUCCESS", "inputId": "input-1"}`)
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/input/input-1", http.StatusOK, `{"inputId": "input-1", "title": "syslog", "status": "INIT", "exposedPort": "6514"}`)
+	ctx := context.Background()
+
+	// Test
+	operation, err := client.CreateInput(ctx, "ldp-ab-12345", InputCreation{Title: "syslog", EngineID: "engine-1", StreamID: "stream-1"})
+	if err != nil {
+		t.Fatalf("CreateInput should not return an error. Got %v", err)
+	}
+	operation, err = client.WaitOperation(ctx, "ldp-ab-12345", operation.OperationID, 1)
+	if err != nil {
+		t.Fatalf("WaitOperation should not return an error. Got %v", err)
+	}
+	input, err := client.Input(ctx, "ldp-ab-12345", operation.InputID)
+	if err != nil {
+		t.Fatalf("Input should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if input.Title != "syslog" || input.ExposedPort != "6514" {
+		t.Fatalf("Input should be decoded. Got %+v", input)
+	}
+	requests := server.Requests()
+	if body := string(requests[0].Body); body != `{"title":"syslog","description":"","engineId":"engine-1","streamId":"stream-1"}` {
+		t.Fatalf("CreateInput should send the input. Got %s", body)
+	}
+}
+
+func TestWaitOperationFailure(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dbaas/logs/ldp-ab-12345/operation/op-1", http.StatusOK, `{"operationId": "op-1", "state": "FAILURE"}`)
+
+	// Test
+	operation, err := client.WaitOperation(context.Background(), "ldp-ab-12345", "op-1", 1)
+
+	// Validate
+	if err == nil || err.Error() != "logs: operation op-1 of ldp-ab-12345 is in FAILURE state" {
+		t.Fatalf("WaitOperation should fail on failed operations. Got %v", err)
+	}
+	if operation == nil || operation.State != OperationStateFailure {
+		t.Fatalf("WaitOperation should return the failed operation. Got %+v", operation)
+	}
+}
@@ -0,0 +1,347 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Reconnection delays of Tail, when not set in TailOptions
+const (
+	DefaultReconnectDelay    = time.Second
+	DefaultMaxReconnectDelay = time.Minute
+)
+
+// MaxMessageSize is the size above which a log message is rejected, dropping
+// the connection
+const MaxMessageSize = 1 << 20
+
+// URLFunc returns the address to read logs from. Tail calls it before each
+// connection, so that expiring addresses, like the temporary log URLs of the
+// load balancers, are renewed.
+type URLFunc func(ctx context.Context) (string, error)
+
+// TailOptions customizes Tail and TailLines
+type TailOptions struct {
+	// HTTPClient connects to the log addresses. It defaults to a client
+	// without timeout nor HTTP/2, as WebSocket upgrades require HTTP/1.1.
+	HTTPClient *http.Client
+
+	// ReconnectDelay is the delay before reconnecting. It is doubled after
+	// each failed connection, up to MaxReconnectDelay, and reset once
+	// connected.
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+
+	// OnError, when set, is called with the errors which caused a reconnection
+	OnError func(err error)
+}
+
+// defaultHTTPClient connects to the log addresses when no client is given
+var defaultHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:        http.ProxyFromEnvironment,
+		TLSNextProto: map[string]func(string, *tls.Conn) http.RoundTripper{},
+	},
+}
+
+// writeError is an error of the log handler, ending Tail
+type writeError struct {
+	err error
+}
+
+func (e *writeError) Error() string {
+	return e.err.Error()
+}
+
+// Tail follows the logs served at the address returned by "source" and
+// writes them to "w", one message per line. The address is either a
+// WebSocket one (ws:// or wss://), like the address of a stream, or an HTTP
+// one (http:// or https://) serving newline delimited messages.
+//
+// Closed or failed connections are opened again, with a new address from
+// "source", until the context is done. Tail only returns with an error of
+// "w", or with the context error.
+func Tail(ctx context.Context, source URLFunc, w io.Writer, opts *TailOptions) error {
+	return tail(ctx, source, opts, func(message string) error {
+		_, err := io.WriteString(w, message+"\n")
+		return err
+	})
+}
+
+// TailLines follows logs as Tail, sending them on the returned channel, one
+// message at a time. The channel is closed once the context is done.
+func TailLines(ctx context.Context, source URLFunc, opts *TailOptions) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		_ = tail(ctx, source, opts, func(message string) error {
+			select {
+			case lines <- message:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return lines
+}
+
+// tail runs the connection loop of Tail and TailLines
+func tail(ctx context.Context, source URLFunc, opts *TailOptions, handle func(message string) error) error {
+	if opts == nil {
+		opts = &TailOptions{}
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	minDelay := opts.ReconnectDelay
+	if minDelay <= 0 {
+		minDelay = DefaultReconnectDelay
+	}
+	maxDelay := opts.MaxReconnectDelay
+	if maxDelay < minDelay {
+		maxDelay = DefaultMaxReconnectDelay
+		if maxDelay < minDelay {
+			maxDelay = minDelay
+		}
+	}
+
+	delay := minDelay
+	for {
+		connected, err := follow(ctx, client, source, func(message string) error {
+			if err := handle(message); err != nil {
+				return &writeError{err: err}
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var werr *writeError
+		if errors.As(err, &werr) {
+			return werr.err
+		}
+		if err != nil && opts.OnError != nil {
+			opts.OnError(err)
+		}
+
+		if connected {
+			delay = minDelay
+		} else if delay *= 2; delay > maxDelay {
+			delay = maxDelay
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// follow reads logs until the connection is closed. It reports whether the
+// connection succeeded, and returns nil when it was closed by the server.
+func follow(ctx context.Context, client *http.Client, source URLFunc, handle func(message string) error) (bool, error) {
+	address, err := source(ctx)
+	if err != nil {
+		return false, err
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return false, fmt.Errorf("logs: invalid log address: %w", err)
+	}
+
+	websocket := false
+	switch u.Scheme {
+	case "ws":
+		u.Scheme, websocket = "http", true
+	case "wss":
+		u.Scheme, websocket = "https", true
+	case "http", "https":
+	default:
+		return false, fmt.Errorf("logs: unsupported log address scheme %q", u.Scheme)
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	key := ""
+	if websocket {
+		nonce := make([]byte, 16)
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return false, err
+		}
+		key = base64.StdEncoding.EncodeToString(nonce)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", key)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	// Switched connections are not bound to the request context anymore
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			resp.Body.Close()
+		case <-done:
+		}
+	}()
+
+	// Addresses hold credentials, only their host is reported
+	if !websocket {
+		if resp.StatusCode != http.StatusOK {
+			return false, fmt.Errorf("logs: unexpected status %q from %s", resp.Status, u.Host)
+		}
+		return true, readLines(resp.Body, handle)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return false, fmt.Errorf("logs: unexpected status %q from %s, expecting a WebSocket upgrade", resp.Status, u.Host)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return false, fmt.Errorf("logs: invalid WebSocket handshake from %s", u.Host)
+	}
+	conn, ok := resp.Body.(io.ReadWriter)
+	if !ok {
+		return false, errors.New("logs: WebSocket connection is not writable")
+	}
+	return true, readMessages(conn, handle)
+}
+
+// readLines handles the lines of a newline delimited stream
+func readLines(r io.Reader, handle func(message string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxMessageSize)
+	for scanner.Scan() {
+		if err := handle(strings.TrimSuffix(scanner.Text(), "\r")); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// WebSocket opcodes, see RFC 6455
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// acceptKey returns the Sec-WebSocket-Accept value expected for a key
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// readMessages handles the text and binary messages of a WebSocket
+// connection, answering pings, until it is closed
+func readMessages(conn io.ReadWriter, handle func(message string) error) error {
+	r := bufio.NewReader(conn)
+	var message []byte
+	for {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return err
+		}
+		fin := header[0]&0x80 != 0
+		opcode := header[0] & 0x0f
+		length := uint64(header[1] & 0x7f)
+		switch length {
+		case 126:
+			ext := make([]byte, 2)
+			if _, err := io.ReadFull(r, ext); err != nil {
+				return err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext))
+		case 127:
+			ext := make([]byte, 8)
+			if _, err := io.ReadFull(r, ext); err != nil {
+				return err
+			}
+			length = binary.BigEndian.Uint64(ext)
+		}
+		if length > MaxMessageSize || uint64(len(message))+length > MaxMessageSize {
+			return fmt.Errorf("logs: WebSocket message larger than %d bytes", MaxMessageSize)
+		}
+
+		var mask []byte
+		if header[1]&0x80 != 0 {
+			mask = make([]byte, 4)
+			if _, err := io.ReadFull(r, mask); err != nil {
+				return err
+			}
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return err
+		}
+		for i := range mask {
+			for j := i; j < len(payload); j += 4 {
+				payload[j] ^= mask[i]
+			}
+		}
+
+		switch opcode {
+		case opText, opBinary, opContinuation:
+			message = append(message, payload...)
+			if fin {
+				if err := handle(strings.TrimSuffix(string(message), "\n")); err != nil {
+					return err
+				}
+				message = message[:0]
+			}
+		case opPing:
+			if err := writeFrame(conn, opPong, payload); err != nil {
+				return err
+			}
+		case opPong:
+		case opClose:
+			return nil
+		default:
+			return fmt.Errorf("logs: unexpected WebSocket opcode %d", opcode)
+		}
+	}
+}
+
+// writeFrame writes a control frame, masked as required from clients
+func writeFrame(w io.Writer, opcode byte, payload []byte) error {
+	if len(payload) > 125 {
+		return errors.New("logs: WebSocket control frame too large")
+	}
+	frame := make([]byte, 6, 6+len(payload))
+	frame[0] = 0x80 | opcode
+	frame[1] = 0x80 | byte(len(payload))
+	if _, err := io.ReadFull(rand.Reader, frame[2:6]); err != nil {
+		return err
+	}
+	for i, b := range payload {
+		frame = append(frame, b^frame[2+i%4])
+	}
+	_, err := w.Write(frame)
+	return err
+}
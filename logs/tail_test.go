@@ -0,0 +1,254 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// staticURL returns a URLFunc counting its calls
+func staticURL(address string, calls *int, mutex *sync.Mutex) URLFunc {
+	return func(ctx context.Context) (string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		*calls++
+		return address, nil
+	}
+}
+
+// collect reads "n" lines from a channel, failing after a second
+func collect(t *testing.T, lines <-chan string, n int) []string {
+	got := []string{}
+	for len(got) < n {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("Lines should not be closed. Got %v", got)
+			}
+			got = append(got, line)
+		case <-time.After(time.Second):
+			t.Fatalf("Lines should be received. Got %v", got)
+		}
+	}
+	return got
+}
+
+// writeServerFrame writes an unmasked WebSocket frame, as servers do
+func writeServerFrame(w io.Writer, fin bool, opcode byte, payload string) {
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	w.Write([]byte{first, byte(len(payload))})
+	io.WriteString(w, payload)
+}
+
+// readClientFrame reads a masked WebSocket frame, as clients send
+func readClientFrame(r *bufio.Reader) (byte, string, error) {
+	header := make([]byte, 6)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, "", err
+	}
+	if header[1]&0x80 == 0 {
+		return 0, "", errors.New("unmasked client frame")
+	}
+	payload := make([]byte, header[1]&0x7f)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, "", err
+	}
+	for i := range payload {
+		payload[i] ^= header[2+i%4]
+	}
+	return header[0] & 0x0f, string(payload), nil
+}
+
+func TestTailHTTP(t *testing.T) {
+	// Init test: the first connection is closed after two lines
+	var mutex sync.Mutex
+	connections := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		connections++
+		n := connections
+		mutex.Unlock()
+		if n == 1 {
+			fmt.Fprint(w, "first\r\nsecond\n")
+			return
+		}
+		fmt.Fprint(w, "third\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Test
+	lines := TailLines(ctx, staticURL(ts.URL+"/tail/?tk=secret", &calls, &mutex), &TailOptions{ReconnectDelay: time.Millisecond})
+	got := collect(t, lines, 3)
+	cancel()
+
+	// Validate
+	if strings.Join(got, ",") != "first,second,third" {
+		t.Fatalf("TailLines should send each line. Got %v", got)
+	}
+	select {
+	case _, ok := <-lines:
+		if ok {
+			t.Fatal("Lines should not be sent once the context is done")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Lines should be closed once the context is done")
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if calls != 2 {
+		t.Fatalf("TailLines should ask for a new address on reconnection. Got %d calls", calls)
+	}
+}
+
+func TestTailWebSocket(t *testing.T) {
+	// Init test: a failed upgrade, then a connection closed by the server
+	var mutex sync.Mutex
+	connections := 0
+	pongs := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		connections++
+		n := connections
+		mutex.Unlock()
+		if n == 1 {
+			http.Error(w, "expired token", http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("Upgrade") != "websocket" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+			http.Error(w, "not a WebSocket upgrade", http.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", acceptKey(r.Header.Get("Sec-WebSocket-Key")))
+		writeServerFrame(rw, true, opText, `{"message":"hello"}`)
+		writeServerFrame(rw, false, opText, "hel")
+		writeServerFrame(rw, true, opPing, "ping")
+		writeServerFrame(rw, true, opContinuation, "lo world\n")
+		rw.Flush()
+		if opcode, payload, err := readClientFrame(rw.Reader); err == nil && opcode == opPong {
+			pongs <- payload
+		}
+		if n == 2 {
+			writeServerFrame(rw, true, opClose, "")
+			rw.Flush()
+			return
+		}
+		conn.Read(make([]byte, 1))
+	}))
+	defer ts.Close()
+
+	calls := 0
+	errs := []error{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := &TailOptions{
+		ReconnectDelay: time.Millisecond,
+		OnError: func(err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			errs = append(errs, err)
+		},
+	}
+
+	// Test
+	lines := TailLines(ctx, staticURL("ws"+strings.TrimPrefix(ts.URL, "http")+"/tail/?tk=secret", &calls, &mutex), opts)
+	got := collect(t, lines, 4)
+	cancel()
+
+	// Validate
+	if strings.Join(got, ",") != `{"message":"hello"},hello world,{"message":"hello"},hello world` {
+		t.Fatalf("TailLines should send each WebSocket message. Got %v", got)
+	}
+	select {
+	case pong := <-pongs:
+		if pong != "ping" {
+			t.Fatalf("Pings should be answered with their payload. Got %q", pong)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Pings should be answered")
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if calls != 3 {
+		t.Fatalf("TailLines should ask for a new address on reconnection. Got %d calls", calls)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `unexpected status "403 Forbidden"`) || strings.Contains(errs[0].Error(), "secret") {
+		t.Fatalf("Failed connections should be reported without the address. Got %v", errs)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestTailWriteError(t *testing.T) {
+	// Init test
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "first\n")
+	}))
+	defer ts.Close()
+	source := func(ctx context.Context) (string, error) {
+		return ts.URL, nil
+	}
+
+	// Test
+	err := Tail(context.Background(), source, failingWriter{}, nil)
+
+	// Validate
+	if err == nil || err.Error() != "disk full" {
+		t.Fatalf("Tail should return the errors of the writer. Got %v", err)
+	}
+}
+
+func TestTailContext(t *testing.T) {
+	// Init test
+	sourceErr := errors.New("no address")
+	calls := 0
+	source := func(ctx context.Context) (string, error) {
+		calls++
+		return "", sourceErr
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Test
+	var reported error
+	err := Tail(ctx, source, ioutil.Discard, &TailOptions{
+		ReconnectDelay:    time.Millisecond,
+		MaxReconnectDelay: 4 * time.Millisecond,
+		OnError:           func(err error) { reported = err },
+	})
+
+	// Validate
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Tail should return the context error. Got %v", err)
+	}
+	if reported != sourceErr || calls < 2 {
+		t.Fatalf("Tail should report the errors and retry. Got %v after %d calls", reported, calls)
+	}
+}
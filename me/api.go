@@ -0,0 +1,106 @@
+package me
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Application represents an API application of the account.
+// Visit https://api.ovh.com/console/#/me/api/application/%7BapplicationId%7D#GET for the full definition
+type Application struct {
+	ApplicationID  int64  `json:"applicationId"`
+	ApplicationKey string `json:"applicationKey"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Status         string `json:"status"`
+}
+
+// Credential represents a consumer key of the account.
+// Visit https://api.ovh.com/console/#/me/api/credential/%7BcredentialId%7D#GET for the full definition
+type Credential struct {
+	CredentialID  int64            `json:"credentialId"`
+	ApplicationID int64            `json:"applicationId"`
+	Creation      time.Time        `json:"creation"`
+	Expiration    time.Time        `json:"expiration"`
+	LastUse       time.Time        `json:"lastUse"`
+	OvhSupport    bool             `json:"ovhSupport"`
+	Rules         []ovh.AccessRule `json:"rules"`
+	Status        string           `json:"status"`
+	AllowedIPs    []string         `json:"allowedIPs"`
+}
+
+// Credential statuses
+const (
+	CredentialStatusExpired           = "expired"
+	CredentialStatusPendingValidation = "pendingValidation"
+	CredentialStatusRefused           = "refused"
+	CredentialStatusValidated         = "validated"
+)
+
+// ApplicationIDs lists the IDs of the API applications, with
+// GET /me/api/application
+func (c *Client) ApplicationIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, "/me/api/application", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Application returns an API application, with
+// GET /me/api/application/{applicationId}
+func (c *Client) Application(ctx context.Context, applicationID int64) (*Application, error) {
+	application := &Application{}
+	if err := c.client.GetWithContext(ctx, fmt.Sprintf("/me/api/application/%d", applicationID), application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// DeleteApplication deletes an API application and all its credentials, with
+// DELETE /me/api/application/{applicationId}
+func (c *Client) DeleteApplication(ctx context.Context, applicationID int64) error {
+	return c.client.DeleteWithContext(ctx, fmt.Sprintf("/me/api/application/%d", applicationID), nil)
+}
+
+// CredentialIDs lists the IDs of the credentials, with GET /me/api/credential.
+// A zero "applicationID" or an empty "status" are not used as filters.
+func (c *Client) CredentialIDs(ctx context.Context, applicationID int64, status string) ([]int64, error) {
+	query := url.Values{}
+	if applicationID != 0 {
+		query.Set("applicationId", strconv.FormatInt(applicationID, 10))
+	}
+	if status != "" {
+		query.Set("status", status)
+	}
+	path := "/me/api/credential"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Credential returns a credential, with GET /me/api/credential/{credentialId}
+func (c *Client) Credential(ctx context.Context, credentialID int64) (*Credential, error) {
+	credential := &Credential{}
+	if err := c.client.GetWithContext(ctx, fmt.Sprintf("/me/api/credential/%d", credentialID), credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// DeleteCredential revokes a credential, with
+// DELETE /me/api/credential/{credentialId}
+func (c *Client) DeleteCredential(ctx context.Context, credentialID int64) error {
+	return c.client.DeleteWithContext(ctx, fmt.Sprintf("/me/api/credential/%d", credentialID), nil)
+}
@@ -0,0 +1,78 @@
+package me
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func TestApplications(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/api/application", http.StatusOK, `[1234]`)
+	server.Handle("GET", "/me/api/application/1234", http.StatusOK, `{"applicationId": 1234, "applicationKey": "app-key", "name": "my-app", "description": "My app", "status": "active"}`)
+	server.Handle("DELETE", "/me/api/application/1234", http.StatusOK, `null`)
+
+	// Test
+	ids, err := client.ApplicationIDs(context.Background())
+	if err != nil {
+		t.Fatalf("ApplicationIDs should not return an error. Got %v", err)
+	}
+	application, err := client.Application(context.Background(), ids[0])
+	if err != nil {
+		t.Fatalf("Application should not return an error. Got %v", err)
+	}
+	if err := client.DeleteApplication(context.Background(), ids[0]); err != nil {
+		t.Fatalf("DeleteApplication should not return an error. Got %v", err)
+	}
+
+	// Validate
+	expected := &Application{ApplicationID: 1234, ApplicationKey: "app-key", Name: "my-app", Description: "My app", Status: "active"}
+	if !reflect.DeepEqual(application, expected) {
+		t.Fatalf("Application should decode the response as %+v. Got %+v", expected, application)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/api/credential?applicationId=1234&status=validated", http.StatusOK, `[42]`)
+	server.Handle("GET", "/me/api/credential/42", http.StatusOK, `{
+			"credentialId": 42,
+			"applicationId": 1234,
+			"creation": "2020-01-01T10:00:00+01:00",
+			"expiration": "2020-01-02T10:00:00+01:00",
+			"lastUse": null,
+			"ovhSupport": false,
+			"rules": [{"method": "GET", "path": "/me"}],
+			"status": "validated",
+			"allowedIPs": null
+		}`)
+	server.Handle("DELETE", "/me/api/credential/42", http.StatusOK, `null`)
+
+	// Test
+	ids, err := client.CredentialIDs(context.Background(), 1234, CredentialStatusValidated)
+	if err != nil {
+		t.Fatalf("CredentialIDs should not return an error. Got %v", err)
+	}
+	credential, err := client.Credential(context.Background(), ids[0])
+	if err != nil {
+		t.Fatalf("Credential should not return an error. Got %v", err)
+	}
+	if err := client.DeleteCredential(context.Background(), ids[0]); err != nil {
+		t.Fatalf("DeleteCredential should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if credential.CredentialID != 42 || credential.Status != CredentialStatusValidated || !credential.LastUse.IsZero() {
+		t.Fatalf("Credential should decode the response. Got %+v", credential)
+	}
+	if !reflect.DeepEqual(credential.Rules, []ovh.AccessRule{{Method: "GET", Path: "/me"}}) {
+		t.Fatalf("Credential should decode the rules. Got %+v", credential.Rules)
+	}
+}
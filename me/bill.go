@@ -0,0 +1,56 @@
+package me
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/order"
+)
+
+// Bill represents a bill of the account.
+// Visit https://api.ovh.com/console/#/me/bill/%7BbillId%7D#GET for the full definition
+type Bill struct {
+	BillID          string      `json:"billId"`
+	Date            time.Time   `json:"date"`
+	OrderID         int64       `json:"orderId"`
+	Category        string      `json:"category"`
+	Password        string      `json:"password"`
+	PdfURL          string      `json:"pdfUrl"`
+	URL             string      `json:"url"`
+	PriceWithTax    order.Price `json:"priceWithTax"`
+	PriceWithoutTax order.Price `json:"priceWithoutTax"`
+	Tax             order.Price `json:"tax"`
+}
+
+// BillIDs lists the IDs of the bills issued between "from" and "to", with
+// GET /me/bill. Zero times are not used as filters.
+func (c *Client) BillIDs(ctx context.Context, from, to time.Time) ([]string, error) {
+	query := url.Values{}
+	if !from.IsZero() {
+		query.Set("date.from", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		query.Set("date.to", to.Format(time.RFC3339))
+	}
+	path := "/me/bill"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Bill returns a bill, with GET /me/bill/{billId}
+func (c *Client) Bill(ctx context.Context, billID string) (*Bill, error) {
+	bill := &Bill{}
+	if err := c.client.GetWithContext(ctx, fmt.Sprintf("/me/bill/%s", url.PathEscape(billID)), bill); err != nil {
+		return nil, err
+	}
+	return bill, nil
+}
@@ -0,0 +1,46 @@
+package me
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBills(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/bill?date.from=2020-01-01T00%3A00%3A00Z", http.StatusOK, `["FR123", "FR456"]`)
+	server.Handle("GET", "/me/bill/FR123", http.StatusOK, `{
+			"billId": "FR123",
+			"date": "2020-01-05T10:00:00+01:00",
+			"orderId": 42,
+			"category": "purchase-cloud",
+			"password": "abcd",
+			"pdfUrl": "https://www.ovh.com/cgi-bin/order/facture.pdf?reference=FR123",
+			"url": "https://www.ovh.com/cgi-bin/order/facture.cgi?reference=FR123",
+			"priceWithTax": {"currencyCode": "EUR", "text": "12.00 €", "value": 12},
+			"priceWithoutTax": {"currencyCode": "EUR", "text": "10.00 €", "value": 10},
+			"tax": {"currencyCode": "EUR", "text": "2.00 €", "value": 2}
+		}`)
+
+	// Test
+	ids, err := client.BillIDs(context.Background(), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+	if err != nil {
+		t.Fatalf("BillIDs should not return an error. Got %v", err)
+	}
+	bill, err := client.Bill(context.Background(), ids[0])
+	if err != nil {
+		t.Fatalf("Bill should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if !reflect.DeepEqual(ids, []string{"FR123", "FR456"}) {
+		t.Fatalf("BillIDs should return the bill IDs. Got %v", ids)
+	}
+	if bill.OrderID != 42 || bill.Category != "purchase-cloud" || bill.PriceWithTax.Value != 12 || bill.Tax.Text != "2.00 €" || bill.Date.Day() != 5 {
+		t.Fatalf("Bill should decode the response. Got %+v", bill)
+	}
+}
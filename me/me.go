@@ -0,0 +1,61 @@
+// Package me provides typed helpers for the OVH /me API, describing the
+// account of the currently logged-in user: its details, bills, payment
+// methods, email notifications, API applications and credentials.
+package me
+
+import (
+	"context"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Currency represents the currency of an account
+type Currency struct {
+	Code   string `json:"code"`
+	Symbol string `json:"symbol"`
+}
+
+// Me represents the account of the currently logged-in user.
+// Visit https://api.ovh.com/console/#/me#GET for the full definition
+type Me struct {
+	Nichandle     string   `json:"nichandle"`
+	CustomerCode  string   `json:"customerCode"`
+	Email         string   `json:"email"`
+	SpareEmail    string   `json:"spareEmail"`
+	Firstname     string   `json:"firstname"`
+	Name          string   `json:"name"`
+	Organisation  string   `json:"organisation"`
+	LegalForm     string   `json:"legalform"`
+	Address       string   `json:"address"`
+	Zip           string   `json:"zip"`
+	City          string   `json:"city"`
+	Area          string   `json:"area"`
+	Country       string   `json:"country"`
+	Phone         string   `json:"phone"`
+	CellPhone     string   `json:"cellPhone"`
+	Language      string   `json:"language"`
+	Currency      Currency `json:"currency"`
+	OvhSubsidiary string   `json:"ovhSubsidiary"`
+	OvhCompany    string   `json:"ovhCompany"`
+	State         string   `json:"state"`
+	KYCValidated  bool     `json:"kycValidated"`
+}
+
+// Client gives access to the /me routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a /me client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// Me returns the account of the currently logged-in user, with GET /me
+func (c *Client) Me(ctx context.Context) (*Me, error) {
+	me := &Me{}
+	if err := c.client.GetWithContext(ctx, "/me", me); err != nil {
+		return nil, err
+	}
+	return me, nil
+}
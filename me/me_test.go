@@ -0,0 +1,85 @@
+package me
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+// recordedMe is a recorded, anonymized, GET /me response
+const recordedMe = `{
+	"nichandle": "xx1111-ovh",
+	"customerCode": "1234-5678-90",
+	"email": "john.doe@example.com",
+	"spareEmail": null,
+	"firstname": "John",
+	"name": "Doe",
+	"organisation": "",
+	"legalform": "individual",
+	"address": "2 rue Kellermann",
+	"zip": "59100",
+	"city": "Roubaix",
+	"area": "",
+	"country": "FR",
+	"phone": "+33.123456789",
+	"cellPhone": "",
+	"fax": "",
+	"language": "fr_FR",
+	"currency": {"code": "EUR", "symbol": "EURO"},
+	"ovhSubsidiary": "FR",
+	"ovhCompany": "ovh",
+	"state": "complete",
+	"kycValidated": true,
+	"vat": "",
+	"sex": null,
+	"birthDay": ""
+}`
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestMe(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me", http.StatusOK, recordedMe)
+
+	// Test
+	me, err := client.Me(context.Background())
+	if err != nil {
+		t.Fatalf("Me should not return an error. Got %v", err)
+	}
+
+	// Validate
+	expected := &Me{
+		Nichandle:     "xx1111-ovh",
+		CustomerCode:  "1234-5678-90",
+		Email:         "john.doe@example.com",
+		Firstname:     "John",
+		Name:          "Doe",
+		LegalForm:     "individual",
+		Address:       "2 rue Kellermann",
+		Zip:           "59100",
+		City:          "Roubaix",
+		Country:       "FR",
+		Phone:         "+33.123456789",
+		Language:      "fr_FR",
+		Currency:      Currency{Code: "EUR", Symbol: "EURO"},
+		OvhSubsidiary: "FR",
+		OvhCompany:    "ovh",
+		State:         "complete",
+		KYCValidated:  true,
+	}
+	if !reflect.DeepEqual(me, expected) {
+		t.Fatalf("Me should decode the recorded response as %+v. Got %+v", expected, me)
+	}
+}
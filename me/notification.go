@@ -0,0 +1,169 @@
+package me
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultNotificationPollInterval is the delay between two polls of a
+// Watcher, used when WatchOptions.Interval is not set
+const DefaultNotificationPollInterval = time.Minute
+
+// Notification represents a notification sent to the account by email,
+// like billing alerts or abuse reports.
+// Visit https://api.ovh.com/console/#/me/notification/email/history/%7Bid%7D#GET for the full definition
+type Notification struct {
+	ID        int64     `json:"id"`
+	Date      time.Time `json:"date"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	FromEmail string    `json:"fromEmail"`
+	FromName  string    `json:"fromName"`
+}
+
+// NotificationIDs lists the IDs of the notifications sent to the account,
+// with GET /me/notification/email/history
+func (c *Client) NotificationIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, "/me/notification/email/history", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Notification returns a notification, with
+// GET /me/notification/email/history/{id}
+func (c *Client) Notification(ctx context.Context, notificationID int64) (*Notification, error) {
+	notification := &Notification{}
+	if err := c.client.GetWithContext(ctx, fmt.Sprintf("/me/notification/email/history/%d", notificationID), notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// WatchOptions configures a Watcher
+type WatchOptions struct {
+	// Interval is the delay between two polls.
+	// DefaultNotificationPollInterval is used when zero.
+	Interval time.Duration
+
+	// IncludeExisting delivers the notifications already sent when the
+	// watcher starts. Only the notifications sent afterwards are delivered
+	// otherwise.
+	IncludeExisting bool
+
+	// OnError, when set, is called with the errors of Watch polls, which
+	// are retried on the next interval
+	OnError func(err error)
+}
+
+// Watcher polls the notifications of the account and reports each of them
+// once
+type Watcher struct {
+	client *Client
+	opts   WatchOptions
+	seen   map[int64]bool
+}
+
+// NewWatcher returns a Watcher of the notifications of the account
+func (c *Client) NewWatcher(opts *WatchOptions) *Watcher {
+	w := &Watcher{client: c}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = DefaultNotificationPollInterval
+	}
+	return w
+}
+
+// Poll returns the notifications not reported yet, oldest first. The first
+// poll only records the existing notifications, unless
+// WatchOptions.IncludeExisting is set.
+//
+// Notifications which could not be fetched are reported by a later poll.
+// Poll must not be called concurrently.
+func (w *Watcher) Poll(ctx context.Context) ([]Notification, error) {
+	ids, err := w.client.NotificationIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	first := w.seen == nil
+	seen := make(map[int64]bool, len(ids))
+	var notifications []Notification
+	for _, id := range ids {
+		if w.seen[id] || (first && !w.opts.IncludeExisting) {
+			seen[id] = true
+			continue
+		}
+		notification, err := w.client.Notification(ctx, id)
+		if err != nil {
+			// Keep the unseen IDs so that they are fetched again
+			w.merge(seen, ids)
+			sortNotifications(notifications)
+			return notifications, err
+		}
+		seen[id] = true
+		notifications = append(notifications, *notification)
+	}
+
+	// Only keep the listed IDs, as notifications are purged after a while
+	w.seen = seen
+	sortNotifications(notifications)
+	return notifications, nil
+}
+
+// merge records the IDs seen by a partial poll, keeping the previous ones
+// still listed
+func (w *Watcher) merge(seen map[int64]bool, ids []int64) {
+	for _, id := range ids {
+		if w.seen[id] {
+			seen[id] = true
+		}
+	}
+	w.seen = seen
+}
+
+// Watch polls the notifications on each interval, starting right away, and
+// delivers the new ones on the returned channel. The channel is closed when
+// the context is done.
+func (w *Watcher) Watch(ctx context.Context) <-chan Notification {
+	out := make(chan Notification)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(w.opts.Interval)
+		defer ticker.Stop()
+		for {
+			notifications, err := w.Poll(ctx)
+			if err != nil && ctx.Err() == nil && w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+			for _, notification := range notifications {
+				select {
+				case out <- notification:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// sortNotifications sorts notifications by date, then ID
+func sortNotifications(notifications []Notification) {
+	sort.Slice(notifications, func(i, j int) bool {
+		if !notifications[i].Date.Equal(notifications[j].Date) {
+			return notifications[i].Date.Before(notifications[j].Date)
+		}
+		return notifications[i].ID < notifications[j].ID
+	})
+}
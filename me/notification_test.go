@@ -0,0 +1,102 @@
+package me
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNotifications(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/notification/email/history", http.StatusOK, `[7]`)
+	server.Handle("GET", "/me/notification/email/history/7", http.StatusOK, `{"id": 7, "date": "2020-01-05T10:00:00+01:00", "subject": "Your bill", "fromEmail": "billing@ovh.net"}`)
+
+	// Test
+	ids, err := client.NotificationIDs(context.Background())
+	if err != nil {
+		t.Fatalf("NotificationIDs should not return an error. Got %v", err)
+	}
+	notification, err := client.Notification(context.Background(), ids[0])
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Notification should not return an error. Got %v", err)
+	}
+	if notification.ID != 7 || notification.Subject != "Your bill" || notification.Date.Day() != 5 {
+		t.Fatalf("Notification should decode the response. Got %+v", notification)
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/notification/email/history", http.StatusOK, `[1]`)
+	server.Handle("GET", "/me/notification/email/history/1", http.StatusOK, `{"id": 1, "subject": "old"}`)
+	server.Handle("GET", "/me/notification/email/history/2", http.StatusOK, `{"id": 2, "date": "2020-01-06T10:00:00Z", "subject": "new"}`)
+	server.Handle("GET", "/me/notification/email/history/3", http.StatusOK, `{"id": 3, "date": "2020-01-05T10:00:00Z", "subject": "newer ID"}`)
+	watcher := client.NewWatcher(nil)
+	ctx := context.Background()
+
+	// Test: existing notifications are skipped
+	notifications, err := watcher.Poll(ctx)
+	if err != nil || len(notifications) != 0 {
+		t.Fatalf("First poll should skip existing notifications. Got %v, %v", notifications, err)
+	}
+
+	// Test: new notifications are reported once, oldest first
+	server.Handle("GET", "/me/notification/email/history", http.StatusOK, `[1, 2, 3]`)
+	notifications, err = watcher.Poll(ctx)
+	if err != nil || len(notifications) != 2 || notifications[0].ID != 3 || notifications[1].ID != 2 {
+		t.Fatalf("Poll should report new notifications by date. Got %+v, %v", notifications, err)
+	}
+	notifications, err = watcher.Poll(ctx)
+	if err != nil || len(notifications) != 0 {
+		t.Fatalf("Poll should not report notifications twice. Got %+v, %v", notifications, err)
+	}
+
+	// Test: notifications failing to be fetched are reported later
+	server.Handle("GET", "/me/notification/email/history", http.StatusOK, `[1, 2, 3, 4]`)
+	if _, err = watcher.Poll(ctx); err == nil {
+		t.Fatalf("Poll should fail when a notification can not be fetched")
+	}
+	server.Handle("GET", "/me/notification/email/history/4", http.StatusOK, `{"id": 4}`)
+	notifications, err = watcher.Poll(ctx)
+	if err != nil || len(notifications) != 1 || notifications[0].ID != 4 {
+		t.Fatalf("Poll should report notifications failing previously. Got %+v, %v", notifications, err)
+	}
+}
+
+func TestWatcherWatch(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/notification/email/history", http.StatusOK, `[1, 2]`)
+	server.Handle("GET", "/me/notification/email/history/1", http.StatusOK, `{"id": 1}`)
+	var pollErr error
+	watcher := client.NewWatcher(&WatchOptions{
+		Interval:        time.Hour,
+		IncludeExisting: true,
+		OnError:         func(err error) { pollErr = err },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Test
+	notifications := watcher.Watch(ctx)
+	notification := <-notifications
+	cancel()
+
+	// Validate
+	if notification.ID != 1 {
+		t.Fatalf("Watch should deliver existing notifications when asked to. Got %+v", notification)
+	}
+	if _, ok := <-notifications; ok {
+		t.Fatalf("Watch should close the channel when the context is done")
+	}
+	if pollErr == nil {
+		t.Fatalf("Watch should report polling errors. Got %v", pollErr)
+	}
+}
@@ -0,0 +1,48 @@
+package me
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PaymentMethodIcon represents the icon of a payment method
+type PaymentMethodIcon struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// PaymentMethod represents a payment method of the account.
+// Visit https://api.ovh.com/console/#/me/payment/method/%7BpaymentMethodId%7D#GET for the full definition
+type PaymentMethod struct {
+	PaymentMethodID int64             `json:"paymentMethodId"`
+	PaymentType     string            `json:"paymentType"`
+	Status          string            `json:"status"`
+	Default         bool              `json:"default"`
+	Description     string            `json:"description"`
+	Label           string            `json:"label"`
+	Icon            PaymentMethodIcon `json:"icon"`
+	CreationDate    time.Time         `json:"creationDate"`
+	ExpirationDate  time.Time         `json:"expirationDate"`
+	LastUpdate      time.Time         `json:"lastUpdate"`
+}
+
+// PaymentMethodIDs lists the IDs of the payment methods, with
+// GET /me/payment/method
+func (c *Client) PaymentMethodIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, "/me/payment/method", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// PaymentMethod returns a payment method, with
+// GET /me/payment/method/{paymentMethodId}
+func (c *Client) PaymentMethod(ctx context.Context, paymentMethodID int64) (*PaymentMethod, error) {
+	method := &PaymentMethod{}
+	if err := c.client.GetWithContext(ctx, fmt.Sprintf("/me/payment/method/%d", paymentMethodID), method); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
@@ -0,0 +1,41 @@
+package me
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPaymentMethods(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me/payment/method", http.StatusOK, `[7]`)
+	server.Handle("GET", "/me/payment/method/7", http.StatusOK, `{
+			"paymentMethodId": 7,
+			"paymentType": "CREDIT_CARD",
+			"status": "VALID",
+			"default": true,
+			"description": null,
+			"label": "************1234",
+			"icon": {"name": "visa", "url": "https://example.com/visa.png"},
+			"creationDate": "2020-01-01T10:00:00+01:00",
+			"expirationDate": "2025-01-31T00:00:00+01:00",
+			"lastUpdate": "2020-01-01T10:00:00+01:00"
+		}`)
+
+	// Test
+	ids, err := client.PaymentMethodIDs(context.Background())
+	if err != nil {
+		t.Fatalf("PaymentMethodIDs should not return an error. Got %v", err)
+	}
+	method, err := client.PaymentMethod(context.Background(), ids[0])
+	if err != nil {
+		t.Fatalf("PaymentMethod should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if method.PaymentMethodID != 7 || !method.Default || method.Icon.Name != "visa" || method.ExpirationDate.Year() != 2025 {
+		t.Fatalf("PaymentMethod should decode the response. Got %+v", method)
+	}
+}
@@ -0,0 +1,286 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Pricing capacities
+const (
+	CapacityInstallation = "installation"
+	CapacityRenew        = "renew"
+	CapacityConsumption  = "consumption"
+	CapacityUpgrade      = "upgrade"
+	CapacityDowngrade    = "downgrade"
+	CapacityDetach       = "detach"
+	CapacityDynamic      = "dynamic"
+)
+
+// Pricing interval units
+const (
+	IntervalUnitHour  = "hour"
+	IntervalUnitDay   = "day"
+	IntervalUnitMonth = "month"
+	IntervalUnitYear  = "year"
+	IntervalUnitNone  = "none"
+)
+
+// DefaultPricingMode is the pricing mode without commitment
+const DefaultPricingMode = "default"
+
+// HoursPerMonth is the number of hours billed for a month of an hourly priced
+// plan, used to compare monthly and hourly prices
+const HoursPerMonth = 730
+
+// UcentsPerUnit is the number of micro-cents of a currency unit, as used by
+// the catalog prices
+const UcentsPerUnit = 100000000
+
+// CatalogLocale holds the currency and tax rate of a catalog
+type CatalogLocale struct {
+	CurrencyCode string  `json:"currencyCode"`
+	Subsidiary   string  `json:"subsidiary"`
+	TaxRate      float64 `json:"taxRate"`
+}
+
+// Range bounds a quantity or a number of repetitions
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Pricing represents a price of a plan, for some capacities like
+// CapacityRenew. Prices are in micro-cents, see UcentsPerUnit, without tax.
+type Pricing struct {
+	Phase        int      `json:"phase"`
+	Capacities   []string `json:"capacities"`
+	Commitment   int      `json:"commitment"`
+	Description  string   `json:"description"`
+	Interval     int      `json:"interval"`
+	IntervalUnit string   `json:"intervalUnit"`
+	Quantity     Range    `json:"quantity"`
+	Repeat       Range    `json:"repeat"`
+	Price        int64    `json:"price"`
+	Tax          int64    `json:"tax"`
+	Mode         string   `json:"mode"`
+	Strategy     string   `json:"strategy"`
+	Type         string   `json:"type"`
+}
+
+// HasCapacity reports whether the pricing applies to a capacity
+func (p *Pricing) HasCapacity(capacity string) bool {
+	for _, c := range p.Capacities {
+		if c == capacity {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanConfiguration is a configuration of a plan, like its datacenter
+type PlanConfiguration struct {
+	Name        string   `json:"name"`
+	IsCustom    bool     `json:"isCustom"`
+	IsMandatory bool     `json:"isMandatory"`
+	Values      []string `json:"values"`
+}
+
+// AddonFamily lists the plan codes of the addons which may be ordered with a
+// plan. At most one of an exclusive family may be chosen.
+type AddonFamily struct {
+	Name      string   `json:"name"`
+	Exclusive bool     `json:"exclusive"`
+	Mandatory bool     `json:"mandatory"`
+	Addons    []string `json:"addons"`
+	Default   string   `json:"default"`
+}
+
+// Plan represents a plan or an addon of a catalog
+type Plan struct {
+	PlanCode       string              `json:"planCode"`
+	InvoiceName    string              `json:"invoiceName"`
+	Product        string              `json:"product"`
+	PricingType    string              `json:"pricingType"`
+	Family         string              `json:"family"`
+	Pricings       []Pricing           `json:"pricings"`
+	Configurations []PlanConfiguration `json:"configurations"`
+	AddonFamilies  []AddonFamily       `json:"addonFamilies"`
+}
+
+// Product describes a product of a catalog
+type Product struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Catalog represents the public catalog of a product line, like "vps".
+// Visit https://api.ovh.com/console/#/order/catalog/public/vps#GET for the full definition
+type Catalog struct {
+	CatalogID int64         `json:"catalogId"`
+	Locale    CatalogLocale `json:"locale"`
+	Plans     []Plan        `json:"plans"`
+	Addons    []Plan        `json:"addons"`
+	Products  []Product     `json:"products"`
+}
+
+// PublicCatalog returns the public catalog of a product line, like "vps",
+// "cloud" or "baremetalServers", for a subsidiary like "FR", with
+// GET /order/catalog/public/{product}. It does not need authentication.
+func (c *Client) PublicCatalog(ctx context.Context, product, subsidiary string) (*Catalog, error) {
+	catalog := &Catalog{}
+	path := fmt.Sprintf("/order/catalog/public/%s?ovhSubsidiary=%s", url.PathEscape(product), url.QueryEscape(subsidiary))
+	if err := c.client.CallAPIWithContext(ctx, "GET", path, nil, catalog, false); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// Plan returns a plan or an addon of the catalog, or nil when not found
+func (c *Catalog) Plan(planCode string) *Plan {
+	for _, plans := range [][]Plan{c.Plans, c.Addons} {
+		for i := range plans {
+			if plans[i].PlanCode == planCode {
+				return &plans[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Pricing returns the pricing of a plan for a mode and a capacity, of the
+// last phase when the price changes over time, or nil when not found
+func (p *Plan) Pricing(mode, capacity string) *Pricing {
+	var pricing *Pricing
+	for i := range p.Pricings {
+		current := &p.Pricings[i]
+		if current.Mode != mode || !current.HasCapacity(capacity) {
+			continue
+		}
+		if pricing == nil || current.Phase > pricing.Phase {
+			pricing = current
+		}
+	}
+	return pricing
+}
+
+// PriceRequest selects a plan, its addons and a pricing mode to estimate
+type PriceRequest struct {
+	PlanCode string
+	// Addons are plan codes of addons of the catalog, ordered with the plan
+	Addons []string
+	// Mode is the pricing mode, DefaultPricingMode when empty
+	Mode string
+	// Quantity of the plan and addons, 1 when not positive
+	Quantity int
+}
+
+// PriceLine is the price of one plan or addon of an estimate
+type PriceLine struct {
+	PlanCode             string
+	MonthlyInUcents      int64
+	HourlyInUcents       int64
+	InstallationInUcents int64
+}
+
+// PriceEstimate holds the prices of a plan and its addons, without tax, in
+// micro-cents. The hourly price of a monthly plan, and the monthly price of
+// an hourly plan, are derived with HoursPerMonth.
+type PriceEstimate struct {
+	CurrencyCode         string
+	MonthlyInUcents      int64
+	HourlyInUcents       int64
+	InstallationInUcents int64
+	Lines                []PriceLine
+}
+
+// Monthly returns the monthly price in currency units
+func (e *PriceEstimate) Monthly() float64 {
+	return float64(e.MonthlyInUcents) / UcentsPerUnit
+}
+
+// Hourly returns the hourly price in currency units
+func (e *PriceEstimate) Hourly() float64 {
+	return float64(e.HourlyInUcents) / UcentsPerUnit
+}
+
+// Installation returns the installation fees in currency units
+func (e *PriceEstimate) Installation() float64 {
+	return float64(e.InstallationInUcents) / UcentsPerUnit
+}
+
+// EstimatePrice computes the monthly and hourly prices of a plan and its
+// addons, from their renewal or consumption pricings. It fails when a plan
+// code is not in the catalog or has no recurring pricing for the mode.
+func (c *Catalog) EstimatePrice(request PriceRequest) (*PriceEstimate, error) {
+	mode := request.Mode
+	if mode == "" {
+		mode = DefaultPricingMode
+	}
+	quantity := int64(request.Quantity)
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	estimate := &PriceEstimate{CurrencyCode: c.Locale.CurrencyCode}
+	for _, planCode := range append([]string{request.PlanCode}, request.Addons...) {
+		plan := c.Plan(planCode)
+		if plan == nil {
+			return nil, fmt.Errorf("order: plan %s is not in the catalog", planCode)
+		}
+		line, err := plan.priceLine(mode)
+		if err != nil {
+			return nil, err
+		}
+		line.MonthlyInUcents *= quantity
+		line.HourlyInUcents *= quantity
+		line.InstallationInUcents *= quantity
+
+		estimate.MonthlyInUcents += line.MonthlyInUcents
+		estimate.HourlyInUcents += line.HourlyInUcents
+		estimate.InstallationInUcents += line.InstallationInUcents
+		estimate.Lines = append(estimate.Lines, line)
+	}
+	return estimate, nil
+}
+
+// priceLine computes the unit prices of a plan for a pricing mode
+func (p *Plan) priceLine(mode string) (PriceLine, error) {
+	line := PriceLine{PlanCode: p.PlanCode}
+	if installation := p.Pricing(mode, CapacityInstallation); installation != nil {
+		line.InstallationInUcents = installation.Price
+	}
+
+	// Hourly plans are billed on consumption, monthly ones on renewal
+	if consumption := p.Pricing(mode, CapacityConsumption); consumption != nil && consumption.IntervalUnit == IntervalUnitHour {
+		line.HourlyInUcents = consumption.Price / int64(interval(consumption))
+		line.MonthlyInUcents = line.HourlyInUcents * HoursPerMonth
+		return line, nil
+	}
+	renew := p.Pricing(mode, CapacityRenew)
+	if renew == nil {
+		return line, fmt.Errorf("order: plan %s has no %s recurring pricing", p.PlanCode, mode)
+	}
+	switch renew.IntervalUnit {
+	case IntervalUnitMonth:
+		line.MonthlyInUcents = renew.Price / int64(interval(renew))
+	case IntervalUnitYear:
+		line.MonthlyInUcents = renew.Price / int64(12*interval(renew))
+	case IntervalUnitHour:
+		line.HourlyInUcents = renew.Price / int64(interval(renew))
+		line.MonthlyInUcents = line.HourlyInUcents * HoursPerMonth
+		return line, nil
+	default:
+		return line, fmt.Errorf("order: plan %s has an unsupported %q pricing interval", p.PlanCode, renew.IntervalUnit)
+	}
+	line.HourlyInUcents = line.MonthlyInUcents / HoursPerMonth
+	return line, nil
+}
+
+// interval returns the interval of a pricing, at least 1
+func interval(pricing *Pricing) int {
+	if pricing.Interval < 1 {
+		return 1
+	}
+	return pricing.Interval
+}
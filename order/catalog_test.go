@@ -0,0 +1,132 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const testCatalog = `{
+	"catalogId": 1234,
+	"locale": {"currencyCode": "EUR", "subsidiary": "FR", "taxRate": 20},
+	"plans": [
+		{
+			"planCode": "vps-starter-1-2-20",
+			"invoiceName": "VPS Starter",
+			"product": "vps-starter",
+			"pricings": [
+				{"phase": 0, "capacities": ["installation"], "mode": "default", "interval": 1, "intervalUnit": "month", "price": 0},
+				{"phase": 0, "capacities": ["renew"], "mode": "default", "interval": 1, "intervalUnit": "month", "price": 365000000},
+				{"phase": 1, "capacities": ["renew"], "mode": "default", "interval": 1, "intervalUnit": "month", "price": 730000000},
+				{"phase": 1, "capacities": ["renew"], "mode": "upfront12", "commitment": 12, "interval": 12, "intervalUnit": "month", "price": 7200000000}
+			],
+			"configurations": [{"name": "vps_datacenter", "isMandatory": true, "values": ["GRA", "SBG"]}],
+			"addonFamilies": [{"name": "snapshot", "addons": ["option-snapshot-vps-starter"]}]
+		},
+		{
+			"planCode": "b2-7.consumption",
+			"invoiceName": "b2-7",
+			"pricings": [
+				{"phase": 1, "capacities": ["consumption"], "mode": "default", "interval": 1, "intervalUnit": "hour", "price": 6000000}
+			]
+		}
+	],
+	"addons": [
+		{
+			"planCode": "option-snapshot-vps-starter",
+			"pricings": [
+				{"phase": 1, "capacities": ["installation"], "mode": "default", "interval": 1, "intervalUnit": "none", "price": 100000000},
+				{"phase": 1, "capacities": ["renew"], "mode": "default", "interval": 1, "intervalUnit": "year", "price": 1460000000}
+			]
+		}
+	]
+}`
+
+func TestPublicCatalog(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/order/catalog/public/vps?ovhSubsidiary=FR", http.StatusOK, testCatalog)
+
+	// Test
+	catalog, err := client.PublicCatalog(context.Background(), "vps", "FR")
+	if err != nil {
+		t.Fatalf("PublicCatalog should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if catalog.Locale.CurrencyCode != "EUR" || len(catalog.Plans) != 2 || len(catalog.Addons) != 1 {
+		t.Fatalf("Catalog should be decoded. Got %+v", catalog)
+	}
+	plan := catalog.Plan("vps-starter-1-2-20")
+	if plan == nil || plan.Configurations[0].Values[1] != "SBG" || plan.AddonFamilies[0].Addons[0] != "option-snapshot-vps-starter" {
+		t.Fatalf("Plan should return the plan. Got %+v", plan)
+	}
+	if pricing := plan.Pricing(DefaultPricingMode, CapacityRenew); pricing == nil || pricing.Price != 730000000 {
+		t.Fatalf("Pricing should return the pricing of the last phase. Got %+v", pricing)
+	}
+	if catalog.Plan("option-snapshot-vps-starter") == nil || catalog.Plan("unknown") != nil {
+		t.Fatal("Plan should look up addons too")
+	}
+	if requests := server.Requests(); requests[0].Authenticated {
+		t.Fatal("PublicCatalog should not be authenticated")
+	}
+}
+
+func TestEstimatePrice(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/order/catalog/public/vps", http.StatusOK, testCatalog)
+	catalog, err := client.PublicCatalog(context.Background(), "vps", "FR")
+	if err != nil {
+		t.Fatalf("PublicCatalog should not return an error. Got %v", err)
+	}
+
+	// Test
+	monthly, err := catalog.EstimatePrice(PriceRequest{PlanCode: "vps-starter-1-2-20", Addons: []string{"option-snapshot-vps-starter"}, Quantity: 2})
+	if err != nil {
+		t.Fatalf("EstimatePrice should not return an error. Got %v", err)
+	}
+	committed, err := catalog.EstimatePrice(PriceRequest{PlanCode: "vps-starter-1-2-20", Mode: "upfront12"})
+	if err != nil {
+		t.Fatalf("EstimatePrice should not return an error. Got %v", err)
+	}
+	hourly, err := catalog.EstimatePrice(PriceRequest{PlanCode: "b2-7.consumption"})
+	if err != nil {
+		t.Fatalf("EstimatePrice should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if monthly.CurrencyCode != "EUR" || monthly.MonthlyInUcents != 1703333332 || monthly.HourlyInUcents != 2333332 || monthly.Installation() != 2.0 {
+		t.Fatalf("EstimatePrice should sum the plan and addons. Got %+v", monthly)
+	}
+	if len(monthly.Lines) != 2 || monthly.Lines[1].MonthlyInUcents != 2*121666666 {
+		t.Fatalf("EstimatePrice should detail each plan. Got %+v", monthly.Lines)
+	}
+	if committed.Monthly() != 6.0 {
+		t.Fatalf("EstimatePrice should spread the price over the interval. Got %+v", committed)
+	}
+	if hourly.Hourly() != 0.06 || hourly.Monthly() != 43.8 {
+		t.Fatalf("EstimatePrice should derive the monthly price of hourly plans. Got %+v", hourly)
+	}
+}
+
+func TestEstimatePriceErrors(t *testing.T) {
+	// Init test
+	catalog := &Catalog{Plans: []Plan{{PlanCode: "vps-starter-1-2-20", Pricings: []Pricing{
+		{Capacities: []string{CapacityRenew}, Mode: DefaultPricingMode, Interval: 1, IntervalUnit: IntervalUnitMonth, Price: 730000000},
+	}}}}
+
+	// Test
+	_, unknown := catalog.EstimatePrice(PriceRequest{PlanCode: "vps-starter-1-2-20", Addons: []string{"unknown"}})
+	_, mode := catalog.EstimatePrice(PriceRequest{PlanCode: "vps-starter-1-2-20", Mode: "upfront12"})
+
+	// Validate
+	if unknown == nil || unknown.Error() != "order: plan unknown is not in the catalog" {
+		t.Fatalf("EstimatePrice should fail on unknown plans. Got %v", unknown)
+	}
+	if mode == nil || mode.Error() != "order: plan vps-starter-1-2-20 has no upfront12 recurring pricing" {
+		t.Fatalf("EstimatePrice should fail without pricing for the mode. Got %v", mode)
+	}
+}
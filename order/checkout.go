@@ -0,0 +1,83 @@
+package order
+
+import (
+	"context"
+)
+
+// Prices holds the total prices of an order
+type Prices struct {
+	WithTax    Price `json:"withTax"`
+	WithoutTax Price `json:"withoutTax"`
+	Tax        Price `json:"tax"`
+}
+
+// Detail represents a line of an order
+type Detail struct {
+	Description string `json:"description"`
+	DetailType  string `json:"detailType"`
+	Domain      string `json:"domain"`
+	Quantity    int    `json:"quantity"`
+	TotalPrice  Price  `json:"totalPrice"`
+	UnitPrice   Price  `json:"unitPrice"`
+}
+
+// Contract represents a contract to accept with an order
+type Contract struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Content string `json:"content"`
+}
+
+// Order represents the quotation of a cart or, once checked out, the
+// resulting order.
+// Visit https://api.ovh.com/console/#/order/cart/%7BcartId%7D/checkout#GET for the full definition
+type Order struct {
+	OrderID   int64      `json:"orderId"`
+	URL       string     `json:"url"`
+	Prices    Prices     `json:"prices"`
+	Details   []Detail   `json:"details"`
+	Contracts []Contract `json:"contracts"`
+}
+
+// CheckoutOptions holds the options of a checkout
+type CheckoutOptions struct {
+	// AutoPayWithPreferredPaymentMethod pays the order with the default
+	// payment method of the account
+	AutoPayWithPreferredPaymentMethod bool `json:"autoPayWithPreferredPaymentMethod"`
+
+	// WaiveRetractationPeriod waives the retractation period, so that the
+	// services are delivered immediately
+	WaiveRetractationPeriod bool `json:"waiveRetractationPeriod"`
+}
+
+// Quote returns the quotation of an assigned cart, without ordering it, with
+// GET /order/cart/{cartId}/checkout
+func (c *Client) Quote(ctx context.Context, cartID string) (*Order, error) {
+	order := &Order{}
+	if err := c.client.GetWithContext(ctx, cartPath(cartID, "/checkout"), order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// ValidateCart orders an assigned cart, with POST /order/cart/{cartId}/checkout
+func (c *Client) ValidateCart(ctx context.Context, cartID string, options *CheckoutOptions) (*Order, error) {
+	if options == nil {
+		options = &CheckoutOptions{}
+	}
+	order := &Order{}
+	if err := c.client.PostWithContext(ctx, cartPath(cartID, "/checkout"), options, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Checkout assigns a cart to the account of the currently logged-in user and
+// orders it. The order must then be paid, unless
+// AutoPayWithPreferredPaymentMethod is set: its URL is in the returned Order.
+func (c *Client) Checkout(ctx context.Context, cartID string, options *CheckoutOptions) (*Order, error) {
+	if err := c.AssignCart(ctx, cartID); err != nil {
+		return nil, err
+	}
+	return c.ValidateCart(ctx, cartID, options)
+}
@@ -0,0 +1,64 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+func TestCheckout(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	order := `{"orderId": 1234, "url": "https://www.ovh.com/cgi-bin/order/display.cgi?orderId=1234", "prices": {"withTax": {"currencyCode": "EUR", "text": "3.60 €", "value": 3.6}, "withoutTax": {"value": 3}, "tax": {"value": 0.6}}, "details": [{"description": "VPS Starter", "detailType": "DURATION", "quantity": 1, "totalPrice": {"value": 3}}], "contracts": [{"name": "General conditions", "url": "https://example.com/cg.pdf"}]}`
+	server.Handle("POST", "/order/cart/a1b2/assign", http.StatusOK, nil)
+	server.Handle("GET", "/order/cart/a1b2/checkout", http.StatusOK, order)
+	server.Handle("POST", "/order/cart/a1b2/checkout", http.StatusOK, order)
+	ctx := context.Background()
+
+	// Test
+	quote, err := client.Quote(ctx, "a1b2")
+	if err != nil {
+		t.Fatalf("Quote should not return an error. Got %v", err)
+	}
+	result, err := client.Checkout(ctx, "a1b2", &CheckoutOptions{AutoPayWithPreferredPaymentMethod: true})
+	if err != nil {
+		t.Fatalf("Checkout should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if quote.Prices.WithTax.Value != 3.6 || quote.Details[0].Description != "VPS Starter" || len(quote.Contracts) != 1 {
+		t.Fatalf("Quote should be decoded. Got %+v", quote)
+	}
+	if result.OrderID != 1234 {
+		t.Fatalf("Checkout should return the order. Got %+v", result)
+	}
+	requests := server.Requests()
+	if len(requests) != 3 || requests[1].Path != "/order/cart/a1b2/assign" || requests[2].Method != "POST" {
+		t.Fatalf("Checkout should assign then validate the cart. Got %+v", requests)
+	}
+	if body := string(requests[2].Body); body != `{"autoPayWithPreferredPaymentMethod":true,"waiveRetractationPeriod":false}` {
+		t.Fatalf("Checkout should send the options. Got %s", body)
+	}
+}
+
+func TestCheckoutAssignError(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/order/cart/a1b2/assign", http.StatusBadRequest, `{"message": "The cart is expired"}`)
+
+	// Test
+	_, err := client.Checkout(context.Background(), "a1b2", nil)
+
+	// Validate
+	apiErr, ok := err.(*ovh.APIError)
+	if !ok || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("Checkout should return the assign error. Got %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 1 {
+		t.Fatalf("Checkout should stop after the assign error. Got %+v", requests)
+	}
+}
@@ -0,0 +1,123 @@
+package order
+
+import (
+	"context"
+	"net/url"
+)
+
+// OfferPrice represents a price of an offer, for a duration and pricing mode
+type OfferPrice struct {
+	Duration      string `json:"duration"`
+	PricingMode   string `json:"pricingMode"`
+	PriceInUcents int64  `json:"priceInUcents"`
+	Price         Price  `json:"price"`
+}
+
+// Offer represents a product offer which may be added to a cart.
+// Visit https://api.ovh.com/console/#/order/cart/%7BcartId%7D/vps#GET for the full definition
+type Offer struct {
+	PlanCode    string       `json:"planCode"`
+	ProductName string       `json:"productName"`
+	ProductType string       `json:"productType"`
+	Prices      []OfferPrice `json:"prices"`
+}
+
+// ItemSettings holds the plan of a cart item
+type ItemSettings struct {
+	PlanCode    string `json:"planCode"`
+	PricingMode string `json:"pricingMode"`
+	Quantity    int    `json:"quantity"`
+}
+
+// ItemPrice represents a price of a cart item
+type ItemPrice struct {
+	Label string `json:"label"`
+	Price Price  `json:"price"`
+}
+
+// Item represents an item of a cart.
+// Visit https://api.ovh.com/console/#/order/cart/%7BcartId%7D/item/%7BitemId%7D#GET for the full definition
+type Item struct {
+	ItemID         int64        `json:"itemId"`
+	CartID         string       `json:"cartId"`
+	ProductID      string       `json:"productId"`
+	Duration       string       `json:"duration"`
+	Settings       ItemSettings `json:"settings"`
+	Prices         []ItemPrice  `json:"prices"`
+	Configurations []int64      `json:"configurations"`
+	Options        []int64      `json:"options"`
+}
+
+// ItemCreation holds the parameters of a new cart item. Durations are ISO
+// 8601 periods, like "P1M".
+type ItemCreation struct {
+	PlanCode    string `json:"planCode"`
+	Duration    string `json:"duration"`
+	PricingMode string `json:"pricingMode"`
+	Quantity    int    `json:"quantity"`
+}
+
+// Configuration represents a configuration of a cart item, like the
+// datacenter of a server
+type Configuration struct {
+	ID    int64  `json:"id"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Offers lists the offers of a product which may be added to a cart, with
+// GET /order/cart/{cartId}/{productName}
+func (c *Client) Offers(ctx context.Context, cartID, product string) ([]Offer, error) {
+	offers := []Offer{}
+	if err := c.client.GetWithContext(ctx, cartPath(cartID, "/%s", url.PathEscape(product)), &offers); err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+// AddItem adds an offer of a product to a cart, with
+// POST /order/cart/{cartId}/{productName}
+func (c *Client) AddItem(ctx context.Context, cartID, product string, creation *ItemCreation) (*Item, error) {
+	item := &Item{}
+	if err := c.client.PostWithContext(ctx, cartPath(cartID, "/%s", url.PathEscape(product)), creation, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Items lists the IDs of the items of a cart, with GET /order/cart/{cartId}/item
+func (c *Client) Items(ctx context.Context, cartID string) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, cartPath(cartID, "/item"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Item returns an item of a cart, with GET /order/cart/{cartId}/item/{itemId}
+func (c *Client) Item(ctx context.Context, cartID string, itemID int64) (*Item, error) {
+	item := &Item{}
+	if err := c.client.GetWithContext(ctx, cartPath(cartID, "/item/%d", itemID), item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem removes an item from a cart, with
+// DELETE /order/cart/{cartId}/item/{itemId}
+func (c *Client) DeleteItem(ctx context.Context, cartID string, itemID int64) error {
+	return c.client.DeleteWithContext(ctx, cartPath(cartID, "/item/%d", itemID), nil)
+}
+
+// AddConfiguration sets a configuration of a cart item, with
+// POST /order/cart/{cartId}/item/{itemId}/configuration. The required
+// configurations of an item are listed by
+// GET /order/cart/{cartId}/item/{itemId}/requiredConfiguration.
+func (c *Client) AddConfiguration(ctx context.Context, cartID string, itemID int64, label, value string) (*Configuration, error) {
+	configuration := &Configuration{}
+	body := map[string]string{"label": label, "value": value}
+	if err := c.client.PostWithContext(ctx, cartPath(cartID, "/item/%d/configuration", itemID), body, configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
@@ -0,0 +1,64 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestItems(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	item := `{"itemId": 42, "cartId": "a1b2", "productId": "vps", "duration": "P1M", "settings": {"planCode": "vps-starter", "pricingMode": "default", "quantity": 1}, "prices": [{"label": "TOTAL", "price": {"currencyCode": "EUR", "text": "3.00 €", "value": 3}}], "configurations": [7]}`
+	server.Handle("GET", "/order/cart/a1b2/vps", http.StatusOK, `[{"planCode": "vps-starter", "productName": "VPS Starter", "productType": "delivery", "prices": [{"duration": "P1M", "pricingMode": "default", "priceInUcents": 300000000, "price": {"currencyCode": "EUR", "text": "3.00 €", "value": 3}}]}]`)
+	server.Handle("POST", "/order/cart/a1b2/vps", http.StatusOK, item)
+	server.Handle("GET", "/order/cart/a1b2/item", http.StatusOK, `[42]`)
+	server.Handle("GET", "/order/cart/a1b2/item/42", http.StatusOK, item)
+	server.Handle("POST", "/order/cart/a1b2/item/42/configuration", http.StatusOK, `{"id": 7, "label": "vps_datacenter", "value": "GRA"}`)
+	server.Handle("DELETE", "/order/cart/a1b2/item/42", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	offers, err := client.Offers(ctx, "a1b2", "vps")
+	if err != nil {
+		t.Fatalf("Offers should not return an error. Got %v", err)
+	}
+	added, err := client.AddItem(ctx, "a1b2", "vps", &ItemCreation{PlanCode: offers[0].PlanCode, Duration: "P1M", PricingMode: "default", Quantity: 1})
+	if err != nil {
+		t.Fatalf("AddItem should not return an error. Got %v", err)
+	}
+	ids, err := client.Items(ctx, "a1b2")
+	if err != nil {
+		t.Fatalf("Items should not return an error. Got %v", err)
+	}
+	fetched, err := client.Item(ctx, "a1b2", ids[0])
+	if err != nil {
+		t.Fatalf("Item should not return an error. Got %v", err)
+	}
+	configuration, err := client.AddConfiguration(ctx, "a1b2", added.ItemID, "vps_datacenter", "GRA")
+	if err != nil {
+		t.Fatalf("AddConfiguration should not return an error. Got %v", err)
+	}
+	if err := client.DeleteItem(ctx, "a1b2", 42); err != nil {
+		t.Fatalf("DeleteItem should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(offers) != 1 || offers[0].Prices[0].PriceInUcents != 300000000 {
+		t.Fatalf("Offers should be decoded. Got %+v", offers)
+	}
+	if fetched.Settings.PlanCode != "vps-starter" || fetched.Prices[0].Price.Value != 3 || fetched.Configurations[0] != 7 {
+		t.Fatalf("Item should be decoded. Got %+v", fetched)
+	}
+	if configuration.ID != 7 {
+		t.Fatalf("Configuration should be decoded. Got %+v", configuration)
+	}
+	requests := server.Requests()
+	if string(requests[1].Body) != `{"planCode":"vps-starter","duration":"P1M","pricingMode":"default","quantity":1}` {
+		t.Fatalf("AddItem should send the item creation. Got %s", requests[1].Body)
+	}
+	if body := string(requests[4].Body); body != `{"label":"vps_datacenter","value":"GRA"}` {
+		t.Fatalf("AddConfiguration should send the configuration. Got %s", body)
+	}
+}
@@ -0,0 +1,110 @@
+// Package order provides typed helpers for the OVH ordering API, under
+// /order/cart: creating a cart, adding offers to it, assigning it to the
+// account and checking it out. Public catalogs, under /order/catalog/public,
+// give the plans and their prices, see Catalog.EstimatePrice.
+//
+// A typical order is:
+//
+//	orders := order.New(client)
+//	cart, err := orders.CreateCart(ctx, &order.CartCreation{OvhSubsidiary: "FR"})
+//	item, err := orders.AddItem(ctx, cart.CartID, "vps", &order.ItemCreation{
+//		PlanCode: "vps-starter-1-2-20", Duration: "P1M", PricingMode: "default", Quantity: 1,
+//	})
+//	_, err = orders.AddConfiguration(ctx, cart.CartID, item.ItemID, "vps_datacenter", "GRA")
+//	result, err := orders.Checkout(ctx, cart.CartID, nil)
+//
+// The price of a plan and its addons is estimated from the catalog:
+//
+//	catalog, err := orders.PublicCatalog(ctx, "vps", "FR")
+//	estimate, err := catalog.EstimatePrice(order.PriceRequest{PlanCode: "vps-starter-1-2-20"})
+//	fmt.Printf("%.2f %s per month\n", estimate.Monthly(), estimate.CurrencyCode)
+package order
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Price represents an amount of money. It is the price type of the whole API,
+// used by the me and billing packages as well.
+type Price struct {
+	CurrencyCode string  `json:"currencyCode"`
+	Text         string  `json:"text"`
+	Value        float64 `json:"value"`
+}
+
+// Cart represents an order cart.
+// Visit https://api.ovh.com/console/#/order/cart/%7BcartId%7D#GET for the full definition
+type Cart struct {
+	CartID      string    `json:"cartId"`
+	Description string    `json:"description"`
+	Expire      time.Time `json:"expire"`
+	ReadOnly    bool      `json:"readOnly"`
+	Items       []int64   `json:"items"`
+}
+
+// CartCreation holds the parameters of a new cart. OvhSubsidiary, like "FR"
+// or "GB", is required.
+type CartCreation struct {
+	OvhSubsidiary string     `json:"ovhSubsidiary"`
+	Description   string     `json:"description,omitempty"`
+	Expire        *time.Time `json:"expire,omitempty"`
+}
+
+// Client gives access to the /order routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns an order client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// cartPath returns the path of a route of a cart
+func cartPath(cartID, format string, args ...interface{}) string {
+	return fmt.Sprintf("/order/cart/%s", url.PathEscape(cartID)) + fmt.Sprintf(format, args...)
+}
+
+// Carts lists the IDs of the carts, with GET /order/cart
+func (c *Client) Carts(ctx context.Context) ([]string, error) {
+	ids := []string{}
+	if err := c.client.GetWithContext(ctx, "/order/cart", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateCart creates a cart, with POST /order/cart
+func (c *Client) CreateCart(ctx context.Context, creation *CartCreation) (*Cart, error) {
+	cart := &Cart{}
+	if err := c.client.PostWithContext(ctx, "/order/cart", creation, cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// Cart returns a cart, with GET /order/cart/{cartId}
+func (c *Client) Cart(ctx context.Context, cartID string) (*Cart, error) {
+	cart := &Cart{}
+	if err := c.client.GetWithContext(ctx, cartPath(cartID, ""), cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// DeleteCart deletes a cart, with DELETE /order/cart/{cartId}
+func (c *Client) DeleteCart(ctx context.Context, cartID string) error {
+	return c.client.DeleteWithContext(ctx, cartPath(cartID, ""), nil)
+}
+
+// AssignCart assigns a cart, created without authentication, to the account
+// of the currently logged-in user, with POST /order/cart/{cartId}/assign. A
+// cart must be assigned to be checked out.
+func (c *Client) AssignCart(ctx context.Context, cartID string) error {
+	return c.client.PostWithContext(ctx, cartPath(cartID, "/assign"), nil, nil)
+}
@@ -0,0 +1,63 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestCart(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	cart := `{"cartId": "a1b2", "description": "test", "expire": "2020-01-02T10:00:00+01:00", "readOnly": false, "items": [42]}`
+	server.Handle("POST", "/order/cart", http.StatusOK, cart)
+	server.Handle("GET", "/order/cart", http.StatusOK, `["a1b2"]`)
+	server.Handle("GET", "/order/cart/a1b2", http.StatusOK, cart)
+	server.Handle("POST", "/order/cart/a1b2/assign", http.StatusOK, nil)
+	server.Handle("DELETE", "/order/cart/a1b2", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	created, err := client.CreateCart(ctx, &CartCreation{OvhSubsidiary: "FR", Description: "test"})
+	if err != nil {
+		t.Fatalf("CreateCart should not return an error. Got %v", err)
+	}
+	ids, err := client.Carts(ctx)
+	if err != nil {
+		t.Fatalf("Carts should not return an error. Got %v", err)
+	}
+	fetched, err := client.Cart(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("Cart should not return an error. Got %v", err)
+	}
+	if err := client.AssignCart(ctx, "a1b2"); err != nil {
+		t.Fatalf("AssignCart should not return an error. Got %v", err)
+	}
+	if err := client.DeleteCart(ctx, "a1b2"); err != nil {
+		t.Fatalf("DeleteCart should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if created.CartID != "a1b2" || fetched.Expire.IsZero() || len(fetched.Items) != 1 || fetched.Items[0] != 42 {
+		t.Fatalf("Cart should be decoded. Got %+v", fetched)
+	}
+	requests := server.Requests()
+	if string(requests[0].Body) != `{"ovhSubsidiary":"FR","description":"test"}` {
+		t.Fatalf("CreateCart should send the cart creation. Got %s", requests[0].Body)
+	}
+	if len(requests) != 5 || requests[3].Path != "/order/cart/a1b2/assign" || !requests[3].Authenticated {
+		t.Fatalf("AssignCart should call POST /order/cart/{cartId}/assign. Got %+v", requests)
+	}
+}
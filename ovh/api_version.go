@@ -0,0 +1,66 @@
+package ovh
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// API versions
+const (
+	APIVersion1 = "1.0"
+	APIVersion2 = "v2"
+)
+
+// apiVersionSegment matches the last path segment of versioned endpoints,
+// like "1.0" or "v2"
+var apiVersionSegment = regexp.MustCompile(`^(\d+\.\d+|v\d+)$`)
+
+// apiVersionContext is the context key of the per-request API versions
+type apiVersionContext struct{}
+
+// WithRequestAPIVersion returns a context sending the calls made with it to
+// the given API version, like APIVersion2, whatever the version of the
+// client endpoint or Client.APIVersion.
+func WithRequestAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionContext{}, version)
+}
+
+// apiVersion returns the API version selected for a call, if any
+func (c *Client) apiVersion(ctx context.Context) string {
+	if ctx != nil {
+		if version, ok := ctx.Value(apiVersionContext{}).(string); ok && version != "" {
+			return version
+		}
+	}
+	return c.APIVersion
+}
+
+// versionedEndpoint returns the endpoint of a call, for the API version
+// selected by its context or the client
+func (c *Client) versionedEndpoint(ctx context.Context, endpoint string) string {
+	return withAPIVersion(endpoint, c.apiVersion(ctx))
+}
+
+// withAPIVersion replaces the version of an endpoint URL, or appends it to
+// endpoints without a version. The endpoint is returned as is when version
+// is empty.
+func withAPIVersion(endpoint, version string) string {
+	if version == "" {
+		return endpoint
+	}
+	return endpointBase(endpoint) + "/" + strings.Trim(version, "/")
+}
+
+// endpointBase returns an endpoint URL without its API version, if any
+func endpointBase(endpoint string) string {
+	i := strings.LastIndex(endpoint, "/")
+	if i < 0 || strings.HasSuffix(endpoint[:i], "/") {
+		// No path, like "https://eu.api.ovh.com"
+		return endpoint
+	}
+	if apiVersionSegment.MatchString(endpoint[i+1:]) {
+		return endpoint[:i]
+	}
+	return endpoint
+}
@@ -0,0 +1,81 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestWithAPIVersion(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint, version, expected string
+	}{
+		{"https://eu.api.ovh.com/1.0", "", "https://eu.api.ovh.com/1.0"},
+		{"https://eu.api.ovh.com/1.0", APIVersion2, "https://eu.api.ovh.com/v2"},
+		{"https://eu.api.ovh.com/v2", APIVersion1, "https://eu.api.ovh.com/1.0"},
+		{"https://eu.api.ovh.com", APIVersion2, "https://eu.api.ovh.com/v2"},
+		{"https://gateway.example.com/ovh", APIVersion2, "https://gateway.example.com/ovh/v2"},
+		{"http://127.0.0.1:8080", "/v2/", "http://127.0.0.1:8080/v2"},
+	} {
+		if got := withAPIVersion(tc.endpoint, tc.version); got != tc.expected {
+			t.Errorf("Endpoint %s with version '%s' should be %s. Got %s", tc.endpoint, tc.version, tc.expected, got)
+		}
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	// Init test: the signing server checks the versioned path is signed
+	ts, client := initSigningServer()
+	defer ts.Close()
+	client.APIVersion = APIVersion2
+
+	var paths []string
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.Path)
+			return next(req)
+		}
+	})
+
+	// Test
+	if err := client.Get("/iam/policy", nil); err != nil {
+		t.Fatalf("Client API version should be signed. Got %v", err)
+	}
+	ctx := WithRequestAPIVersion(context.Background(), APIVersion1)
+	if err := client.GetWithContext(ctx, "/me", nil); err != nil {
+		t.Fatalf("Request API version should be signed. Got %v", err)
+	}
+
+	// Validate
+	if len(paths) != 2 || paths[0] != "/v2/iam/policy" || paths[1] != "/1.0/me" {
+		t.Fatalf("Calls should be sent to the selected API version. Got %v", paths)
+	}
+}
+
+func TestAPIVersionTimeSync(t *testing.T) {
+	// Init test
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte("0"))
+	}))
+	defer ts.Close()
+	client, _ := NewClientWithOptions(ts.URL+"/1.0",
+		WithAppKey(MockApplicationKey, MockApplicationSecret),
+		WithConsumerKey(MockConsumerKey),
+		WithAPIVersion(APIVersion2),
+	)
+
+	// Test
+	if err := client.Get("/iam/resource", nil); err != nil {
+		t.Fatalf("Call should not fail. Got %v", err)
+	}
+
+	// Validate
+	if len(paths) != 2 || paths[0] != "/1.0/auth/time" || paths[1] != "/v2/iam/resource" {
+		t.Fatalf("Server time should be read from the 1.0 routes. Got %v", paths)
+	}
+}
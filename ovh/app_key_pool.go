@@ -0,0 +1,201 @@
+package ovh
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// throttlePause is how long a throttled application key is skipped, when the
+// response gives no Retry-After delay
+const throttlePause = time.Second
+
+// Application holds the credentials of an OVH application. The consumer key
+// must have been requested with this application key, consumer keys are
+// bound to the application which requested them.
+type Application struct {
+	AppKey      string
+	AppSecret   string
+	ConsumerKey string
+}
+
+// AppKeyStats holds the usage of an application key of an AppKeyPool
+type AppKeyStats struct {
+	AppKey string
+	// Requests is the number of requests sent with the key, LastMinute the
+	// number of them sent during the last minute
+	Requests   int64
+	LastMinute int64
+	// Throttled is the number of throttled responses received with the key
+	Throttled int64
+	// PausedUntil is set while the key is skipped, after a throttled response
+	PausedUntil time.Time
+}
+
+// AppKeyPool spreads the requests of a client across several applications,
+// to stay below the rate limits of each of them. Each request is signed with
+// the next application key, in turn. Keys which received a throttled
+// response are skipped for the delay requested by the API, unless all the
+// keys are throttled. It is safe for concurrent use, and may be shared by
+// several clients.
+type AppKeyPool struct {
+	mutex sync.Mutex
+	apps  []*pooledApp
+	next  int
+}
+
+// pooledApp is an application of a pool, with its usage
+type pooledApp struct {
+	Application
+	requests    int64
+	throttled   int64
+	pausedUntil time.Time
+
+	// Requests of the last minute, by second
+	seconds [60]int64
+	counts  [60]int64
+}
+
+// NewAppKeyPool returns a pool rotating among the given applications
+func NewAppKeyPool(apps ...Application) *AppKeyPool {
+	p := &AppKeyPool{}
+	for _, app := range apps {
+		p.apps = append(p.apps, &pooledApp{Application: app})
+	}
+	return p
+}
+
+// Applications returns the applications of the pool
+func (p *AppKeyPool) Applications() []Application {
+	apps := make([]Application, len(p.apps))
+	for i, app := range p.apps {
+		apps[i] = app.Application
+	}
+	return apps
+}
+
+// Stats returns the usage of each application key of the pool
+func (p *AppKeyPool) Stats() []AppKeyStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	stats := make([]AppKeyStats, len(p.apps))
+	for i, app := range p.apps {
+		stats[i] = AppKeyStats{
+			AppKey:     app.AppKey,
+			Requests:   app.requests,
+			LastMinute: app.lastMinute(now),
+			Throttled:  app.throttled,
+		}
+		if now.Before(app.pausedUntil) {
+			stats[i].PausedUntil = app.pausedUntil
+		}
+	}
+	return stats
+}
+
+// pick returns the application signing the next request: the next one which
+// is not throttled, or the one resuming first when all are
+func (p *AppKeyPool) pick() *Application {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.apps) == 0 {
+		return nil
+	}
+	now := time.Now()
+	chosen := -1
+	for i := range p.apps {
+		index := (p.next + i) % len(p.apps)
+		if !now.Before(p.apps[index].pausedUntil) {
+			chosen = index
+			break
+		}
+		if chosen < 0 || p.apps[index].pausedUntil.Before(p.apps[chosen].pausedUntil) {
+			chosen = index
+		}
+	}
+	p.next = (chosen + 1) % len(p.apps)
+
+	app := p.apps[chosen]
+	app.requests++
+	second := now.Unix()
+	if slot := second % 60; app.seconds[slot] != second {
+		app.seconds[slot] = second
+		app.counts[slot] = 1
+	} else {
+		app.counts[slot]++
+	}
+	return &app.Application
+}
+
+// lookup returns the application of a key, or nil if not in the pool
+func (p *AppKeyPool) lookup(appKey string) *Application {
+	for _, app := range p.apps {
+		if app.AppKey == appKey {
+			return &app.Application
+		}
+	}
+	return nil
+}
+
+// observe records a response to a request sent with an application key:
+// throttled keys are paused
+func (p *AppKeyPool) observe(appKey string, response *http.Response) {
+	if response == nil || response.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, app := range p.apps {
+		if app.AppKey != appKey {
+			continue
+		}
+		app.throttled++
+		delay := retryAfter(response)
+		if delay <= 0 {
+			delay = throttlePause
+		}
+		if until := time.Now().Add(delay); until.After(app.pausedUntil) {
+			app.pausedUntil = until
+		}
+	}
+}
+
+// lastMinute returns the number of requests sent during the last minute
+func (a *pooledApp) lastMinute(now time.Time) int64 {
+	var total int64
+	for slot, second := range a.seconds {
+		if now.Unix()-second < 60 {
+			total += a.counts[slot]
+		}
+	}
+	return total
+}
+
+// requestAppKey returns the application key of a new request, from the pool
+// if any
+func (c *Client) requestAppKey() string {
+	if c.AppKeyPool != nil {
+		if app := c.AppKeyPool.pick(); app != nil {
+			return app.AppKey
+		}
+	}
+	return c.credentials().appKey
+}
+
+// signingCredentials returns the credentials signing a request, those of the
+// pooled application it was built with if any
+func (c *Client) signingCredentials(req *http.Request) credentials {
+	keys := c.credentials()
+	if c.AppKeyPool != nil {
+		if app := c.AppKeyPool.lookup(req.Header.Get("X-Ovh-Application")); app != nil {
+			keys.appKey = app.AppKey
+			keys.appSecret = app.AppSecret
+			keys.consumerKey = app.ConsumerKey
+		}
+	}
+	return keys
+}
@@ -0,0 +1,166 @@
+package ovh
+
+// Common helpers are in ovh_test.go
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// initPoolServer returns a server checking the signatures of the given
+// applications, throttling the requests signed with "throttled"
+func initPoolServer(t *testing.T, apps []Application, throttled string) (*httptest.Server, *[]string) {
+	getEndpointForSignature = func(c *Client) string {
+		return "http://localhost"
+	}
+
+	var mutex sync.Mutex
+	keys := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			fmt.Fprint(w, time.Now().Unix())
+			return
+		}
+		appKey := r.Header.Get("X-Ovh-Application")
+		mutex.Lock()
+		keys = append(keys, appKey)
+		mutex.Unlock()
+
+		for _, app := range apps {
+			if app.AppKey != appKey {
+				continue
+			}
+			timestamp, _ := strconv.ParseInt(r.Header.Get("X-Ovh-Timestamp"), 10, 64)
+			expected := Sign(app.AppSecret, app.ConsumerKey, r.Method, "http://localhost"+r.URL.RequestURI(), "", timestamp)
+			if r.Header.Get("X-Ovh-Consumer") != app.ConsumerKey || r.Header.Get("X-Ovh-Signature") != expected {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errorCode":"INVALID_SIGNATURE","message":"Invalid signature"}`))
+				return
+			}
+			if appKey == throttled {
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"message":"Too many requests"}`))
+				return
+			}
+			w.Write([]byte(`"success"`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errorCode":"INVALID_KEY","message":"Invalid application key"}`))
+	}))
+	return ts, &keys
+}
+
+func TestAppKeyPool(t *testing.T) {
+	// Init test
+	apps := []Application{
+		{AppKey: "app-1", AppSecret: "secret-1", ConsumerKey: "consumer-1"},
+		{AppKey: "app-2", AppSecret: "secret-2", ConsumerKey: "consumer-2"},
+		{AppKey: "app-3", AppSecret: "secret-3", ConsumerKey: "consumer-3"},
+	}
+	ts, keys := initPoolServer(t, apps, "")
+	defer ts.Close()
+	pool := NewAppKeyPool(apps...)
+	client, err := NewClientWithOptions(ts.URL, WithAppKeyPool(pool))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+
+	// Test: the time synchronization is sent with app-1
+	if _, err := client.TimeDelta(); err != nil {
+		t.Fatalf("TimeDelta should not fail. Got %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		if err := client.Get("/some/resource", nil); err != nil {
+			t.Fatalf("Pooled requests should be signed with their application. Got %v", err)
+		}
+	}
+
+	// Validate
+	if got := strings.Join(*keys, ","); got != "app-2,app-3,app-1,app-2,app-3,app-1" {
+		t.Fatalf("Requests should rotate among the applications. Got %s", got)
+	}
+	if client.AppKey != "app-1" || client.ConsumerKey != "consumer-1" {
+		t.Fatalf("WithAppKeyPool should set the first application as credentials. Got %s, %s", client.AppKey, client.ConsumerKey)
+	}
+	for i, stats := range pool.Stats() {
+		expected := int64(2)
+		if i == 0 {
+			expected = 3
+		}
+		if stats.Requests != expected || stats.LastMinute != expected || stats.Throttled != 0 || !stats.PausedUntil.IsZero() {
+			t.Fatalf("Stats should count the requests of each key. Got %+v", stats)
+		}
+	}
+	if masked := client.MaskSecret("secret-2 consumer-3"); masked != "**** ****" {
+		t.Fatalf("MaskSecret should mask the pooled secrets. Got %s", masked)
+	}
+}
+
+func TestAppKeyPoolThrottled(t *testing.T) {
+	// Init test: requests signed with app-1 are throttled
+	apps := []Application{
+		{AppKey: "app-1", AppSecret: "secret-1", ConsumerKey: "consumer-1"},
+		{AppKey: "app-2", AppSecret: "secret-2", ConsumerKey: "consumer-2"},
+	}
+	ts, keys := initPoolServer(t, apps, "app-1")
+	defer ts.Close()
+	pool := NewAppKeyPool(apps...)
+	client, err := NewClientWithOptions(ts.URL, WithAppKeyPool(pool))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+
+	// Test: the time synchronization is sent with app-2
+	first := client.Get("/some/resource", nil)
+	var errs []error
+	for i := 0; i < 3; i++ {
+		errs = append(errs, client.Get("/some/resource", nil))
+	}
+
+	// Validate
+	if apiErr, ok := first.(*APIError); !ok || apiErr.Code != http.StatusTooManyRequests {
+		t.Fatalf("First request should be throttled. Got %v", first)
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Throttled keys should be skipped. Got %v", err)
+		}
+	}
+	if got := strings.Join(*keys, ","); got != "app-1,app-2,app-2,app-2" {
+		t.Fatalf("Requests should avoid the throttled key. Got %s", got)
+	}
+	stats := pool.Stats()
+	if stats[0].Throttled != 1 || stats[0].PausedUntil.Before(time.Now().Add(50*time.Second)) || stats[1].Requests != 4 {
+		t.Fatalf("Stats should report the throttled key. Got %+v", stats)
+	}
+}
+
+func TestAppKeyPoolAllThrottled(t *testing.T) {
+	// Init test: both keys are paused, app-2 resumes first
+	pool := NewAppKeyPool(Application{AppKey: "app-1"}, Application{AppKey: "app-2"})
+	throttled := func(delay string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{delay}}}
+	}
+	pool.observe("app-1", throttled("60"))
+	pool.observe("app-2", throttled("30"))
+	pool.observe("app-2", &http.Response{StatusCode: http.StatusOK})
+
+	// Test
+	app := pool.pick()
+
+	// Validate
+	if app.AppKey != "app-2" {
+		t.Fatalf("The key resuming first should be picked when all are throttled. Got %s", app.AppKey)
+	}
+	if stats := pool.Stats(); stats[1].Throttled != 1 {
+		t.Fatalf("Only throttled responses should be counted. Got %+v", stats)
+	}
+}
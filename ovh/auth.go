@@ -0,0 +1,83 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CredentialStatusValidated is the status of the consumer keys allowed to
+// call the API
+const CredentialStatusValidated = "validated"
+
+// AuthDetails represents the authentication of the client, as returned by
+// GET /auth/details
+type AuthDetails struct {
+	// Method is the authentication method, for instance "account" or
+	// "provider"
+	Method        string       `json:"method"`
+	Account       string       `json:"account"`
+	User          string       `json:"user"`
+	Description   string       `json:"description"`
+	Identities    []string     `json:"identities"`
+	Roles         []string     `json:"roles"`
+	AllowedRoutes []AccessRule `json:"allowedRoutes"`
+}
+
+// MissingRulesError is returned by RequireRules when the consumer key does
+// not grant some of the required rules
+type MissingRulesError struct {
+	CredentialID int64
+	Missing      []AccessRule
+}
+
+func (err *MissingRulesError) Error() string {
+	rules := make([]string, len(err.Missing))
+	for i, rule := range err.Missing {
+		rules[i] = rule.Method + " " + rule.Path
+	}
+	return fmt.Sprintf("go-ovh: consumer key %d does not grant %s", err.CredentialID, strings.Join(rules, ", "))
+}
+
+// CurrentCredential returns the consumer key of the client, with
+// GET /auth/currentCredential
+func (c *Client) CurrentCredential(ctx context.Context) (*Credential, error) {
+	credential := &Credential{}
+	if err := c.GetWithContext(ctx, "/auth/currentCredential", credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// AuthDetails returns the authentication details of the client, with
+// GET /auth/details
+func (c *Client) AuthDetails(ctx context.Context) (*AuthDetails, error) {
+	details := &AuthDetails{}
+	if err := c.GetWithContext(ctx, "/auth/details", details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// RequireRules checks that the consumer key of the client is validated, not
+// expired, and grants all the "required" rules, see HasRules. Missing rules
+// are reported with a *MissingRulesError, so that tools may fail before
+// doing anything.
+func (c *Client) RequireRules(ctx context.Context, required []AccessRule) error {
+	credential, err := c.CurrentCredential(ctx)
+	if err != nil {
+		return err
+	}
+	if credential.Status != CredentialStatusValidated {
+		return fmt.Errorf("go-ovh: consumer key %d is in %q status", credential.CredentialID, credential.Status)
+	}
+	if credential.Expiration != nil && !getLocalTime().Before(*credential.Expiration) {
+		return fmt.Errorf("go-ovh: consumer key %d expired on %s", credential.CredentialID, credential.Expiration.Format(time.RFC3339))
+	}
+
+	if missing := missingRules(credential.Rules, required); len(missing) > 0 {
+		return &MissingRulesError{CredentialID: credential.CredentialID, Missing: missing}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Error codes of the API for requests rejected because of their signature or
+// credential
+const (
+	ErrorCodeInvalidSignature  = "INVALID_SIGNATURE"
+	ErrorCodeInvalidCredential = "INVALID_CREDENTIAL"
+)
+
+// AuthError is returned when the API rejects the signature or the credential
+// of a request, even after the time delta was synchronized again. This
+// usually means that the application secret or the consumer key is wrong, or
+// that the consumer key expired.
+//
+// The APIError may be retrieved with errors.As.
+type AuthError struct {
+	APIError *APIError
+}
+
+func (err *AuthError) Error() string {
+	return fmt.Sprintf("go-ovh: request rejected by the API after time synchronization, please check your credentials: %v", err.APIError)
+}
+
+// Unwrap returns the APIError
+func (err *AuthError) Unwrap() error {
+	return err.APIError
+}
+
+// isAuthError checks if an error is an API rejection of the request signature
+// or credential
+func isAuthError(err error) bool {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return false
+	}
+	return apiErr.ErrorCode == ErrorCodeInvalidSignature || apiErr.ErrorCode == ErrorCodeInvalidCredential
+}
+
+// IsCredentialExpired checks if an error is an API rejection of the consumer
+// key, typically because it expired or was revoked
+func IsCredentialExpired(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == ErrorCodeInvalidCredential
+}
+
+// renewConsumerKey replaces the consumer key "expired" with the one returned
+// by OnCredentialExpired. Concurrent calls for the same expired key only call
+// OnCredentialExpired once.
+func (c *Client) renewConsumerKey(ctx context.Context, expired string) error {
+	c.credentialMutex.Lock()
+	defer c.credentialMutex.Unlock()
+
+	// Already renewed by a concurrent request
+	if c.ConsumerKey != expired {
+		return nil
+	}
+	consumerKey, err := c.OnCredentialExpired(ctx)
+	if err != nil {
+		return fmt.Errorf("go-ovh: unable to renew the expired consumer key: %w", err)
+	}
+	if consumerKey == "" {
+		return fmt.Errorf("go-ovh: unable to renew the expired consumer key: no consumer key returned")
+	}
+	c.keysMutex.Lock()
+	c.ConsumerKey = consumerKey
+	c.keysMutex.Unlock()
+	return nil
+}
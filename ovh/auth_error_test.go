@@ -0,0 +1,156 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initAuthErrorServer starts a server rejecting the first "failures" API
+// requests with "errorCode", and counting the /auth/time requests
+func initAuthErrorServer(t *testing.T, failures int32, errorCode string) (*httptest.Server, *Client, *int32, *int32) {
+	var hits, syncs int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			atomic.AddInt32(&syncs, 1)
+			fmt.Fprint(w, time.Now().Unix())
+			return
+		}
+		if atomic.AddInt32(&hits, 1) <= failures {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"class":"Client::BadRequest","message":"Invalid signature","errorCode":"%s"}`, errorCode)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	client, err := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	if err != nil {
+		t.Fatalf("NewClient should not fail. Got %v", err)
+	}
+	return ts, client, &hits, &syncs
+}
+
+func TestAuthErrorResync(t *testing.T) {
+	// Init test
+	ts, client, hits, syncs := initAuthErrorServer(t, 1, ErrorCodeInvalidSignature)
+	defer ts.Close()
+
+	// Test
+	err := client.Post("/me/task/contactChange/42/accept", nil, nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Post should succeed after time synchronization. Got %v", err)
+	}
+	if atomic.LoadInt32(hits) != 2 || atomic.LoadInt32(syncs) != 2 {
+		t.Fatalf("Request should be sent again after a new time synchronization. Got %d hits, %d syncs", atomic.LoadInt32(hits), atomic.LoadInt32(syncs))
+	}
+}
+
+func TestAuthErrorPersistent(t *testing.T) {
+	// Init test
+	ts, client, hits, _ := initAuthErrorServer(t, 10, ErrorCodeInvalidCredential)
+	defer ts.Close()
+
+	// Test
+	err := client.Get("/me", nil)
+
+	// Validate
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("Persistent rejections should return an AuthError. Got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != ErrorCodeInvalidCredential {
+		t.Fatalf("AuthError should wrap the APIError. Got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Fatalf("Request should be sent again only once. Got %d hits", n)
+	}
+}
+
+func TestAuthErrorUnAuth(t *testing.T) {
+	// Init test
+	ts, client, hits, syncs := initAuthErrorServer(t, 10, ErrorCodeInvalidSignature)
+	defer ts.Close()
+
+	// Test
+	err := client.GetUnAuth("/auth/details", nil)
+
+	// Validate
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || errors.As(err, new(*AuthError)) {
+		t.Fatalf("Unauthenticated requests should return the APIError. Got %v", err)
+	}
+	if atomic.LoadInt32(hits) != 1 || atomic.LoadInt32(syncs) != 0 {
+		t.Fatalf("Unauthenticated requests should not be sent again. Got %d hits, %d syncs", atomic.LoadInt32(hits), atomic.LoadInt32(syncs))
+	}
+}
+
+func TestOnCredentialExpired(t *testing.T) {
+	// Init test
+	var hits, renewals int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			fmt.Fprint(w, time.Now().Unix())
+			return
+		}
+		atomic.AddInt32(&hits, 1)
+		if r.Header.Get("X-Ovh-Consumer") != "renewed" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"This credential is not valid","errorCode":"INVALID_CREDENTIAL"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, "expired")
+	client.OnCredentialExpired = func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&renewals, 1)
+		return "renewed", nil
+	}
+
+	// Test
+	err := client.Get("/me", nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Get should succeed with the renewed consumer key. Got %v", err)
+	}
+	if client.ConsumerKey != "renewed" || atomic.LoadInt32(&renewals) != 1 {
+		t.Fatalf("Consumer key should be renewed once. Got '%s' after %d renewals", client.ConsumerKey, atomic.LoadInt32(&renewals))
+	}
+	if n := atomic.LoadInt32(&hits); n != 3 {
+		t.Fatalf("Request should be sent after time sync and after renewal. Got %d hits", n)
+	}
+
+	// Test: renewal errors are returned
+	client.ConsumerKey = "expired"
+	client.OnCredentialExpired = func(ctx context.Context) (string, error) {
+		return "", errors.New("secret store unavailable")
+	}
+	err = client.Get("/me", nil)
+	if err == nil || IsCredentialExpired(err) {
+		t.Fatalf("Renewal errors should be returned. Got %v", err)
+	}
+}
+
+func TestIsCredentialExpired(t *testing.T) {
+	if !IsCredentialExpired(fmt.Errorf("wrapped: %w", &APIError{ErrorCode: ErrorCodeInvalidCredential})) {
+		t.Fatalf("INVALID_CREDENTIAL errors should be detected")
+	}
+	if !IsCredentialExpired(&AuthError{APIError: &APIError{ErrorCode: ErrorCodeInvalidCredential}}) {
+		t.Fatalf("INVALID_CREDENTIAL AuthErrors should be detected")
+	}
+	if IsCredentialExpired(&APIError{ErrorCode: ErrorCodeInvalidSignature}) || IsCredentialExpired(nil) {
+		t.Fatalf("Other errors should not be detected")
+	}
+}
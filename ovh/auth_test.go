@@ -0,0 +1,116 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestAuthDetails(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{
+		"method": "account",
+		"account": "xx1111-ovh",
+		"user": null,
+		"identities": ["urn:v1:eu:identity:account:xx1111-ovh"],
+		"roles": ["ADMIN"],
+		"allowedRoutes": [{"method": "GET", "path": "/*"}]
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	details, err := client.AuthDetails(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("AuthDetails should not return an error. Got %v", err)
+	}
+	if InputRequest.URL.String() != "/auth/details" {
+		t.Fatalf("AuthDetails should call GET /auth/details. Got %s", InputRequest.URL.String())
+	}
+	if details.Account != "xx1111-ovh" || len(details.Identities) != 1 || !reflect.DeepEqual(details.AllowedRoutes, []AccessRule{{Method: "GET", Path: "/*"}}) {
+		t.Fatalf("AuthDetails should decode the response. Got %+v", details)
+	}
+}
+
+func TestCurrentCredential(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{
+		"credentialId": 42,
+		"applicationId": 7,
+		"status": "expired",
+		"allowedIPs": ["203.0.113.0/24"],
+		"creation": "2020-01-01T00:00:00+01:00",
+		"lastUse": "2020-02-01T00:00:00+01:00"
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	credential, err := client.CurrentCredential(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("CurrentCredential should not check the status. Got %v", err)
+	}
+	if credential.ApplicationID != 7 || credential.Status != "expired" || credential.LastUse == nil || credential.AllowedIPs[0] != "203.0.113.0/24" {
+		t.Fatalf("CurrentCredential should decode the response. Got %+v", credential)
+	}
+}
+
+func TestRequireRules(t *testing.T) {
+	for name, tc := range map[string]struct {
+		credential string
+		expected   string
+	}{
+		"granted": {
+			credential: `{"credentialId": 42, "status": "validated", "rules": [{"method": "GET", "path": "/*"}, {"method": "POST", "path": "/domain/*"}]}`,
+		},
+		"missing": {
+			credential: `{"credentialId": 42, "status": "validated", "rules": [{"method": "GET", "path": "/domain/*"}]}`,
+			expected:   "go-ovh: consumer key 42 does not grant GET /me, POST /domain/zone/example.com/refresh",
+		},
+		"expired": {
+			credential: `{"credentialId": 42, "status": "validated", "expiration": "2016-03-01T00:00:00Z", "rules": [{"method": "GET", "path": "/*"}]}`,
+			expected:   "go-ovh: consumer key 42 expired on 2016-03-01T00:00:00Z",
+		},
+		"refused": {
+			credential: `{"credentialId": 42, "status": "refused"}`,
+			expected:   `go-ovh: consumer key 42 is in "refused" status`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Init test
+			var InputRequest *http.Request
+			ts, client := initMockServer(&InputRequest, 200, tc.credential, nil, time.Duration(0))
+			defer ts.Close()
+
+			// Test
+			err := client.RequireRules(context.Background(), []AccessRule{
+				{Method: "GET", Path: "/me"},
+				{Method: "POST", Path: "/domain/zone/example.com/refresh"},
+			})
+
+			// Validate
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("RequireRules should not return an error. Got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Unexpected error. Expected %s. Got %v", tc.expected, err)
+			}
+			var missingErr *MissingRulesError
+			if name == "missing" && (!errors.As(err, &missingErr) || len(missingErr.Missing) != 2) {
+				t.Fatalf("Missing rules should be reported with a MissingRulesError. Got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package ovh
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchRequest describes a single authenticated request of a Batch
+type BatchRequest struct {
+	// HTTP method, like "GET"
+	Method string
+	// Path of the request, relative to the endpoint
+	Path string
+	// Body serialized as JSON, nil for no body
+	Body interface{}
+	// Result, when not nil, receives the decoded response
+	Result interface{}
+}
+
+// BatchResult holds the outcome of a single request of a Batch
+type BatchResult struct {
+	// Response received from the API, see CallAPIFull
+	Response *Response
+	// Error of the request, nil on success
+	Err error
+}
+
+// Batch sends all the requests, running up to "concurrency" of them in
+// parallel, and returns their results in the same order. This is typically
+// used to fetch the details of all the IDs returned by a list route:
+//
+//	requests := make([]ovh.BatchRequest, len(ids))
+//	servers := make([]Server, len(ids))
+//	for i, id := range ids {
+//		requests[i] = ovh.BatchRequest{Method: "GET", Path: "/dedicated/server/" + id, Result: &servers[i]}
+//	}
+//	results := client.Batch(ctx, requests, 10)
+//
+// Requests which could not be started because the context is done fail with
+// the context error. Requests are retried like any other request, according
+// to Client.Retry.
+func (c *Client) Batch(ctx context.Context, requests []BatchRequest, concurrency int) []BatchResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	results := make([]BatchResult, len(requests))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+
+	for i := range requests {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(requests); j++ {
+				results[j] = BatchResult{Response: &Response{}, Err: err}
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			request := requests[i]
+			response, err := c.CallAPIFullWithContext(ctx, request.Method, request.Path, request.Body)
+			if err == nil {
+				err = c.decode(request.Method, request.Path, response.Body, request.Result)
+			}
+			results[i] = BatchResult{Response: response, Err: err}
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
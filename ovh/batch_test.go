@@ -0,0 +1,90 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestBatch(t *testing.T) {
+	// Init test: a server echoing the requested ID, except for missing ones
+	var inFlight, maxInFlight int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/item/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"s_val":"%s %s"}`, r.Method, id)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	ids := []string{"1", "2", "missing", "4", "5", "6"}
+	items := make([]SomeData, len(ids))
+	requests := make([]BatchRequest, len(ids))
+	for i, id := range ids {
+		requests[i] = BatchRequest{Method: "GET", Path: "/item/" + id, Result: &items[i]}
+	}
+
+	// Test
+	results := client.Batch(context.Background(), requests, 2)
+
+	// Validate
+	if len(results) != len(ids) {
+		t.Fatalf("Batch should return a result per request. Got %d", len(results))
+	}
+	for i, id := range ids {
+		if id == "missing" {
+			if results[i].Err == nil || results[i].Response.StatusCode != http.StatusNotFound {
+				t.Fatalf("Failed request should report its error. Got %+v", results[i])
+			}
+			continue
+		}
+		if results[i].Err != nil || items[i].StringValue != "GET "+id {
+			t.Fatalf("Request %s should be decoded. Got '%s', %v", id, items[i].StringValue, results[i].Err)
+		}
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Fatalf("Batch should run at most 2 requests in parallel. Got %d", max)
+	}
+}
+
+func TestBatchCanceled(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Test
+	results := client.Batch(ctx, []BatchRequest{{Method: "GET", Path: "/a"}, {Method: "GET", Path: "/b"}}, 1)
+
+	// Validate
+	for _, result := range results {
+		if result.Err != context.Canceled || result.Response == nil {
+			t.Fatalf("Requests should not be started once canceled. Got %+v", result)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package ovh
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// BodyTooLargeError is returned when a request body exceeds
+// Client.MaxRequestBytes, or a response body Client.MaxResponseBytes. It
+// matches ErrRequestTooLarge or ErrResponseTooLarge with errors.Is.
+type BodyTooLargeError struct {
+	// Response is set for response bodies, unset for request bodies
+	Response bool
+	// Method and Path of the request, when known
+	Method string
+	Path   string
+	// Limit is the configured maximum size, in bytes
+	Limit int64
+	// Size of the body, in bytes, or -1 when the body was not read entirely
+	Size int64
+}
+
+func (err *BodyTooLargeError) Error() string {
+	kind, option := "request", "MaxRequestBytes"
+	if err.Response {
+		kind, option = "response", "MaxResponseBytes"
+	}
+	call := ""
+	if err.Method != "" {
+		call = fmt.Sprintf(" of %s %s", err.Method, err.Path)
+	}
+	size := "more than"
+	if err.Size >= 0 {
+		size = fmt.Sprintf("%d bytes, more than", err.Size)
+	}
+	return fmt.Sprintf("go-ovh: %s body%s is %s the configured %s of %d bytes", kind, call, size, option, err.Limit)
+}
+
+// Is matches ErrRequestTooLarge or ErrResponseTooLarge
+func (err *BodyTooLargeError) Is(target error) bool {
+	if err.Response {
+		return target == ErrResponseTooLarge
+	}
+	return target == ErrRequestTooLarge
+}
+
+// checkRequestSize checks the size of a request body against MaxRequestBytes
+func (c *Client) checkRequestSize(method, path string, body []byte) error {
+	if c.MaxRequestBytes > 0 && int64(len(body)) > c.MaxRequestBytes {
+		return &BodyTooLargeError{Method: method, Path: path, Limit: c.MaxRequestBytes, Size: int64(len(body))}
+	}
+	return nil
+}
+
+// responseTooLarge returns the BodyTooLargeError of a response
+func (c *Client) responseTooLarge(response *http.Response, size int64) error {
+	err := &BodyTooLargeError{Response: true, Limit: c.MaxResponseBytes, Size: size}
+	if response.Request != nil {
+		err.Method = response.Request.Method
+		err.Path = response.Request.URL.Path
+	}
+	return err
+}
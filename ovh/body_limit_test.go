@@ -0,0 +1,61 @@
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestMaxRequestBytes(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{}`, nil, time.Duration(0))
+	defer ts.Close()
+	WithBodyLimits(16, 0)(client)
+
+	// Test
+	err := client.Post("/some/resource", map[string]string{"description": "more than sixteen bytes"}, nil)
+
+	// Validate
+	var sizeErr *BodyTooLargeError
+	if !errors.As(err, &sizeErr) || !errors.Is(err, ErrRequestTooLarge) || errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Large requests should fail with a BodyTooLargeError. Got %v", err)
+	}
+	if InputRequest != nil {
+		t.Fatalf("Large requests should not be sent")
+	}
+	expected := "go-ovh: request body of POST /some/resource is 41 bytes, more than the configured MaxRequestBytes of 16 bytes"
+	if err.Error() != expected {
+		t.Fatalf("Unexpected error message. Expected %s. Got %s", expected, err.Error())
+	}
+
+	// Test: small requests are sent
+	if err := client.Post("/some/resource", map[string]int{"a": 1}, nil); err != nil {
+		t.Fatalf("Small requests should be sent. Got %v", err)
+	}
+}
+
+func TestMaxResponseBytesContentLength(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"`+strings.Repeat("a", 1024)+`"`, nil, time.Duration(0))
+	defer ts.Close()
+	client.MaxResponseBytes = 512
+
+	// Test
+	var res string
+	err := client.Get("/some/resource", &res)
+
+	// Validate
+	var sizeErr *BodyTooLargeError
+	if !errors.As(err, &sizeErr) || !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Large responses should fail with a BodyTooLargeError. Got %v", err)
+	}
+	if !sizeErr.Response || sizeErr.Size != 1026 || sizeErr.Method != "GET" || sizeErr.Path != "/some/resource" {
+		t.Fatalf("BodyTooLargeError should describe the response. Got %+v", sizeErr)
+	}
+}
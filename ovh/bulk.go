@@ -0,0 +1,99 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the number of parallel requests issued by bulk
+// helpers when no explicit concurrency is given
+const DefaultBulkConcurrency = 4
+
+// BulkDeleteResult summarizes a DeleteAll call
+type BulkDeleteResult struct {
+	// Path of the deleted resources
+	Deleted []string
+	// Errors of the resources which could not be deleted, by path
+	Failed map[string]error
+}
+
+// DeleteAll lists the resource IDs returned by GET "path" and deletes each of
+// them with DELETE "path/{id}", running up to "concurrency" deletions in
+// parallel. "path" may include a query string to filter the listed resources,
+// for instance "/domain/zone/example.com/record?fieldType=TXT".
+//
+// An error is returned if the resources could not be listed or if the context
+// is canceled. Deletion errors are collected in the result, along with the
+// successfully deleted resources. Deletions are retried like any other
+// request, according to Client.Retry.
+func (c *Client) DeleteAll(ctx context.Context, path string, concurrency int) (*BulkDeleteResult, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	ids, err := c.listIDs(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := strings.TrimRight(strings.SplitN(path, "?", 2)[0], "/")
+	result := &BulkDeleteResult{
+		Deleted: []string{},
+		Failed:  map[string]error{},
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+
+	for _, id := range ids {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(itemPath string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := c.DeleteWithContext(ctx, itemPath, nil)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				result.Failed[itemPath] = err
+			} else {
+				result.Deleted = append(result.Deleted, itemPath)
+			}
+		}(basePath + "/" + url.PathEscape(id))
+	}
+	wg.Wait()
+
+	return result, ctx.Err()
+}
+
+// listIDs returns the IDs listed by a GET on "path", as strings. IDs may be
+// numbers or strings, depending on the route.
+func (c *Client) listIDs(ctx context.Context, path string) ([]string, error) {
+	var raw genericJSON
+	if err := c.CallAPIWithContext(ctx, "GET", path, nil, &raw, true); err != nil {
+		return nil, err
+	}
+
+	items, ok := raw.value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("go-ovh: GET %s did not return a list", path)
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, fmt.Sprint(item))
+	}
+	return ids, nil
+}
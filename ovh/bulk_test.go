@@ -0,0 +1,72 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestDeleteAll(t *testing.T) {
+	// Init test
+	var mutex sync.Mutex
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.String() == "/domain/zone/example.com/record?fieldType=TXT":
+			w.Write([]byte(`[1,2,3,5000000001]`))
+		case r.Method == "DELETE" && r.URL.Path == "/domain/zone/example.com/record/2":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Record not found"}`))
+		case r.Method == "DELETE":
+			mutex.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mutex.Unlock()
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	// Test
+	result, err := client.DeleteAll(context.Background(), "/domain/zone/example.com/record?fieldType=TXT", 2)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("DeleteAll should not return an error. Got %v", err)
+	}
+	sort.Strings(result.Deleted)
+	expected := []string{
+		"/domain/zone/example.com/record/1",
+		"/domain/zone/example.com/record/3",
+		"/domain/zone/example.com/record/5000000001",
+	}
+	if len(result.Deleted) != len(expected) {
+		t.Fatalf("DeleteAll should report %v as deleted. Got %v", expected, result.Deleted)
+	}
+	for i := range expected {
+		if result.Deleted[i] != expected[i] {
+			t.Fatalf("DeleteAll should report %v as deleted. Got %v", expected, result.Deleted)
+		}
+	}
+	if len(deleted) != len(expected) {
+		t.Fatalf("DeleteAll should send %d deletions. Got %v", len(expected), deleted)
+	}
+	apiErr, ok := result.Failed["/domain/zone/example.com/record/2"].(*APIError)
+	if len(result.Failed) != 1 || !ok || apiErr.Code != http.StatusNotFound {
+		t.Fatalf("DeleteAll should report record 2 as failed with a 404. Got %v", result.Failed)
+	}
+
+	// Test: canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.DeleteAll(ctx, "/domain/zone/example.com/record?fieldType=TXT", 2); err == nil {
+		t.Fatalf("DeleteAll should fail with a canceled context")
+	}
+}
@@ -0,0 +1,245 @@
+package ovh
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses kept by NewMemoryCache when no
+// explicit size is given
+const DefaultCacheSize = 1000
+
+// Cache stores GET responses, see Client.Cache. Implementations must be safe
+// for concurrent use.
+type Cache interface {
+	// Get returns the entry stored for "key", if any
+	Get(key string) (*CacheEntry, bool)
+	// Set stores an entry for "key"
+	Set(key string, entry *CacheEntry)
+	// Delete removes the entry stored for "key", if any
+	Delete(key string)
+}
+
+// CacheTTLProvider may be implemented by caches choosing the lifetime of
+// their entries, like DiskCache. A positive TTL overrides Client.CacheTTL and
+// the Cache-Control headers of the response.
+type CacheTTLProvider interface {
+	// TTL returns the lifetime of the entry of "key", 0 to keep the default
+	TTL(key string) time.Duration
+}
+
+// CacheEntry is a cached response
+type CacheEntry struct {
+	Response Response
+	// Expires is the time until which the response is used without asking
+	// the API. Afterwards, it is revalidated with its ETag, if any.
+	Expires time.Time
+	// ETag of the response, from the ETag header
+	ETag string
+}
+
+// fresh checks if the entry may be used without asking the API
+func (e *CacheEntry) fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// response returns a copy of the cached response
+func (e *CacheEntry) response() *Response {
+	res := e.Response
+	res.Header = e.Response.Header.Clone()
+	res.Body = append([]byte(nil), e.Response.Body...)
+	return &res
+}
+
+// cacheKey identifies a GET response in the cache. The responses of
+// authenticated calls depend on the account, their keys end with a hash of
+// the credentials, as a URL fragment, so that clients with different
+// credentials may share a cache.
+func (c *Client) cacheKey(ctx context.Context, path string, needAuth bool) string {
+	key := c.versionedEndpoint(ctx, c.endpoint) + path
+	if needAuth {
+		keys := c.credentials()
+		sum := sha256.Sum256([]byte(keys.appKey + "+" + keys.consumerKey + "+" + keys.clientID))
+		key += "#" + hex.EncodeToString(sum[:16])
+	}
+	return key
+}
+
+// callCached serves GET calls from the cache, asking the API when the cached
+// response is stale and revalidating it with its ETag. Mutating calls
+// invalidate the response cached for their path. Calls with additional
+// headers, like pagination ones, are never cached.
+func (c *Client) callCached(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*Response, error) {
+	key := c.cacheKey(ctx, path, needAuth)
+	if strings.ToUpper(method) != "GET" || len(header) > 0 {
+		if isMutating(method) {
+			c.Cache.Delete(key)
+		}
+		return c.fetch(ctx, method, path, reqBody, header, needAuth)
+	}
+
+	entry, cached := c.Cache.Get(key)
+	if cached && entry.fresh() {
+		return entry.response(), nil
+	}
+	if cached && entry.ETag != "" {
+		header = http.Header{"If-None-Match": []string{entry.ETag}}
+	}
+
+	res, err := c.fetch(ctx, method, path, reqBody, header, needAuth)
+	if cached && res.StatusCode == http.StatusNotModified {
+		// The cached entry may be read concurrently, update a copy
+		updated := *entry
+		updated.Expires = c.cacheExpiry(key, res.Header)
+		c.Cache.Set(key, &updated)
+		return updated.response(), nil
+	}
+	if err != nil {
+		return res, err
+	}
+
+	if entry := c.newCacheEntry(key, res); entry != nil {
+		c.Cache.Set(key, entry)
+	} else if cached {
+		c.Cache.Delete(key)
+	}
+	return res, nil
+}
+
+// newCacheEntry returns the cache entry of a response, or nil if it must not
+// be cached
+func (c *Client) newCacheEntry(key string, res *Response) *CacheEntry {
+	if cacheDirective(res.Header, "no-store") != "" {
+		return nil
+	}
+	entry := &CacheEntry{
+		Response: *res,
+		Expires:  c.cacheExpiry(key, res.Header),
+		ETag:     res.Header.Get("ETag"),
+	}
+	if !entry.fresh() && entry.ETag == "" {
+		return nil
+	}
+	return entry
+}
+
+// cacheExpiry returns the expiry of a response: the TTL of the cache or
+// CacheTTL from now, when set, or its Cache-Control max-age. Responses without
+// max-age must be revalidated.
+func (c *Client) cacheExpiry(key string, header http.Header) time.Time {
+	now := time.Now()
+	if provider, ok := c.Cache.(CacheTTLProvider); ok {
+		if ttl := provider.TTL(key); ttl > 0 {
+			return now.Add(ttl)
+		}
+	}
+	if c.CacheTTL > 0 {
+		return now.Add(c.CacheTTL)
+	}
+	if cacheDirective(header, "no-cache") != "" {
+		return now
+	}
+	maxAge, err := strconv.Atoi(cacheDirective(header, "max-age"))
+	if err != nil || maxAge <= 0 {
+		return now
+	}
+	return now.Add(time.Duration(maxAge) * time.Second)
+}
+
+// cacheDirective returns the value of a Cache-Control directive, "name" for
+// directives without value, or an empty string when it is absent
+func cacheDirective(header http.Header, name string) string {
+	for _, value := range header["Cache-Control"] {
+		for _, directive := range strings.Split(value, ",") {
+			directive = strings.TrimSpace(directive)
+			key, arg := directive, ""
+			if i := strings.Index(directive, "="); i >= 0 {
+				key, arg = directive[:i], strings.Trim(directive[i+1:], `"`)
+			}
+			if strings.EqualFold(key, name) {
+				if arg == "" {
+					return name
+				}
+				return arg
+			}
+		}
+	}
+	return ""
+}
+
+// MemoryCache is an in-memory Cache keeping the most recently used responses
+type MemoryCache struct {
+	mutex   sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// memoryCacheItem is an element of MemoryCache.order
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache keeping at most "size" responses,
+// DefaultCacheSize is used if size is not positive
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &MemoryCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get returns the entry stored for "key", if any
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores an entry for "key", evicting the least recently used one when
+// the cache is full
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete removes the entry stored for "key", if any
+func (m *MemoryCache) Delete(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+		delete(m.entries, key)
+	}
+}
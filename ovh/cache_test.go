@@ -0,0 +1,213 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initCacheServer starts a server counting its hits, answering with the given
+// Cache-Control and ETag headers, and with 304 to matching If-None-Match
+func initCacheServer(t *testing.T, cacheControl, etag string) (*httptest.Server, *Client, *int32) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		fmt.Fprintf(w, "%d", n)
+	}))
+
+	client, err := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	if err != nil {
+		t.Fatalf("NewClient should not fail. Got %v", err)
+	}
+	client.Cache = NewMemoryCache(0)
+	return ts, client, &hits
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	// Init test
+	ts, client, hits := initCacheServer(t, "private, max-age=60", "")
+	defer ts.Close()
+
+	// Test
+	var first, second, other int
+	client.GetUnAuth("/order/catalog/public/cloud", &first)
+	client.GetUnAuth("/order/catalog/public/cloud", &second)
+	client.GetUnAuth("/order/catalog/public/cloud?ovhSubsidiary=FR", &other)
+
+	// Validate
+	if first != 1 || second != 1 {
+		t.Fatalf("Fresh responses should be served from cache. Got %d, %d", first, second)
+	}
+	if other != 2 || atomic.LoadInt32(hits) != 2 {
+		t.Fatalf("Responses should be cached per path. Got %d after %d hits", other, atomic.LoadInt32(hits))
+	}
+
+	// Test: mutating calls invalidate the path
+	client.PutUnAuth("/order/catalog/public/cloud", nil, nil)
+	client.GetUnAuth("/order/catalog/public/cloud", &second)
+	if second != 4 {
+		t.Fatalf("Mutating calls should invalidate the cached response. Got %d", second)
+	}
+}
+
+func TestCacheETag(t *testing.T) {
+	// Init test
+	ts, client, hits := initCacheServer(t, "", `"v1"`)
+	defer ts.Close()
+
+	// Test
+	var first, second int
+	client.GetUnAuth("/domain/zone", &first)
+	res, err := client.callAPIFull(context.Background(), "GET", "/domain/zone", nil, nil, false)
+	res.Unmarshal(&second)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Revalidated responses should not fail. Got %v", err)
+	}
+	if atomic.LoadInt32(hits) != 2 {
+		t.Fatalf("Responses without max-age should be revalidated. Got %d hits", atomic.LoadInt32(hits))
+	}
+	if res.StatusCode != http.StatusOK || first != 1 || second != 1 {
+		t.Fatalf("Not modified responses should be served from cache. Got %d, %d, %d", res.StatusCode, first, second)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	// Init test
+	ts, client, hits := initCacheServer(t, "no-cache", "")
+	defer ts.Close()
+	client.CacheTTL = 50 * time.Millisecond
+
+	// Test
+	var res int
+	client.GetUnAuth("/me", &res)
+	client.GetUnAuth("/me", &res)
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Fatalf("CacheTTL should override Cache-Control. Got %d hits", n)
+	}
+	time.Sleep(100 * time.Millisecond)
+	client.GetUnAuth("/me", &res)
+
+	// Validate
+	if res != 2 {
+		t.Fatalf("Expired responses should be fetched again. Got %d", res)
+	}
+}
+
+func TestCacheBypass(t *testing.T) {
+	for name, cacheControl := range map[string]string{
+		"no-store":   "no-store",
+		"no max-age": "max-age=0",
+	} {
+		// Init test
+		ts, client, hits := initCacheServer(t, cacheControl, "")
+
+		// Test
+		var res int
+		client.GetUnAuth("/me", &res)
+		client.GetUnAuth("/me", &res)
+		client.Request("GET", "/me").Header("X-Pagination-Mode", "CachedObjectList-Pages").UnAuth().Do(context.Background(), &res)
+		ts.Close()
+
+		// Validate
+		if n := atomic.LoadInt32(hits); n != 3 {
+			t.Fatalf("Responses with %s should not be cached. Got %d hits", name, n)
+		}
+	}
+}
+
+func TestCacheConcurrentRevalidation(t *testing.T) {
+	// Init test
+	ts, client, hits := initCacheServer(t, "", `"v1"`)
+	defer ts.Close()
+	var first int
+	client.GetUnAuth("/domain/zone", &first)
+
+	// Test: revalidations update the entry read by the other calls
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client.GetUnAuth("/domain/zone", &results[i])
+		}(i)
+	}
+	wg.Wait()
+
+	// Validate
+	for _, res := range results {
+		if res != first {
+			t.Fatalf("Revalidated responses should be served from cache. Got %v", results)
+		}
+	}
+	if n := atomic.LoadInt32(hits); n != int32(len(results)+1) {
+		t.Fatalf("Each call should revalidate the response. Got %d hits", n)
+	}
+}
+
+func TestCacheCredentials(t *testing.T) {
+	// Init test: two accounts sharing a cache
+	ts, client, hits := initCacheServer(t, "max-age=60", "")
+	defer ts.Close()
+	client.timeDeltaDone = true
+	other, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, "other-consumer-key")
+	other.timeDeltaDone = true
+	other.Cache = client.Cache
+
+	// Test
+	var mine, theirs, public, publicAgain int
+	client.Get("/me", &mine)
+	other.Get("/me", &theirs)
+	client.GetUnAuth("/order/catalog/public/cloud", &public)
+	other.GetUnAuth("/order/catalog/public/cloud", &publicAgain)
+
+	// Validate
+	if mine != 1 || theirs != 2 {
+		t.Fatalf("Authenticated responses should be cached per credentials. Got %d, %d", mine, theirs)
+	}
+	if public != 3 || publicAgain != 3 || atomic.LoadInt32(hits) != 3 {
+		t.Fatalf("Unauthenticated responses should be shared. Got %d, %d", public, publicAgain)
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	// Init test
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{ETag: "a"})
+	cache.Set("b", &CacheEntry{ETag: "b"})
+
+	// Test
+	cache.Get("a")
+	cache.Set("c", &CacheEntry{ETag: "c"})
+
+	// Validate
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf("Least recently used entry should be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("Recently used entry should be kept")
+	}
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("Deleted entry should be removed")
+	}
+}
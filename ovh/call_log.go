@@ -0,0 +1,80 @@
+package ovh
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCallLogSize is the number of calls kept by EnableCallLog when no
+// explicit size is given
+const DefaultCallLogSize = 1000
+
+// CallLogEntry describes a single API call made by a Client
+type CallLogEntry struct {
+	// HTTP method of the call
+	Method string
+	// Path of the call, relative to the endpoint
+	Path string
+	// HTTP status code of the response, 0 if no response was received
+	Status int
+	// Time spent sending the request and receiving the response
+	Duration time.Duration
+}
+
+// callLog is a size bounded ring buffer of CallLogEntry
+type callLog struct {
+	mutex   sync.Mutex
+	entries []CallLogEntry
+	next    int
+	full    bool
+}
+
+// add records an entry, overwriting the oldest one when the buffer is full
+func (l *callLog) add(entry CallLogEntry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.entries[l.next] = entry
+	l.next++
+	if l.next == len(l.entries) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// list returns a copy of the recorded entries, oldest first
+func (l *callLog) list() []CallLogEntry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.full {
+		return append([]CallLogEntry(nil), l.entries[:l.next]...)
+	}
+	entries := make([]CallLogEntry, 0, len(l.entries))
+	entries = append(entries, l.entries[l.next:]...)
+	return append(entries, l.entries[:l.next]...)
+}
+
+// EnableCallLog starts recording all API calls made by the client. Only the
+// last "size" calls are kept, DefaultCallLogSize is used if size is not
+// positive. Enabling the log again discards previous entries.
+func (c *Client) EnableCallLog(size int) {
+	if size <= 0 {
+		size = DefaultCallLogSize
+	}
+	c.callLog = &callLog{entries: make([]CallLogEntry, size)}
+}
+
+// DisableCallLog stops recording API calls and discards recorded entries
+func (c *Client) DisableCallLog() {
+	c.callLog = nil
+}
+
+// CallLog returns the calls recorded since EnableCallLog, oldest first. It
+// returns nil when the call log is not enabled.
+func (c *Client) CallLog() []CallLogEntry {
+	if c.callLog == nil {
+		return nil
+	}
+	return c.callLog.list()
+}
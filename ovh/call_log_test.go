@@ -0,0 +1,57 @@
+package ovh
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestCallLog(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusNotFound, `{"message":"Not found"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test: disabled by default
+	client.Get("/me", nil)
+	if client.CallLog() != nil {
+		t.Fatalf("CallLog should be nil when not enabled. Got %v", client.CallLog())
+	}
+
+	// Test: record calls, keeping only the most recent ones
+	client.EnableCallLog(3)
+	client.Get("/me", nil)
+	client.Post("/domain/zone/example.com/refresh", nil, nil)
+	client.Put("/me", SomeData{IntValue: 42}, nil)
+	client.Delete("/domain/zone/example.com/record/42", nil)
+
+	type call struct {
+		Method string
+		Path   string
+		Status int
+	}
+	expected := []call{
+		{"POST", "/domain/zone/example.com/refresh", http.StatusNotFound},
+		{"PUT", "/me", http.StatusNotFound},
+		{"DELETE", "/domain/zone/example.com/record/42", http.StatusNotFound},
+	}
+	var got []call
+	for _, entry := range client.CallLog() {
+		if entry.Duration <= 0 {
+			t.Fatalf("CallLog entries should have a duration. Got %v", entry.Duration)
+		}
+		got = append(got, call{entry.Method, entry.Path, entry.Status})
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("CallLog should be %v. Got %v", expected, got)
+	}
+
+	// Test: disable
+	client.DisableCallLog()
+	if client.CallLog() != nil {
+		t.Fatalf("CallLog should be nil once disabled. Got %v", client.CallLog())
+	}
+}
@@ -0,0 +1,152 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+// Circuit breaker states
+const (
+	// CircuitClosed lets all the requests through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all the requests with ErrCircuitOpen
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through, whose outcome
+	// closes or opens the circuit again
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker stops sending requests to the API after consecutive
+// failures, network errors, 5xx or 429 responses, so that workers do not hammer
+// it during an outage. Once the cooldown is over, a probe request is let
+// through: if it succeeds the circuit closes, otherwise it opens again for
+// another cooldown.
+//
+// It may be shared by several clients calling the same endpoint.
+type CircuitBreaker struct {
+	// OnStateChange, when set, is called on each state change, for instance
+	// to report the state of the circuit to health checks. It must be set
+	// before the circuit breaker is used.
+	OnStateChange func(from, to CircuitState)
+
+	mutex      sync.Mutex
+	threshold  int
+	cooldown   time.Duration
+	state      CircuitState
+	failures   int
+	openedAt   time.Time
+	probing    bool
+	generation int
+}
+
+// NewCircuitBreaker returns a circuit breaker opening after "threshold"
+// consecutive failures, for "cooldown" before probing the API again
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// State returns the current state of the circuit. An open circuit whose
+// cooldown is over is reported as half-open.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Reset closes the circuit
+func (b *CircuitBreaker) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.failures = 0
+	b.setState(CircuitClosed)
+}
+
+// allow checks if a request may be sent, returning the generation of the
+// circuit to give to record, or ErrCircuitOpen
+func (b *CircuitBreaker) allow() (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return 0, ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+	case CircuitHalfOpen:
+		if b.probing {
+			return 0, ErrCircuitOpen
+		}
+	}
+	b.probing = b.state == CircuitHalfOpen
+	return b.generation, nil
+}
+
+// record updates the circuit with the outcome of a request allowed in the
+// given generation. Requests canceled by their caller are not counted, nor
+// are the local errors occurring before the request is sent, like policy
+// denials or signature failures: only transport errors are API failures.
+func (b *CircuitBreaker) record(ctx context.Context, generation int, response *http.Response, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Outcome of a request sent before the last state change
+	if generation != b.generation {
+		return
+	}
+	var transportErr *url.Error
+	if err != nil && (ctx.Err() != nil || !errors.As(err, &transportErr)) {
+		b.probing = false
+		return
+	}
+
+	b.probing = false
+	if err == nil && response.StatusCode < http.StatusInternalServerError && response.StatusCode != http.StatusTooManyRequests {
+		b.failures = 0
+		b.setState(CircuitClosed)
+		return
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.setState(CircuitOpen)
+	}
+}
+
+// setState changes the state of the circuit, starting a new generation
+func (b *CircuitBreaker) setState(state CircuitState) {
+	if state == b.state {
+		return
+	}
+	from := b.state
+	b.state = state
+	b.generation++
+	if b.OnStateChange != nil {
+		b.OnStateChange(from, state)
+	}
+}
@@ -0,0 +1,123 @@
+package ovh
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestCircuitBreaker(t *testing.T) {
+	// Init test: an API failing until it recovers
+	status := http.StatusServiceUnavailable
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"message": "down"}`)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	var changes []string
+	breaker := NewCircuitBreaker(2, 50*time.Millisecond)
+	breaker.OnStateChange = func(from, to CircuitState) {
+		changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+	}
+	client.CircuitBreaker = breaker
+
+	// Test: consecutive failures open the circuit
+	client.GetUnAuth("/some/resource", nil)
+	client.GetUnAuth("/some/resource", nil)
+	err := client.GetUnAuth("/some/resource", nil)
+
+	// Validate
+	if err != ErrCircuitOpen || calls != 2 || breaker.State() != CircuitOpen {
+		t.Fatalf("Circuit should open after 2 failures. Got %v after %d calls, %s", err, calls, breaker.State())
+	}
+
+	// Test: a failing probe opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Fatalf("Circuit should be half-open after the cooldown. Got %s", state)
+	}
+	client.GetUnAuth("/some/resource", nil)
+	if state := breaker.State(); state != CircuitOpen || calls != 3 {
+		t.Fatalf("Failing probe should open the circuit. Got %s after %d calls", state, calls)
+	}
+
+	// Test: a successful probe closes the circuit
+	status = http.StatusOK
+	time.Sleep(60 * time.Millisecond)
+	err = client.GetUnAuth("/some/resource", nil)
+
+	// Validate
+	if err != nil || breaker.State() != CircuitClosed {
+		t.Fatalf("Successful probe should close the circuit. Got %v, %s", err, breaker.State())
+	}
+	expected := "[closed->open open->half-open half-open->open open->half-open half-open->closed]"
+	if fmt.Sprint(changes) != expected {
+		t.Fatalf("State changes should be reported. Expected %s. Got %v", expected, changes)
+	}
+}
+
+func TestCircuitBreakerClientErrors(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusNotFound, `{"message": "not found"}`, nil, time.Duration(0))
+	defer ts.Close()
+	client.CircuitBreaker = NewCircuitBreaker(1, time.Minute)
+
+	// Test
+	client.Get("/some/resource", nil)
+	err := client.Get("/some/resource", nil)
+
+	// Validate: client errors are not API failures
+	if err == ErrCircuitOpen || client.CircuitBreaker.State() != CircuitClosed {
+		t.Fatalf("Client errors should not open the circuit. Got %v", err)
+	}
+}
+
+func TestCircuitBreakerLocalErrors(t *testing.T) {
+	// Init test: calls denied by the policy once changed by a middleware
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusOK, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+	client.CircuitBreaker = NewCircuitBreaker(1, time.Minute)
+	WithPolicy(&CallPolicy{Deny: []AccessRule{{Method: "GET", Path: "/secret"}}})(client)
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.URL.Path = "/secret"
+			return next(req)
+		}
+	})
+
+	// Test
+	client.Get("/some/resource", nil)
+	err := client.Get("/some/resource", nil)
+
+	// Validate: local errors are not API failures
+	if !errors.Is(err, ErrPolicyDenied) || client.CircuitBreaker.State() != CircuitClosed {
+		t.Fatalf("Local errors should not open the circuit. Got %v, %s", err, client.CircuitBreaker.State())
+	}
+}
+
+func TestCircuitBreakerTooManyRequests(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusTooManyRequests, `{"message": "slow down"}`, nil, time.Duration(0))
+	defer ts.Close()
+	client.CircuitBreaker = NewCircuitBreaker(1, time.Minute)
+
+	// Test
+	client.Get("/some/resource", nil)
+	err := client.Get("/some/resource", nil)
+
+	// Validate: throttled calls are API failures
+	if err != ErrCircuitOpen {
+		t.Fatalf("429 responses should open the circuit. Got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ClientSet holds named clients for several endpoints or accounts, like
+// "ovh-eu" and "ovh-ca", or production and sandbox credentials. The clients
+// it creates share its HTTP transport and rate limiter.
+//
+//	set := ovh.NewClientSet(ovh.WithTimeout(30 * time.Second))
+//	if _, err := set.LoadProfiles(); err != nil {
+//		return err
+//	}
+//	errs := set.ForEach(ctx, func(ctx context.Context, name string, client *ovh.Client) error {
+//		return client.GetWithContext(ctx, "/me", &me)
+//	})
+type ClientSet struct {
+	// Transport is the HTTP transport of the clients created by the set,
+	// so that they share their connections
+	Transport http.RoundTripper
+
+	// RateLimiter, when set, is shared by the clients created by the set,
+	// typically when they use the same application
+	RateLimiter *RateLimiter
+
+	options []Option
+	mutex   sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewClientSet returns an empty client set. The options are applied to all
+// the clients it creates.
+func NewClientSet(opts ...Option) *ClientSet {
+	return &ClientSet{
+		Transport: NewTransport(TransportConfig{}),
+		options:   opts,
+		clients:   map[string]*Client{},
+	}
+}
+
+// Add registers a client under "name", replacing any client of that name.
+// The client is used as is.
+func (s *ClientSet) Add(name string, client *Client) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.clients[name] = client
+}
+
+// AddEndpoint creates a client for "endpoint", configured with the set
+// options then "opts", and registers it under "name"
+func (s *ClientSet) AddEndpoint(name, endpoint string, opts ...Option) (*Client, error) {
+	options := append([]Option{}, s.options...)
+	options = append(options, WithHTTPClient(&http.Client{Transport: s.Transport}))
+	options = append(options, opts...)
+	client, err := NewClientWithOptions(endpoint, options...)
+	if err != nil {
+		return nil, fmt.Errorf("client %s: %w", name, err)
+	}
+	if s.RateLimiter != nil {
+		client.RateLimiter = s.RateLimiter
+	}
+	s.Add(name, client)
+	return client, nil
+}
+
+// AddProfile creates a client from the configuration profile "profile", see
+// WithProfile, and registers it under "name"
+func (s *ClientSet) AddProfile(name, profile string) (*Client, error) {
+	return s.AddEndpoint(name, "", WithProfile(profile))
+}
+
+// LoadProfiles creates a client for each "[profile <name>]" section of the
+// configuration files, registered under the profile name, and returns the
+// sorted names of the profiles. The configuration file may be set with
+// WithConfigFile among the set options.
+func (s *ClientSet) LoadProfiles() ([]string, error) {
+	loader := &Client{}
+	for _, opt := range s.options {
+		opt(loader)
+	}
+	cfg, err := loader.loadConfigFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []string
+	for _, section := range cfg.SectionStrings() {
+		if strings.HasPrefix(section, "profile ") {
+			profiles = append(profiles, strings.TrimSpace(strings.TrimPrefix(section, "profile ")))
+		}
+	}
+	sort.Strings(profiles)
+	for _, profile := range profiles {
+		if _, err := s.AddProfile(profile, profile); err != nil {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
+// Remove unregisters the client "name", if any
+func (s *ClientSet) Remove(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.clients, name)
+}
+
+// Get returns the client "name", if any
+func (s *ClientSet) Get(name string) (*Client, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	client, ok := s.clients[name]
+	return client, ok
+}
+
+// Client returns the client "name", or an error if there is none
+func (s *ClientSet) Client(name string) (*Client, error) {
+	client, ok := s.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("go-ovh: no client named '%s' in the set", name)
+	}
+	return client, nil
+}
+
+// Names returns the sorted names of the clients
+func (s *ClientSet) Names() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	names := make([]string, 0, len(s.clients))
+	for name := range s.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ByEndpoint returns the sorted names of the clients using an endpoint,
+// given by name, like "ovh-ca", or by URL
+func (s *ClientSet) ByEndpoint(endpoint string) []string {
+	endpointURL := resolveEndpoint(endpoint)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	names := []string{}
+	for name, client := range s.clients {
+		if client.endpoint == endpointURL {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForEach calls "fn" concurrently for each client of the set. It returns the
+// errors by client name, nil if all calls succeeded.
+func (s *ClientSet) ForEach(ctx context.Context, fn func(ctx context.Context, name string, client *Client) error) map[string]error {
+	s.mutex.RLock()
+	clients := make(map[string]*Client, len(s.clients))
+	for name, client := range s.clients {
+		clients[name] = client
+	}
+	s.mutex.RUnlock()
+
+	var mutex sync.Mutex
+	var errs map[string]error
+	var wg sync.WaitGroup
+	for name, client := range clients {
+		wg.Add(1)
+		go func(name string, client *Client) {
+			defer wg.Done()
+			if err := fn(ctx, name, client); err != nil {
+				mutex.Lock()
+				defer mutex.Unlock()
+				if errs == nil {
+					errs = map[string]error{}
+				}
+				errs[name] = err
+			}
+		}(name, client)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,130 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+// initClientSetServer starts a server answering with the consumer key of the
+// requests, and writes a configuration file with a profile per consumer key
+func initClientSetServer(t *testing.T, consumerKeys ...string) (*httptest.Server, string) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			fmt.Fprint(w, MockTime)
+			return
+		}
+		consumerKey := r.Header.Get("X-Ovh-Consumer")
+		if consumerKey == "revoked" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "This credential does not exist"}`)
+			return
+		}
+		fmt.Fprintf(w, `"%s"`, consumerKey)
+	}))
+
+	config := ""
+	for _, consumerKey := range consumerKeys {
+		config += fmt.Sprintf("[profile %s]\nendpoint=%s\napplication_key=key\napplication_secret=secret\nconsumer_key=%s\n\n", consumerKey, ts.URL, consumerKey)
+	}
+	dir, err := ioutil.TempDir("", "clientset")
+	if err != nil {
+		t.Fatalf("TempDir should not fail. Got %v", err)
+	}
+	path := filepath.Join(dir, "ovh.conf")
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatalf("WriteFile should not fail. Got %v", err)
+	}
+	return ts, path
+}
+
+func TestClientSetProfiles(t *testing.T) {
+	// Init test
+	ts, path := initClientSetServer(t, "prod", "sandbox")
+	defer ts.Close()
+	defer os.RemoveAll(filepath.Dir(path))
+	set := NewClientSet(WithConfigFile(path))
+	set.RateLimiter = NewRateLimiter(100, 10)
+
+	// Test
+	profiles, err := set.LoadProfiles()
+	if err != nil {
+		t.Fatalf("LoadProfiles should not fail. Got %v", err)
+	}
+	var mutex sync.Mutex
+	consumerKeys := map[string]string{}
+	errs := set.ForEach(context.Background(), func(ctx context.Context, name string, client *Client) error {
+		var consumerKey string
+		err := client.GetWithContext(ctx, "/me", &consumerKey)
+		mutex.Lock()
+		defer mutex.Unlock()
+		consumerKeys[name] = consumerKey
+		return err
+	})
+
+	// Validate
+	if fmt.Sprint(profiles) != "[prod sandbox]" || fmt.Sprint(set.Names()) != "[prod sandbox]" {
+		t.Fatalf("LoadProfiles should add a client per profile. Got %v and %v", profiles, set.Names())
+	}
+	if errs != nil {
+		t.Fatalf("ForEach should not fail. Got %v", errs)
+	}
+	if consumerKeys["prod"] != "prod" || consumerKeys["sandbox"] != "sandbox" {
+		t.Fatalf("Each client should use its profile credentials. Got %v", consumerKeys)
+	}
+	prod, _ := set.Client("prod")
+	sandbox, _ := set.Client("sandbox")
+	if prod.Client.Transport != set.Transport || sandbox.Client.Transport != set.Transport || prod.Client == sandbox.Client {
+		t.Fatalf("Clients should share the set transport, with their own HTTP client")
+	}
+	if prod.RateLimiter != set.RateLimiter || sandbox.RateLimiter != set.RateLimiter {
+		t.Fatalf("Clients should share the set rate limiter")
+	}
+}
+
+func TestClientSetRouting(t *testing.T) {
+	// Init test
+	ts, path := initClientSetServer(t, "revoked")
+	defer ts.Close()
+	defer os.RemoveAll(filepath.Dir(path))
+	set := NewClientSet(WithConfigFile(path))
+	eu, _ := NewClient("ovh-eu", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	set.Add("eu", eu)
+
+	// Test
+	if _, err := set.AddProfile("customer-1", "revoked"); err != nil {
+		t.Fatalf("AddProfile should not fail. Got %v", err)
+	}
+	_, errUnknown := set.AddProfile("customer-2", "unknown")
+	errs := set.ForEach(context.Background(), func(ctx context.Context, name string, client *Client) error {
+		if name == "eu" {
+			return nil
+		}
+		return client.GetWithContext(ctx, "/me", nil)
+	})
+
+	// Validate
+	if errUnknown == nil {
+		t.Fatalf("AddProfile should fail with an unknown profile")
+	}
+	if fmt.Sprint(set.ByEndpoint("ovh-eu")) != "[eu]" || fmt.Sprint(set.ByEndpoint(ts.URL+"/")) != "[customer-1]" {
+		t.Fatalf("ByEndpoint should return the clients of an endpoint. Got %v and %v", set.ByEndpoint("ovh-eu"), set.ByEndpoint(ts.URL))
+	}
+	var apiErr *APIError
+	if len(errs) != 1 || !errors.As(errs["customer-1"], &apiErr) {
+		t.Fatalf("ForEach should return the errors by client name. Got %v", errs)
+	}
+	set.Remove("eu")
+	if _, err := set.Client("eu"); err == nil || fmt.Sprint(set.Names()) != "[customer-1]" {
+		t.Fatalf("Removed clients should not be found. Got %v and %v", err, set.Names())
+	}
+}
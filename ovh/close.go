@@ -0,0 +1,107 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrClientClosed is returned by the calls started after Close
+var ErrClientClosed = errors.New("go-ovh: client is closed")
+
+// callTracker counts the calls in flight, for Close
+type callTracker struct {
+	mutex   sync.Mutex
+	closed  bool
+	count   int
+	drained chan struct{}
+}
+
+// trackedCallContext marks the contexts of the calls in flight
+type trackedCallContext struct{}
+
+// beginCall registers a call in flight, until the returned function is
+// called. Calls fail with ErrClientClosed once the client is closed, except
+// those made on behalf of a call in flight, like the time synchronization.
+func (c *Client) beginCall(ctx context.Context) (context.Context, func(), error) {
+	tracker := &c.calls
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.closed && ctx.Value(trackedCallContext{}) == nil {
+		return ctx, nil, ErrClientClosed
+	}
+	tracker.count++
+
+	var once sync.Once
+	done := func() {
+		once.Do(tracker.end)
+	}
+	return context.WithValue(ctx, trackedCallContext{}, true), done, nil
+}
+
+// end unregisters a call in flight
+func (t *callTracker) end() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.count--
+	if t.count == 0 && t.drained != nil {
+		close(t.drained)
+		t.drained = nil
+	}
+}
+
+// InFlight returns the number of calls in flight, including the downloads
+// whose body is not closed yet
+func (c *Client) InFlight() int {
+	c.calls.mutex.Lock()
+	defer c.calls.mutex.Unlock()
+	return c.calls.count
+}
+
+// Close shuts the client down gracefully, for instance when a service stops:
+// calls started after Close fail with ErrClientClosed, and Close waits for the
+// calls in flight, including the downloads whose body is not closed yet, until
+// the context is done. The idle connections of the HTTP client are then
+// closed. It returns the context error if calls were still in flight.
+//
+// Requests built with NewRequest and sent with Do are not tracked.
+func (c *Client) Close(ctx context.Context) error {
+	tracker := &c.calls
+	tracker.mutex.Lock()
+	tracker.closed = true
+	var drained chan struct{}
+	if tracker.count > 0 {
+		if tracker.drained == nil {
+			tracker.drained = make(chan struct{})
+		}
+		drained = tracker.drained
+	}
+	tracker.mutex.Unlock()
+
+	var err error
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	if c.Client != nil {
+		c.Client.CloseIdleConnections()
+	}
+	return err
+}
+
+// trackedBody ends a call in flight when its body is closed
+type trackedBody struct {
+	io.ReadCloser
+	done func()
+}
+
+func (b *trackedBody) Close() error {
+	defer b.done()
+	return b.ReadCloser.Close()
+}
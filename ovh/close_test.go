@@ -0,0 +1,159 @@
+package ovh
+
+// Common helpers are in ovh_test.go
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// waitInFlight waits until "n" calls of the client are in flight
+func waitInFlight(t *testing.T, client *Client, n int) {
+	deadline := time.Now().Add(time.Second)
+	for client.InFlight() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d calls should be in flight. Got %d", n, client.InFlight())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClose(t *testing.T) {
+	// Init test: a call in flight for 100ms
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, 100*time.Millisecond)
+	defer ts.Close()
+
+	results := make(chan error, 1)
+	go func() {
+		results <- client.Get("/some/resource", nil)
+	}()
+	waitInFlight(t, client, 1)
+
+	// Test
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := client.Close(ctx)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Close should wait for the calls in flight. Got %v", err)
+	}
+	select {
+	case callErr := <-results:
+		if callErr != nil {
+			t.Fatalf("Calls in flight should complete. Got %v", callErr)
+		}
+	default:
+		t.Fatal("Close should return after the calls in flight")
+	}
+	if err := client.Get("/some/resource", nil); err != ErrClientClosed {
+		t.Fatalf("Calls should fail once the client is closed. Got %v", err)
+	}
+	if _, err := client.GetRaw("/some/resource"); err != ErrClientClosed {
+		t.Fatalf("Downloads should fail once the client is closed. Got %v", err)
+	}
+	if client.InFlight() != 0 {
+		t.Fatalf("Rejected calls should not be in flight. Got %d", client.InFlight())
+	}
+}
+
+func TestCloseTimeout(t *testing.T) {
+	// Init test: a call in flight for 300ms
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, 300*time.Millisecond)
+	defer ts.Close()
+
+	results := make(chan error, 1)
+	go func() {
+		results <- client.Get("/some/resource", nil)
+	}()
+	waitInFlight(t, client, 1)
+
+	// Test
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.Close(ctx)
+
+	// Validate
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Close should stop waiting with the context. Got %v", err)
+	}
+	if callErr := <-results; callErr != nil {
+		t.Fatalf("Calls in flight should not be interrupted. Got %v", callErr)
+	}
+	if client.InFlight() != 0 {
+		t.Fatalf("Completed calls should not be in flight. Got %d", client.InFlight())
+	}
+}
+
+func TestCloseDownload(t *testing.T) {
+	// Init test: a download whose body is not closed yet
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"content"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	body, err := client.GetRaw("/some/file")
+	if err != nil {
+		t.Fatalf("GetRaw should not fail. Got %v", err)
+	}
+	closed := make(chan error, 1)
+	go func() {
+		closed <- client.Close(context.Background())
+	}()
+
+	// Test
+	select {
+	case <-closed:
+		t.Fatal("Close should wait for the body to be closed")
+	case <-time.After(20 * time.Millisecond):
+	}
+	content, _ := ioutil.ReadAll(body)
+	body.Close()
+	body.Close()
+
+	// Validate
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close should not fail. Got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close should return once the body is closed")
+	}
+	if string(content) != `"content"` || client.InFlight() != 0 {
+		t.Fatalf("Download should complete. Got %q with %d calls in flight", content, client.InFlight())
+	}
+}
+
+func TestCloseNestedCalls(t *testing.T) {
+	// Init test: a call in flight when the client is closed
+	client := &Client{}
+	ctx, done, err := client.beginCall(context.Background())
+	if err != nil {
+		t.Fatalf("beginCall should not fail. Got %v", err)
+	}
+	closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	client.Close(closeCtx)
+
+	// Test
+	_, nestedDone, nestedErr := client.beginCall(ctx)
+	_, _, newErr := client.beginCall(context.Background())
+
+	// Validate
+	if nestedErr != nil {
+		t.Fatalf("Calls made on behalf of calls in flight should be allowed. Got %v", nestedErr)
+	}
+	if newErr != ErrClientClosed {
+		t.Fatalf("New calls should be rejected. Got %v", newErr)
+	}
+	nestedDone()
+	done()
+	if client.InFlight() != 0 {
+		t.Fatalf("Ended calls should not be in flight. Got %d", client.InFlight())
+	}
+}
@@ -0,0 +1,65 @@
+package ovh
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptCompression returns a copy of a request asking for gzip compressed
+// responses, unless the client disables compression. The header is set
+// explicitly, so that custom transports compress responses as well, which
+// disables the transparent decompression of http.Transport: it is only set by
+// Client.Do, which decompresses the responses with decompress. Requests
+// already choosing an encoding are returned as is.
+func (c *Client) acceptCompression(req *http.Request) *http.Request {
+	if req.Header.Get("Accept-Encoding") != "" {
+		return req
+	}
+	req = req.Clone(req.Context())
+	if c.DisableCompression {
+		req.Header.Set("Accept-Encoding", "identity")
+	} else {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	return req
+}
+
+// decompress replaces the body of gzip compressed responses with its
+// decompressed content. The response headers then describe the decompressed
+// body, as for responses decompressed by http.Transport.
+func decompress(response *http.Response) {
+	if !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	response.Body = &gzipBody{body: response.Body}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+}
+
+// gzipBody decompresses a response body, lazily so that empty bodies, like
+// the ones of HEAD requests, are not an error
+type gzipBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+// Read implements io.Reader
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// Close implements io.Closer
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
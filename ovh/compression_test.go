@@ -0,0 +1,100 @@
+package ovh
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+// initGzipServer starts a server compressing its response when asked to,
+// reporting the Accept-Encoding header of the requests
+func initGzipServer(encodings *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*encodings = append(*encodings, r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(`{"i_val":42,"s_val":"plain"}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.Method == "HEAD" {
+			return
+		}
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte(`{"i_val":42,"s_val":"compressed"}`))
+		writer.Close()
+	}))
+}
+
+func TestCompression(t *testing.T) {
+	// Init test
+	var encodings []string
+	ts := initGzipServer(&encodings)
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	// Test
+	var data SomeData
+	if err := client.Get("/some/resource", &data); err != nil {
+		t.Fatalf("Compressed responses should be decoded. Got %v", err)
+	}
+	res, err := client.CallAPIFull("GET", "/some/resource", nil)
+	if err != nil {
+		t.Fatalf("CallAPIFull should not fail. Got %v", err)
+	}
+	if err := client.CallAPI("HEAD", "/some/resource", nil, nil, true); err != nil {
+		t.Fatalf("Empty compressed responses should not fail. Got %v", err)
+	}
+	req, _ := client.NewRequest("GET", "/some/resource", nil, true)
+	external, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Requests sent by other clients should not fail. Got %v", err)
+	}
+	defer external.Body.Close()
+	var externalData SomeData
+	externalErr := json.NewDecoder(external.Body).Decode(&externalData)
+
+	// Validate
+	if data.StringValue != "compressed" {
+		t.Fatalf("Response should be decompressed. Got %+v", data)
+	}
+	if res.Header.Get("Content-Encoding") != "" || string(res.Body) != `{"i_val":42,"s_val":"compressed"}` {
+		t.Fatalf("Response should describe the decompressed body. Got %v, %s", res.Header, res.Body)
+	}
+	if externalErr != nil || externalData.StringValue != "compressed" || !external.Uncompressed {
+		t.Fatalf("Requests sent by other clients should be decompressed by their transport. Got %+v, %v", externalData, externalErr)
+	}
+	if encodings[0] != "gzip" {
+		t.Fatalf("Requests should accept gzip responses. Got %v", encodings)
+	}
+}
+
+func TestWithoutCompression(t *testing.T) {
+	// Init test
+	var encodings []string
+	ts := initGzipServer(&encodings)
+	defer ts.Close()
+	client, _ := NewClientWithOptions(ts.URL,
+		WithAppKey(MockApplicationKey, MockApplicationSecret),
+		WithConsumerKey(MockConsumerKey),
+		WithoutCompression(),
+	)
+	client.timeDeltaDone = true
+
+	// Test
+	var data SomeData
+	err := client.Get("/some/resource", &data)
+
+	// Validate
+	if err != nil || data.StringValue != "plain" {
+		t.Fatalf("Uncompressed response should be decoded. Got %+v, %v", data, err)
+	}
+	if encodings[0] != "identity" {
+		t.Fatalf("Requests should not accept compressed responses. Got %v", encodings)
+	}
+}
@@ -0,0 +1,169 @@
+package ovh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration file formats
+const (
+	configFormatINI  = "ini"
+	configFormatJSON = "json"
+	configFormatYAML = "yaml"
+)
+
+// yamlMappingStart matches a line opening a YAML mapping, like "ovh-eu:",
+// which is not valid in ini files
+var yamlMappingStart = regexp.MustCompile(`^[A-Za-z0-9_.-]+:\s*(#.*)?$`)
+
+// configFormat returns the format of a configuration file, from its extension
+// or, for other extensions like ".conf", from its content
+func configFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return configFormatJSON
+	case ".yaml", ".yml":
+		return configFormatYAML
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return configFormatJSON
+	}
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if line == "---" || yamlMappingStart.MatchString(line) {
+			return configFormatYAML
+		}
+		break
+	}
+	return configFormatINI
+}
+
+// iniConfig returns the content of a configuration file as ini data,
+// converting JSON and YAML documents.
+//
+// Their top-level mappings are the sections, except "endpoints" and
+// "profiles" which hold the endpoint sections and the "[profile <name>]"
+// sections by name. Top-level values belong to the "default" section.
+//
+//	{
+//	  "default": {"endpoint": "ovh-eu"},
+//	  "endpoints": {"ovh-eu": {"application_key": "..."}},
+//	  "profiles": {"staging": {"endpoint": "ovh-ca"}}
+//	}
+func iniConfig(path string, data []byte) ([]byte, error) {
+	var document map[string]interface{}
+	switch configFormat(path, data) {
+	case configFormatJSON:
+		if err := json.Unmarshal(data, &document); err != nil {
+			return nil, err
+		}
+	case configFormatYAML:
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, err
+		}
+	default:
+		return data, nil
+	}
+
+	sections := map[string]map[string]interface{}{}
+	for name, value := range document {
+		children, isMap := value.(map[string]interface{})
+		if !isMap {
+			sections["default"] = mergeKeys(sections["default"], map[string]interface{}{name: value})
+			continue
+		}
+		if name != "endpoints" && name != "profiles" {
+			sections[name] = mergeKeys(sections[name], children)
+			continue
+		}
+		for child, value := range children {
+			keys, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("section '%s' of '%s' is not a mapping", child, name)
+			}
+			if name == "profiles" {
+				child = "profile " + child
+			}
+			sections[child] = mergeKeys(sections[child], keys)
+		}
+	}
+
+	// Sections and keys are sorted to keep the conversion stable
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	file := ini.Empty()
+	for _, name := range names {
+		section, err := file.NewSection(name)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(sections[name]))
+		for key := range sections[name] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value, err := configScalar(sections[name][key])
+			if err != nil {
+				return nil, fmt.Errorf("unsupported value for '%s' in section '%s': %v", key, name, err)
+			}
+			if _, err := section.NewKey(key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := file.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// configScalar converts a JSON or YAML scalar to its ini value
+func configScalar(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%T is not a scalar", value)
+	}
+}
+
+// mergeKeys adds the "keys" of a section to "section", which may be nil
+func mergeKeys(section, keys map[string]interface{}) map[string]interface{} {
+	if section == nil {
+		section = map[string]interface{}{}
+	}
+	for key, value := range keys {
+		section[key] = value
+	}
+	return section
+}
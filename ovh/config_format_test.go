@@ -0,0 +1,104 @@
+package ovh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestConfigFormat(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path, data, expected string
+	}{
+		"ini":            {"ovh.conf", "\n; comment\n[ovh-eu]\napplication_key=key\n", configFormatINI},
+		"ini key":        {"ovh.conf", "endpoint: ovh-eu\n", configFormatINI},
+		"json extension": {"ovh.json", "", configFormatJSON},
+		"json content":   {"ovh.conf", "  {\"default\": {}}", configFormatJSON},
+		"yaml extension": {"ovh.yml", "", configFormatYAML},
+		"yaml mapping":   {"ovh.conf", "# comment\novh-eu:\n  application_key: key\n", configFormatYAML},
+		"yaml document":  {"ovh.conf", "---\nendpoint: ovh-eu\n", configFormatYAML},
+	} {
+		if format := configFormat(tc.path, []byte(tc.data)); format != tc.expected {
+			t.Fatalf("%s: format should be %s. Got %s", name, tc.expected, format)
+		}
+	}
+}
+
+func TestConfigJSON(t *testing.T) {
+	// Prepare
+	dir, _ := ioutil.TempDir("", "go-ovh")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ovh.json")
+	ioutil.WriteFile(path, []byte(`{
+		"endpoint": "ovh-ca",
+		"endpoints": {
+			"ovh-ca": {"application_key": "ca-key", "application_secret": "ca#secret;", "consumer_key": "ca-ck"}
+		},
+		"profiles": {
+			"staging": {"endpoint": "ovh-eu", "application_key": "staging-key", "application_secret": "staging-secret"}
+		}
+	}`), 0600)
+
+	// Test
+	client, err := NewClientWithConfigFile(path, "")
+	if err != nil {
+		t.Fatalf("JSON configuration should be loaded. Got %v", err)
+	}
+	staging, err := NewClientWithOptions("", WithConfigFile(path), WithProfile("staging"))
+	if err != nil {
+		t.Fatalf("JSON profiles should be loaded. Got %v", err)
+	}
+
+	// Validate
+	if client.endpoint != OvhCA || client.AppKey != "ca-key" || client.AppSecret != "ca#secret;" || client.ConsumerKey != "ca-ck" {
+		t.Fatalf("Configuration should be read from the endpoint section. Got %s, %s, %s", client.endpoint, client.AppKey, client.AppSecret)
+	}
+	if staging.endpoint != OvhEU || staging.AppKey != "staging-key" {
+		t.Fatalf("Configuration should be read from the profile section. Got %s, %s", staging.endpoint, staging.AppKey)
+	}
+}
+
+func TestConfigYAML(t *testing.T) {
+	// Prepare: a YAML document in the default configuration file
+	ioutil.WriteFile(systemConfigPath, []byte(`
+default:
+  endpoint: ovh-eu
+ovh-eu:
+  application_key: yaml-key
+  application_secret: "yaml secret"
+  consumer_key: 42
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("YAML configuration should be loaded. Got %v", err)
+	}
+	if client.endpoint != OvhEU || client.AppKey != "yaml-key" || client.AppSecret != "yaml secret" || client.ConsumerKey != "42" {
+		t.Fatalf("YAML values should be read. Got %s, %s, %s, %s", client.endpoint, client.AppKey, client.AppSecret, client.ConsumerKey)
+	}
+}
+
+func TestConfigInvalidDocument(t *testing.T) {
+	// Prepare
+	dir, _ := ioutil.TempDir("", "go-ovh")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ovh.yaml")
+	ioutil.WriteFile(path, []byte("ovh-eu:\n  application_key: [1, 2]\n"), 0600)
+
+	// Test
+	_, err := NewClientWithConfigFile(path, "ovh-eu")
+
+	// Validate
+	expected := "unable to parse configuration file '" + path + "': unsupported value for 'application_key' in section 'ovh-eu': []interface {} is not a scalar"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Invalid documents should be reported. Expected %s. Got %v", expected, err)
+	}
+}
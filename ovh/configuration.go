@@ -1,10 +1,13 @@
 package ovh
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"gopkg.in/ini.v1"
@@ -12,91 +15,264 @@ import (
 
 // Use variables for easier test overload
 var (
-	systemConfigPath = "/etc/ovh.conf"
-	userConfigPath   = "/.ovh.conf" // prefixed with homeDir
-	localConfigPath  = "./ovh.conf"
+	systemConfigPath    = "/etc/ovh.conf"
+	systemConfigDirPath = "/etc/ovh.conf.d" // drop-in "*.conf" files
+	userConfigPath      = "/.ovh.conf"      // prefixed with homeDir
+	localConfigPath     = "./ovh.conf"
 )
 
-// currentUserHome attempts to get current user's home directory
+// StrictConfig makes configuration loading fail when a configuration file
+// exists but can not be read, typically because of wrong permissions. Missing
+// configuration files are never an error.
+var StrictConfig = false
+
+// currentUser is a function to be overwritten during the tests
+var currentUser = user.Current
+
+// currentUserHome attempts to get current user's home directory. If the
+// current user can not be resolved, fallback on $HOME then, for Windows,
+// on %USERPROFILE%.
 func currentUserHome() (string, error) {
-	userHome := ""
-	usr, err := user.Current()
-	if err != nil {
-		// Fallback by trying to read $HOME
-		userHome = os.Getenv("HOME")
-		if userHome != "" {
-			err = nil
+	if usr, err := currentUser(); err == nil && usr.HomeDir != "" {
+		return usr.HomeDir, nil
+	}
+	for _, name := range []string{"HOME", "USERPROFILE"} {
+		if userHome := os.Getenv(name); userHome != "" {
+			return userHome, nil
 		}
-	} else {
-		userHome = usr.HomeDir
 	}
-	return userHome, nil
+	return "", fmt.Errorf("unable to resolve current user home directory")
 }
 
+// utf8BOM is the byte order mark some editors, like Notepad, insert at the
+// beginning of UTF-8 files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
 // appendConfigurationFile only if it exists. We need to do this because
 // ini package will fail to load configuration at all if a configuration
-// file is missing. This is racy, but better than always failing.
-func appendConfigurationFile(cfg *ini.File, path string) {
-	if file, err := os.Open(path); err == nil {
-		file.Close()
-		cfg.Append(path)
+// file is missing. The file is read upfront so that a leading UTF-8 BOM can be
+// stripped before it reaches the ini parser.
+//
+// When StrictConfig is set, a file which exists but can not be read is
+// reported as an error instead of being silently skipped.
+func appendConfigurationFile(cfg *ini.File, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if StrictConfig && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to read configuration file '%s': %v", path, err)
+		}
+		return nil
 	}
+	data, err = iniConfig(path, bytes.TrimPrefix(data, utf8BOM))
+	if err != nil {
+		if StrictConfig {
+			return fmt.Errorf("unable to parse configuration file '%s': %v", path, err)
+		}
+		return nil
+	}
+	cfg.Append(data)
+	return nil
+}
+
+// localConfigDisabled checks if the OVH_DISABLE_LOCAL_CONFIG environment
+// variable forbids loading the configuration file from the working directory.
+// This should be set when running in untrusted directories.
+func (c *Client) localConfigDisabled() bool {
+	disabled, err := strconv.ParseBool(os.Getenv(c.envVar("DISABLE_LOCAL_CONFIG")))
+	return err == nil && disabled
+}
+
+// appendConfigurationDir appends all "*.conf" files from a drop-in directory,
+// in lexical order. This is a no-op if the directory does not exist.
+func appendConfigurationDir(cfg *ini.File, path string) error {
+	files, err := filepath.Glob(filepath.Join(path, "*.conf"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := appendConfigurationFile(cfg, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configFilePath returns the configuration file explicitly requested for the
+// client, if any
+func (c *Client) configFilePath() string {
+	if c.configFile != "" {
+		return c.configFile
+	}
+	return os.Getenv(c.envVar("CONFIG"))
+}
+
+// profileName returns the configuration profile selected for the client with
+// WithProfile or the OVH_PROFILE environment variable, if any
+func (c *Client) profileName() string {
+	if c.profile != "" {
+		return strings.TrimSpace(c.profile)
+	}
+	return strings.TrimSpace(os.Getenv(c.envVar("PROFILE")))
+}
+
+// loadConfigFiles loads the requested configuration file or, by default, all
+// the configuration files by order of increasing priority. Default
+// configuration files are optional. Only load file from user home if home
+// could be resolved.
+func (c *Client) loadConfigFiles() (*ini.File, error) {
+	cfg := ini.Empty()
+	if path := c.configFilePath(); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read configuration file '%s': %v", path, err)
+		}
+		data, err = iniConfig(path, bytes.TrimPrefix(data, utf8BOM))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse configuration file '%s': %v", path, err)
+		}
+		if err := cfg.Append(data); err != nil {
+			return nil, fmt.Errorf("unable to parse configuration file '%s': %v", path, err)
+		}
+		return cfg, nil
+	}
+
+	if err := appendConfigurationFile(cfg, systemConfigPath); err != nil {
+		return nil, err
+	}
+	if err := appendConfigurationDir(cfg, systemConfigDirPath); err != nil {
+		return nil, err
+	}
+	if home, err := currentUserHome(); err == nil {
+		userConfigFullPath := filepath.Join(home, userConfigPath)
+		if err := appendConfigurationFile(cfg, userConfigFullPath); err != nil {
+			return nil, err
+		}
+	}
+	if !c.localConfigDisabled() {
+		if err := appendConfigurationFile(cfg, localConfigPath); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
 }
 
 // loadConfig loads client configuration from params, environments or configuration
 // files (by order of decreasing precedence).
 //
-// loadConfig will check OVH_CONSUMER_KEY, OVH_APPLICATION_KEY, OVH_APPLICATION_SECRET
-// and OVH_ENDPOINT environment variables. If any is present, it will take precedence
+// loadConfig will check OVH_CONSUMER_KEY, OVH_APPLICATION_KEY, OVH_APPLICATION_SECRET,
+// OVH_CLIENT_ID, OVH_CLIENT_SECRET and OVH_ENDPOINT environment variables. If any is present, it will take precedence
 // over any configuration from file.
 //
+// Surrounding whitespace is trimmed from the endpoint name, the application key
+// and the consumer key, wherever they come from. The application secret is
+// never altered.
+//
 // Configuration files are ini files. They share the same format as python-ovh,
 // node-ovh, php-ovh and all other wrappers. If any wrapper is configured, all
-// can re-use the same configuration. loadConfig will check for configuration in:
+// can re-use the same configuration. They may also be JSON or YAML documents,
+// detected from their extension or content, see iniConfig. loadConfig will
+// check for configuration in:
 //
-// - ./ovh.conf
+// - ./ovh.conf, unless OVH_DISABLE_LOCAL_CONFIG is set to a true value
 // - $HOME/.ovh.conf
+// - /etc/ovh.conf.d/*.conf, in lexical order
 // - /etc/ovh.conf
 //
+// When a configuration file is given to NewClientWithConfigFile, or with the
+// OVH_CONFIG environment variable, only this file is loaded and it must exist.
+//
+// A profile, selected with WithProfile or the OVH_PROFILE environment
+// variable, reads the endpoint and credentials from the "[profile <name>]"
+// section instead of the endpoint one.
+//
+// Environment variables are prefixed with "OVH_" unless another prefix is
+// set with WithEnvPrefix.
 func (c *Client) loadConfig(endpointName string) error {
-	// Load configuration files by order of increasing priority. All configuration
-	// files are optional. Only load file from user home if home could be resolve
-	cfg := ini.Empty()
-	appendConfigurationFile(cfg, systemConfigPath)
-	if home, err := currentUserHome(); err == nil {
-		userConfigFullPath := filepath.Join(home, userConfigPath)
-		appendConfigurationFile(cfg, userConfigFullPath)
+	c.configEndpoint = endpointName
+	c.configParams = c.credentials()
+
+	cfg, err := c.newConfig()
+	if err != nil {
+		return err
 	}
-	appendConfigurationFile(cfg, localConfigPath)
+	if err := c.loadConfigFrom(cfg, endpointName); err != nil {
+		return err
+	}
+	c.secretFiles = cfg.files
+	c.configConsumerKey = c.ConsumerKey
+	return nil
+}
 
-	// Canonicalize configuration
-	if endpointName == "" {
-		endpointName = getConfigValue(cfg, "default", "endpoint", "ovh-eu")
+// newConfig loads the configuration files of the client
+func (c *Client) newConfig() (*config, error) {
+	files, err := c.loadConfigFiles()
+	if err != nil {
+		return nil, err
 	}
+	return &config{File: files, envPrefix: c.envVar(""), values: map[string]ConfigValue{}}, nil
+}
 
-	if c.AppKey == "" {
-		c.AppKey = getConfigValue(cfg, endpointName, "application_key", "")
+// loadConfigFrom loads the client configuration, see loadConfig
+func (c *Client) loadConfigFrom(cfg *config, endpointName string) error {
+
+	// Canonicalize configuration. Endpoint names, application keys and
+	// consumer keys never contain whitespace, trim any stray one. Application
+	// secrets are used as is.
+	endpointName = strings.TrimSpace(endpointName)
+
+	// Credentials are read from the endpoint section or, when a profile is
+	// selected, from its section which also provides the endpoint
+	section := endpointName
+	if profile := c.profileName(); profile != "" {
+		section = "profile " + profile
+		if !cfg.HasSection(section) {
+			return fmt.Errorf("unknown profile '%s', please check your configuration", profile)
+		}
+		if endpointName == "" {
+			endpointName = strings.TrimSpace(getConfigValue(cfg, section, "endpoint", ""))
+		}
+	}
+	if endpointName == "" {
+		endpointName = strings.TrimSpace(getConfigValue(cfg, "default", "endpoint", "ovh-eu"))
+	}
+	if section == "" {
+		section = endpointName
 	}
 
-	if c.AppSecret == "" {
-		c.AppSecret = getConfigValue(cfg, endpointName, "application_secret", "")
+	if err := c.loadCredentials(cfg, section); err != nil {
+		return err
 	}
 
-	if c.ConsumerKey == "" {
-		c.ConsumerKey = getConfigValue(cfg, endpointName, "consumer_key", "")
+	// User-Agent may be set per endpoint, or profile, or globally in the
+	// default section
+	if c.userAgent == "" {
+		c.userAgent = getConfigValue(cfg, section, "user_agent", "")
+	}
+	if c.userAgent == "" {
+		c.userAgent = getConfigValue(cfg, "default", "user_agent", "")
 	}
 
 	// Load real endpoint URL by name. If endpoint contains a '/', consider it as a URL
-	if strings.Contains(endpointName, "/") {
-		c.endpoint = endpointName
-	} else {
-		c.endpoint = Endpoints[endpointName]
-	}
+	c.endpoint = resolveEndpoint(endpointName)
 
 	// If we still have no valid endpoint, AppKey or AppSecret, return an error
 	if c.endpoint == "" {
 		return fmt.Errorf("unknown endpoint '%s', consider checking 'Endpoints' list of using an URL", endpointName)
 	}
+
+	// OAuth2 credentials replace the application keys
+	if c.ClientID != "" || c.ClientSecret != "" {
+		if c.AppKey != "" || c.AppSecret != "" || c.ConsumerKey != "" {
+			return fmt.Errorf("can not use both application keys and OAuth2 client credentials, please check your configuration")
+		}
+		if c.ClientID == "" {
+			return fmt.Errorf("missing OAuth2 client ID, please check your configuration")
+		}
+		if c.ClientSecret == "" {
+			return fmt.Errorf("missing OAuth2 client secret, please check your configuration")
+		}
+		return c.initOAuth2()
+	}
 	if c.AppKey == "" {
 		return fmt.Errorf("missing application key, please check your configuration or consult the documentation to create one")
 	}
@@ -109,22 +285,25 @@ func (c *Client) loadConfig(endpointName string) error {
 
 // getConfigValue returns the value of OVH_<NAME> or ``name`` value from ``section``. If
 // the value could not be read from either env or any configuration files, return 'def'
-func getConfigValue(cfg *ini.File, section, name, def string) string {
+func getConfigValue(cfg *config, section, name, def string) string {
 	// Attempt to load from environment
-	fromEnv := os.Getenv("OVH_" + strings.ToUpper(name))
+	fromEnv := cfg.getenv(name)
 	if len(fromEnv) > 0 {
+		cfg.record(name, fromEnv, ConfigSourceEnv, cfg.envName(name))
 		return fromEnv
 	}
 
 	// Attempt to load from configuration
 	fromSection := cfg.Section(section)
 	if fromSection == nil {
-		return def
+		return cfg.recordDefault(name, def)
 	}
 
 	fromSectionKey := fromSection.Key(name)
 	if fromSectionKey == nil {
-		return def
+		return cfg.recordDefault(name, def)
 	}
-	return fromSectionKey.String()
+	value := fromSectionKey.String()
+	cfg.record(name, value, ConfigSourceFile, sectionKeyName(section, name))
+	return value
 }
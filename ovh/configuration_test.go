@@ -1,9 +1,13 @@
 package ovh
 
 import (
+	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"os/user"
 	"testing"
+	"time"
 )
 
 //
@@ -14,6 +18,7 @@ var home string
 
 func setup() {
 	systemConfigPath = "./ovh.unittest.global.conf"
+	systemConfigDirPath = "./ovh.unittest.global.conf.d"
 	userConfigPath = "/.ovh.unittest.user.conf"
 	localConfigPath = "./ovh.unittest.local.conf"
 	home, _ = currentUserHome()
@@ -21,6 +26,7 @@ func setup() {
 
 func teardown() {
 	os.Remove(systemConfigPath)
+	os.RemoveAll(systemConfigDirPath)
 	os.Remove(home + userConfigPath)
 	os.Remove(localConfigPath)
 }
@@ -111,6 +117,114 @@ consumer_key=user
 	}
 }
 
+func TestConfigDisableLocal(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(home+userConfigPath, []byte(`
+[ovh-eu]
+application_key=user
+application_secret=user
+consumer_key=user
+`), 0660)
+	ioutil.WriteFile(localConfigPath, []byte(`
+[ovh-eu]
+consumer_key=local
+`), 0660)
+	os.Setenv("OVH_DISABLE_LOCAL_CONFIG", "1")
+
+	// Clear
+	defer ioutil.WriteFile(home+userConfigPath, []byte(``), 0660)
+	defer ioutil.WriteFile(localConfigPath, []byte(``), 0660)
+	defer os.Unsetenv("OVH_DISABLE_LOCAL_CONFIG")
+
+	// Test: local file is ignored
+	client := Client{}
+	if err := client.loadConfig("ovh-eu"); err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.ConsumerKey != "user" {
+		t.Fatalf("client.ConsumerKey should be 'user'. Got '%s'", client.ConsumerKey)
+	}
+
+	// Test: local file is loaded when the flag is false
+	os.Setenv("OVH_DISABLE_LOCAL_CONFIG", "0")
+	client = Client{}
+	if err := client.loadConfig("ovh-eu"); err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.ConsumerKey != "local" {
+		t.Fatalf("client.ConsumerKey should be 'local'. Got '%s'", client.ConsumerKey)
+	}
+}
+
+func TestConfigFromDropInDir(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=system
+application_secret=system
+consumer_key=system
+`), 0660)
+	os.Mkdir(systemConfigDirPath, 0770)
+	ioutil.WriteFile(systemConfigDirPath+"/10-first.conf", []byte(`
+[ovh-eu]
+application_secret=first
+consumer_key=first
+`), 0660)
+	ioutil.WriteFile(systemConfigDirPath+"/20-second.conf", []byte(`
+[ovh-eu]
+consumer_key=second
+`), 0660)
+	ioutil.WriteFile(systemConfigDirPath+"/30-ignored.txt", []byte(`
+[ovh-eu]
+consumer_key=ignored
+`), 0660)
+
+	// Clear
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	defer os.RemoveAll(systemConfigDirPath)
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "system" {
+		t.Fatalf("client.AppKey should be 'system'. Got '%s'", client.AppKey)
+	}
+	if client.AppSecret != "first" {
+		t.Fatalf("client.AppSecret should be 'first'. Got '%s'", client.AppSecret)
+	}
+	if client.ConsumerKey != "second" {
+		t.Fatalf("client.ConsumerKey should be 'second'. Got '%s'", client.ConsumerKey)
+	}
+}
+
+func TestConfigWithBOM(t *testing.T) {
+	// Prepare: a file saved by Notepad, with a UTF-8 BOM and CRLF line endings
+	ioutil.WriteFile(localConfigPath, []byte("\xEF\xBB\xBF[ovh-eu]\r\napplication_key=bom\r\napplication_secret=bom\r\n"), 0660)
+
+	// Clear
+	defer ioutil.WriteFile(localConfigPath, []byte(``), 0660)
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "bom" {
+		t.Fatalf("client.AppKey should be 'bom'. Got '%s'", client.AppKey)
+	}
+	if client.AppSecret != "bom" {
+		t.Fatalf("client.AppSecret should be 'bom'. Got '%s'", client.AppSecret)
+	}
+}
+
 func TestConfigFromEnv(t *testing.T) {
 	// Prepare
 	ioutil.WriteFile(systemConfigPath, []byte(`
@@ -220,6 +334,121 @@ consumer_key=example.com
 
 }
 
+func TestConfigUserAgent(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(localConfigPath, []byte(`
+[default]
+user_agent=deployment-42
+`), 0660)
+
+	// Clear
+	defer ioutil.WriteFile(localConfigPath, []byte(``), 0660)
+
+	// Test: from configuration
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	if err := client.Get("/some/resource", nil); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+	ensureHeaderPresent(t, InputRequest, "User-Agent", "deployment-42 "+userAgent)
+
+	// Test: programmatic value wins
+	client.SetUserAgent("my-app/1.0")
+	if err := client.Get("/some/resource", nil); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+	ensureHeaderPresent(t, InputRequest, "User-Agent", "my-app/1.0 "+userAgent)
+}
+
+func TestConfigWhitespace(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[default]
+endpoint = "ovh-eu "
+
+[ovh-eu]
+application_key = " system "
+application_secret = " system "
+consumer_key = "system	"
+`), 0660)
+
+	// Clear
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test: from file
+	client := Client{}
+	err := client.loadConfig("")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.endpoint != OvhEU {
+		t.Fatalf("client.endpoint should be '%s'. Got '%s'", OvhEU, client.endpoint)
+	}
+	if client.AppKey != "system" {
+		t.Fatalf("client.AppKey should be 'system'. Got '%s'", client.AppKey)
+	}
+	if client.AppSecret != " system " {
+		t.Fatalf("client.AppSecret should not be trimmed. Got '%s'", client.AppSecret)
+	}
+	if client.ConsumerKey != "system" {
+		t.Fatalf("client.ConsumerKey should be 'system'. Got '%s'", client.ConsumerKey)
+	}
+
+	// Test: from environment
+	os.Setenv("OVH_ENDPOINT", " ovh-ca\n")
+	defer os.Unsetenv("OVH_ENDPOINT")
+
+	client = Client{AppKey: "param", AppSecret: "param"}
+	if err = client.loadConfig(""); err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.endpoint != OvhCA {
+		t.Fatalf("client.endpoint should be '%s'. Got '%s'", OvhCA, client.endpoint)
+	}
+
+	// Test: from parameters
+	client = Client{AppKey: "param", AppSecret: "param"}
+	if err = client.loadConfig("\tovh-us "); err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.endpoint != OvhUS {
+		t.Fatalf("client.endpoint should be '%s'. Got '%s'", OvhUS, client.endpoint)
+	}
+}
+
+func TestCurrentUserHome(t *testing.T) {
+	// Prepare: current user can not be resolved
+	currentUser = func() (*user.User, error) {
+		return nil, fmt.Errorf("unknown user")
+	}
+	defer func() { currentUser = user.Current }()
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	defer os.Setenv("USERPROFILE", os.Getenv("USERPROFILE"))
+
+	// Test: $HOME
+	os.Setenv("HOME", "/home/user")
+	os.Setenv("USERPROFILE", `C:\Users\user`)
+	if got, err := currentUserHome(); err != nil || got != "/home/user" {
+		t.Fatalf("currentUserHome should fallback on $HOME. Got '%s' (%v)", got, err)
+	}
+
+	// Test: %USERPROFILE%
+	os.Unsetenv("HOME")
+	if got, err := currentUserHome(); err != nil || got != `C:\Users\user` {
+		t.Fatalf("currentUserHome should fallback on %%USERPROFILE%%. Got '%s' (%v)", got, err)
+	}
+
+	// Test: unresolvable
+	os.Unsetenv("USERPROFILE")
+	if _, err := currentUserHome(); err == nil {
+		t.Fatalf("currentUserHome should fail when no home directory can be found")
+	}
+}
+
 func TestMissingParam(t *testing.T) {
 	// Setup
 	var err error
@@ -248,6 +477,141 @@ func TestMissingParam(t *testing.T) {
 	client.AppSecret = "param"
 }
 
+func TestConfigFromConfigFile(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=system
+application_secret=system
+consumer_key=system
+`), 0660)
+	path := "./ovh.unittest.custom.conf"
+	ioutil.WriteFile(path, []byte(`
+[ovh-ca]
+application_key=custom
+application_secret=custom
+`), 0660)
+
+	// Clear
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	defer os.Remove(path)
+
+	// Test
+	client, err := NewClientWithConfigFile(path, "ovh-ca")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("NewClientWithConfigFile failed with: '%v'", err)
+	}
+	if client.AppKey != "custom" || client.AppSecret != "custom" {
+		t.Fatalf("client should be configured from the custom file. Got '%s', '%s'", client.AppKey, client.AppSecret)
+	}
+	if client.ConsumerKey != "" {
+		t.Fatalf("default configuration files should be ignored. Got '%s'", client.ConsumerKey)
+	}
+	if client.endpoint != OvhCA {
+		t.Fatalf("client.endpoint should be the OVH CA one. Got '%s'", client.endpoint)
+	}
+
+	if _, err := NewClientWithConfigFile("./ovh.unittest.missing.conf", "ovh-eu"); err == nil {
+		t.Fatalf("NewClientWithConfigFile should fail when the file is missing")
+	}
+}
+
+func TestConfigFromConfigEnv(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(localConfigPath, []byte(`
+[ovh-eu]
+application_key=local
+application_secret=local
+`), 0660)
+	path := "./ovh.unittest.env.conf"
+	ioutil.WriteFile(path, []byte(`
+[ovh-eu]
+application_key=env
+application_secret=env
+`), 0660)
+	os.Setenv("OVH_CONFIG", path)
+
+	// Clear
+	defer ioutil.WriteFile(localConfigPath, []byte(``), 0660)
+	defer os.Remove(path)
+	defer os.Unsetenv("OVH_CONFIG")
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "env" || client.AppSecret != "env" {
+		t.Fatalf("client should be configured from OVH_CONFIG. Got '%s', '%s'", client.AppKey, client.AppSecret)
+	}
+
+	os.Setenv("OVH_CONFIG", "./ovh.unittest.missing.conf")
+	if err := client.loadConfig("ovh-eu"); err == nil {
+		t.Fatalf("loadConfig should fail when OVH_CONFIG file is missing")
+	}
+}
+
+func TestConfigProfiles(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[default]
+endpoint=ovh-eu
+
+[ovh-eu]
+application_key=eu
+application_secret=eu
+
+[profile prod]
+endpoint=ovh-ca
+application_key=prod
+application_secret=prod
+consumer_key=prod
+
+[profile staging]
+application_key=staging
+application_secret=staging
+`), 0660)
+
+	// Clear
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test: the option selects the profile endpoint and credentials
+	client, err := NewClientWithOptions("", WithProfile("prod"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions failed with: '%v'", err)
+	}
+	if client.endpoint != OvhCA || client.AppKey != "prod" || client.ConsumerKey != "prod" {
+		t.Fatalf("client should be configured from the 'prod' profile. Got '%s', '%s', '%s'", client.endpoint, client.AppKey, client.ConsumerKey)
+	}
+
+	// Test: OVH_PROFILE selects the profile, falling back on the default endpoint
+	os.Setenv("OVH_PROFILE", "staging")
+	defer os.Unsetenv("OVH_PROFILE")
+	client = &Client{}
+	if err := client.loadConfig(""); err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.endpoint != OvhEU || client.AppKey != "staging" {
+		t.Fatalf("client should be configured from the 'staging' profile. Got '%s', '%s'", client.endpoint, client.AppKey)
+	}
+
+	// Test: the option takes precedence over OVH_PROFILE
+	client, err = NewClientWithOptions("", WithProfile("prod"))
+	if err != nil || client.AppKey != "prod" {
+		t.Fatalf("WithProfile should take precedence over OVH_PROFILE. Got %v", err)
+	}
+
+	// Test: unknown profiles are an error
+	if _, err := NewClientWithOptions("", WithProfile("unknown")); err == nil {
+		t.Fatalf("NewClientWithOptions should fail with an unknown profile")
+	}
+}
+
 //
 // Main
 //
@@ -0,0 +1,49 @@
+//go:build !windows
+// +build !windows
+
+package ovh
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestConfigUnreadableFile(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("file permissions are not enforced for root")
+	}
+
+	// Prepare
+	ioutil.WriteFile(home+userConfigPath, []byte(`
+[ovh-eu]
+application_key=user
+application_secret=user
+consumer_key=user
+`), 0000)
+
+	// Clear
+	defer os.Remove(home + userConfigPath)
+
+	// Test: unreadable files are skipped by default
+	client := Client{AppKey: "param", AppSecret: "param"}
+	if err := client.loadConfig("ovh-eu"); err != nil {
+		t.Fatalf("loadConfig should skip unreadable files when not strict. Got '%v'", err)
+	}
+
+	// Test: unreadable files are an error in strict mode
+	StrictConfig = true
+	defer func() { StrictConfig = false }()
+
+	client = Client{AppKey: "param", AppSecret: "param"}
+	if err := client.loadConfig("ovh-eu"); err == nil {
+		t.Fatalf("loadConfig should fail on unreadable files in strict mode")
+	}
+
+	// Test: missing files are fine, even in strict mode
+	os.Remove(home + userConfigPath)
+	client = Client{AppKey: "param", AppSecret: "param"}
+	if err := client.loadConfig("ovh-eu"); err != nil {
+		t.Fatalf("loadConfig should ignore missing files in strict mode. Got '%v'", err)
+	}
+}
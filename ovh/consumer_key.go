@@ -1,8 +1,10 @@
 package ovh
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Map user friendly access level names to corresponding HTTP verbs
@@ -25,6 +27,20 @@ type AccessRule struct {
 	Path string `json:"path"`
 }
 
+// Credential represents a consumer key, as returned by
+// GET /auth/currentCredential
+type Credential struct {
+	CredentialID  int64        `json:"credentialId"`
+	ApplicationID int64        `json:"applicationId"`
+	Status        string       `json:"status"`
+	Rules         []AccessRule `json:"rules"`
+	AllowedIPs    []string     `json:"allowedIPs"`
+	Creation      *time.Time   `json:"creation"`
+	Expiration    *time.Time   `json:"expiration"`
+	LastUse       *time.Time   `json:"lastUse"`
+	OVHSupport    bool         `json:"ovhSupport"`
+}
+
 // CkValidationState represents the response when asking a new consumerKey.
 type CkValidationState struct {
 	// Consumer key, which need to be validated by customer.
@@ -102,12 +118,91 @@ func (ck *CkRequest) AddRecursiveRules(methods []string, path string) {
 // Do executes the request. On success, set the consumer key in the client
 // and return the URL the user needs to visit to validate the key
 func (ck *CkRequest) Do() (*CkValidationState, error) {
+	return ck.DoWithContext(context.Background())
+}
+
+// DoWithContext executes the request. On success, set the consumer key in the
+// client and return the URL the user needs to visit to validate the key
+func (ck *CkRequest) DoWithContext(ctx context.Context) (*CkValidationState, error) {
 	state := CkValidationState{}
-	err := ck.client.PostUnAuth("/auth/credential", ck, &state)
+	err := ck.client.PostUnAuthWithContext(withInternalCall(ctx), "/auth/credential", ck, &state)
 
 	if err == nil {
+		ck.client.keysMutex.Lock()
 		ck.client.ConsumerKey = state.ConsumerKey
+		ck.client.keysMutex.Unlock()
 	}
 
 	return &state, err
 }
+
+// HasRules checks that the current consumer key grants all the "required"
+// rules, by fetching its rules from /auth/currentCredential. It returns
+// whether all rules are granted and the list of missing rules, if any.
+//
+// Required rule paths may use the '*' wildcard, they are then only covered by
+// granted rules at least as broad. For instance, "/domain/*" is covered by
+// both "/*" and "/domain/*" but not by "/domain/zone/*".
+func (c *Client) HasRules(required []AccessRule) (bool, []AccessRule, error) {
+	return c.HasRulesWithContext(context.Background(), required)
+}
+
+// HasRulesWithContext checks that the current consumer key grants all the
+// "required" rules. See HasRules.
+func (c *Client) HasRulesWithContext(ctx context.Context, required []AccessRule) (bool, []AccessRule, error) {
+	credential, err := c.CurrentCredential(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	missing := missingRules(credential.Rules, required)
+	return len(missing) == 0, missing, nil
+}
+
+// missingRules returns the "required" rules not covered by the "granted" ones
+func missingRules(granted, required []AccessRule) []AccessRule {
+	missing := []AccessRule{}
+	for _, rule := range required {
+		if !rulesCover(granted, rule) {
+			missing = append(missing, rule)
+		}
+	}
+	return missing
+}
+
+// rulesCover checks if any of the "granted" rules covers the "required" rule
+func rulesCover(granted []AccessRule, required AccessRule) bool {
+	for _, rule := range granted {
+		if strings.EqualFold(rule.Method, required.Method) && matchPath(rule.Path, required.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath checks if "path" matches "pattern" where '*' matches any sequence
+// of characters, including '/'
+func matchPath(pattern, path string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == '*' {
+			// Collapse consecutive wildcards, then try all possible suffixes
+			for len(pattern) > 0 && pattern[0] == '*' {
+				pattern = pattern[1:]
+			}
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(path); i++ {
+				if matchPath(pattern, path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if path == "" || pattern[0] != path[0] {
+			return false
+		}
+		pattern = pattern[1:]
+		path = path[1:]
+	}
+	return path == ""
+}
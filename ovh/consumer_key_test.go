@@ -51,6 +51,29 @@ func TestNewCkRequest(t *testing.T) {
 	ensureHeaderPresent(t, InputRequest, "X-Ovh-Application", MockApplicationKey)
 }
 
+func TestCkRequestConcurrentCredentials(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{"consumerKey":"new-consumer-key","state":"pendingValidation"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test: credentials are read while the consumer key is replaced
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			client.credentials()
+		}
+	}()
+	_, err := client.NewCkRequest().Do()
+	<-done
+
+	// Validate
+	if err != nil || client.credentials().consumerKey != "new-consumer-key" {
+		t.Fatalf("CkRequest.Do() should set the consumer key. Got %v, %s", err, client.credentials().consumerKey)
+	}
+}
+
 func TestInvalidCkRequest(t *testing.T) {
 	// Init test
 	var InputRequest *http.Request
@@ -156,3 +179,52 @@ func TestCkRequestRedirection(t *testing.T) {
 		t.Fatalf("NewCkRequestWithRedirection should set ckRequest.Redirection")
 	}
 }
+
+func TestHasRules(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{
+		"status":"validated",
+		"rules":[
+			{"method":"GET","path":"/*"},
+			{"method":"POST","path":"/domain/zone/*"},
+			{"method":"DELETE","path":"/domain/zone/*/record/*"}
+		]
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test: all covered
+	ok, missing, err := client.HasRules([]AccessRule{
+		{Method: "GET", Path: "/me"},
+		{Method: "GET", Path: "/domain/*"},
+		{Method: "POST", Path: "/domain/zone/example.com/refresh"},
+		{Method: "DELETE", Path: "/domain/zone/example.com/record/42"},
+	})
+	if err != nil {
+		t.Fatalf("HasRules should not return an error. Got: %v", err)
+	}
+	if InputRequest.Method != "GET" || InputRequest.URL.String() != "/auth/currentCredential" {
+		t.Fatalf("HasRules should call GET /auth/currentCredential. Got %s %s", InputRequest.Method, InputRequest.URL.String())
+	}
+	if !ok || len(missing) != 0 {
+		t.Fatalf("HasRules should report all rules as covered. Got %v, missing %v", ok, missing)
+	}
+
+	// Test: partially covered
+	ok, missing, err = client.HasRules([]AccessRule{
+		{Method: "GET", Path: "/me"},
+		{Method: "POST", Path: "/domain/*"},
+		{Method: "PUT", Path: "/domain/zone/example.com/record/42"},
+		{Method: "DELETE", Path: "/domain/zone/example.com/record/42"},
+	})
+	if err != nil {
+		t.Fatalf("HasRules should not return an error. Got: %v", err)
+	}
+	expected := []AccessRule{
+		{Method: "POST", Path: "/domain/*"},
+		{Method: "PUT", Path: "/domain/zone/example.com/record/42"},
+	}
+	if ok || !reflect.DeepEqual(missing, expected) {
+		t.Fatalf("HasRules should report %v as missing. Got %v, missing %v", expected, ok, missing)
+	}
+}
@@ -0,0 +1,179 @@
+package ovh
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// CredentialProvider fetches credentials from a secret store, so that they do
+// not have to be written in configuration files.
+type CredentialProvider interface {
+	// Credential returns the value of "key", for example "application_secret",
+	// for the endpoint "endpoint", as named in the configuration. An empty
+	// value is returned, without error, when the store has no such credential.
+	Credential(endpoint, key string) (string, error)
+}
+
+// CredentialProviders maps the names accepted by the "credential_backend"
+// configuration key to their provider. Custom providers may be registered.
+//
+// The "keyring" provider reads the OS credential store: the Keychain on macOS,
+// the Credential Manager on Windows and the Secret Service on Linux, see
+// KeyringProvider.
+var CredentialProviders = map[string]CredentialProvider{
+	"keyring": KeyringProvider{},
+}
+
+// Configuration keys of both authentication modes
+var (
+	appCredentialKeys    = []string{"application_key", "application_secret", "consumer_key"}
+	oauth2CredentialKeys = []string{"client_id", "client_secret"}
+)
+
+// credentialProvider returns the provider selected by the
+// "credential_backend" key of the endpoint or default section, if any. The
+// "vault_path" key selects a VaultProvider when no backend is set.
+func credentialProvider(cfg *config, endpointName string) (CredentialProvider, error) {
+	backend := getConfigValue(cfg, endpointName, "credential_backend", "")
+	if backend == "" {
+		backend = getConfigValue(cfg, "default", "credential_backend", "")
+	}
+	backend = strings.TrimSpace(backend)
+	if backend == "" {
+		vaultPath := getConfigValue(cfg, endpointName, "vault_path", "")
+		if vaultPath == "" {
+			vaultPath = getConfigValue(cfg, "default", "vault_path", "")
+		}
+		if vaultPath = strings.TrimSpace(vaultPath); vaultPath != "" {
+			return NewVaultProvider(vaultPath), nil
+		}
+		return nil, nil
+	}
+
+	provider, ok := CredentialProviders[backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown credential backend '%s', consider checking 'CredentialProviders' list", backend)
+	}
+	return provider, nil
+}
+
+// loadCredentials loads the application keys or OAuth2 credentials missing
+// from the client, first from environment and configuration files, then from
+// the credential backend.
+//
+// Credentials given as parameters select the authentication mode: keys of the
+// other mode are not loaded. Otherwise, both are loaded and must not be mixed.
+func (c *Client) loadCredentials(cfg *config, endpointName string) error {
+	oauth2Params := c.ClientID != "" || c.ClientSecret != ""
+	keysParams := c.AppKey != "" || c.AppSecret != "" || c.ConsumerKey != ""
+
+	appValues := []*string{&c.AppKey, &c.AppSecret, &c.ConsumerKey}
+	oauth2Values := []*string{&c.ClientID, &c.ClientSecret}
+
+	if !oauth2Params {
+		if err := loadConfigValues(cfg, endpointName, appCredentialKeys, appValues); err != nil {
+			return err
+		}
+	}
+	if !keysParams {
+		if err := loadConfigValues(cfg, endpointName, oauth2CredentialKeys, oauth2Values); err != nil {
+			return err
+		}
+	}
+
+	provider, err := credentialProvider(cfg, endpointName)
+	if err != nil {
+		return err
+	}
+	// Only query the backend for the authentication mode in use, if known
+	if provider != nil {
+		switch {
+		case c.ClientID != "" || c.ClientSecret != "":
+			err = loadProviderValues(cfg, provider, endpointName, oauth2CredentialKeys, oauth2Values)
+		case c.AppKey != "" || c.AppSecret != "" || c.ConsumerKey != "":
+			err = loadProviderValues(cfg, provider, endpointName, appCredentialKeys, appValues)
+		default:
+			err = loadProviderValues(cfg, provider, endpointName, appCredentialKeys, appValues)
+			if err == nil && c.AppKey == "" && c.AppSecret == "" {
+				err = loadProviderValues(cfg, provider, endpointName, oauth2CredentialKeys, oauth2Values)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	c.AppKey = strings.TrimSpace(c.AppKey)
+	c.ConsumerKey = strings.TrimSpace(c.ConsumerKey)
+	c.ClientID = strings.TrimSpace(c.ClientID)
+	return nil
+}
+
+// loadConfigValues fills the empty values from environment or configuration
+func loadConfigValues(cfg *config, endpointName string, keys []string, values []*string) error {
+	for i, key := range keys {
+		if *values[i] != "" {
+			continue
+		}
+		value, err := getConfigSecret(cfg, endpointName, key)
+		if err != nil {
+			return err
+		}
+		*values[i] = value
+	}
+	return nil
+}
+
+// getConfigSecret returns the value of a credential from environment or
+// configuration, like getConfigValue, or from the file named by the
+// "<name>_file" key, like OVH_APPLICATION_SECRET_FILE, as mounted by Docker
+// or Kubernetes secrets. By order of decreasing precedence: OVH_<NAME>,
+// OVH_<NAME>_FILE, then the "<name>" and "<name>_file" keys of the section.
+//
+// Trailing newlines are stripped from files, the rest of their content is
+// used as is.
+func getConfigSecret(cfg *config, section, name string) (string, error) {
+	if value := cfg.getenv(name); value != "" {
+		cfg.record(name, value, ConfigSourceEnv, cfg.envName(name))
+		return value, nil
+	}
+	source, origin := ConfigSourceEnv, cfg.envName(name+"_file")
+	path := cfg.getenv(name + "_file")
+	if path == "" {
+		if value := cfg.Section(section).Key(name).String(); value != "" {
+			cfg.record(name, value, ConfigSourceFile, sectionKeyName(section, name))
+			return value, nil
+		}
+		source, origin = ConfigSourceFile, sectionKeyName(section, name+"_file")
+		path = cfg.Section(section).Key(name + "_file").String()
+	}
+	if path = strings.TrimSpace(path); path == "" {
+		return "", nil
+	}
+
+	cfg.files = append(cfg.files, path)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read '%s' from file '%s': %v", name, path, err)
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	cfg.record(name, value, source, origin)
+	return value, nil
+}
+
+// loadProviderValues fills the empty values from a credential provider
+func loadProviderValues(cfg *config, provider CredentialProvider, endpointName string, keys []string, values []*string) error {
+	for i, key := range keys {
+		if *values[i] != "" {
+			continue
+		}
+		value, err := provider.Credential(endpointName, key)
+		if err != nil {
+			return fmt.Errorf("unable to load '%s' from credential backend: %v", key, err)
+		}
+		*values[i] = value
+		cfg.record(key, value, ConfigSourceBackend, "")
+	}
+	return nil
+}
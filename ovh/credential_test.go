@@ -0,0 +1,163 @@
+package ovh
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+// mockCredentialProvider serves credentials from a map of "endpoint/key"
+type mockCredentialProvider map[string]string
+
+func (p mockCredentialProvider) Credential(endpoint, key string) (string, error) {
+	if value, ok := p[endpoint+"/"+key]; ok && value == "error" {
+		return "", errors.New("backend unavailable")
+	}
+	return p[endpoint+"/"+key], nil
+}
+
+func TestCredentialBackend(t *testing.T) {
+	// Prepare
+	CredentialProviders["mock"] = mockCredentialProvider{
+		"ovh-eu/application_secret": "backend",
+		"ovh-eu/consumer_key":       "backend",
+		"ovh-eu/client_secret":      "backend",
+	}
+	defer delete(CredentialProviders, "mock")
+
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[default]
+credential_backend=mock
+
+[ovh-eu]
+application_key=file
+consumer_key=file
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate: the backend only fills the missing credentials
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "file" || client.AppSecret != "backend" || client.ConsumerKey != "file" {
+		t.Fatalf("Missing credentials should be loaded from backend. Got '%s', '%s', '%s'", client.AppKey, client.AppSecret, client.ConsumerKey)
+	}
+	if client.ClientSecret != "" {
+		t.Fatalf("OAuth2 credentials should not be loaded along application keys. Got '%s'", client.ClientSecret)
+	}
+}
+
+func TestCredentialBackendOAuth2(t *testing.T) {
+	// Prepare
+	CredentialProviders["mock"] = mockCredentialProvider{
+		"ovh-eu/client_id":     "backend-id",
+		"ovh-eu/client_secret": "backend-secret",
+	}
+	defer delete(CredentialProviders, "mock")
+
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+credential_backend=mock
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.ClientID != "backend-id" || client.ClientSecret != "backend-secret" || client.oauth2 == nil {
+		t.Fatalf("OAuth2 credentials should be loaded from backend. Got '%s', '%s'", client.ClientID, client.ClientSecret)
+	}
+}
+
+func TestCredentialBackendErrors(t *testing.T) {
+	// Prepare
+	CredentialProviders["mock"] = mockCredentialProvider{
+		"ovh-eu/application_secret": "error",
+	}
+	defer delete(CredentialProviders, "mock")
+
+	// Test: backend error
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+credential_backend=mock
+application_key=file
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	client := Client{}
+	if err := client.loadConfig("ovh-eu"); err == nil {
+		t.Fatalf("Credential backend errors should be returned")
+	}
+
+	// Test: unknown backend
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+credential_backend=unknown
+application_key=file
+application_secret=file
+`), 0660)
+
+	client = Client{}
+	if err := client.loadConfig("ovh-eu"); err == nil {
+		t.Fatalf("Unknown credential backends should fail")
+	}
+}
+
+func TestCredentialFiles(t *testing.T) {
+	// Prepare: secrets mounted as files
+	dir, err := ioutil.TempDir("", "go-ovh-secrets")
+	if err != nil {
+		t.Fatalf("TempDir failed with: '%v'", err)
+	}
+	defer os.RemoveAll(dir)
+	envSecret := filepath.Join(dir, "env_secret")
+	fileKey := filepath.Join(dir, "consumer_key")
+	ioutil.WriteFile(envSecret, []byte("env-secret\n"), 0600)
+	ioutil.WriteFile(fileKey, []byte("file-ck\r\n"), 0600)
+
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file
+consumer_key_file=`+fileKey+`
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	os.Setenv("OVH_APPLICATION_SECRET_FILE", envSecret)
+	defer os.Unsetenv("OVH_APPLICATION_SECRET_FILE")
+
+	// Test
+	client := Client{}
+	err = client.loadConfig("ovh-eu")
+
+	// Validate: environment files take precedence over configuration values
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "file" || client.AppSecret != "env-secret" || client.ConsumerKey != "file-ck" {
+		t.Fatalf("Credentials should be read from secret files. Got '%s', '%s', '%s'", client.AppKey, client.AppSecret, client.ConsumerKey)
+	}
+
+	// Test: missing secret files are an error
+	os.Setenv("OVH_APPLICATION_SECRET_FILE", filepath.Join(dir, "missing"))
+	client = Client{}
+	err = client.loadConfig("ovh-eu")
+
+	// Validate
+	if err == nil || !strings.Contains(err.Error(), "unable to read 'application_secret' from file") {
+		t.Fatalf("loadConfig should fail on missing secret files. Got '%v'", err)
+	}
+}
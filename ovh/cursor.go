@@ -0,0 +1,93 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Cursor pagination headers, used by the v2 routes
+const (
+	CursorHeader     = "X-Pagination-Cursor"
+	CursorNextHeader = "X-Pagination-Cursor-Next"
+)
+
+// CursorPager iterates over the pages of a list endpoint using cursor
+// pagination, like the v2 routes. Each page returns the cursor of the next
+// one in the X-Pagination-Cursor-Next header, pages are only fetched when
+// requested so that arbitrarily large lists can be streamed.
+//
+//	pager := client.NewCursorPager("/iam/resource", 50)
+//	ctx = ovh.WithRequestAPIVersion(ctx, ovh.APIVersion2)
+//	for {
+//		page, ok, err := pager.Next(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		if !ok {
+//			break
+//		}
+//		var resources []Resource
+//		if err := page.Unmarshal(&resources); err != nil {
+//			return err
+//		}
+//		...
+//	}
+type CursorPager struct {
+	client *Client
+	path   string
+	size   int
+	cursor string
+	done   bool
+	err    error
+}
+
+// NewCursorPager returns a CursorPager listing "path", "size" items per page.
+// The API default page size is used when "size" is not positive.
+func (c *Client) NewCursorPager(path string, size int) *CursorPager {
+	return &CursorPager{client: c, path: path, size: size}
+}
+
+// Next fetches the next page. It returns false, without error, once all
+// pages were fetched. Once an error is returned, it is returned by all the
+// following calls.
+func (p *CursorPager) Next(ctx context.Context) (*Response, bool, error) {
+	if p.err != nil {
+		return nil, false, p.err
+	}
+	if p.done {
+		return nil, false, nil
+	}
+
+	header := http.Header{}
+	if p.size > 0 {
+		header.Set("X-Pagination-Size", strconv.Itoa(p.size))
+	}
+	if p.cursor != "" {
+		header.Set(CursorHeader, p.cursor)
+	}
+
+	page, err := p.client.callAPIFull(ctx, "GET", p.path, nil, header, true)
+	if err != nil {
+		p.err = err
+		return nil, false, err
+	}
+
+	// The last page has no next cursor
+	p.cursor = page.Header.Get(CursorNextHeader)
+	p.done = p.cursor == ""
+	return page, true, nil
+}
+
+// Cursor returns the cursor of the next page, empty when the first page was
+// not fetched yet or the last page was reached. It may be stored to resume
+// the iteration later with SetCursor.
+func (p *CursorPager) Cursor() string {
+	return p.cursor
+}
+
+// SetCursor makes the next call to Next fetch the page of "cursor"
+func (p *CursorPager) SetCursor(cursor string) {
+	p.cursor = cursor
+	p.done = false
+}
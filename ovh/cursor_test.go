@@ -0,0 +1,139 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initCursorServer starts a server listing "count" integers, 2 per page,
+// with the index of the next item as cursor
+func initCursorServer(count int) (*httptest.Server, *Client, *[]string) {
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	getEndpointForSignature = func(c *Client) string {
+		return "http://localhost"
+	}
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.Header.Get(CursorHeader)
+		requests = append(requests, fmt.Sprintf("%s %s/%s", r.URL.Path, cursor, r.Header.Get("X-Pagination-Size")))
+		if cursor == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": "Invalid cursor"}`)
+			return
+		}
+
+		first, _ := strconv.Atoi(cursor)
+		last := first + 2
+		if last < count {
+			w.Header().Set(CursorNextHeader, strconv.Itoa(last))
+		} else {
+			last = count
+		}
+		items := []int{}
+		for i := first; i < last; i++ {
+			items = append(items, i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}))
+
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+	return ts, client, &requests
+}
+
+func TestCursorPager(t *testing.T) {
+	// Init test
+	ts, client, requests := initCursorServer(5)
+	defer ts.Close()
+	ctx := WithRequestAPIVersion(context.Background(), APIVersion2)
+
+	// Test
+	var pages []string
+	pager := client.NewCursorPager("/iam/resource", 2)
+	for {
+		page, ok, err := pager.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next should not fail. Got %v", err)
+		}
+		if !ok {
+			break
+		}
+		var items []int
+		if err := page.Unmarshal(&items); err != nil {
+			t.Fatalf("Page should be decoded. Got %v", err)
+		}
+		pages = append(pages, fmt.Sprint(items))
+	}
+
+	// Validate
+	if fmt.Sprint(pages) != "[[0 1] [2 3] [4]]" {
+		t.Fatalf("CursorPager should return all pages. Got %v", pages)
+	}
+	if fmt.Sprint(*requests) != "[/v2/iam/resource /2 /v2/iam/resource 2/2 /v2/iam/resource 4/2]" {
+		t.Fatalf("CursorPager should follow the cursors. Got %v", *requests)
+	}
+	if _, ok, err := pager.Next(ctx); ok || err != nil {
+		t.Fatalf("CursorPager should stay done. Got %v and %v", ok, err)
+	}
+}
+
+func TestCursorPagerResume(t *testing.T) {
+	// Init test
+	ts, client, requests := initCursorServer(5)
+	defer ts.Close()
+	ctx := context.Background()
+
+	// Test
+	pager := client.NewCursorPager("/iam/resource", 0)
+	if _, _, err := pager.Next(ctx); err != nil {
+		t.Fatalf("Next should not fail. Got %v", err)
+	}
+	resumed := client.NewCursorPager("/iam/resource", 0)
+	resumed.SetCursor(pager.Cursor())
+	page, ok, err := resumed.Next(ctx)
+	if err != nil || !ok {
+		t.Fatalf("Resumed pager should return a page. Got %v and %v", ok, err)
+	}
+
+	// Validate
+	var items []int
+	if err := page.Unmarshal(&items); err != nil || fmt.Sprint(items) != "[2 3]" {
+		t.Fatalf("Resumed pager should return the next page. Got %v and %v", items, err)
+	}
+	if fmt.Sprint(*requests) != "[/iam/resource / /iam/resource 2/]" {
+		t.Fatalf("Pager without size should not send one. Got %v", *requests)
+	}
+}
+
+func TestCursorPagerError(t *testing.T) {
+	// Init test
+	ts, client, requests := initCursorServer(5)
+	defer ts.Close()
+	ctx := context.Background()
+
+	// Test
+	pager := client.NewCursorPager("/iam/resource", 2)
+	pager.SetCursor("invalid")
+	_, ok, err := pager.Next(ctx)
+	_, okAgain, errAgain := pager.Next(ctx)
+
+	// Validate
+	if apiErr, isAPIErr := err.(*APIError); ok || !isAPIErr || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("Next should return the API error. Got %v and %v", ok, err)
+	}
+	if okAgain || errAgain != err || len(*requests) != 1 {
+		t.Fatalf("Next should keep returning the error without calling the API. Got %v and %v", okAgain, errAgain)
+	}
+}
@@ -0,0 +1,108 @@
+package ovh
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedHeaders are never written in clear by the debug log
+var redactedHeaders = []string{"Authorization", "X-Ovh-Consumer", "X-Ovh-Signature"}
+
+// debugLogger writes one structured line per request, response and body
+type debugLogger struct {
+	client *Client
+	mutex  sync.Mutex
+	output io.Writer
+}
+
+// latencyLogger is implemented by loggers which log the latency of the
+// responses, measured by Client.Do, and the requests which did not get any
+// response
+type latencyLogger interface {
+	logResponse(*http.Response, time.Duration)
+	logRequestError(*http.Request, time.Duration, error)
+}
+
+// EnableDebugLog sets the client Logger to write the method, URL, headers,
+// body, status code and latency of every API call to "w", one "key=value"
+// line per event. Credentials are redacted, see SanitizeHeaders and
+// MaskSecret. Bodies are truncated to MaxLoggedBodyBytes.
+func (c *Client) EnableDebugLog(w io.Writer) {
+	c.Logger = &debugLogger{
+		client: c,
+		output: w,
+	}
+}
+
+// SanitizeHeaders returns a copy of "headers" with the authentication headers
+// redacted and any client secret masked in the other values
+func (c *Client) SanitizeHeaders(headers http.Header) http.Header {
+	sanitized := make(http.Header, len(headers))
+	for name, values := range headers {
+		for _, value := range values {
+			sanitized.Add(name, c.MaskSecret(value))
+		}
+	}
+	for _, name := range redactedHeaders {
+		if _, ok := sanitized[name]; ok {
+			sanitized.Set(name, secretMask)
+		}
+	}
+	return sanitized
+}
+
+// formatHeaders formats headers in a stable order
+func formatHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, name+": "+strings.Join(headers[name], ", "))
+	}
+	return strings.Join(fields, "; ")
+}
+
+// write writes a single log line
+func (l *debugLogger) write(event string, req *http.Request, format string, args ...interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	prefix := fmt.Sprintf("go-ovh: event=%s ", event)
+	if req != nil {
+		prefix += fmt.Sprintf("method=%s url=%q ", req.Method, l.client.MaskSecret(req.URL.String()))
+	}
+	fmt.Fprintf(l.output, prefix+format+"\n", args...)
+}
+
+func (l *debugLogger) LogRequest(req *http.Request) {
+	l.write("request", req, "headers=%q", formatHeaders(l.client.SanitizeHeaders(req.Header)))
+}
+
+func (l *debugLogger) LogRequestBody(req *http.Request, body []byte) {
+	l.write("request_body", req, "body=%q", l.client.MaskSecret(string(body)))
+}
+
+func (l *debugLogger) LogResponse(response *http.Response) {
+	l.write("response", response.Request, "status=%d headers=%q", response.StatusCode, formatHeaders(l.client.SanitizeHeaders(response.Header)))
+}
+
+func (l *debugLogger) logResponse(response *http.Response, latency time.Duration) {
+	l.write("response", response.Request, "status=%d latency=%s headers=%q", response.StatusCode, latency, formatHeaders(l.client.SanitizeHeaders(response.Header)))
+}
+
+func (l *debugLogger) LogResponseBody(response *http.Response, body []byte) {
+	l.write("response_body", response.Request, "status=%d body=%q", response.StatusCode, l.client.MaskSecret(string(body)))
+}
+
+func (l *debugLogger) logRequestError(req *http.Request, latency time.Duration, err error) {
+	l.write("error", req, "latency=%s error=%q", latency, l.client.MaskSecret(err.Error()))
+}
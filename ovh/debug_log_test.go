@@ -0,0 +1,136 @@
+package ovh
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestDebugLog(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	client.EnableDebugLog(&output)
+
+	// Test
+	var res string
+	if err := client.Post("/some/resource", SomeData{IntValue: 42}, &res); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+
+	// Validate
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Debug log should have 4 lines. Got %q", lines)
+	}
+	for i, event := range []string{"request", "request_body", "response", "response_body"} {
+		if !strings.HasPrefix(lines[i], "go-ovh: event="+event+" method=POST url=\""+ts.URL+"/some/resource\"") {
+			t.Fatalf("Line %d should log the %s. Got '%s'", i, event, lines[i])
+		}
+	}
+	for _, expected := range []string{"X-Ovh-Application: " + MockApplicationKey, "X-Ovh-Signature: ****", "X-Ovh-Consumer: ****"} {
+		if !strings.Contains(lines[0], expected) {
+			t.Fatalf("Request headers should contain '%s'. Got '%s'", expected, lines[0])
+		}
+	}
+	if strings.Contains(output.String(), MockConsumerKey) || strings.Contains(output.String(), "$1$") {
+		t.Fatalf("Credentials should be redacted. Got '%s'", output.String())
+	}
+	if !strings.Contains(lines[1], `body="{\"i_val\":42}"`) {
+		t.Fatalf("Request body should be logged. Got '%s'", lines[1])
+	}
+	if !strings.Contains(lines[2], "status=200 latency=") {
+		t.Fatalf("Response status and latency should be logged. Got '%s'", lines[2])
+	}
+	if !strings.Contains(lines[3], `body="\"success\""`) {
+		t.Fatalf("Response body should be logged. Got '%s'", lines[3])
+	}
+}
+
+func TestDebugLogLatency(t *testing.T) {
+	// Init test: the client Timeout makes the HTTP client clone the requests
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, 20*time.Millisecond)
+	defer ts.Close()
+
+	var output bytes.Buffer
+	client.EnableDebugLog(&output)
+
+	// Test
+	for i := 0; i < 2; i++ {
+		if err := client.Get("/some/resource", nil); err != nil {
+			t.Fatalf("Unexpected error while calling API: %v", err)
+		}
+	}
+
+	// Validate
+	responses := 0
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if !strings.HasPrefix(line, "go-ovh: event=response ") {
+			continue
+		}
+		responses++
+		i := strings.Index(line, "latency=")
+		if i < 0 {
+			t.Fatalf("Responses should be logged with their latency. Got '%s'", line)
+		}
+		latency, err := time.ParseDuration(strings.Fields(line[i+len("latency="):])[0])
+		if err != nil || latency < 20*time.Millisecond {
+			t.Fatalf("Latency should include the response delay. Got '%s'", line)
+		}
+	}
+	if responses != 2 {
+		t.Fatalf("Debug log should have 2 responses. Got '%s'", output.String())
+	}
+}
+
+func TestDebugLogError(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	client.EnableDebugLog(&output)
+	client.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("no route to " + MockApplicationSecret)
+	}))
+
+	// Test
+	if err := client.Get("/some/resource", nil); err == nil {
+		t.Fatalf("Request should fail")
+	}
+
+	// Validate
+	if !strings.Contains(output.String(), `event=error method=GET`) || strings.Contains(output.String(), MockApplicationSecret) {
+		t.Fatalf("Transport errors should be logged without secret. Got '%s'", output.String())
+	}
+	if !strings.Contains(output.String(), "latency=") {
+		t.Fatalf("Failed requests should be logged with their latency. Got '%s'", output.String())
+	}
+}
+
+func TestSanitizeHeaders(t *testing.T) {
+	client := Client{AppSecret: "secret"}
+	headers := http.Header{
+		"Authorization": {"Bearer token"},
+		"X-Custom":      {"contains secret"},
+	}
+
+	sanitized := client.SanitizeHeaders(headers)
+
+	if sanitized.Get("Authorization") != "****" || sanitized.Get("X-Custom") != "contains ****" {
+		t.Fatalf("Headers should be sanitized. Got %v", sanitized)
+	}
+	if headers.Get("Authorization") != "Bearer token" {
+		t.Fatalf("Original headers should not be altered. Got %v", headers)
+	}
+}
@@ -0,0 +1,147 @@
+package ovh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsError is returned by clients with StrictDecoding when a
+// response has fields the result type does not cover. The known fields are
+// decoded anyway.
+type UnknownFieldsError struct {
+	Method string
+	Path   string
+	// Fields is the sorted list of unknown fields, nested ones being
+	// prefixed by their parents, like "nodes[].extra"
+	Fields []string
+}
+
+func (err *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("go-ovh: response of %s %s has unknown fields: %s", err.Method, err.Path, strings.Join(err.Fields, ", "))
+}
+
+// decode decodes the response body of a call into resType, reporting the
+// fields resType does not cover according to StrictDecoding and
+// OnUnknownFields
+func (c *Client) decode(method, path string, body []byte, resType interface{}) error {
+	if err := decodeBody(body, resType); err != nil {
+		return err
+	}
+	if resType == nil || len(bytes.TrimSpace(body)) == 0 || (!c.StrictDecoding && c.OnUnknownFields == nil) {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return err
+	}
+	fields := unknownFields(value, reflect.TypeOf(resType), "")
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	if c.OnUnknownFields != nil {
+		c.OnUnknownFields(method, path, fields)
+	}
+	if c.StrictDecoding {
+		return &UnknownFieldsError{Method: method, Path: path, Fields: fields}
+	}
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the fields of a generic JSON value which would be
+// ignored when decoding it into the type t
+func unknownFields(value interface{}, t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Custom decoders handle their fields by themselves
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+
+	var fields []string
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		known := jsonFields(t)
+		for name, fieldValue := range object {
+			field, ok := known[strings.ToLower(name)]
+			if !ok {
+				fields = append(fields, prefix+name)
+				continue
+			}
+			fields = append(fields, unknownFields(fieldValue, field.Type, prefix+name+".")...)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for name, fieldValue := range object {
+			fields = append(fields, unknownFields(fieldValue, t.Elem(), prefix+name+".")...)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		// Report the fields of all the items once
+		seen := map[string]bool{}
+		for _, item := range items {
+			for _, field := range unknownFields(item, t.Elem(), strings.TrimSuffix(prefix, ".")+"[].") {
+				if !seen[field] {
+					seen[field] = true
+					fields = append(fields, field)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// jsonFields returns the fields of a struct decoded by encoding/json, by
+// lower-cased JSON name, including those of embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedField := range jsonFields(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedField
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// Unexported
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field
+	}
+	return fields
+}
@@ -0,0 +1,97 @@
+package ovh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestStrictDecoding(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusOK, `{"i_val":42,"s_val":"Hello","new_val":true}`, nil, time.Duration(0))
+	defer ts.Close()
+	client.StrictDecoding = true
+
+	// Test
+	var data SomeData
+	err := client.Get("/some/resource", &data)
+
+	// Validate
+	var unknownErr *UnknownFieldsError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("Strict decoding should return an UnknownFieldsError. Got %v", err)
+	}
+	if err.Error() != "go-ovh: response of GET /some/resource has unknown fields: new_val" {
+		t.Fatalf("UnknownFieldsError should list the unknown fields. Got %s", err)
+	}
+	if data.IntValue != 42 || data.StringValue != "Hello" {
+		t.Fatalf("Known fields should be decoded anyway. Got %+v", data)
+	}
+}
+
+func TestOnUnknownFields(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusOK, `{
+		"name": "ns1", "Zone": "gra", "ignored": 1, "raw": {"any": 1},
+		"nodes": [{"id": 1, "extra": 1}, {"id": 2, "extra": 2, "other": 3}],
+		"tags": {"a": {"id": 1, "color": "red"}},
+		"Embedded": 1, "inner": 2
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	type Inner struct {
+		Inner int `json:"inner"`
+	}
+	type Node struct {
+		ID int `json:"id"`
+	}
+	var res struct {
+		Inner
+		Name    string          `json:"name"`
+		Zone    string          `json:"zone"`
+		Ignored int             `json:"-"`
+		Raw     json.RawMessage `json:"raw"`
+		Nodes   []Node          `json:"nodes"`
+		Tags    map[string]Node `json:"tags"`
+	}
+	var reported string
+	client.OnUnknownFields = func(method, path string, fields []string) {
+		reported = fmt.Sprintf("%s %s %v", method, path, fields)
+	}
+
+	// Test
+	if err := client.Get("/some/resource", &res); err != nil {
+		t.Fatalf("Unknown fields should not fail without strict decoding. Got %v", err)
+	}
+
+	// Validate
+	if expected := "GET /some/resource [Embedded ignored nodes[].extra nodes[].other tags.a.color]"; reported != expected {
+		t.Fatalf("OnUnknownFields should be called with the unknown fields. Expected %s. Got %s", expected, reported)
+	}
+}
+
+func TestStrictDecodingKnownFields(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusOK, `[{"i_val":42,"s_val":"Hello"}]`, nil, time.Duration(0))
+	defer ts.Close()
+	client.StrictDecoding = true
+
+	// Test
+	var data []SomeData
+	err := client.Get("/some/resource", &data)
+	var generic interface{}
+	errGeneric := client.Get("/some/resource", &generic)
+
+	// Validate
+	if err != nil || errGeneric != nil || len(data) != 1 {
+		t.Fatalf("Responses without unknown fields should be decoded. Got %v and %v", err, errGeneric)
+	}
+}
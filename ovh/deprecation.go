@@ -0,0 +1,55 @@
+package ovh
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Deprecation describes a call to an API route announced as deprecated by
+// the API, using the "Deprecation" and/or "Sunset" response headers.
+type Deprecation struct {
+	// HTTP method of the call
+	Method string
+	// Path of the call, relative to the endpoint
+	Path string
+	// Raw value of the "Deprecation" header, if any
+	Deprecation string
+	// Raw value of the "Sunset" header, if any
+	Sunset string
+}
+
+// DeprecationError is returned instead of the call result when calling a
+// deprecated route while Client.StrictDeprecation is set.
+type DeprecationError struct {
+	Deprecation
+}
+
+func (err *DeprecationError) Error() string {
+	msg := fmt.Sprintf("go-ovh: %s %s is deprecated", err.Method, err.Path)
+	if err.Sunset != "" {
+		msg += fmt.Sprintf(" and will be removed on %s", err.Sunset)
+	}
+	return msg
+}
+
+// checkDeprecation notifies the deprecation handler if the response announces
+// a deprecated route. In strict mode, it returns a DeprecationError.
+func (c *Client) checkDeprecation(method, path string, response *http.Response) error {
+	deprecation := Deprecation{
+		Method:      method,
+		Path:        path,
+		Deprecation: response.Header.Get("Deprecation"),
+		Sunset:      response.Header.Get("Sunset"),
+	}
+	if deprecation.Deprecation == "" && deprecation.Sunset == "" {
+		return nil
+	}
+
+	if c.OnDeprecation != nil {
+		c.OnDeprecation(&deprecation)
+	}
+	if c.StrictDeprecation {
+		return &DeprecationError{deprecation}
+	}
+	return nil
+}
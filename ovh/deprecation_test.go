@@ -0,0 +1,57 @@
+package ovh
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestDeprecation(t *testing.T) {
+	// Init test
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/deprecated" {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", "Sat, 31 Dec 2022 23:59:59 GMT")
+		}
+		w.Write([]byte(`"success"`))
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	var notices []*Deprecation
+	client.OnDeprecation = func(deprecation *Deprecation) {
+		notices = append(notices, deprecation)
+	}
+
+	// Test: warn only by default
+	var res string
+	if err := client.GetUnAuth("/deprecated", &res); err != nil {
+		t.Fatalf("Calling a deprecated route should not fail by default. Got %v", err)
+	}
+	if res != "success" {
+		t.Fatalf("Calling a deprecated route should decode the response. Got '%s'", res)
+	}
+	if len(notices) != 1 || notices[0].Path != "/deprecated" || notices[0].Sunset != "Sat, 31 Dec 2022 23:59:59 GMT" {
+		t.Fatalf("OnDeprecation should be called once for /deprecated. Got %v", notices)
+	}
+
+	// Test: strict mode
+	client.StrictDeprecation = true
+	err := client.GetUnAuth("/deprecated", &res)
+	if _, ok := err.(*DeprecationError); !ok {
+		t.Fatalf("Calling a deprecated route in strict mode should return a DeprecationError. Got %v", err)
+	}
+	if len(notices) != 2 {
+		t.Fatalf("OnDeprecation should also be called in strict mode. Got %d calls", len(notices))
+	}
+
+	// Test: regular routes are not affected by strict mode
+	if err := client.GetUnAuth("/regular", &res); err != nil {
+		t.Fatalf("Calling a regular route in strict mode should not fail. Got %v", err)
+	}
+	if len(notices) != 2 {
+		t.Fatalf("OnDeprecation should not be called for regular routes. Got %d calls", len(notices))
+	}
+}
@@ -0,0 +1,179 @@
+package ovh
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// ConfigSourceParam is the source of the values given to the client
+// constructor or options, see DiagnoseConfig
+const ConfigSourceParam = "param"
+
+// Configuration file statuses, see ConfigFile
+const (
+	ConfigFileLoaded     = "loaded"
+	ConfigFileMissing    = "missing"
+	ConfigFileUnreadable = "unreadable"
+	ConfigFileInvalid    = "invalid"
+	ConfigFileDisabled   = "disabled"
+)
+
+// ConfigFile describes a configuration file looked up by the client
+type ConfigFile struct {
+	Path string
+
+	// Status is ConfigFileLoaded, ConfigFileMissing, ConfigFileUnreadable,
+	// ConfigFileInvalid, or ConfigFileDisabled for ./ovh.conf when
+	// OVH_DISABLE_LOCAL_CONFIG is set
+	Status string
+
+	// Format of loaded files, "ini", "json" or "yaml"
+	Format string
+
+	// Error explains why an unreadable or invalid file was skipped
+	Error string
+}
+
+// ConfigReport describes how the configuration of a client is resolved, see
+// DiagnoseConfig
+type ConfigReport struct {
+	EnvConfig
+
+	// EndpointName is the name, or URL, of the endpoint resolved to Endpoint
+	EndpointName string
+
+	// Profile is the selected configuration profile, if any
+	Profile string
+
+	// Files are the configuration files looked up, by order of increasing
+	// priority
+	Files []ConfigFile
+
+	// SecretFiles are the files credentials were read from, see the
+	// OVH_<NAME>_FILE environment variables
+	SecretFiles []string
+}
+
+// String describes the report for diagnostics, with secrets masked
+func (report *ConfigReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "endpoint: %s (%s)\n", report.EndpointName, report.Endpoint)
+	if report.Profile != "" {
+		fmt.Fprintf(&b, "profile: %s\n", report.Profile)
+	}
+	fmt.Fprintf(&b, "environment prefix: %s\n", report.EnvPrefix)
+	b.WriteString("files:\n")
+	for _, file := range report.Files {
+		fmt.Fprintf(&b, "  %s: %s", file.Path, file.Status)
+		if file.Format != "" {
+			fmt.Fprintf(&b, " (%s)", file.Format)
+		}
+		if file.Error != "" {
+			fmt.Fprintf(&b, ": %s", file.Error)
+		}
+		b.WriteString("\n")
+	}
+	for _, path := range report.SecretFiles {
+		fmt.Fprintf(&b, "  %s: secret\n", path)
+	}
+	b.WriteString("values:\n")
+	for _, line := range strings.SplitAfter(report.EnvConfig.String(), "\n") {
+		if line != "" {
+			b.WriteString("  " + line)
+		}
+	}
+	return b.String()
+}
+
+// DiagnoseConfig resolves the configuration of a client for "endpoint",
+// like NewClientWithOptions with the same options, and reports which
+// configuration files were found, where each value was read from and the
+// resolved endpoint URL. Nothing is sent to the API.
+//
+// The report is returned along with the error when the configuration is
+// invalid, for example when a credential is missing.
+func DiagnoseConfig(endpoint string, opts ...Option) (*ConfigReport, error) {
+	client := &Client{}
+	for _, opt := range opts {
+		opt(client)
+	}
+	params := client.credentials()
+
+	report := &ConfigReport{Profile: client.profileName(), Files: client.configFiles()}
+	cfg, err := client.newConfig()
+	if err != nil {
+		report.EnvPrefix = client.envVar("")
+		return report, err
+	}
+	err = client.loadConfigFrom(cfg, endpoint)
+
+	if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+		cfg.record("endpoint", endpoint, ConfigSourceParam, "")
+	}
+	for key, value := range map[string]string{
+		"application_key":    params.appKey,
+		"application_secret": params.appSecret,
+		"consumer_key":       params.consumerKey,
+		"client_id":          params.clientID,
+		"client_secret":      params.clientSecret,
+	} {
+		cfg.record(key, value, ConfigSourceParam, "")
+	}
+
+	report.EnvConfig = EnvConfig{EnvPrefix: cfg.envPrefix, Endpoint: client.endpoint, Values: cfg.values}
+	report.EndpointName = cfg.values["endpoint"].Value
+	report.SecretFiles = cfg.files
+	return report, err
+}
+
+// configFiles describes the configuration files looked up by the client, see
+// loadConfigFiles
+func (c *Client) configFiles() []ConfigFile {
+	if path := c.configFilePath(); path != "" {
+		return []ConfigFile{describeConfigFile(path)}
+	}
+
+	paths := []string{systemConfigPath}
+	if files, err := filepath.Glob(filepath.Join(systemConfigDirPath, "*.conf")); err == nil {
+		paths = append(paths, files...)
+	}
+	if home, err := currentUserHome(); err == nil {
+		paths = append(paths, filepath.Join(home, userConfigPath))
+	}
+
+	var files []ConfigFile
+	for _, path := range paths {
+		files = append(files, describeConfigFile(path))
+	}
+	if c.localConfigDisabled() {
+		return append(files, ConfigFile{Path: localConfigPath, Status: ConfigFileDisabled})
+	}
+	return append(files, describeConfigFile(localConfigPath))
+}
+
+// describeConfigFile checks if a configuration file can be loaded
+func describeConfigFile(path string) ConfigFile {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ConfigFile{Path: path, Status: ConfigFileMissing}
+	}
+	if err != nil {
+		return ConfigFile{Path: path, Status: ConfigFileUnreadable, Error: err.Error()}
+	}
+
+	data = bytes.TrimPrefix(data, utf8BOM)
+	format := configFormat(path, data)
+	if data, err = iniConfig(path, data); err == nil {
+		_, err = ini.Load(data)
+	}
+	if err != nil {
+		return ConfigFile{Path: path, Status: ConfigFileInvalid, Format: format, Error: err.Error()}
+	}
+	return ConfigFile{Path: path, Status: ConfigFileLoaded, Format: format}
+}
@@ -0,0 +1,96 @@
+package ovh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestDiagnoseConfig(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file-secret
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	os.MkdirAll(systemConfigDirPath, 0755)
+	defer os.RemoveAll(systemConfigDirPath)
+	invalidPath := filepath.Join(systemConfigDirPath, "10-invalid.conf")
+	ioutil.WriteFile(invalidPath, []byte(`{"ovh-eu": `), 0660)
+	os.Setenv("OVH_DISABLE_LOCAL_CONFIG", "1")
+	defer os.Unsetenv("OVH_DISABLE_LOCAL_CONFIG")
+
+	// Test
+	report, err := DiagnoseConfig("ovh-eu", WithConsumerKey("param-ck"))
+
+	// Validate
+	if err != nil {
+		t.Fatalf("DiagnoseConfig failed with: '%v'", err)
+	}
+	if report.EndpointName != "ovh-eu" || report.Endpoint != OvhEU {
+		t.Fatalf("DiagnoseConfig should resolve the endpoint. Got '%s', '%s'", report.EndpointName, report.Endpoint)
+	}
+	files := map[string]ConfigFile{}
+	for _, file := range report.Files {
+		files[file.Path] = file
+	}
+	if file := files[systemConfigPath]; file.Status != ConfigFileLoaded || file.Format != configFormatINI {
+		t.Fatalf("DiagnoseConfig should report loaded files. Got %+v", file)
+	}
+	if file := files[invalidPath]; file.Status != ConfigFileInvalid || file.Format != configFormatJSON || file.Error == "" {
+		t.Fatalf("DiagnoseConfig should report invalid files. Got %+v", file)
+	}
+	if file := files[localConfigPath]; file.Status != ConfigFileDisabled {
+		t.Fatalf("DiagnoseConfig should report the disabled local file. Got %+v", file)
+	}
+
+	expected := map[string]ConfigValue{
+		"endpoint":           {Value: "ovh-eu", Source: ConfigSourceParam},
+		"application_key":    {Value: "file", Source: ConfigSourceFile, Origin: "[ovh-eu] application_key"},
+		"application_secret": {Value: "file-secret", Source: ConfigSourceFile, Origin: "[ovh-eu] application_secret"},
+		"consumer_key":       {Value: "param-ck", Source: ConfigSourceParam},
+	}
+	for key, value := range expected {
+		if report.Values[key] != value {
+			t.Fatalf("DiagnoseConfig should report the source of %s. Expected %+v. Got %+v", key, value, report.Values[key])
+		}
+	}
+
+	s := report.String()
+	if strings.Contains(s, "file-secret") || strings.Contains(s, "param-ck") {
+		t.Fatalf("ConfigReport should mask secrets. Got %s", s)
+	}
+	for _, line := range []string{
+		"endpoint: ovh-eu (" + OvhEU + ")\n",
+		"  " + systemConfigPath + ": loaded (ini)\n",
+		"  " + localConfigPath + ": disabled\n",
+		"  application_key=file (file [ovh-eu] application_key)\n",
+	} {
+		if !strings.Contains(s, line) {
+			t.Fatalf("ConfigReport should contain %q. Got %s", line, s)
+		}
+	}
+}
+
+func TestDiagnoseConfigInvalid(t *testing.T) {
+	// Prepare
+	dir, _ := ioutil.TempDir("", "go-ovh")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "missing.conf")
+
+	// Test
+	report, err := DiagnoseConfig("ovh-eu", WithConfigFile(path))
+
+	// Validate
+	if err == nil || report == nil {
+		t.Fatalf("DiagnoseConfig should return the report along with the error. Got %+v, '%v'", report, err)
+	}
+	if len(report.Files) != 1 || report.Files[0].Path != path || report.Files[0].Status != ConfigFileMissing {
+		t.Fatalf("DiagnoseConfig should only report the requested file. Got %+v", report.Files)
+	}
+}
@@ -0,0 +1,206 @@
+package ovh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDiskCachePaths are the paths cached by NewDiskCache when none is
+// given: the order catalogs and the API schemas
+var DefaultDiskCachePaths = []string{"/order/catalog/*", "/", "*.json"}
+
+// DiskCache is a Cache storing responses as files of a directory, so that
+// they are kept across runs. It is meant for large and slowly changing
+// responses, like order catalogs and API schemas, only caching the paths it
+// is configured for. Disk errors are not reported: entries which can not be
+// read are considered missing.
+type DiskCache struct {
+	dir   string
+	ttl   time.Duration
+	paths []string
+	mutex sync.Mutex
+}
+
+// diskCacheFile is the content of a DiskCache file
+type diskCacheFile struct {
+	Key   string      `json:"key"`
+	Entry *CacheEntry `json:"entry"`
+}
+
+// NewDiskCache returns a DiskCache storing its files in "dir", created if
+// needed. "ttl", when positive, is the lifetime of the responses, overriding
+// Client.CacheTTL and their Cache-Control headers. "paths" lists the cached
+// paths, relative to the endpoint and without query: exact paths, like "/",
+// prefixes ending with a '*', like "/order/catalog/*", or suffixes starting
+// with a '*', like "*.json". DefaultDiskCachePaths is used when none is given.
+func NewDiskCache(dir string, ttl time.Duration, paths ...string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = DefaultDiskCachePaths
+	}
+	return &DiskCache{dir: dir, ttl: ttl, paths: paths}, nil
+}
+
+// Get returns the entry stored for "key", if any
+func (d *DiskCache) Get(key string) (*CacheEntry, bool) {
+	if !d.matches(key) {
+		return nil, false
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	file, err := d.read(d.file(key))
+	if err != nil || file.Key != key || file.Entry == nil {
+		return nil, false
+	}
+	return file.Entry, true
+}
+
+// Set stores an entry for "key", if its path is cached
+func (d *DiskCache) Set(key string, entry *CacheEntry) {
+	if !d.matches(key) {
+		return
+	}
+	data, err := json.Marshal(diskCacheFile{Key: key, Entry: entry})
+	if err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// Write then rename, so that readers never see partial files
+	tmp, err := ioutil.TempFile(d.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), d.file(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes the entry stored for "key", if any
+func (d *DiskCache) Delete(key string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	os.Remove(d.file(key))
+}
+
+// TTL returns the lifetime of the entries, see CacheTTLProvider
+func (d *DiskCache) TTL(key string) time.Duration {
+	return d.ttl
+}
+
+// Invalidate removes the entries of the paths matching "pattern", written
+// as the paths given to NewDiskCache, for instance "/order/catalog/*"
+func (d *DiskCache) Invalidate(pattern string) error {
+	return d.remove(func(key string) bool {
+		return matchCachePath(pattern, cacheKeyPath(key))
+	})
+}
+
+// Clear removes all the entries
+func (d *DiskCache) Clear() error {
+	return d.remove(func(string) bool { return true })
+}
+
+// remove removes the entries whose key is selected by "selected"
+func (d *DiskCache) remove(selected func(key string) bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	names, err := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		file, err := d.read(name)
+		if err != nil || selected(file.Key) {
+			if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// read reads a cache file
+func (d *DiskCache) read(name string) (*diskCacheFile, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	file := &diskCacheFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// file returns the name of the file of a key
+func (d *DiskCache) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// matches checks if the path of a key is cached
+func (d *DiskCache) matches(key string) bool {
+	path := cacheKeyPath(key)
+	for _, pattern := range d.paths {
+		if matchCachePath(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheKeyPath returns the path of a cache key, relative to the endpoint and
+// without its query
+func cacheKeyPath(key string) string {
+	u, err := url.Parse(key)
+	if err != nil {
+		return ""
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	// Remove the API version of the endpoint, if any
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	if apiVersionSegment.MatchString(segments[0]) {
+		if len(segments) == 1 {
+			return "/"
+		}
+		return "/" + segments[1]
+	}
+	return path
+}
+
+// matchCachePath checks if a path matches a pattern: exact path, prefix
+// ending with a '*' or suffix starting with a '*'
+func matchCachePath(pattern, path string) bool {
+	switch {
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(path, strings.TrimSuffix(pattern, "*"))
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(path, strings.TrimPrefix(pattern, "*"))
+	}
+	return path == pattern
+}
@@ -0,0 +1,101 @@
+package ovh
+
+import (
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func initDiskCache(t *testing.T, client *Client) string {
+	dir, err := ioutil.TempDir("", "disk-cache")
+	if err != nil {
+		t.Fatalf("TempDir should not fail. Got %v", err)
+	}
+	cache, err := NewDiskCache(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskCache should not fail. Got %v", err)
+	}
+	client.Cache = cache
+	return dir
+}
+
+func TestDiskCache(t *testing.T) {
+	// Init test: responses without cache headers
+	ts, client, hits := initCacheServer(t, "", "")
+	defer ts.Close()
+	dir := initDiskCache(t, client)
+	defer os.RemoveAll(dir)
+
+	// Test
+	var first, second, schema, me, meAgain int
+	client.GetUnAuth("/order/catalog/public/cloud?ovhSubsidiary=FR", &first)
+	client.GetUnAuth("/me.json", &schema)
+	client.GetUnAuth("/me", &me)
+	client.GetUnAuth("/me", &meAgain)
+
+	// A client of another run, with the same directory
+	other, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	other.Cache, _ = NewDiskCache(dir, time.Hour)
+	other.GetUnAuth("/order/catalog/public/cloud?ovhSubsidiary=FR", &second)
+
+	// Validate
+	if first != 1 || second != 1 || schema != 2 {
+		t.Fatalf("Catalogs and schemas should be served from the disk. Got %d, %d and %d", first, second, schema)
+	}
+	if me != 3 || meAgain != 4 || atomic.LoadInt32(hits) != 4 {
+		t.Fatalf("Other paths should not be cached. Got %d and %d after %d hits", me, meAgain, atomic.LoadInt32(hits))
+	}
+}
+
+func TestDiskCacheInvalidate(t *testing.T) {
+	// Init test
+	ts, client, _ := initCacheServer(t, "", "")
+	defer ts.Close()
+	dir := initDiskCache(t, client)
+	defer os.RemoveAll(dir)
+	cache := client.Cache.(*DiskCache)
+
+	var catalog, schema int
+	client.GetUnAuth("/order/catalog/public/cloud", &catalog)
+	client.GetUnAuth("/me.json", &schema)
+
+	// Test
+	if err := cache.Invalidate("/order/catalog/*"); err != nil {
+		t.Fatalf("Invalidate should not fail. Got %v", err)
+	}
+	client.GetUnAuth("/order/catalog/public/cloud", &catalog)
+	client.GetUnAuth("/me.json", &schema)
+	if catalog != 3 || schema != 2 {
+		t.Fatalf("Invalidate should only remove the matching paths. Got %d and %d", catalog, schema)
+	}
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear should not fail. Got %v", err)
+	}
+	client.GetUnAuth("/me.json", &schema)
+
+	// Validate
+	if schema != 4 {
+		t.Fatalf("Clear should remove all the entries. Got %d", schema)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Fatalf("The cache should hold a file per entry. Got %d files", len(files))
+	}
+}
+
+func TestCacheKeyPath(t *testing.T) {
+	for key, expected := range map[string]string{
+		"https://eu.api.ovh.com/1.0/":                          "/",
+		"https://eu.api.ovh.com/1.0/me.json":                   "/me.json",
+		"https://eu.api.ovh.com/v2/iam/policy?limit=1":         "/iam/policy",
+		"http://127.0.0.1:8080/order/catalog/public/cloud?a=b": "/order/catalog/public/cloud",
+		"http://127.0.0.1:8080":                                "/",
+	} {
+		if path := cacheKeyPath(key); path != expected {
+			t.Errorf("The path of %s should be %s. Got %s", key, expected, path)
+		}
+	}
+}
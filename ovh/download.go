@@ -0,0 +1,69 @@
+package ovh
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// GetRaw sends a signed GET request to "path" and returns the response body
+// without reading it, to stream large payloads. The caller must close it.
+// Errors are returned as for Get. MaxResponseBytes does not apply.
+func (c *Client) GetRaw(path string) (io.ReadCloser, error) {
+	return c.GetRawWithContext(context.Background(), path)
+}
+
+// GetRawWithContext is the same as GetRaw, with a context. The context
+// applies to the whole download.
+func (c *Client) GetRawWithContext(ctx context.Context, path string) (io.ReadCloser, error) {
+	ctx, end, err := c.beginCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.getRaw(ctx, path)
+	if err != nil {
+		end()
+		return nil, err
+	}
+	return &trackedBody{ReadCloser: body, done: end}, nil
+}
+
+// getRaw sends the request of GetRawWithContext and returns the response body
+func (c *Client) getRaw(ctx context.Context, path string) (io.ReadCloser, error) {
+	response, err := c.send(ctx, "GET", path, nil, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Errors are small JSON documents, read them as usual
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		defer response.Body.Close()
+		body, err := c.readBody(response)
+		if err != nil {
+			return nil, err
+		}
+		return nil, checkResponse(response, body)
+	}
+	if err := c.checkDeprecation("GET", path, response); err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// Download streams the response of a signed GET request to "path" into "w"
+// and returns the number of bytes written
+func (c *Client) Download(path string, w io.Writer) (int64, error) {
+	return c.DownloadWithContext(context.Background(), path, w)
+}
+
+// DownloadWithContext is the same as Download, with a context
+func (c *Client) DownloadWithContext(ctx context.Context, path string, w io.Writer) (int64, error) {
+	body, err := c.GetRawWithContext(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	return io.Copy(w, body)
+}
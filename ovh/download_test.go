@@ -0,0 +1,86 @@
+package ovh
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestGetRaw(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, "%PDF-1.4 not json", nil, 0)
+	defer ts.Close()
+
+	// Test
+	body, err := client.GetRaw("/me/bill/FR123/download")
+	if err != nil {
+		t.Fatalf("GetRaw should not fail. Got %v", err)
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+
+	// Validate
+	if err != nil || string(data) != "%PDF-1.4 not json" {
+		t.Fatalf("GetRaw should return the raw body. Got '%s', %v", data, err)
+	}
+	if InputRequest.Header.Get("X-Ovh-Signature") == "" {
+		t.Fatalf("GetRaw request should be signed")
+	}
+}
+
+func TestGetRawError(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 404, `{"message":"The requested object (id = FR123) does not exist"}`, nil, 0)
+	defer ts.Close()
+
+	// Test
+	body, err := client.GetRaw("/me/bill/FR123/download")
+
+	// Validate
+	var apiErr *APIError
+	if body != nil || !errors.As(err, &apiErr) || apiErr.Code != 404 {
+		t.Fatalf("GetRaw should return an APIError. Got %v", err)
+	}
+	if apiErr.Message != "The requested object (id = FR123) does not exist" {
+		t.Fatalf("APIError message should be decoded. Got '%s'", apiErr.Message)
+	}
+}
+
+func TestDownloadGzip(t *testing.T) {
+	// Init test
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	payload := bytes.Repeat([]byte("log line\n"), 10000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gzipWriter := gzip.NewWriter(w)
+		gzipWriter.Write(payload)
+		gzipWriter.Close()
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+	client.MaxResponseBytes = 10
+
+	// Test
+	var buffer bytes.Buffer
+	n, err := client.Download("/dbaas/logs/export", &buffer)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Download should not fail. Got %v", err)
+	}
+	if n != int64(len(payload)) || !bytes.Equal(buffer.Bytes(), payload) {
+		t.Fatalf("Download should write the decompressed body. Got %d bytes", n)
+	}
+}
@@ -0,0 +1,35 @@
+package ovh
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// DryRunHeader is set on the responses of mutating calls which were not sent,
+// see Client.DryRun
+const DryRunHeader = "X-Ovh-Dry-Run"
+
+// dryRun handles a mutating request of a dry-run client: the request, built
+// and signed, is logged and passed to OnDryRun instead of being sent. It
+// returns an empty "204 No Content" response, with the DryRunHeader set.
+func (c *Client) dryRun(req *http.Request) (*http.Response, error) {
+	if c.Logger != nil {
+		c.Logger.LogRequest(req)
+		c.logRequestBody(req)
+	}
+	if c.OnDryRun != nil {
+		c.OnDryRun(req)
+	}
+
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{DryRunHeader: []string{"true"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package ovh
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestDryRun(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{"i_val":1}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	logger := &mockBodyLogger{}
+	client.Logger = logger
+	var dryRequests []*http.Request
+	WithDryRun(func(req *http.Request) {
+		dryRequests = append(dryRequests, req)
+	})(client)
+
+	// Test: mutating calls are not sent
+	res := SomeData{IntValue: 42}
+	response, err := client.CallAPIFullWithContext(context.Background(), "POST", "/some/resource", &SomeData{StringValue: "new"})
+	if err != nil {
+		t.Fatalf("Dry run call should not return an error. Got %v", err)
+	}
+	if err := client.Put("/some/resource", &SomeData{StringValue: "new"}, &res); err != nil {
+		t.Fatalf("Dry run call should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if InputRequest != nil {
+		t.Fatalf("Dry run mutating calls should not be sent. Got %s %s", InputRequest.Method, InputRequest.URL)
+	}
+	if response.StatusCode != http.StatusNoContent || response.Header.Get(DryRunHeader) != "true" {
+		t.Fatalf("Dry run calls should return an empty dry run response. Got %d %v", response.StatusCode, response.Header)
+	}
+	if res.IntValue != 42 {
+		t.Fatalf("Dry run calls should not decode anything. Got %d", res.IntValue)
+	}
+	if len(dryRequests) != 2 || dryRequests[0].Method != "POST" || dryRequests[1].Method != "PUT" {
+		t.Fatalf("OnDryRun should be called for each mutating call. Got %v", dryRequests)
+	}
+	if dryRequests[0].Header.Get("X-Ovh-Signature") == "" {
+		t.Fatalf("Dry run requests should be signed")
+	}
+	body, _ := dryRequests[0].GetBody()
+	if data, _ := ioutil.ReadAll(body); string(data) != `{"s_val":"new"}` {
+		t.Fatalf("Dry run request body should be readable. Got %s", data)
+	}
+	if logger.requestBody != `{"s_val":"new"}` {
+		t.Fatalf("Dry run requests should be logged. Got %s", logger.requestBody)
+	}
+
+	// Test: other calls are sent
+	if err := client.Get("/some/resource", &res); err != nil {
+		t.Fatalf("Dry run GET should not return an error. Got %v", err)
+	}
+	if InputRequest == nil || res.IntValue != 1 {
+		t.Fatalf("Dry run GET should be sent. Got %d", res.IntValue)
+	}
+}
@@ -0,0 +1,55 @@
+package ovh
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// endpointsMutex protects Endpoints against concurrent RegisterEndpoint calls
+var endpointsMutex sync.RWMutex
+
+// RegisterEndpoint adds, or replaces, the endpoint "name" in Endpoints, so
+// that private or white-label API gateways can be used by name, like the
+// official ones. "endpointURL" must be an absolute http or https URL,
+// usually including the API version, for example "https://api.example.com/1.0".
+// Endpoints without a version, like "https://api.example.com", are used with
+// Client.APIVersion or WithRequestAPIVersion. It is safe to call concurrently with the creation of clients, unlike direct
+// modifications of Endpoints.
+func RegisterEndpoint(name, endpointURL string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid endpoint name '%s', it must not be empty nor contain a '/'", name)
+	}
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint URL '%s': %v", endpointURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid endpoint URL '%s', it must be an absolute http or https URL", endpointURL)
+	}
+
+	endpointsMutex.Lock()
+	defer endpointsMutex.Unlock()
+	Endpoints[name] = normalizeEndpointURL(endpointURL)
+	return nil
+}
+
+// resolveEndpoint returns the URL of an endpoint name. Names with a '/' are
+// considered as URLs. An empty string is returned for unknown names.
+func resolveEndpoint(name string) string {
+	if strings.Contains(name, "/") {
+		return normalizeEndpointURL(name)
+	}
+
+	endpointsMutex.RLock()
+	defer endpointsMutex.RUnlock()
+	return Endpoints[name]
+}
+
+// normalizeEndpointURL removes the trailing slashes of an endpoint URL, as
+// paths, starting with a '/', are appended to it
+func normalizeEndpointURL(endpointURL string) string {
+	return strings.TrimRight(endpointURL, "/")
+}
@@ -0,0 +1,59 @@
+package ovh
+
+import (
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestRegisterEndpoint(t *testing.T) {
+	// Clear
+	defer delete(Endpoints, "example")
+
+	// Test
+	if err := RegisterEndpoint("example", "https://api.example.com/1.0/"); err != nil {
+		t.Fatalf("RegisterEndpoint should not fail. Got %v", err)
+	}
+	client, err := NewClient("example", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("NewClient should accept registered endpoints. Got %v", err)
+	}
+	if client.endpoint != "https://api.example.com/1.0" {
+		t.Fatalf("Endpoint should be 'https://api.example.com/1.0'. Got '%s'", client.endpoint)
+	}
+}
+
+func TestRegisterEndpointErrors(t *testing.T) {
+	for name, endpointURL := range map[string]string{
+		"":          "https://api.example.com/1.0",
+		"a/b":       "https://api.example.com/1.0",
+		"relative":  "/1.0",
+		"no-scheme": "api.example.com/1.0",
+		"ftp":       "ftp://api.example.com/1.0",
+	} {
+		if err := RegisterEndpoint(name, endpointURL); err == nil {
+			delete(Endpoints, name)
+			t.Fatalf("RegisterEndpoint(%q, %q) should fail", name, endpointURL)
+		}
+	}
+}
+
+func TestEndpointURLTrailingSlash(t *testing.T) {
+	// Test
+	client, err := NewClient("https://api.example.com/1.0/", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("NewClient should not fail. Got %v", err)
+	}
+	if client.endpoint != "https://api.example.com/1.0" {
+		t.Fatalf("Trailing slash should be removed. Got '%s'", client.endpoint)
+	}
+	for name := range Endpoints {
+		if resolveEndpoint(name) == "" {
+			t.Fatalf("Endpoint '%s' should be resolved", name)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package ovh
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables read by the
+// clients, see WithEnvPrefix
+const DefaultEnvPrefix = "OVH_"
+
+// Configuration value sources, see ConfigValue
+const (
+	ConfigSourceEnv     = "env"
+	ConfigSourceFile    = "file"
+	ConfigSourceBackend = "backend"
+	ConfigSourceDefault = "default"
+)
+
+// secretConfigKeys are the configuration keys masked by EnvConfig.String
+var secretConfigKeys = map[string]bool{
+	"application_secret": true,
+	"consumer_key":       true,
+	"client_secret":      true,
+}
+
+// ConfigValue is a configuration value, along with where it was read from
+type ConfigValue struct {
+	Value string
+
+	// Source is ConfigSourceEnv, ConfigSourceFile, ConfigSourceBackend or
+	// ConfigSourceDefault
+	Source string
+
+	// Origin is the environment variable, like "OVH_APPLICATION_KEY", or the
+	// configuration section and key, like "[ovh-eu] application_key", the
+	// value was read from. It is empty for backends and defaults.
+	Origin string
+}
+
+// EnvConfig is the client configuration resolved from the environment and
+// the configuration files, see ConfigFromEnv
+type EnvConfig struct {
+	// EnvPrefix of the environment variables, like "OVH_"
+	EnvPrefix string
+
+	// Endpoint is the URL of the resolved endpoint, if any
+	Endpoint string
+
+	// Values are the configuration values by key, like "endpoint" or
+	// "application_key". Unset keys are missing.
+	Values map[string]ConfigValue
+}
+
+// String describes the configuration for diagnostics, one key per line by
+// lexical order, with secrets masked
+func (cfg *EnvConfig) String() string {
+	keys := make([]string, 0, len(cfg.Values))
+	for key := range cfg.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		value := cfg.Values[key]
+		if secretConfigKeys[key] {
+			value.Value = secretMask
+		}
+		fmt.Fprintf(&b, "%s=%s (%s", key, value.Value, value.Source)
+		if value.Origin != "" {
+			fmt.Fprintf(&b, " %s", value.Origin)
+		}
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+// ConfigFromEnv resolves the client configuration from the environment,
+// the configuration files and the credential backends, like
+// NewClientWithOptions, and reports where each value was read from. Options
+// like WithEnvPrefix, WithProfile or WithConfigFile select the configuration
+// to resolve.
+//
+// The configuration resolved so far is returned along with the error when
+// the configuration is invalid, for example when a credential is missing.
+func ConfigFromEnv(opts ...Option) (*EnvConfig, error) {
+	client := &Client{}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	cfg, err := client.newConfig()
+	if err != nil {
+		return nil, err
+	}
+	err = client.loadConfigFrom(cfg, "")
+	return &EnvConfig{
+		EnvPrefix: cfg.envPrefix,
+		Endpoint:  client.endpoint,
+		Values:    cfg.values,
+	}, err
+}
+
+// envVar returns the name of the environment variable "name" of the client,
+// like "OVH_CONFIG" for "CONFIG"
+func (c *Client) envVar(name string) string {
+	if c.envPrefix != "" {
+		return c.envPrefix + name
+	}
+	return DefaultEnvPrefix + name
+}
+
+// config is the configuration loaded from files, along with the prefix of
+// the environment variables overriding it. It records where the values are
+// read from.
+type config struct {
+	*ini.File
+	envPrefix string
+	values    map[string]ConfigValue
+	// Secret files read, see getConfigSecret
+	files []string
+}
+
+// envName returns the environment variable overriding the configuration key
+// "name"
+func (cfg *config) envName(name string) string {
+	return cfg.envPrefix + strings.ToUpper(name)
+}
+
+// getenv returns the environment variable overriding the configuration key
+// "name"
+func (cfg *config) getenv(name string) string {
+	return os.Getenv(cfg.envName(name))
+}
+
+// record records the source of a value, when not empty
+func (cfg *config) record(name, value, source, origin string) {
+	if value != "" {
+		cfg.values[name] = ConfigValue{Value: value, Source: source, Origin: origin}
+	}
+}
+
+// recordDefault records and returns a default value
+func (cfg *config) recordDefault(name, def string) string {
+	cfg.record(name, def, ConfigSourceDefault, "")
+	return def
+}
+
+// sectionKeyName describes a configuration key, like "[ovh-eu] consumer_key"
+func sectionKeyName(section, name string) string {
+	return fmt.Sprintf("[%s] %s", section, name)
+}
@@ -0,0 +1,84 @@
+package ovh
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestEnvPrefix(t *testing.T) {
+	// Prepare: two sets of credentials in the environment
+	os.Setenv("OVH_APPLICATION_KEY", "default")
+	os.Setenv("OVH_APPLICATION_SECRET", "default")
+	os.Setenv("TENANT_APPLICATION_KEY", "tenant")
+	os.Setenv("TENANT_APPLICATION_SECRET", "tenant-secret")
+	os.Setenv("TENANT_ENDPOINT", "ovh-ca")
+	defer os.Unsetenv("OVH_APPLICATION_KEY")
+	defer os.Unsetenv("OVH_APPLICATION_SECRET")
+	defer os.Unsetenv("TENANT_APPLICATION_KEY")
+	defer os.Unsetenv("TENANT_APPLICATION_SECRET")
+	defer os.Unsetenv("TENANT_ENDPOINT")
+
+	// Test
+	client, err := NewClientWithOptions("", WithEnvPrefix("TENANT_"))
+
+	// Validate
+	if err != nil {
+		t.Fatalf("NewClientWithOptions failed with: '%v'", err)
+	}
+	if client.AppKey != "tenant" || client.AppSecret != "tenant-secret" || client.endpoint != OvhCA {
+		t.Fatalf("Configuration should be read from prefixed variables. Got '%s', '%s', '%s'", client.AppKey, client.AppSecret, client.endpoint)
+	}
+}
+
+func TestConfigFromEnvSources(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[default]
+endpoint=ovh-eu
+
+[ovh-eu]
+application_key=file
+consumer_key=file-ck
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	os.Setenv("OVH_APPLICATION_SECRET", "env-secret")
+	defer os.Unsetenv("OVH_APPLICATION_SECRET")
+
+	// Test
+	cfg, err := ConfigFromEnv()
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed with: '%v'", err)
+	}
+	if cfg.EnvPrefix != DefaultEnvPrefix || cfg.Endpoint != OvhEU {
+		t.Fatalf("ConfigFromEnv should resolve the endpoint. Got '%s', '%s'", cfg.EnvPrefix, cfg.Endpoint)
+	}
+	expected := map[string]ConfigValue{
+		"endpoint":           {Value: "ovh-eu", Source: ConfigSourceFile, Origin: "[default] endpoint"},
+		"application_key":    {Value: "file", Source: ConfigSourceFile, Origin: "[ovh-eu] application_key"},
+		"application_secret": {Value: "env-secret", Source: ConfigSourceEnv, Origin: "OVH_APPLICATION_SECRET"},
+		"consumer_key":       {Value: "file-ck", Source: ConfigSourceFile, Origin: "[ovh-eu] consumer_key"},
+	}
+	for key, value := range expected {
+		if cfg.Values[key] != value {
+			t.Fatalf("ConfigFromEnv should report the source of %s. Expected %+v. Got %+v", key, value, cfg.Values[key])
+		}
+	}
+	if s := cfg.String(); strings.Contains(s, "env-secret") || strings.Contains(s, "file-ck") || !strings.Contains(s, "application_key=file (file [ovh-eu] application_key)") {
+		t.Fatalf("EnvConfig should describe the configuration with secrets masked. Got %s", s)
+	}
+
+	// Test: invalid configurations are reported
+	os.Unsetenv("OVH_APPLICATION_SECRET")
+	cfg, err = ConfigFromEnv()
+
+	// Validate
+	if err == nil || cfg == nil || cfg.Values["application_key"].Value != "file" {
+		t.Fatalf("ConfigFromEnv should return the configuration along with the error. Got %+v, '%v'", cfg, err)
+	}
+}
@@ -3,15 +3,44 @@ package ovh
 import "fmt"
 
 // APIError represents an error that can occurred while calling the API.
+//
+// It is returned as a *APIError, or wrapped in a *ValidationError when the
+// API details the invalid fields of a request, and may be retrieved from
+// wrapped errors with errors.As. The raw error body is returned by
+// CallAPIRawJSON.
 type APIError struct {
 	// Error message.
 	Message string
+	// HTTP status code of the response
+	StatusCode int `json:"-"`
 	// HTTP code.
+	//
+	// Deprecated: use StatusCode, Code is kept for compatibility.
 	Code int
 	// ID of the request
 	QueryID string
+	// Class of the error, for instance "Client::Forbidden"
+	Class string `json:"class"`
+	// Machine readable error code, for instance "INVALID_SIGNATURE", when
+	// provided by the API. Unlike Message, it does not depend on the
+	// language of the call, and should be used to match errors.
+	ErrorCode string `json:"errorCode"`
+	// Language of Message, from the Content-Language header, if any. See
+	// Client.Language to select it.
+	Language string `json:"-"`
+
+	// RFC 7807 problem details, returned by the v2 routes. Message is set
+	// to Detail, or Title, when the API provides no message.
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
 }
 
 func (err *APIError) Error() string {
-	return fmt.Sprintf("Error %d: %q", err.Code, err.Message)
+	code := err.StatusCode
+	if code == 0 {
+		code = err.Code
+	}
+	return fmt.Sprintf("Error %d: %q", code, err.Message)
 }
@@ -1,9 +1,11 @@
 package ovh
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestErrorString(t *testing.T) {
@@ -18,4 +20,73 @@ func TestErrorString(t *testing.T) {
 	if got != expected {
 		t.Errorf("expected %q, got %q", expected, got)
 	}
+	if got := (&APIError{StatusCode: http.StatusNotFound, Message: "Not found"}).Error(); got != `Error 404: "Not found"` {
+		t.Errorf("expected the StatusCode in the message, got %q", got)
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusForbidden, `{
+		"class": "Client::Forbidden",
+		"message": "Invalid signature",
+		"errorCode": "INVALID_SIGNATURE",
+		"httpCode": "403 Forbidden"
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	err := client.Get("/me", nil)
+	wrapped := fmt.Errorf("fetching account: %w", err)
+
+	// Validate
+	var apiErr *APIError
+	if !errors.As(wrapped, &apiErr) {
+		t.Fatalf("errors.As should find an APIError. Got %v", wrapped)
+	}
+	expected := &APIError{
+		StatusCode: http.StatusForbidden,
+		Code:       http.StatusForbidden,
+		Message:    "Invalid signature",
+		Class:      "Client::Forbidden",
+		ErrorCode:  "INVALID_SIGNATURE",
+	}
+	if *apiErr != *expected {
+		t.Fatalf("APIError should be %+v. Got %+v", expected, apiErr)
+	}
+}
+
+func TestErrorProblemDetails(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusNotFound, `{
+		"type": "https://api.ovh.com/problems/not-found",
+		"title": "Resource not found",
+		"status": 404,
+		"detail": "The policy 42 does not exist",
+		"instance": "/v2/iam/policy/42"
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	err := client.Get("/iam/policy/42", nil)
+
+	// Validate
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Problem details should be returned as an APIError. Got %v", err)
+	}
+	expected := &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       http.StatusNotFound,
+		Message:    "The policy 42 does not exist",
+		Type:       "https://api.ovh.com/problems/not-found",
+		Title:      "Resource not found",
+		Detail:     "The policy 42 does not exist",
+		Instance:   "/v2/iam/policy/42",
+	}
+	if *apiErr != *expected {
+		t.Fatalf("APIError should be %+v. Got %+v", expected, apiErr)
+	}
 }
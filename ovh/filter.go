@@ -0,0 +1,172 @@
+package ovh
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter operators of the X-Pagination-Filter header
+const (
+	FilterEq   = "eq"
+	FilterIn   = "in"
+	FilterLike = "like"
+)
+
+// FilterCondition is a condition of a Filter, on the value of a field
+type FilterCondition struct {
+	Field    string
+	Operator string
+	Values   []string
+}
+
+// Filter selects and sorts the items of a list endpoint. Paginated lists,
+// see Pager.SetFilter, are filtered with the X-Pagination-Filter and
+// X-Pagination-Sort headers, other routes with query parameters:
+//
+//	filter := ovh.NewFilter().Eq("state", "ok").In("datacenter", "gra1", "rbx2").SortDesc("name").PageSize(50)
+//	pager := client.NewPager("/dedicated/server", 0)
+//	pager.SetFilter(filter)
+//
+// Values are formatted with fmt.Sprint, except times which are formatted
+// with RFC 3339.
+type Filter struct {
+	conditions []FilterCondition
+	sortField  string
+	descending bool
+	pageSize   int
+}
+
+// NewFilter returns an empty filter
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// Eq keeps the items whose field equals "value"
+func (f *Filter) Eq(field string, value interface{}) *Filter {
+	return f.add(field, FilterEq, value)
+}
+
+// In keeps the items whose field equals one of "values"
+func (f *Filter) In(field string, values ...interface{}) *Filter {
+	return f.add(field, FilterIn, values...)
+}
+
+// Like keeps the items whose field matches "pattern", where "%" matches any
+// sequence of characters
+func (f *Filter) Like(field, pattern string) *Filter {
+	return f.add(field, FilterLike, pattern)
+}
+
+// SortAsc sorts the items by a field, in ascending order
+func (f *Filter) SortAsc(field string) *Filter {
+	f.sortField, f.descending = field, false
+	return f
+}
+
+// SortDesc sorts the items by a field, in descending order
+func (f *Filter) SortDesc(field string) *Filter {
+	f.sortField, f.descending = field, true
+	return f
+}
+
+// PageSize sets the number of items per page of the pagers using the filter
+func (f *Filter) PageSize(size int) *Filter {
+	f.pageSize = size
+	return f
+}
+
+// Conditions returns the conditions of the filter
+func (f *Filter) Conditions() []FilterCondition {
+	return append([]FilterCondition(nil), f.conditions...)
+}
+
+// add appends a condition
+func (f *Filter) add(field, operator string, values ...interface{}) *Filter {
+	condition := FilterCondition{Field: field, Operator: operator}
+	for _, value := range values {
+		condition.Values = append(condition.Values, formatFilterValue(value))
+	}
+	f.conditions = append(f.conditions, condition)
+	return f
+}
+
+// formatFilterValue returns the string form of a filter value
+func formatFilterValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Header returns the pagination headers of the filter: X-Pagination-Filter,
+// holding the "field:operator=value" conditions joined by "&", with the
+// values of "in" conditions joined by ",", and X-Pagination-Sort with
+// X-Pagination-Sort-Order. Fields and values are query escaped.
+func (f *Filter) Header() http.Header {
+	header := http.Header{}
+	if len(f.conditions) > 0 {
+		conditions := make([]string, 0, len(f.conditions))
+		for _, condition := range f.conditions {
+			values := make([]string, len(condition.Values))
+			for i, value := range condition.Values {
+				values[i] = url.QueryEscape(value)
+			}
+			conditions = append(conditions, url.QueryEscape(condition.Field)+":"+condition.Operator+"="+strings.Join(values, ","))
+		}
+		header.Set("X-Pagination-Filter", strings.Join(conditions, "&"))
+	}
+	if f.sortField != "" {
+		header.Set("X-Pagination-Sort", f.sortField)
+		if f.descending {
+			header.Set("X-Pagination-Sort-Order", "DESC")
+		} else {
+			header.Set("X-Pagination-Sort-Order", "ASC")
+		}
+	}
+	return header
+}
+
+// Query returns the conditions of the filter as query parameters, for the
+// routes filtering their lists with query parameters, like
+// GET /dedicated/server/{serviceName}/task?status=todo. Only "eq" and "in"
+// conditions may be expressed as parameters, the values of "in" ones being
+// repeated.
+func (f *Filter) Query() (url.Values, error) {
+	query := url.Values{}
+	for _, condition := range f.conditions {
+		switch condition.Operator {
+		case FilterEq, FilterIn:
+			query[condition.Field] = append(query[condition.Field], condition.Values...)
+		default:
+			return nil, fmt.Errorf("go-ovh: %s filter on %s can not be expressed as query parameters", condition.Operator, condition.Field)
+		}
+	}
+	return query, nil
+}
+
+// Path returns "path" with the conditions of the filter as query parameters,
+// see Query
+func (f *Filter) Path(path string) (string, error) {
+	query, err := f.Query()
+	if err != nil {
+		return "", err
+	}
+	if len(query) == 0 {
+		return path, nil
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + query.Encode(), nil
+}
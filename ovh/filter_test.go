@@ -0,0 +1,92 @@
+package ovh
+
+// Common helpers are in ovh_test.go
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFilterHeader(t *testing.T) {
+	// Init test
+	since := time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)
+	filter := NewFilter().
+		Eq("state", "ok").
+		In("datacenter", "gra1", "rbx,2").
+		Like("name", "ns%.ovh.net").
+		Eq("creationDate", since).
+		SortDesc("name")
+
+	// Test
+	header := filter.Header()
+
+	// Validate
+	expected := "state:eq=ok&datacenter:in=gra1,rbx%2C2&name:like=ns%25.ovh.net&creationDate:eq=2020-01-02T10%3A00%3A00Z"
+	if got := header.Get("X-Pagination-Filter"); got != expected {
+		t.Fatalf("Header should encode the conditions. Got %s", got)
+	}
+	if header.Get("X-Pagination-Sort") != "name" || header.Get("X-Pagination-Sort-Order") != "DESC" {
+		t.Fatalf("Header should encode the sort order. Got %v", header)
+	}
+	if header := NewFilter().SortAsc("id").Header(); header.Get("X-Pagination-Filter") != "" || header.Get("X-Pagination-Sort-Order") != "ASC" {
+		t.Fatalf("Header should only hold the given settings. Got %v", header)
+	}
+	if conditions := filter.Conditions(); len(conditions) != 4 || conditions[1].Values[1] != "rbx,2" {
+		t.Fatalf("Conditions should return the unescaped values. Got %+v", conditions)
+	}
+}
+
+func TestFilterQuery(t *testing.T) {
+	// Init test
+	filter := NewFilter().Eq("status", "todo").In("function", "reboot", "hardReboot").Eq("enabled", true)
+
+	// Test
+	path, err := filter.Path("/dedicated/server/ns1234.ovh.net/task")
+	if err != nil {
+		t.Fatalf("Path should not fail. Got %v", err)
+	}
+	withQuery, err := NewFilter().Eq("kind", "haproxy").Path("/some/list?size=2")
+	if err != nil {
+		t.Fatalf("Path should not fail. Got %v", err)
+	}
+	_, likeErr := NewFilter().Like("name", "ns%").Query()
+
+	// Validate
+	if path != "/dedicated/server/ns1234.ovh.net/task?enabled=true&function=reboot&function=hardReboot&status=todo" {
+		t.Fatalf("Path should append the query parameters. Got %s", path)
+	}
+	if withQuery != "/some/list?size=2&kind=haproxy" {
+		t.Fatalf("Path should keep the existing query. Got %s", withQuery)
+	}
+	if likeErr == nil || likeErr.Error() != "go-ovh: like filter on name can not be expressed as query parameters" {
+		t.Fatalf("Query should reject like conditions. Got %v", likeErr)
+	}
+}
+
+func TestPagerFilter(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `[3, 1]`, nil, time.Duration(0))
+	defer ts.Close()
+	filter := NewFilter().Eq("state", "ok").SortAsc("id").PageSize(20)
+
+	// Test
+	var items []int
+	err := client.ListWithFilter(context.Background(), "/some/list", filter, &items)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ListWithFilter should not fail. Got %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("ListWithFilter should return the items. Got %v", items)
+	}
+	if InputRequest.Header.Get("X-Pagination-Filter") != "state:eq=ok" || InputRequest.Header.Get("X-Pagination-Sort") != "id" {
+		t.Fatalf("Pager should send the filter headers. Got %v", InputRequest.Header)
+	}
+	if InputRequest.Header.Get("X-Pagination-Size") != "20" || InputRequest.Header.Get("X-Pagination-Mode") != "CachedObjectList-Pages" {
+		t.Fatalf("Pager should use the page size of the filter. Got %v", InputRequest.Header)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package ovh
+
+import (
+	"context"
+)
+
+// Typed wrappers, returning the decoded response instead of filling an out
+// parameter. They require Go 1.18 or later.
+//
+//	me, err := ovh.Get[me.Me](client, "/me")
+
+// Get is a typed wrapper for the GET method
+func Get[T any](c *Client, url string) (T, error) {
+	return GetWithContext[T](context.Background(), c, url)
+}
+
+// Post is a typed wrapper for the POST method
+func Post[T any](c *Client, url string, reqBody interface{}) (T, error) {
+	return PostWithContext[T](context.Background(), c, url, reqBody)
+}
+
+// Put is a typed wrapper for the PUT method
+func Put[T any](c *Client, url string, reqBody interface{}) (T, error) {
+	return PutWithContext[T](context.Background(), c, url, reqBody)
+}
+
+// Delete is a typed wrapper for the DELETE method
+func Delete[T any](c *Client, url string) (T, error) {
+	return DeleteWithContext[T](context.Background(), c, url)
+}
+
+// GetWithContext is a typed wrapper for the GET method
+func GetWithContext[T any](ctx context.Context, c *Client, url string) (T, error) {
+	return call[T](ctx, c, "GET", url, nil)
+}
+
+// PostWithContext is a typed wrapper for the POST method
+func PostWithContext[T any](ctx context.Context, c *Client, url string, reqBody interface{}) (T, error) {
+	return call[T](ctx, c, "POST", url, reqBody)
+}
+
+// PutWithContext is a typed wrapper for the PUT method
+func PutWithContext[T any](ctx context.Context, c *Client, url string, reqBody interface{}) (T, error) {
+	return call[T](ctx, c, "PUT", url, reqBody)
+}
+
+// DeleteWithContext is a typed wrapper for the DELETE method
+func DeleteWithContext[T any](ctx context.Context, c *Client, url string) (T, error) {
+	return call[T](ctx, c, "DELETE", url, nil)
+}
+
+// call sends an authenticated request and decodes its response as a T. The
+// zero value is returned on error.
+func call[T any](ctx context.Context, c *Client, method, url string, reqBody interface{}) (T, error) {
+	var res T
+	if err := c.CallAPIWithContext(ctx, method, url, reqBody, &res, true); err != nil {
+		var zero T
+		return zero, err
+	}
+	return res, nil
+}
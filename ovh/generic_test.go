@@ -0,0 +1,65 @@
+//go:build go1.18
+// +build go1.18
+
+package ovh
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestGenericGet(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{"i_val":42}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	res, err := Get[SomeData](client, "/some/resource")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Get should not fail. Got %v", err)
+	}
+	if res.IntValue != 42 {
+		t.Fatalf("Get should decode the response. Got %+v", res)
+	}
+	ensureHeaderPresent(t, InputRequest, "X-Ovh-Consumer", MockConsumerKey)
+}
+
+func TestGenericPost(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	var InputRequestBody string
+	ts, client := initMockServer(&InputRequest, 200, `[1,2]`, &InputRequestBody, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	res, err := Post[[]int](client, "/some/resource", SomeData{IntValue: 42})
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Post should not fail. Got %v", err)
+	}
+	if len(res) != 2 || InputRequest.Method != "POST" || InputRequestBody != `{"i_val":42}` {
+		t.Fatalf("Post should send the body and decode the response. Got %v, %s %s", res, InputRequest.Method, InputRequestBody)
+	}
+}
+
+func TestGenericError(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 400, `{"i_val":42,"message":"bad"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	res, err := Delete[*SomeData](client, "/some/resource")
+
+	// Validate
+	if err == nil || res != nil {
+		t.Fatalf("Delete should return the zero value on error. Got %v, %v", res, err)
+	}
+}
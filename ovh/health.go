@@ -0,0 +1,50 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProbeResult holds the measures of a Probe
+type ProbeResult struct {
+	// Latency is the duration of the GET /auth/time call
+	Latency time.Duration
+	// ServerTime is the time of the API
+	ServerTime time.Time
+	// TimeDelta is the delay between the API and the local clock, as
+	// measured by this call
+	TimeDelta time.Duration
+}
+
+// Probe performs a ping to the OVH API, like PingWithContext, measuring the
+// latency of the call and returning the server time. It does not need any
+// credential, and does not change the time delta used to sign requests.
+func (c *Client) Probe(ctx context.Context) (*ProbeResult, error) {
+	start := time.Now()
+	serverTime, err := c.getTimeWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+
+	return &ProbeResult{
+		Latency:    latency,
+		ServerTime: *serverTime,
+		TimeDelta:  getLocalTime().Sub(*serverTime),
+	}, nil
+}
+
+// Check validates the credentials of the client by fetching the current
+// consumer key, see CurrentCredential. It fails when the API rejects the
+// credentials, or when the consumer key is not validated.
+func (c *Client) Check(ctx context.Context) (*Credential, error) {
+	credential, err := c.CurrentCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if credential.Status != CredentialStatusValidated {
+		return credential, fmt.Errorf("go-ovh: consumer key %d is in %q status", credential.CredentialID, credential.Status)
+	}
+	return credential, nil
+}
@@ -0,0 +1,84 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestProbe(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, "1457018870", nil, 10*time.Millisecond)
+	defer ts.Close()
+	client.timeDeltaDone = false
+
+	// Test
+	result, err := client.Probe(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Probe should not return an error. Got %v", err)
+	}
+	if InputRequest.URL.String() != "/auth/time" || InputRequest.Header.Get("X-Ovh-Signature") != "" {
+		t.Fatalf("Probe should call GET /auth/time without authentication. Got %s", InputRequest.URL.String())
+	}
+	if !result.ServerTime.Equal(time.Unix(1457018870, 0)) || result.TimeDelta != time.Duration(MockTime-1457018870)*time.Second {
+		t.Fatalf("Probe should return the server time. Got %+v", result)
+	}
+	if result.Latency < 10*time.Millisecond {
+		t.Fatalf("Probe should measure the latency. Got %v", result.Latency)
+	}
+	if client.timeDeltaDone {
+		t.Fatalf("Probe should not synchronize the time delta")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{
+		"credentialId": 42,
+		"applicationId": 7,
+		"status": "validated",
+		"rules": [{"method": "GET", "path": "/*"}],
+		"expiration": "2030-01-01T00:00:00+01:00",
+		"lastUse": null
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	credential, err := client.Check(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Check should not return an error. Got %v", err)
+	}
+	if InputRequest.URL.String() != "/auth/currentCredential" || InputRequest.Header.Get("X-Ovh-Consumer") != MockConsumerKey {
+		t.Fatalf("Check should call GET /auth/currentCredential. Got %s", InputRequest.URL.String())
+	}
+	if credential.CredentialID != 42 || len(credential.Rules) != 1 || credential.Expiration == nil || credential.LastUse != nil {
+		t.Fatalf("Check should decode the credential. Got %+v", credential)
+	}
+}
+
+func TestCheckNotValidated(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{"credentialId": 42, "status": "pendingValidation"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	credential, err := client.Check(context.Background())
+
+	// Validate
+	if err == nil || err.Error() != `go-ovh: consumer key 42 is in "pendingValidation" status` {
+		t.Fatalf("Check should fail for consumer keys which are not validated. Got %v", err)
+	}
+	if credential == nil || credential.Status != "pendingValidation" {
+		t.Fatalf("Check should return the credential. Got %+v", credential)
+	}
+}
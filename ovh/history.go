@@ -0,0 +1,217 @@
+package ovh
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHistorySize is the number of requests kept by EnableHistory when no
+// explicit size is given
+const DefaultHistorySize = 1000
+
+// sensitiveFieldNames are the parts of the body field names whose values are
+// masked in the history, matched case-insensitively
+var sensitiveFieldNames = []string{"password", "secret", "token"}
+
+// HistoryEntry describes a request sent by a Client, see EnableHistory
+type HistoryEntry struct {
+	// Time the request was sent
+	Time time.Time
+	// HTTP method of the request
+	Method string
+	// Path of the request, relative to the endpoint
+	Path string
+	// Body of the request, with the client secrets and the sensitive fields,
+	// like passwords, masked. Non JSON bodies are only described.
+	Body string
+	// HTTP status code of the response, 0 if no response was received
+	Status int
+	// Time spent sending the request and receiving the response
+	Latency time.Duration
+	// ID of the request, from the X-Ovh-QueryID response header
+	QueryID string
+	// Err is the error of the request, when no response was received
+	Err error
+}
+
+// HistorySink receives the requests recorded by a client, for instance to
+// store them in an audit log. It must be safe for concurrent use.
+type HistorySink interface {
+	Record(entry HistoryEntry)
+}
+
+// HistorySinkFunc adapts a function to the HistorySink interface
+type HistorySinkFunc func(entry HistoryEntry)
+
+// Record calls f(entry)
+func (f HistorySinkFunc) Record(entry HistoryEntry) {
+	f(entry)
+}
+
+// HistoryConfig configures the history of a client, see EnableHistory
+type HistoryConfig struct {
+	// Size is the number of requests kept in memory, DefaultHistorySize when
+	// zero. Nothing is kept in memory when negative, which is useful along a
+	// Sink.
+	Size int
+
+	// Sink, when set, receives each recorded request
+	Sink HistorySink
+
+	// MutationsOnly only records the requests which may modify resources:
+	// POST, PUT, PATCH and DELETE ones
+	MutationsOnly bool
+}
+
+// history is the history of a client, with a size bounded ring buffer
+type history struct {
+	config  HistoryConfig
+	mutex   sync.Mutex
+	entries []HistoryEntry
+	next    int
+	full    bool
+}
+
+// add records an entry, overwriting the oldest one when the buffer is full
+func (h *history) add(entry HistoryEntry) {
+	if h.config.Sink != nil {
+		h.config.Sink.Record(entry)
+	}
+	if len(h.entries) == 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.entries[h.next] = entry
+	h.next++
+	if h.next == len(h.entries) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// list returns a copy of the recorded entries, oldest first
+func (h *history) list() []HistoryEntry {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.full {
+		return append([]HistoryEntry(nil), h.entries[:h.next]...)
+	}
+	entries := make([]HistoryEntry, 0, len(h.entries))
+	entries = append(entries, h.entries[h.next:]...)
+	return append(entries, h.entries[:h.next]...)
+}
+
+// EnableHistory starts recording the requests sent by the client, each
+// attempt of retried calls included, along with their sanitized body, status
+// code, latency and query ID. A nil config keeps the last
+// DefaultHistorySize requests in memory. Enabling the history again discards
+// previous entries.
+func (c *Client) EnableHistory(config *HistoryConfig) {
+	h := &history{}
+	if config != nil {
+		h.config = *config
+	}
+	size := h.config.Size
+	if size == 0 {
+		size = DefaultHistorySize
+	}
+	if size > 0 {
+		h.entries = make([]HistoryEntry, size)
+	}
+	c.history = h
+}
+
+// DisableHistory stops recording requests and discards recorded entries
+func (c *Client) DisableHistory() {
+	c.history = nil
+}
+
+// History returns the requests recorded since EnableHistory, oldest first.
+// It returns nil when the history is not enabled.
+func (c *Client) History() []HistoryEntry {
+	if c.history == nil {
+		return nil
+	}
+	return c.history.list()
+}
+
+// recordHistory records a request in the history, if enabled
+func (c *Client) recordHistory(start time.Time, method, path string, body []byte, contentType string, response *http.Response, err error) {
+	h := c.history
+	if h == nil || (h.config.MutationsOnly && !isMutating(method)) {
+		return
+	}
+
+	entry := HistoryEntry{
+		Time:    start,
+		Method:  method,
+		Path:    c.MaskSecret(path),
+		Body:    c.sanitizeBody(body, contentType),
+		Latency: time.Since(start),
+		Err:     err,
+	}
+	if response != nil {
+		entry.Status = response.StatusCode
+		entry.QueryID = response.Header.Get("X-Ovh-QueryID")
+	}
+	h.add(entry)
+}
+
+// sanitizeBody returns a request body suitable for the history, with the
+// client secrets and the sensitive JSON fields masked
+func (c *Client) sanitizeBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if contentType != "" && !strings.Contains(contentType, "json") {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return c.MaskSecret(string(body))
+	}
+	sanitized, err := json.Marshal(maskSensitiveFields(value))
+	if err != nil {
+		return c.MaskSecret(string(body))
+	}
+	return c.MaskSecret(string(sanitized))
+}
+
+// maskSensitiveFields replaces the values of the sensitive fields of a
+// decoded JSON value
+func maskSensitiveFields(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, field := range value {
+			if isSensitiveField(name) {
+				value[name] = secretMask
+			} else {
+				value[name] = maskSensitiveFields(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = maskSensitiveFields(item)
+		}
+	}
+	return value
+}
+
+// isSensitiveField checks if a body field holds a secret
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveFieldNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
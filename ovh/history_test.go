@@ -0,0 +1,94 @@
+package ovh
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestHistory(t *testing.T) {
+	// Init test
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ovh-QueryID", "EU.ext-1.abcdef")
+		fmt.Fprint(w, `null`)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	// Test: disabled by default
+	client.Get("/me", nil)
+	if client.History() != nil {
+		t.Fatalf("History should be nil when not enabled. Got %v", client.History())
+	}
+
+	// Test
+	client.EnableHistory(&HistoryConfig{Size: 2})
+	client.Get("/me", nil)
+	client.Post("/me/sshKey", map[string]interface{}{
+		"keyName": "laptop",
+		"nested":  []interface{}{map[string]string{"rootPassword": "hunter2"}},
+		"comment": MockConsumerKey,
+	}, nil)
+	client.Put("/me", &RawBody{ContentType: "text/plain", Data: strings.NewReader("raw")}, nil)
+
+	// Validate
+	history := client.History()
+	if len(history) != 2 || history[0].Method != "POST" || history[1].Method != "PUT" {
+		t.Fatalf("History should keep the last requests. Got %+v", history)
+	}
+	entry := history[0]
+	expected := `{"comment":"****","keyName":"laptop","nested":[{"rootPassword":"****"}]}`
+	if entry.Body != expected {
+		t.Fatalf("History bodies should be sanitized. Expected %s. Got %s", expected, entry.Body)
+	}
+	if entry.Path != "/me/sshKey" || entry.Status != http.StatusOK || entry.QueryID != "EU.ext-1.abcdef" || entry.Latency <= 0 || entry.Time.IsZero() {
+		t.Fatalf("History should describe the requests. Got %+v", entry)
+	}
+	if body := history[1].Body; body != "<3 bytes of text/plain>" {
+		t.Fatalf("History should only describe raw bodies. Got %s", body)
+	}
+
+	// Test: disable
+	client.DisableHistory()
+	if client.History() != nil {
+		t.Fatalf("History should be nil once disabled. Got %v", client.History())
+	}
+}
+
+func TestHistorySink(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusOK, `null`, nil, time.Duration(0))
+	defer ts.Close()
+
+	var mutex sync.Mutex
+	var recorded []string
+	client.EnableHistory(&HistoryConfig{
+		Size:          -1,
+		MutationsOnly: true,
+		Sink: HistorySinkFunc(func(entry HistoryEntry) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			recorded = append(recorded, entry.Method+" "+entry.Path)
+		}),
+	})
+
+	// Test
+	client.Get("/me", nil)
+	client.Delete("/me/sshKey/laptop", nil)
+
+	// Validate
+	if fmt.Sprint(recorded) != "[DELETE /me/sshKey/laptop]" {
+		t.Fatalf("Sink should receive the mutations. Got %v", recorded)
+	}
+	if history := client.History(); len(history) != 0 {
+		t.Fatalf("History should not be kept in memory with a negative size. Got %v", history)
+	}
+}
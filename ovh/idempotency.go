@@ -0,0 +1,120 @@
+package ovh
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultIdempotencyHeader is the header carrying idempotency keys, when
+// IdempotencyConfig.Header is empty
+const DefaultIdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyConfig configures the idempotency keys attached to mutating
+// requests. A key identifies a logical operation: it is the same for all the
+// attempts of a call, so that replays can be detected.
+type IdempotencyConfig struct {
+	// Header is the header carrying the key. DefaultIdempotencyHeader is
+	// used when empty.
+	Header string
+
+	// Generate attaches a random key to the POST calls which were not given
+	// one, see WithIdempotencyKey.
+	Generate bool
+}
+
+// idempotencyKeyContext is the context key of the idempotency keys
+type idempotencyKeyContext struct{}
+
+// WithIdempotencyKey returns a context attaching "key" to the mutating calls
+// made with it. Callers retrying an operation by themselves should reuse the
+// same key for all their attempts.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+// NewIdempotencyKey returns a random idempotency key, formatted as a UUID
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("go-ovh: unable to generate an idempotency key: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// DuplicateRequestError is returned when a call uses the idempotency key of
+// another call of the client which is still in flight. The call is not sent.
+type DuplicateRequestError struct {
+	Key string
+}
+
+func (err *DuplicateRequestError) Error() string {
+	return fmt.Sprintf("go-ovh: a request with idempotency key %s is already in flight", err.Key)
+}
+
+// idempotencyTracker records the idempotency keys of the calls in flight
+type idempotencyTracker struct {
+	mutex    sync.Mutex
+	inFlight map[string]bool
+}
+
+// acquire marks a key as in flight, unless it already is
+func (t *idempotencyTracker) acquire(key string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.inFlight[key] {
+		return false
+	}
+	if t.inFlight == nil {
+		t.inFlight = map[string]bool{}
+	}
+	t.inFlight[key] = true
+	return true
+}
+
+// release marks a key as no longer in flight
+func (t *idempotencyTracker) release(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.inFlight, key)
+}
+
+// withIdempotencyKey returns the headers of a call with its idempotency key,
+// if any, and a function to call once the call completed. The key is taken
+// from the headers, then from the context, then generated for POST calls
+// when configured.
+func (c *Client) withIdempotencyKey(ctx context.Context, method string, header http.Header) (http.Header, func(), error) {
+	if !isMutating(method) {
+		return header, func() {}, nil
+	}
+
+	name := DefaultIdempotencyHeader
+	if c.Idempotency != nil && c.Idempotency.Header != "" {
+		name = c.Idempotency.Header
+	}
+	key := header.Get(name)
+	if key == "" {
+		key, _ = ctx.Value(idempotencyKeyContext{}).(string)
+	}
+	if key == "" && c.Idempotency != nil && c.Idempotency.Generate && strings.ToUpper(method) == "POST" {
+		key = NewIdempotencyKey()
+	}
+	if key == "" {
+		return header, func() {}, nil
+	}
+
+	if !c.idempotency.acquire(key) {
+		return header, nil, &DuplicateRequestError{Key: key}
+	}
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(name, key)
+	return header, func() { c.idempotency.release(key) }, nil
+}
@@ -0,0 +1,124 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestIdempotencyKey(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test: caller supplied key
+	ctx := WithIdempotencyKey(context.Background(), "my-key")
+	if err := client.PostWithContext(ctx, "/some/resource", nil, nil); err != nil {
+		t.Fatalf("Client.Post should not return an error. Got %v", err)
+	}
+	ensureHeaderPresent(t, InputRequest, DefaultIdempotencyHeader, "my-key")
+
+	// Test: no key by default
+	if err := client.Post("/some/resource", nil, nil); err != nil {
+		t.Fatalf("Client.Post should not return an error. Got %v", err)
+	}
+	if key := InputRequest.Header.Get(DefaultIdempotencyHeader); key != "" {
+		t.Fatalf("Idempotency keys should not be generated by default. Got %s", key)
+	}
+
+	// Test: generated keys, for POST calls only
+	client.Idempotency = &IdempotencyConfig{Header: "X-Request-Key", Generate: true}
+	if err := client.Post("/some/resource", nil, nil); err != nil {
+		t.Fatalf("Client.Post should not return an error. Got %v", err)
+	}
+	key := InputRequest.Header.Get("X-Request-Key")
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(key) {
+		t.Fatalf("Generated idempotency key should be a UUID. Got '%s'", key)
+	}
+	if err := client.Post("/some/resource", nil, nil); err != nil {
+		t.Fatalf("Client.Post should not return an error. Got %v", err)
+	}
+	if InputRequest.Header.Get("X-Request-Key") == key {
+		t.Fatalf("Generated idempotency keys should be unique. Got %s twice", key)
+	}
+	if err := client.Put("/some/resource", nil, nil); err != nil {
+		t.Fatalf("Client.Put should not return an error. Got %v", err)
+	}
+	if key := InputRequest.Header.Get("X-Request-Key"); key != "" {
+		t.Fatalf("Idempotency keys should only be generated for POST calls. Got %s", key)
+	}
+}
+
+func TestIdempotencyKeyRetry(t *testing.T) {
+	// Init test
+	var keys []string
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(DefaultIdempotencyHeader))
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`"success"`))
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.Retry = &RetryConfig{MaxRetries: 1, Delay: time.Millisecond, RetryNonIdempotent: true}
+	client.Idempotency = &IdempotencyConfig{Generate: true}
+
+	// Test
+	if err := client.PostUnAuth("/some/resource", nil, nil); err != nil {
+		t.Fatalf("Client.Post should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("Retries should reuse the idempotency key. Got %v", keys)
+	}
+}
+
+func TestIdempotencyKeyInFlight(t *testing.T) {
+	// Init test
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		w.Write([]byte(`"success"`))
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	ctx := WithIdempotencyKey(context.Background(), "order-42")
+	errs := make(chan error)
+	go func() {
+		errs <- client.PostUnAuthWithContext(ctx, "/order", nil, nil)
+	}()
+	<-started
+
+	// Test
+	err := client.PostUnAuthWithContext(ctx, "/order", nil, nil)
+	close(release)
+
+	// Validate
+	duplicate, ok := err.(*DuplicateRequestError)
+	if !ok || duplicate.Key != "order-42" {
+		t.Fatalf("Calls with the key of a call in flight should fail with a DuplicateRequestError. Got %v", err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("The call in flight should succeed. Got %v", err)
+	}
+	if err := client.PostUnAuthWithContext(ctx, "/order", nil, nil); err != nil {
+		t.Fatalf("Keys should be released once calls complete. Got %v", err)
+	}
+}
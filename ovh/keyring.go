@@ -0,0 +1,19 @@
+package ovh
+
+// keyringService prefixes the endpoint name to build the keyring service
+const keyringService = "ovh:"
+
+// KeyringProvider is a CredentialProvider reading the OS credential store.
+// Credentials are stored as generic passwords. The service is "ovh:" followed by
+// the endpoint name, like "ovh:ovh-eu", and the account is the configuration
+// key, like "application_secret":
+//
+//   - macOS: security add-generic-password -s ovh:ovh-eu -a application_secret -w
+//   - Linux: secret-tool store --label=ovh service ovh:ovh-eu account application_secret
+//   - Windows: cmdkey /generic:ovh:ovh-eu/application_secret /user:ovh /pass
+type KeyringProvider struct{}
+
+// Credential reads a credential from the OS credential store
+func (KeyringProvider) Credential(endpoint, key string) (string, error) {
+	return keyringGet(keyringService+endpoint, key)
+}
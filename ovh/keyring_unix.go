@@ -0,0 +1,45 @@
+//go:build !windows
+// +build !windows
+
+package ovh
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// runKeyringCommand runs a keyring command line tool and returns its output,
+// overloaded during the tests
+var runKeyringCommand = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+// keyringGet reads a generic password with the "security" tool on macOS and
+// "secret-tool" on other systems. Missing passwords are not an error.
+func keyringGet(service, account string) (string, error) {
+	var output []byte
+	var err error
+	if runtime.GOOS == "darwin" {
+		output, err = runKeyringCommand("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	} else {
+		output, err = runKeyringCommand("secret-tool", "lookup", "service", service, "account", account)
+	}
+
+	// Both tools exit with status 1, and no output, for missing passwords
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.ExitCode() == 1 || runtime.GOOS == "darwin" && exitErr.ExitCode() == 44 {
+			return "", nil
+		}
+		if stderr := strings.TrimSpace(string(exitErr.Stderr)); stderr != "" {
+			return "", errors.New(stderr)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(output, "\r\n")), nil
+}
@@ -0,0 +1,54 @@
+//go:build !windows
+// +build !windows
+
+package ovh
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestKeyringProvider(t *testing.T) {
+	// Init test
+	defer func(run func(string, ...string) ([]byte, error)) { runKeyringCommand = run }(runKeyringCommand)
+
+	var command string
+	runKeyringCommand = func(name string, args ...string) ([]byte, error) {
+		command = fmt.Sprintln(name, args)
+		if args[len(args)-1] == "consumer_key" || args[len(args)-2] == "consumer_key" {
+			return exec.Command("sh", "-c", "exit 1").Output()
+		}
+		return []byte("secret\n"), nil
+	}
+
+	// Test: stored credential
+	value, err := KeyringProvider{}.Credential("ovh-eu", "application_secret")
+	if err != nil || value != "secret" {
+		t.Fatalf("Keyring credential should be 'secret'. Got '%s', %v", value, err)
+	}
+	expected := "secret-tool [lookup service ovh:ovh-eu account application_secret]\n"
+	if runtime.GOOS == "darwin" {
+		expected = "security [find-generic-password -s ovh:ovh-eu -a application_secret -w]\n"
+	}
+	if command != expected {
+		t.Fatalf("Keyring should be queried with %q. Got %q", expected, command)
+	}
+
+	// Test: missing credential
+	value, err = KeyringProvider{}.Credential("ovh-eu", "consumer_key")
+	if err != nil || value != "" {
+		t.Fatalf("Missing keyring credential should be empty. Got '%s', %v", value, err)
+	}
+
+	// Test: tool failure
+	runKeyringCommand = func(name string, args ...string) ([]byte, error) {
+		return exec.Command("sh", "-c", "echo 'no secret service' >&2; exit 2").Output()
+	}
+	if _, err := (KeyringProvider{}).Credential("ovh-eu", "application_secret"); err == nil || err.Error() != "no secret service" {
+		t.Fatalf("Keyring errors should be returned. Got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+//go:build windows
+// +build windows
+
+package ovh
+
+import (
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var (
+	advapi32      = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW = advapi32.NewProc("CredReadW")
+	procCredFree  = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric = 1
+	errorNotFound   = syscall.Errno(1168)
+)
+
+// winCredential mirrors the CREDENTIALW structure
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// keyringGet reads the password of the "service/account" generic credential
+// from the Windows Credential Manager. Missing credentials are not an error.
+func keyringGet(service, account string) (string, error) {
+	target, err := syscall.UTF16PtrFromString(service + "/" + account)
+	if err != nil {
+		return "", err
+	}
+
+	var credential *winCredential
+	ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&credential)))
+	if ret == 0 {
+		if err == errorNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(credential)))
+
+	size := int(credential.CredentialBlobSize)
+	if size == 0 {
+		return "", nil
+	}
+	blob := (*[1 << 20]byte)(unsafe.Pointer(credential.CredentialBlob))[:size:size]
+	return decodeCredentialBlob(blob), nil
+}
+
+// decodeCredentialBlob decodes passwords stored as UTF-16, like cmdkey does,
+// or as UTF-8
+func decodeCredentialBlob(blob []byte) string {
+	if len(blob)%2 != 0 || blob[1] != 0 {
+		return string(blob)
+	}
+	chars := make([]uint16, len(blob)/2)
+	for i := range chars {
+		chars[i] = uint16(blob[2*i]) | uint16(blob[2*i+1])<<8
+	}
+	return string(utf16.Decode(chars))
+}
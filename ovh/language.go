@@ -0,0 +1,33 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+)
+
+// languageContext is the context key of the per-request languages
+type languageContext struct{}
+
+// WithRequestLanguage returns a context sending the calls made with it with
+// an Accept-Language header, like "fr-FR", whatever Client.Language.
+func WithRequestLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageContext{}, language)
+}
+
+// language returns the language selected for a call, if any
+func (c *Client) language(ctx context.Context) string {
+	if ctx != nil {
+		if language, ok := ctx.Value(languageContext{}).(string); ok && language != "" {
+			return language
+		}
+	}
+	return c.Language
+}
+
+// setLanguage sets the Accept-Language header of a request, when a language
+// is selected
+func (c *Client) setLanguage(ctx context.Context, req *http.Request) {
+	if language := c.language(ctx); language != "" {
+		req.Header.Set("Accept-Language", language)
+	}
+}
@@ -0,0 +1,72 @@
+package ovh
+
+// Common helpers are in ovh_test.go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// initLanguageServer returns a server failing with a message localized in
+// the requested language, and the Accept-Language headers received
+func initLanguageServer(t *testing.T) (*httptest.Server, *[]string) {
+	languages := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := r.Header.Get("Accept-Language")
+		languages = append(languages, language)
+
+		message := "This service does not exist"
+		if language == "fr-FR" {
+			message = "Ce service n'existe pas"
+			w.Header().Set("Content-Language", "fr-FR")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"class": "Client::NotFound", "errorCode": "SERVICE_NOT_FOUND", "message": "` + message + `"}`))
+	}))
+	return ts, &languages
+}
+
+func TestLanguage(t *testing.T) {
+	// Init test
+	ts, languages := initLanguageServer(t)
+	defer ts.Close()
+	client, err := NewClientWithOptions(ts.URL, WithAppKey(MockApplicationKey, MockApplicationSecret), WithConsumerKey(MockConsumerKey), WithLanguage("fr-FR"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+
+	// Test
+	localized := client.GetUnAuth("/some/resource", nil)
+	english := client.GetUnAuthWithContext(WithRequestLanguage(context.Background(), "en-US"), "/some/resource", nil)
+
+	// Validate
+	var apiErr *APIError
+	if !errors.As(localized, &apiErr) || apiErr.Message != "Ce service n'existe pas" || apiErr.Language != "fr-FR" || apiErr.ErrorCode != "SERVICE_NOT_FOUND" {
+		t.Fatalf("Errors should be localized in the client language. Got %+v", localized)
+	}
+	if !errors.As(english, &apiErr) || apiErr.Message != "This service does not exist" || apiErr.Language != "" || apiErr.ErrorCode != "SERVICE_NOT_FOUND" {
+		t.Fatalf("Calls should select their own language. Got %+v", english)
+	}
+	if len(*languages) != 2 || (*languages)[0] != "fr-FR" || (*languages)[1] != "en-US" {
+		t.Fatalf("Accept-Language should be sent. Got %v", *languages)
+	}
+}
+
+func TestLanguageUnset(t *testing.T) {
+	// Init test
+	ts, languages := initLanguageServer(t)
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	// Test
+	client.GetUnAuth("/some/resource", nil)
+
+	// Validate
+	if len(*languages) != 1 || (*languages)[0] != "" {
+		t.Fatalf("Accept-Language should not be sent by default. Got %v", *languages)
+	}
+}
@@ -0,0 +1,193 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LeastPrivilegeRules returns the minimal consumer key rules granting the
+// "calls", given as a method and a path relative to the API version. Methods
+// are upper cased, GET by default, query strings are dropped, duplicates and
+// rules covered by a wildcard rule of the list are removed. Rules are sorted
+// by path, then method.
+func LeastPrivilegeRules(calls []AccessRule) []AccessRule {
+	seen := map[AccessRule]bool{}
+	unique := []AccessRule{}
+	for _, call := range calls {
+		rule := normalizeCall(call.Method, call.Path)
+		if !seen[rule] {
+			seen[rule] = true
+			unique = append(unique, rule)
+		}
+	}
+
+	rules := []AccessRule{}
+	for i, rule := range unique {
+		covered := false
+		for j, other := range unique {
+			if i != j && rule.Method == other.Method && matchPath(other.Path, rule.Path) && !matchPath(rule.Path, other.Path) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Path != rules[j].Path {
+			return rules[i].Path < rules[j].Path
+		}
+		return rules[i].Method < rules[j].Method
+	})
+	return rules
+}
+
+// GeneralizeRules returns the least privilege rules of "calls" with the path
+// segments which look like identifiers, see PathTemplate, replaced by the '*'
+// wildcard. A key granting "GET /domain/zone/*/record" may then manage the
+// records of any zone, and not only those of the recorded calls. Note that
+// '*' also matches '/', so that the rules are broader than the routes.
+func GeneralizeRules(calls []AccessRule) []AccessRule {
+	generalized := make([]AccessRule, len(calls))
+	for i, call := range calls {
+		rule := normalizeCall(call.Method, call.Path)
+		rule.Path = strings.Replace(PathTemplate(rule.Path), "{id}", "*", -1)
+		generalized[i] = rule
+	}
+	return LeastPrivilegeRules(generalized)
+}
+
+// normalizeCall returns the rule granting exactly a call
+func normalizeCall(method, path string) AccessRule {
+	if method == "" {
+		method = "GET"
+	}
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return AccessRule{Method: strings.ToUpper(method), Path: path}
+}
+
+// RulesAudit compares the rules of a consumer key to the calls of an
+// application, see AuditRules
+type RulesAudit struct {
+	// CredentialID of the audited consumer key
+	CredentialID int64
+
+	// Missing lists the least privilege rules of the calls not granted by
+	// the consumer key
+	Missing []AccessRule
+
+	// Unused lists the rules of the consumer key granting none of the calls,
+	// which could be revoked
+	Unused []AccessRule
+}
+
+// AuditRules compares the rules of the consumer key of the client, fetched
+// from /auth/currentCredential, to the "calls" of an application, for
+// instance recorded by a RuleRecorder.
+func (c *Client) AuditRules(ctx context.Context, calls []AccessRule) (*RulesAudit, error) {
+	credential, err := c.CurrentCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	required := LeastPrivilegeRules(calls)
+	audit := &RulesAudit{
+		CredentialID: credential.CredentialID,
+		Missing:      missingRules(credential.Rules, required),
+		Unused:       []AccessRule{},
+	}
+	for _, rule := range credential.Rules {
+		used := false
+		for _, call := range required {
+			if strings.EqualFold(rule.Method, call.Method) && (matchPath(rule.Path, call.Path) || matchPath(call.Path, rule.Path)) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			audit.Unused = append(audit.Unused, rule)
+		}
+	}
+	return audit, nil
+}
+
+// NewCkRequestForCalls helps create a new ck request granting the least
+// privilege rules of "calls", see LeastPrivilegeRules
+func (c *Client) NewCkRequestForCalls(calls []AccessRule) *CkRequest {
+	ck := c.NewCkRequest()
+	ck.AccessRules = append(ck.AccessRules, LeastPrivilegeRules(calls)...)
+	return ck
+}
+
+// RuleRecorder collects the calls made by a client, to request a consumer key
+// granting only them. Calls are recorded by its Middleware, or by
+// RecordRequest when used as the OnDryRun callback of a dry-run client, which
+// only sees the mutating calls.
+type RuleRecorder struct {
+	mutex sync.Mutex
+	calls []AccessRule
+	seen  map[AccessRule]bool
+}
+
+// NewRuleRecorder returns an empty RuleRecorder
+func NewRuleRecorder() *RuleRecorder {
+	return &RuleRecorder{seen: map[AccessRule]bool{}}
+}
+
+// Record adds a call, given as a method and a path relative to the API
+// version
+func (r *RuleRecorder) Record(method, path string) {
+	call := normalizeCall(method, path)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.seen[call] {
+		r.seen[call] = true
+		r.calls = append(r.calls, call)
+	}
+}
+
+// RecordRequest adds the call of an API request. The API version segment of
+// its URL, like "/1.0" or "/v2", is dropped.
+func (r *RuleRecorder) RecordRequest(req *http.Request) {
+	path := req.URL.Path
+	segment := strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(segment, '/'); i >= 0 {
+		segment = segment[:i]
+	}
+	if apiVersionSegment.MatchString(segment) {
+		path = strings.TrimPrefix(path, "/"+segment)
+	}
+	r.Record(req.Method, path)
+}
+
+// Middleware returns a Middleware recording the calls of the client, see
+// Client.Use
+func (r *RuleRecorder) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			r.RecordRequest(req)
+			return next(req)
+		}
+	}
+}
+
+// Calls returns the recorded calls, in the order they were first made
+func (r *RuleRecorder) Calls() []AccessRule {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]AccessRule{}, r.calls...)
+}
+
+// Rules returns the least privilege rules of the recorded calls, see
+// LeastPrivilegeRules
+func (r *RuleRecorder) Rules() []AccessRule {
+	return LeastPrivilegeRules(r.Calls())
+}
@@ -0,0 +1,157 @@
+package ovh
+
+// Common helpers are in ovh_test.go
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLeastPrivilegeRules(t *testing.T) {
+	// Init test
+	calls := []AccessRule{
+		{Method: "get", Path: "/me"},
+		{Path: "/me?fields=name"},
+		{Method: "POST", Path: "/domain/zone/example.com/refresh"},
+		{Method: "GET", Path: "/domain/zone/example.com/record/42"},
+		{Method: "GET", Path: "/domain/zone/*"},
+		{Method: "DELETE", Path: "/domain/zone/example.com/record/42"},
+	}
+
+	// Test
+	rules := LeastPrivilegeRules(calls)
+	generalized := GeneralizeRules(calls)
+
+	// Validate
+	expected := []AccessRule{
+		{Method: "GET", Path: "/domain/zone/*"},
+		{Method: "DELETE", Path: "/domain/zone/example.com/record/42"},
+		{Method: "POST", Path: "/domain/zone/example.com/refresh"},
+		{Method: "GET", Path: "/me"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("LeastPrivilegeRules should return the minimal rules. Got %v", rules)
+	}
+	expected = []AccessRule{
+		{Method: "GET", Path: "/domain/zone/*"},
+		{Method: "DELETE", Path: "/domain/zone/*/record/*"},
+		{Method: "POST", Path: "/domain/zone/*/refresh"},
+		{Method: "GET", Path: "/me"},
+	}
+	if !reflect.DeepEqual(generalized, expected) {
+		t.Fatalf("GeneralizeRules should replace the identifiers by wildcards. Got %v", generalized)
+	}
+	if rules := LeastPrivilegeRules(nil); rules == nil || len(rules) != 0 {
+		t.Fatalf("LeastPrivilegeRules should return an empty list without calls. Got %#v", rules)
+	}
+}
+
+func TestRuleRecorder(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{}`, nil, time.Duration(0))
+	defer ts.Close()
+	recorder := NewRuleRecorder()
+	client.Use(recorder.Middleware())
+
+	// Test
+	client.Get("/me", nil)
+	client.Get("/me?fields=name", nil)
+	client.Post("/domain/zone/example.com/refresh", nil, nil)
+	recorder.RecordRequest(httptest.NewRequest("DELETE", "https://eu.api.ovh.com/1.0/domain/zone/example.com/record/42", nil))
+	recorder.RecordRequest(httptest.NewRequest("GET", "https://eu.api.ovh.com/v2/iam/policy", nil))
+
+	// Validate
+	expected := []AccessRule{
+		{Method: "GET", Path: "/me"},
+		{Method: "POST", Path: "/domain/zone/example.com/refresh"},
+		{Method: "DELETE", Path: "/domain/zone/example.com/record/42"},
+		{Method: "GET", Path: "/iam/policy"},
+	}
+	if calls := recorder.Calls(); !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("RuleRecorder should record the calls once, without API version. Got %v", calls)
+	}
+	if rules := recorder.Rules(); len(rules) != 4 || rules[0].Path != "/domain/zone/example.com/record/42" {
+		t.Fatalf("Rules should return the sorted rules. Got %v", rules)
+	}
+}
+
+func TestNewCkRequestForCalls(t *testing.T) {
+	const expectedRequest = `{"accessRules":[{"method":"GET","path":"/domain/zone/*"},{"method":"GET","path":"/me"}]}`
+
+	// Init test
+	var InputRequest *http.Request
+	var InputRequestBody string
+	ts, client := initMockServer(&InputRequest, 200, `{
+		"validationUrl":"https://validation.url",
+		"consumerKey":"`+MockConsumerKey+`",
+		"state":"pendingValidation"
+	}`, &InputRequestBody, time.Duration(0))
+	client.ConsumerKey = ""
+	defer ts.Close()
+
+	// Test
+	ckRequest := client.NewCkRequestForCalls([]AccessRule{
+		{Method: "GET", Path: "/me"},
+		{Method: "GET", Path: "/domain/zone/example.com"},
+		{Method: "GET", Path: "/domain/zone/*"},
+	})
+	_, err := ckRequest.DoWithContext(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("CkRequest.Do() should not return an error. Got: %q", err)
+	}
+	if InputRequestBody != expectedRequest {
+		t.Fatalf("NewCkRequestForCalls should request the least privilege rules '%s'. Got %s", expectedRequest, InputRequestBody)
+	}
+	if client.ConsumerKey != MockConsumerKey {
+		t.Fatalf("CkRequest.Do() should set client.ConsumerKey to %s. Got %s", MockConsumerKey, client.ConsumerKey)
+	}
+}
+
+func TestAuditRules(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{
+		"credentialId": 42,
+		"status":"validated",
+		"rules":[
+			{"method":"GET","path":"/me"},
+			{"method":"GET","path":"/domain/*"},
+			{"method":"DELETE","path":"/*"},
+			{"method":"PUT","path":"/sms/*"}
+		]
+	}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	audit, err := client.AuditRules(context.Background(), []AccessRule{
+		{Method: "GET", Path: "/me"},
+		{Method: "GET", Path: "/domain/zone/example.com/record?fieldType=TXT"},
+		{Method: "POST", Path: "/domain/zone/example.com/refresh"},
+	})
+
+	// Validate
+	if err != nil {
+		t.Fatalf("AuditRules should not return an error. Got: %v", err)
+	}
+	if InputRequest.Method != "GET" || InputRequest.URL.String() != "/auth/currentCredential" {
+		t.Fatalf("AuditRules should call GET /auth/currentCredential. Got %s %s", InputRequest.Method, InputRequest.URL.String())
+	}
+	if audit.CredentialID != 42 {
+		t.Fatalf("AuditRules should return the credential ID. Got %d", audit.CredentialID)
+	}
+	expected := []AccessRule{{Method: "POST", Path: "/domain/zone/example.com/refresh"}}
+	if !reflect.DeepEqual(audit.Missing, expected) {
+		t.Fatalf("AuditRules should report the missing rules. Got %v", audit.Missing)
+	}
+	expected = []AccessRule{{Method: "DELETE", Path: "/*"}, {Method: "PUT", Path: "/sms/*"}}
+	if !reflect.DeepEqual(audit.Unused, expected) {
+		t.Fatalf("AuditRules should report the unused rules. Got %v", audit.Unused)
+	}
+}
@@ -0,0 +1,43 @@
+package ovh
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Dummy credentials of the clients created with NewClientForTesting
+const (
+	TestingApplicationKey    = "testing-application-key"
+	TestingApplicationSecret = "testing-application-secret"
+	TestingConsumerKey       = "testing-consumer-key"
+)
+
+// NewClientForTesting creates a client for a fake API, typically an
+// httptest.Server or the "ovh-local" endpoint when "serverURL" is empty. It
+// uses dummy credentials, which options may replace, and never reads the
+// environment nor the configuration files, so that tests and examples can
+// not use the credentials of a developer by mistake.
+func NewClientForTesting(serverURL string, opts ...Option) (*Client, error) {
+	if serverURL == "" {
+		serverURL = OvhLocal
+	}
+	client := Client{
+		Client:         newHTTPClient(),
+		timeDeltaMutex: &sync.Mutex{},
+		timeDeltaDone:  false,
+		Timeout:        time.Duration(DefaultTimeout),
+		AppKey:         TestingApplicationKey,
+		AppSecret:      TestingApplicationSecret,
+		ConsumerKey:    TestingConsumerKey,
+	}
+	for _, opt := range opts {
+		opt(&client)
+	}
+
+	client.endpoint = resolveEndpoint(serverURL)
+	if client.endpoint == "" {
+		return nil, fmt.Errorf("unknown endpoint '%s', consider checking 'Endpoints' list of using an URL", serverURL)
+	}
+	return &client, nil
+}
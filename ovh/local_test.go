@@ -0,0 +1,79 @@
+package ovh
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestNewClientForTesting(t *testing.T) {
+	// Prepare: configuration which must not be read
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[default]
+user_agent=from-file
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	os.Setenv("OVH_CONSUMER_KEY", "from-env")
+	defer os.Unsetenv("OVH_CONSUMER_KEY")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			fmt.Fprint(w, time.Now().Unix())
+			return
+		}
+		fmt.Fprintf(w, `{"consumer": %q, "agent": %q}`, r.Header.Get("X-Ovh-Consumer"), r.Header.Get("User-Agent"))
+	}))
+	defer ts.Close()
+
+	// Test
+	client, err := NewClientForTesting(ts.URL)
+	if err != nil {
+		t.Fatalf("NewClientForTesting should not fail. Got %v", err)
+	}
+	var res struct {
+		Consumer string `json:"consumer"`
+		Agent    string `json:"agent"`
+	}
+	if err := client.Get("/me", &res); err != nil {
+		t.Fatalf("Requests should be sent to the test server. Got %v", err)
+	}
+
+	// Validate
+	if client.Endpoint() != ts.URL || client.AppKey != TestingApplicationKey || client.AppSecret != TestingApplicationSecret {
+		t.Fatalf("Client should use the server and dummy credentials. Got %s, %s", client.Endpoint(), client.AppKey)
+	}
+	if res.Consumer != TestingConsumerKey {
+		t.Fatalf("Environment should not be read. Got consumer key %s", res.Consumer)
+	}
+	if strings.HasPrefix(res.Agent, "from-file") {
+		t.Fatalf("Configuration files should not be read. Got User-Agent %s", res.Agent)
+	}
+}
+
+func TestNewClientForTestingEndpoints(t *testing.T) {
+	// Test
+	local, err := NewClientForTesting("")
+	if err != nil {
+		t.Fatalf("NewClientForTesting should not fail. Got %v", err)
+	}
+	custom, err := NewClientForTesting("ovh-local", WithConsumerKey("custom"))
+	if err != nil {
+		t.Fatalf("NewClientForTesting should not fail. Got %v", err)
+	}
+	_, unknownErr := NewClientForTesting("unknown")
+
+	// Validate
+	if local.Endpoint() != OvhLocal || custom.Endpoint() != OvhLocal || custom.ConsumerKey != "custom" {
+		t.Fatalf("Clients should use the ovh-local endpoint and options. Got %s, %s, %s", local.Endpoint(), custom.Endpoint(), custom.ConsumerKey)
+	}
+	if unknownErr == nil {
+		t.Fatalf("Unknown endpoints should be rejected")
+	}
+}
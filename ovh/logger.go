@@ -1,9 +1,13 @@
 package ovh
 
 import (
+	"io/ioutil"
 	"net/http"
 )
 
+// truncatedMarker is appended to bodies truncated before being logged
+const truncatedMarker = "...[truncated]"
+
 // Logger is the interface that should be implemented for loggers that wish to
 // log HTTP requests and HTTP responses.
 type Logger interface {
@@ -13,3 +17,49 @@ type Logger interface {
 	// LogResponse logs an HTTP response.
 	LogResponse(*http.Response)
 }
+
+// BodyLogger may be implemented by a Logger wishing to log request and
+// response bodies as well. Bodies are truncated to Client.MaxLoggedBodyBytes.
+type BodyLogger interface {
+	// LogRequestBody logs the body of an HTTP request.
+	LogRequestBody(*http.Request, []byte)
+
+	// LogResponseBody logs the body of an HTTP response.
+	LogResponseBody(*http.Response, []byte)
+}
+
+// truncateBody truncates body to max bytes, if max is positive, appending a
+// marker when truncated
+func truncateBody(body []byte, max int) []byte {
+	if max <= 0 || len(body) <= max {
+		return body
+	}
+	truncated := make([]byte, 0, max+len(truncatedMarker))
+	truncated = append(truncated, body[:max]...)
+	return append(truncated, truncatedMarker...)
+}
+
+// logRequestBody passes the request body to the logger if it supports it
+func (c *Client) logRequestBody(req *http.Request) {
+	logger, ok := c.Logger.(BodyLogger)
+	if !ok || req.GetBody == nil {
+		return
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return
+	}
+	logger.LogRequestBody(req, truncateBody(data, c.MaxLoggedBodyBytes))
+}
+
+// logResponseBody passes the response body to the logger if it supports it
+func (c *Client) logResponseBody(response *http.Response, body []byte) {
+	if logger, ok := c.Logger.(BodyLogger); ok {
+		logger.LogResponseBody(response, truncateBody(body, c.MaxLoggedBodyBytes))
+	}
+}
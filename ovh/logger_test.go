@@ -0,0 +1,62 @@
+package ovh
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+type mockBodyLogger struct {
+	requestBody  string
+	responseBody string
+}
+
+func (l *mockBodyLogger) LogRequest(*http.Request)   {}
+func (l *mockBodyLogger) LogResponse(*http.Response) {}
+
+func (l *mockBodyLogger) LogRequestBody(req *http.Request, body []byte) {
+	l.requestBody = string(body)
+}
+
+func (l *mockBodyLogger) LogResponseBody(resp *http.Response, body []byte) {
+	l.responseBody = string(body)
+}
+
+func TestBodyLogger(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"0123456789abcdef"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	logger := &mockBodyLogger{}
+	client.Logger = logger
+
+	// Test: whole bodies by default
+	var res string
+	if err := client.Post("/some/resource", SomeData{IntValue: 42}, &res); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+	if logger.requestBody != `{"i_val":42}` {
+		t.Fatalf("Request body should be logged. Got '%s'", logger.requestBody)
+	}
+	if logger.responseBody != `"0123456789abcdef"` {
+		t.Fatalf("Response body should be logged. Got '%s'", logger.responseBody)
+	}
+
+	// Test: truncated bodies
+	client.MaxLoggedBodyBytes = 5
+	if err := client.Post("/some/resource", SomeData{IntValue: 42}, &res); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+	if logger.requestBody != `{"i_v...[truncated]` {
+		t.Fatalf("Request body should be truncated. Got '%s'", logger.requestBody)
+	}
+	if logger.responseBody != `"0123...[truncated]` {
+		t.Fatalf("Response body should be truncated. Got '%s'", logger.responseBody)
+	}
+	if res != "0123456789abcdef" {
+		t.Fatalf("Truncating logs should not alter the response. Got '%s'", res)
+	}
+}
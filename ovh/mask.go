@@ -0,0 +1,32 @@
+package ovh
+
+import (
+	"regexp"
+	"strings"
+)
+
+// secretMask replaces secrets in masked strings
+const secretMask = "****"
+
+// signatureRegexp matches request signatures, as sent in X-Ovh-Signature
+var signatureRegexp = regexp.MustCompile(`\$1\$[0-9a-fA-F]{40}`)
+
+// MaskSecret returns "s" with the client application secret, consumer key,
+// OAuth2 client secret and any request signature replaced by "****". It is suitable to redact log
+// messages or errors which may contain credentials. Secrets of the applications of
+// the AppKeyPool are masked too.
+func (c *Client) MaskSecret(s string) string {
+	keys := c.credentials()
+	secrets := []string{keys.appSecret, keys.consumerKey, keys.clientSecret}
+	if c.AppKeyPool != nil {
+		for _, app := range c.AppKeyPool.Applications() {
+			secrets = append(secrets, app.AppSecret, app.ConsumerKey)
+		}
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, secretMask, -1)
+		}
+	}
+	return signatureRegexp.ReplaceAllString(s, secretMask)
+}
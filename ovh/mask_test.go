@@ -0,0 +1,29 @@
+package ovh
+
+import (
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestMaskSecret(t *testing.T) {
+	client := Client{
+		AppKey:      MockApplicationKey,
+		AppSecret:   MockApplicationSecret,
+		ConsumerKey: MockConsumerKey,
+	}
+
+	input := "secret=" + MockApplicationSecret + " ck=" + MockConsumerKey + " ak=" + MockApplicationKey +
+		" sig=$1$8a21169b341aa23e82192e07457ca978006b1ba9"
+	expected := "secret=**** ck=**** ak=" + MockApplicationKey + " sig=****"
+
+	if got := client.MaskSecret(input); got != expected {
+		t.Fatalf("MaskSecret should return %q. Got %q", expected, got)
+	}
+
+	// Empty credentials must not be replaced
+	client = Client{}
+	if got := client.MaskSecret("nothing to hide"); got != "nothing to hide" {
+		t.Fatalf("MaskSecret should not alter strings without secrets. Got %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package ovh
+
+import (
+	"strings"
+	"time"
+)
+
+// Metrics is implemented by metrics collectors, for example Prometheus ones,
+// to observe the API calls of a client. See Client.Metrics.
+type Metrics interface {
+	// ObserveRequest is called once per attempt of each API call. It must be
+	// safe for concurrent use.
+	ObserveRequest(RequestMetrics)
+}
+
+// RequestMetrics describes a single attempt of an API call
+type RequestMetrics struct {
+	// HTTP method of the call
+	Method string
+	// Path of the call, relative to the endpoint
+	Path string
+	// PathTemplate is the path with identifiers replaced by "{id}", see
+	// PathTemplate. Use it as label to keep the metrics cardinality low.
+	PathTemplate string
+	// StatusCode of the response, 0 if no response was received
+	StatusCode int
+	// Time spent sending the request and receiving the response headers
+	Duration time.Duration
+	// Err is the transport error, if any. API errors are only reported by
+	// their StatusCode.
+	Err error
+}
+
+// PathTemplate returns "path" without query string, and with the segments
+// which look like identifiers replaced by "{id}". Route segments of the API
+// are made of ASCII letters only, segments with any other character, like
+// "example.com", "ns1234.ip-1-2-3.eu" or "42", are considered identifiers.
+func PathTemplate(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && !isRouteSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isRouteSegment checks if a path segment only has ASCII letters
+func isRouteSegment(segment string) bool {
+	for _, r := range segment {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
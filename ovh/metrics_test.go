@@ -0,0 +1,66 @@
+package ovh
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// mockMetrics records the observed requests
+type mockMetrics struct {
+	mutex    sync.Mutex
+	requests []RequestMetrics
+}
+
+func (m *mockMetrics) ObserveRequest(r RequestMetrics) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.requests = append(m.requests, r)
+}
+
+func TestMetrics(t *testing.T) {
+	// Init test
+	ts, client, _ := initFlakyServer(t, 1, http.StatusServiceUnavailable)
+	defer ts.Close()
+	client.Retry = &RetryConfig{MaxRetries: 1, Delay: time.Millisecond}
+	metrics := &mockMetrics{}
+	client.Metrics = metrics
+
+	// Test
+	if err := client.GetUnAuth("/domain/zone/example.com/record/42?fieldType=A", nil); err != nil {
+		t.Fatalf("GET should succeed after a retry. Got %v", err)
+	}
+
+	// Validate
+	if len(metrics.requests) != 2 {
+		t.Fatalf("Each attempt should be observed. Got %d", len(metrics.requests))
+	}
+	if metrics.requests[0].StatusCode != http.StatusServiceUnavailable || metrics.requests[1].StatusCode != http.StatusOK {
+		t.Fatalf("Status codes should be observed. Got %d, %d", metrics.requests[0].StatusCode, metrics.requests[1].StatusCode)
+	}
+	r := metrics.requests[1]
+	if r.Method != "GET" || r.Path != "/domain/zone/example.com/record/42?fieldType=A" || r.Duration <= 0 || r.Err != nil {
+		t.Fatalf("Request should be observed. Got %+v", r)
+	}
+	if r.PathTemplate != "/domain/zone/{id}/record/{id}" {
+		t.Fatalf("PathTemplate should be '/domain/zone/{id}/record/{id}'. Got '%s'", r.PathTemplate)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/me":                                         "/me",
+		"/me/bill/FR1234?date.from=2020":              "/me/bill/{id}",
+		"/dedicated/server/ns1234.ip-1-2-3.eu/":       "/dedicated/server/{id}/",
+		"/cloud/project/0123abcd/instance":            "/cloud/project/{id}/instance",
+		"/ip/192.0.2.0%2F24":                          "/ip/{id}",
+		"/ipLoadbalancing/loadbalancer-abc/http/farm": "/ipLoadbalancing/{id}/http/farm",
+	} {
+		if got := PathTemplate(path); got != expected {
+			t.Fatalf("PathTemplate(%q) should be '%s'. Got '%s'", path, expected, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ovh
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Handler sends an API request and returns its response
+type Handler func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to intercept requests and responses, for
+// instance to add logging, metrics or custom headers
+type Middleware func(next Handler) Handler
+
+// Use appends middlewares to the client. The first middleware is the
+// outermost one. Middlewares see the requests before they are signed, so they
+// may alter the headers, body or URL, and the responses once received. They
+// are applied to each attempt of a retried request.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// chain wraps a handler with the client middlewares
+func (c *Client) chain(handler Handler) Handler {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	return handler
+}
+
+// signAndDo returns the innermost handler, signing the request if needed then
+// sending it, or handing it to dryRun. The signature is computed on the
+// request as left by the middlewares. "target" and "path" are the URL and
+// relative path the request was built with.
+func (c *Client) signAndDo(ctx context.Context, target, path string, needAuth bool) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		// Middlewares may have changed the method or URL
+		if err := c.checkCall(req.Context(), req.Method, c.apiPath(req.URL.String())); err != nil {
+			return nil, err
+		}
+		if needAuth {
+			var body []byte
+			if req.Body != nil {
+				var err error
+				if body, err = ioutil.ReadAll(req.Body); err != nil {
+					return nil, err
+				}
+				req.Body.Close()
+				req.Body = ioutil.NopCloser(bytes.NewReader(body))
+				req.GetBody = func() (io.ReadCloser, error) {
+					return ioutil.NopCloser(bytes.NewReader(body)), nil
+				}
+				req.ContentLength = int64(len(body))
+			}
+
+			// Keep the path as built unless a middleware changed the URL
+			if url := req.URL.String(); url != target {
+				path = strings.TrimPrefix(url, c.versionedEndpoint(ctx, c.endpoint))
+			}
+			if err := c.sign(ctx, req, path, body); err != nil {
+				return nil, err
+			}
+		}
+		if c.DryRun && isMutating(req.Method) {
+			return c.dryRun(req)
+		}
+		return c.Do(req)
+	}
+}
@@ -0,0 +1,123 @@
+package ovh
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initSigningServer starts a server echoing the request body, provided the
+// request signature is valid
+func initSigningServer() (*httptest.Server, *Client) {
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	getEndpointForSignature = func(c *Client) string {
+		return "http://localhost"
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		h := sha1.New()
+		h.Write([]byte(fmt.Sprintf("%s+%s+%s+http://localhost%s+%s+%s",
+			MockApplicationSecret,
+			r.Header.Get("X-Ovh-Consumer"),
+			r.Method,
+			r.URL.RequestURI(),
+			body,
+			r.Header.Get("X-Ovh-Timestamp"),
+		)))
+		if r.Header.Get("X-Ovh-Signature") != fmt.Sprintf("$1$%x", h.Sum(nil)) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-Tag", r.Header.Get("X-Tag"))
+		w.Write(body)
+	}))
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+	return ts, client
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	var calls []string
+	tracer := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, "before "+name)
+				response, err := next(req)
+				calls = append(calls, "after "+name)
+				return response, err
+			}
+		}
+	}
+	client.Use(tracer("a"), tracer("b"))
+
+	// Test
+	if err := client.Get("/some/resource", nil); err != nil {
+		t.Fatalf("Request with middlewares should not fail. Got %v", err)
+	}
+
+	// Validate
+	expected := "[before a before b after b after a]"
+	if fmt.Sprint(calls) != expected {
+		t.Fatalf("Middlewares should be called in registration order. Expected %s. Got %v", expected, calls)
+	}
+}
+
+func TestMiddlewareBeforeSigning(t *testing.T) {
+	// Init test: a middleware altering headers, query and body
+	ts, client := initSigningServer()
+	defer ts.Close()
+
+	var status int
+	var tag string
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Ovh-Signature") != "" {
+				t.Fatalf("Middlewares should see requests before signing")
+			}
+			req.Header.Set("X-Tag", "tagged")
+			query := req.URL.Query()
+			query.Set("tag", "1")
+			req.URL.RawQuery = query.Encode()
+			body := []byte(`{"i_val":43}`)
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			req.ContentLength = int64(len(body))
+
+			response, err := next(req)
+			if err == nil {
+				status = response.StatusCode
+				tag = response.Header.Get("X-Tag")
+			}
+			return response, err
+		}
+	})
+
+	// Test
+	var res struct {
+		IVal int `json:"i_val"`
+	}
+	if err := client.Post("/some/resource", map[string]int{"i_val": 42}, &res); err != nil {
+		t.Fatalf("Request altered by a middleware should be signed. Got %v", err)
+	}
+
+	// Validate
+	if res.IVal != 43 {
+		t.Fatalf("Middleware body should be sent. Got %d", res.IVal)
+	}
+	if status != http.StatusOK || tag != "tagged" {
+		t.Fatalf("Middleware should see the response. Got status %d, tag '%s'", status, tag)
+	}
+}
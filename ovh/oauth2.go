@@ -0,0 +1,98 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2TokenURLs maps API endpoints to the token URL of their OVH SSO, used
+// by OAuth2 clients. Add an entry to use OAuth2 with another endpoint.
+var OAuth2TokenURLs = map[string]string{
+	OvhEU: "https://www.ovh.com/auth/oauth2/token",
+	OvhCA: "https://ca.ovh.com/auth/oauth2/token",
+	OvhUS: "https://us.ovhcloud.com/auth/oauth2/token",
+}
+
+// oauth2TokenExpiryDelta renews access tokens a bit before they expire, so
+// that they do not expire in flight
+const oauth2TokenExpiryDelta = 10 * time.Second
+
+// oauth2Token caches the access token of an OAuth2 client
+type oauth2Token struct {
+	mutex       sync.Mutex
+	tokenURL    string
+	accessToken string
+	expiry      time.Time
+}
+
+// NewOAuth2Client represents a new client to call the API, authenticated with
+// an OAuth2 service account using the client credentials flow rather than
+// signed requests. Missing parameters are loaded from environment or
+// configuration files, using the "client_id" and "client_secret" keys.
+func NewOAuth2Client(endpoint, clientID, clientSecret string) (*Client, error) {
+	return NewClientWithOptions(endpoint, WithClientCredentials(clientID, clientSecret))
+}
+
+// initOAuth2 enables OAuth2 authentication once the configuration is loaded
+func (c *Client) initOAuth2() error {
+	tokenURL, ok := OAuth2TokenURLs[c.endpoint]
+	if !ok {
+		return fmt.Errorf("OAuth2 authentication is not supported on endpoint '%s'", c.endpoint)
+	}
+	c.oauth2 = &oauth2Token{tokenURL: tokenURL}
+	return nil
+}
+
+// getAccessToken returns a valid access token, fetching a new one from the
+// token URL when none was fetched yet or the previous one expired
+func (c *Client) getAccessToken(ctx context.Context) (string, error) {
+	c.oauth2.mutex.Lock()
+	defer c.oauth2.mutex.Unlock()
+
+	if c.oauth2.accessToken != "" && getLocalTime().Before(c.oauth2.expiry) {
+		return c.oauth2.accessToken, nil
+	}
+
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {"all"},
+	}
+	req, err := http.NewRequest("POST", c.oauth2.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.getUserAgent())
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	response, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil && response.StatusCode < 300 {
+		return "", fmt.Errorf("go-ovh: invalid OAuth2 token response: %v", err)
+	}
+	if response.StatusCode >= 300 || token.AccessToken == "" {
+		return "", fmt.Errorf("go-ovh: unable to get an OAuth2 access token: %d %s %s", response.StatusCode, token.Error, token.ErrorDescription)
+	}
+
+	c.oauth2.accessToken = token.AccessToken
+	c.oauth2.expiry = getLocalTime().Add(time.Duration(token.ExpiresIn)*time.Second - oauth2TokenExpiryDelta)
+	return c.oauth2.accessToken, nil
+}
@@ -0,0 +1,192 @@
+package ovh
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initOAuth2Server starts a server acting both as the OVH SSO, issuing tokens
+// expiring after "expiresIn" seconds, and as the API, echoing the bearer token
+func initOAuth2Server(expiresIn int, tokens *int32) (*httptest.Server, *Client) {
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/oauth2/token" {
+			clientID, clientSecret, _ := r.BasicAuth()
+			r.ParseForm()
+			if clientID != "client" || clientSecret != "secret" || r.PostForm.Get("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad credentials"}`)
+				return
+			}
+			n := atomic.AddInt32(tokens, 1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+			return
+		}
+		if r.Header.Get("X-Ovh-Signature") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `"%s"`, r.Header.Get("Authorization"))
+	}))
+	OAuth2TokenURLs[ts.URL] = ts.URL + "/oauth2/token"
+
+	client, _ := NewOAuth2Client(ts.URL, "client", "secret")
+	return ts, client
+}
+
+func TestOAuth2Client(t *testing.T) {
+	// Init test
+	var tokens int32
+	ts, client := initOAuth2Server(3600, &tokens)
+	defer ts.Close()
+	defer delete(OAuth2TokenURLs, ts.URL)
+	if client == nil {
+		t.Fatalf("NewOAuth2Client should create a client")
+	}
+
+	// Test: the token is fetched once, then cached
+	for i := 0; i < 2; i++ {
+		var res string
+		if err := client.Get("/some/resource", &res); err != nil {
+			t.Fatalf("OAuth2 request should not fail. Got %v", err)
+		}
+		if res != "Bearer token-1" {
+			t.Fatalf("OAuth2 request should send the access token. Got '%s'", res)
+		}
+	}
+
+	// Validate
+	if tokens != 1 {
+		t.Fatalf("Access token should be fetched once. Got %d", tokens)
+	}
+}
+
+func TestOAuth2TokenRefresh(t *testing.T) {
+	// Init test
+	var tokens int32
+	ts, client := initOAuth2Server(60, &tokens)
+	defer ts.Close()
+	defer delete(OAuth2TokenURLs, ts.URL)
+
+	var res string
+	if err := client.Get("/some/resource", &res); err != nil {
+		t.Fatalf("OAuth2 request should not fail. Got %v", err)
+	}
+
+	// Test: the token expires
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime+60, 0)
+	}
+	if err := client.Get("/some/resource", &res); err != nil {
+		t.Fatalf("OAuth2 request should not fail. Got %v", err)
+	}
+
+	// Validate
+	if res != "Bearer token-2" || tokens != 2 {
+		t.Fatalf("Expired access token should be renewed. Got '%s' after %d tokens", res, tokens)
+	}
+}
+
+func TestOAuth2InvalidCredentials(t *testing.T) {
+	// Init test
+	var tokens int32
+	ts, client := initOAuth2Server(3600, &tokens)
+	defer ts.Close()
+	defer delete(OAuth2TokenURLs, ts.URL)
+	client.ClientSecret = "wrong"
+
+	// Test
+	err := client.Get("/some/resource", nil)
+
+	// Validate
+	if err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("Invalid OAuth2 credentials should return the SSO error. Got %v", err)
+	}
+}
+
+func TestOAuth2Config(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+client_id=system
+client_secret=system
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test: credentials from configuration
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.ClientID != "system" || client.ClientSecret != "system" {
+		t.Fatalf("OAuth2 credentials should be loaded from configuration. Got '%s', '%s'", client.ClientID, client.ClientSecret)
+	}
+	if client.oauth2 == nil || client.oauth2.tokenURL != OAuth2TokenURLs[OvhEU] {
+		t.Fatalf("OAuth2 should be enabled with the endpoint token URL. Got %+v", client.oauth2)
+	}
+
+	// Test: environment takes precedence
+	os.Setenv("OVH_CLIENT_SECRET", "env")
+	defer os.Unsetenv("OVH_CLIENT_SECRET")
+	client = Client{}
+	client.loadConfig("ovh-eu")
+	if client.ClientSecret != "env" {
+		t.Fatalf("client.ClientSecret should be 'env'. Got '%s'", client.ClientSecret)
+	}
+
+	// Test: application keys given as parameters win
+	client = Client{AppKey: "param", AppSecret: "param"}
+	if err := client.loadConfig("ovh-eu"); err != nil || client.oauth2 != nil {
+		t.Fatalf("Application keys parameters should disable OAuth2. Got %v", err)
+	}
+}
+
+func TestOAuth2ConfigErrors(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=system
+application_secret=system
+client_id=system
+client_secret=system
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+
+	// Test: mixed credentials
+	client := Client{}
+	if err := client.loadConfig("ovh-eu"); err == nil {
+		t.Fatalf("Mixing application keys and OAuth2 credentials should fail")
+	}
+
+	// Test: missing client secret
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+client_id=system
+`), 0660)
+	client = Client{}
+	if err := client.loadConfig("ovh-eu"); err == nil {
+		t.Fatalf("Missing OAuth2 client secret should fail")
+	}
+
+	// Test: unsupported endpoint
+	client = Client{ClientID: "param", ClientSecret: "param"}
+	if err := client.loadConfig("kimsufi-eu"); err == nil {
+		t.Fatalf("OAuth2 on an endpoint without token URL should fail")
+	}
+}
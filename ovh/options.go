@@ -0,0 +1,261 @@
+package ovh
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Option configures a Client created with NewClientWithOptions
+type Option func(*Client)
+
+// NewClientWithOptions creates an API client for the specified endpoint,
+// configured with the given options. Credentials which are not given as
+// options are loaded from environment or configuration files, like NewClient.
+func NewClientWithOptions(endpoint string, opts ...Option) (*Client, error) {
+	client := Client{
+		Client:         newHTTPClient(),
+		timeDeltaMutex: &sync.Mutex{},
+		timeDeltaDone:  false,
+		Timeout:        time.Duration(DefaultTimeout),
+	}
+	for _, opt := range opts {
+		opt(&client)
+	}
+
+	// Get and check the configuration
+	if err := client.loadConfig(endpoint); err != nil {
+		return nil, err
+	}
+	if client.syncTimeDelta {
+		if _, err := client.getTimeDelta(); err != nil {
+			return nil, err
+		}
+	}
+	return &client, nil
+}
+
+// newHTTPClient returns the default HTTP client of a Client, sharing the
+// default transport
+func newHTTPClient() *http.Client {
+	return &http.Client{Transport: defaultTransport}
+}
+
+// WithAppKey sets the application key and secret
+func WithAppKey(appKey, appSecret string) Option {
+	return func(c *Client) {
+		c.AppKey = appKey
+		c.AppSecret = appSecret
+	}
+}
+
+// WithConsumerKey sets the consumer key
+func WithConsumerKey(consumerKey string) Option {
+	return func(c *Client) {
+		c.ConsumerKey = consumerKey
+	}
+}
+
+// WithAppKeyPool spreads the requests across the applications of a pool,
+// each request being signed with the next one, see AppKeyPool. The first
+// application of the pool is also set as the client credentials.
+func WithAppKeyPool(pool *AppKeyPool) Option {
+	return func(c *Client) {
+		c.AppKeyPool = pool
+		if apps := pool.Applications(); len(apps) > 0 {
+			c.AppKey = apps[0].AppKey
+			c.AppSecret = apps[0].AppSecret
+			c.ConsumerKey = apps[0].ConsumerKey
+		}
+	}
+}
+
+// WithClientCredentials sets the OAuth2 client ID and secret of a service
+// account, see NewOAuth2Client
+func WithClientCredentials(clientID, clientSecret string) Option {
+	return func(c *Client) {
+		c.ClientID = clientID
+		c.ClientSecret = clientSecret
+	}
+}
+
+// WithConfigFile loads the configuration file at "path" instead of the default
+// ones, see NewClientWithConfigFile
+func WithConfigFile(path string) Option {
+	return func(c *Client) {
+		c.configFile = path
+	}
+}
+
+// WithProfile selects a configuration profile: the endpoint, when not given
+// to NewClientWithOptions, and the credentials are read from the
+// "[profile <name>]" section of the configuration files. It takes precedence
+// over the OVH_PROFILE environment variable.
+func WithProfile(name string) Option {
+	return func(c *Client) {
+		c.profile = name
+	}
+}
+
+// WithEnvPrefix sets the prefix of the environment variables read by the
+// client, DefaultEnvPrefix ("OVH_") when empty. For example, with "TENANT_",
+// the application key is read from TENANT_APPLICATION_KEY and the
+// configuration file from TENANT_CONFIG.
+func WithEnvPrefix(prefix string) Option {
+	return func(c *Client) {
+		c.envPrefix = prefix
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests. Its timeout
+// is overridden by the client Timeout, see WithTimeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.Client = httpClient
+		}
+	}
+}
+
+// WithTransportConfig sets the HTTP transport of the client to a transport
+// from NewTransport, for instance to tune its connection pool with
+// MaxIdleConnsPerHost and IdleConnTimeout
+func WithTransportConfig(config TransportConfig) Option {
+	return func(c *Client) {
+		c.SetTransport(NewTransport(config))
+	}
+}
+
+// WithTimeout sets the timeout of the requests
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.Timeout = timeout
+	}
+}
+
+// WithTimeDeltaSync computes the time delta with the API, with GET /auth/time,
+// when the client is created instead of before its first signed request, so
+// that an unreachable API or a failing synchronization is reported by
+// NewClientWithOptions. The request is bound by the client Timeout.
+func WithTimeDeltaSync() Option {
+	return func(c *Client) {
+		c.syncTimeDelta = true
+	}
+}
+
+// WithBodyLimits sets the maximum sizes of the request and response bodies,
+// see Client.MaxRequestBytes and Client.MaxResponseBytes. Zero disables a
+// limit.
+func WithBodyLimits(maxRequestBytes, maxResponseBytes int64) Option {
+	return func(c *Client) {
+		c.MaxRequestBytes = maxRequestBytes
+		c.MaxResponseBytes = maxResponseBytes
+	}
+}
+
+// WithRetry enables automatic retries of failed idempotent requests
+func WithRetry(retry *RetryConfig) Option {
+	return func(c *Client) {
+		c.Retry = retry
+	}
+}
+
+// WithRetryPolicy retries failed requests as decided by a custom policy, see
+// Client.RetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithCircuitBreaker stops sending requests after consecutive failures, see
+// Client.CircuitBreaker
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Client) {
+		c.CircuitBreaker = breaker
+	}
+}
+
+// WithDefaultRequestTimeout limits the duration of each call, see
+// Client.RequestTimeout
+func WithDefaultRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.RequestTimeout = timeout
+	}
+}
+
+// WithAPIVersion selects the API version of all the calls, see
+// Client.APIVersion
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.APIVersion = version
+	}
+}
+
+// WithLanguage sets the language of the API error messages, see
+// Client.Language
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.Language = language
+	}
+}
+
+// WithIdempotency configures the idempotency keys of mutating calls
+func WithIdempotency(config *IdempotencyConfig) Option {
+	return func(c *Client) {
+		c.Idempotency = config
+	}
+}
+
+// WithReadOnly forbids all calls but GET ones, see Client.ReadOnly
+func WithReadOnly() Option {
+	return func(c *Client) {
+		c.ReadOnly = true
+	}
+}
+
+// WithPolicy restricts the calls of the client, see Client.Policy
+func WithPolicy(policy *CallPolicy) Option {
+	return func(c *Client) {
+		c.Policy = policy
+	}
+}
+
+// WithDryRun enables the dry-run mode: mutating calls are passed to
+// "onRequest", which may be nil, instead of being sent. See Client.DryRun.
+func WithDryRun(onRequest func(*http.Request)) Option {
+	return func(c *Client) {
+		c.DryRun = true
+		c.OnDryRun = onRequest
+	}
+}
+
+// WithStrictDecoding makes calls fail when their response has fields the
+// result type does not cover, see Client.StrictDecoding
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.StrictDecoding = true
+	}
+}
+
+// WithoutCompression stops asking for compressed responses, see
+// Client.DisableCompression
+func WithoutCompression() Option {
+	return func(c *Client) {
+		c.DisableCompression = true
+	}
+}
+
+// WithLogger sets the logger of HTTP requests and responses
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
+
+// WithUserAgent sets a custom User-Agent prefix, see SetUserAgent
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
@@ -0,0 +1,118 @@
+package ovh
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestNewClientWithOptions(t *testing.T) {
+	// Init test
+	httpClient := &http.Client{}
+	logger := &mockBodyLogger{}
+	retry := &RetryConfig{MaxRetries: 2}
+
+	// Test
+	client, err := NewClientWithOptions("ovh-eu",
+		WithAppKey(MockApplicationKey, MockApplicationSecret),
+		WithConsumerKey(MockConsumerKey),
+		WithHTTPClient(httpClient),
+		WithTimeout(42*time.Second),
+		WithRetry(retry),
+		WithLogger(logger),
+		WithUserAgent("my-app/1.2"),
+	)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+	if client.AppKey != MockApplicationKey || client.AppSecret != MockApplicationSecret || client.ConsumerKey != MockConsumerKey {
+		t.Fatalf("Credentials should be set from options. Got '%s', '%s', '%s'", client.AppKey, client.AppSecret, client.ConsumerKey)
+	}
+	if client.endpoint != OvhEU {
+		t.Fatalf("Endpoint should be resolved. Got '%s'", client.endpoint)
+	}
+	if client.Client != httpClient {
+		t.Fatalf("HTTP client should be set from options")
+	}
+	if client.Timeout != 42*time.Second {
+		t.Fatalf("Timeout should be 42s. Got %v", client.Timeout)
+	}
+	if client.Retry != retry || client.Logger != logger {
+		t.Fatalf("Retry and Logger should be set from options")
+	}
+	if client.userAgent != "my-app/1.2" {
+		t.Fatalf("User-Agent should be set from options. Got '%s'", client.userAgent)
+	}
+}
+
+func TestNewClientWithOptionsDefaults(t *testing.T) {
+	// Test
+	client, err := NewClientWithOptions("ovh-eu", WithAppKey(MockApplicationKey, MockApplicationSecret), WithHTTPClient(nil))
+
+	// Validate
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+	if client.Client == nil {
+		t.Fatalf("A nil HTTP client should keep the default one")
+	}
+	if client.Timeout != DefaultTimeout {
+		t.Fatalf("Timeout should default to DefaultTimeout. Got %v", client.Timeout)
+	}
+
+	if _, err := NewClientWithOptions("ovh-eu"); err == nil {
+		t.Fatalf("NewClientWithOptions should fail without credentials")
+	}
+}
+
+func TestWithTimeDeltaSync(t *testing.T) {
+	// Init test
+	syncs := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			syncs++
+			fmt.Fprint(w, MockTime)
+			return
+		}
+		fmt.Fprint(w, `"success"`)
+	}))
+	defer ts.Close()
+
+	// Test
+	client, err := NewClientWithOptions(ts.URL, WithAppKey(MockApplicationKey, MockApplicationSecret), WithConsumerKey(MockConsumerKey), WithTimeDeltaSync())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+	created := syncs
+	err = client.Get("/some/resource", nil)
+
+	// Validate
+	if created != 1 {
+		t.Fatalf("Time should be synchronized when the client is created. Got %d synchronizations", created)
+	}
+	if err != nil || syncs != 1 {
+		t.Fatalf("Signed calls should use the time delta of the creation. Got %v, %d synchronizations", err, syncs)
+	}
+}
+
+func TestWithTimeDeltaSyncError(t *testing.T) {
+	// Init test
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	// Test
+	client, err := NewClientWithOptions(ts.URL, WithAppKey(MockApplicationKey, MockApplicationSecret), WithTimeDeltaSync())
+
+	// Validate
+	if err == nil || client != nil {
+		t.Fatalf("NewClientWithOptions should report synchronization failures. Got %v", err)
+	}
+}
@@ -4,13 +4,15 @@ package ovh
 import (
 	"bytes"
 	"context"
-	"crypto/sha1"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,6 +20,12 @@ import (
 // DefaultTimeout api requests after 180s
 const DefaultTimeout = 180 * time.Second
 
+// DefaultClockSkewTolerance is the default Client.ClockSkewTolerance
+const DefaultClockSkewTolerance = 30 * time.Second
+
+// userAgent identifies this library in the User-Agent header of all requests
+const userAgent = "github.com/ovh/go-ovh"
+
 // Endpoints
 const (
 	OvhEU        = "https://eu.api.ovh.com/1.0"
@@ -28,9 +36,14 @@ const (
 	SoyoustartEU = "https://eu.api.soyoustart.com/1.0"
 	SoyoustartCA = "https://ca.api.soyoustart.com/1.0"
 	RunaboveCA   = "https://api.runabove.com/1.0"
+
+	// OvhLocal is a local fake or sandbox API, for development, see
+	// NewClientForTesting
+	OvhLocal = "http://localhost:8080/1.0"
 )
 
-// Endpoints conveniently maps endpoints names to their URI for external configuration
+// Endpoints conveniently maps endpoints names to their URI for external
+// configuration. Use RegisterEndpoint to add custom endpoints.
 var Endpoints = map[string]string{
 	"ovh-eu":        OvhEU,
 	"ovh-ca":        OvhCA,
@@ -40,14 +53,26 @@ var Endpoints = map[string]string{
 	"soyoustart-eu": SoyoustartEU,
 	"soyoustart-ca": SoyoustartCA,
 	"runabove-ca":   RunaboveCA,
+	"ovh-local":     OvhLocal,
 }
 
 // Errors
 var (
-	ErrAPIDown = errors.New("go-vh: the OVH API is down, it does't respond to /time anymore")
+	ErrAPIDown          = errors.New("go-vh: the OVH API is down, it does't respond to /time anymore")
+	ErrResponseTooLarge = errors.New("go-ovh: response body exceeds the configured MaxResponseBytes")
+	ErrRequestTooLarge  = errors.New("go-ovh: request body exceeds the configured MaxRequestBytes")
+	ErrReadOnly         = errors.New("go-ovh: only GET calls are allowed on a read-only client")
+	ErrCircuitOpen      = errors.New("go-ovh: circuit breaker is open after consecutive API failures")
+	ErrPolicyDenied     = errors.New("go-ovh: call forbidden by the client policy")
 )
 
-// Client represents a client to call the OVH API
+// Client represents a client to call the OVH API.
+//
+// A Client is safe for concurrent use by multiple goroutines, and should be
+// shared by them: the time delta, access token, credentials and caches are
+// synchronized internally. Like http.Client, its exported fields and setup
+// methods, such as Use, SetDefaultQuery, SetUserAgent or SetTransport, must
+// not be changed once requests are sent concurrently.
 type Client struct {
 	// Self generated tokens. Create one by visiting
 	// https://eu.api.ovh.com/createApp/
@@ -60,15 +85,197 @@ type Client struct {
 	// ConsumerKey holds the user/app specific token. It must have been validated before use.
 	ConsumerKey string
 
+	// ClientID and ClientSecret hold the OAuth2 service account credentials,
+	// used instead of the application and consumer keys. See NewOAuth2Client
+	ClientID     string
+	ClientSecret string
+
+	// OAuth2 access token, when authenticated with ClientID and ClientSecret
+	oauth2 *oauth2Token
+
 	// API endpoint
 	endpoint string
 
+	// RequestTimeout, when set, limits the duration of each call, including
+	// its retries and the reading of the response, unlike Timeout which
+	// limits each HTTP request. Calls may select another timeout with
+	// WithRequestTimeout. Downloads, whose body is read by the caller, are
+	// not limited.
+	RequestTimeout time.Duration
+
+	// APIVersion, when set, replaces the API version of the endpoint, like
+	// APIVersion2, or is appended to endpoints without a version. Calls may
+	// select another version with WithRequestAPIVersion.
+	APIVersion string
+
+	// Language, when set, is sent in the Accept-Language header, like
+	// "fr-FR", so that the API error messages are localized. Calls may
+	// select another language with WithRequestLanguage.
+	Language string
+
 	// Client is the underlying HTTP client used to run the requests. It may be overloaded but a default one is instanciated in ``NewClient`` by default.
 	Client *http.Client
 
+	// ReadOnly forbids all calls but GET ones, which fail with ErrReadOnly
+	// before being built or signed. Requests built by NewRequest and
+	// NewSignedRequest, signed by SignRequest or altered by middlewares are
+	// checked as well.
+	ReadOnly bool
+
+	// Policy, when set, restricts the calls of the client to the ones it
+	// allows, whatever the rights of the consumer key. Forbidden calls fail
+	// with a *PolicyError before being signed.
+	Policy *CallPolicy
+
+	// DryRun builds and signs mutating calls (POST, PUT, PATCH, DELETE) but
+	// does not send them. They are logged and passed to OnDryRun, if set, and
+	// succeed with an empty response, without decoding anything. Other calls
+	// are sent as usual, so that changes can be previewed.
+	DryRun bool
+
+	// OnDryRun, when set, is called with each request not sent by a DryRun
+	// client. Its body can be read with GetBody.
+	OnDryRun func(*http.Request)
+
+	// DisableCompression stops asking for gzip compressed responses, which
+	// are otherwise decompressed transparently
+	DisableCompression bool
+
 	// Logger is used to log HTTP requests and responses.
 	Logger Logger
 
+	// MaxLoggedBodyBytes truncates the bodies passed to a BodyLogger. Bodies
+	// are passed whole when zero.
+	MaxLoggedBodyBytes int
+
+	// OnDeprecation, when set, is called for each call to a route announced
+	// as deprecated by the API.
+	OnDeprecation func(*Deprecation)
+
+	// StrictDeprecation makes calls to deprecated routes return a
+	// DeprecationError. Note that the request has been processed by the API
+	// when this error is returned.
+	StrictDeprecation bool
+
+	// StrictDecoding makes calls fail with an UnknownFieldsError when their
+	// response has fields the result type does not cover, for example new
+	// fields added by the API.
+	StrictDecoding bool
+
+	// OnUnknownFields, when set, is called with the response fields the
+	// result type of a call does not cover
+	OnUnknownFields func(method, path string, fields []string)
+
+	// OnCredentialExpired, when set, is called when the API rejects the
+	// consumer key, typically because it expired or was revoked. It returns a
+	// new consumer key, for example from a secret store or after running the
+	// validation flow again, used to send the request again.
+	OnCredentialExpired func(ctx context.Context) (string, error)
+
+	// Serializes the renewals of the consumer key, see OnCredentialExpired
+	credentialMutex sync.Mutex
+
+	// Guards the updates of the credentials, see ReloadConfig
+	keysMutex sync.RWMutex
+
+	// Endpoint and credentials given when creating the client, kept by
+	// ReloadConfig, and the secret files read by the last load
+	configEndpoint string
+	configParams   credentials
+	secretFiles    []string
+
+	// Consumer key read from the configuration by the last load, replaced by
+	// ReloadConfig only when the configuration changes it
+	configConsumerKey string
+
+	// User-Agent prefix, see SetUserAgent
+	userAgent string
+
+	// Configuration file replacing the default ones, see NewClientWithConfigFile
+	configFile string
+
+	// Configuration profile, see WithProfile
+	profile string
+
+	// Prefix of the environment variables, see WithEnvPrefix
+	envPrefix string
+
+	// Metrics, when set, observes each attempt of the API calls
+	Metrics Metrics
+
+	// Tracer, when set, starts a span for each API call
+	Tracer Tracer
+
+	// Cache, when set, stores the responses of GET calls, see NewMemoryCache.
+	// Responses are used until their Cache-Control max-age, then revalidated
+	// with their ETag. Authenticated responses are cached per credentials, so
+	// that it may be shared by clients with different credentials.
+	Cache Cache
+
+	// CacheTTL, when set, overrides the max-age of cached responses
+	CacheTTL time.Duration
+
+	// Idempotency, when set, configures the idempotency keys of mutating
+	// calls, see WithIdempotencyKey. Calls using the key of another call in
+	// flight fail with a DuplicateRequestError.
+	Idempotency *IdempotencyConfig
+
+	// Idempotency keys of the calls in flight
+	idempotency idempotencyTracker
+
+	// Recorded API calls, see EnableCallLog
+	callLog *callLog
+
+	// Recorded requests, see EnableHistory
+	history *history
+
+	// Query parameters added to all requests, see SetDefaultQuery
+	defaultQuery url.Values
+
+	// Request and response interceptors, see Use
+	middlewares []Middleware
+
+	// Calls in flight, see Close
+	calls callTracker
+
+	// Retry configures automatic retries of failed idempotent requests.
+	// Requests are not retried when nil.
+	Retry *RetryConfig
+
+	// RetryPolicy, when set, decides retries instead of Retry, for instance
+	// to retry depending on the API error codes.
+	RetryPolicy RetryPolicy
+
+	// RetryBudget, when set, limits the number of retries across all the
+	// requests of the client. It may be shared by several clients.
+	RetryBudget *RetryBudget
+
+	// RateLimiter, when set, limits the rate of requests sent by the client.
+	// Throttled responses with a Retry-After header hold all its requests for
+	// the requested delay. It may be shared by several clients.
+	RateLimiter *RateLimiter
+
+	// AppKeyPool, when set, spreads the requests across several applications:
+	// each request is signed with the next application of the pool instead
+	// of AppKey, AppSecret and ConsumerKey. See WithAppKeyPool.
+	AppKeyPool *AppKeyPool
+
+	// CircuitBreaker, when set, rejects the requests with ErrCircuitOpen
+	// after consecutive failures, until the API recovers. It may be shared by
+	// several clients calling the same endpoint.
+	CircuitBreaker *CircuitBreaker
+
+	// MaxResponseBytes limits the size of response bodies, measured after
+	// decompression to protect against decompression bombs. Larger responses
+	// fail with a *BodyTooLargeError matching ErrResponseTooLarge, without
+	// being read entirely. No limit is applied when zero.
+	MaxResponseBytes int64
+
+	// MaxRequestBytes limits the size of request bodies. Larger requests
+	// fail with a *BodyTooLargeError matching ErrRequestTooLarge, without
+	// being sent. No limit is applied when zero.
+	MaxRequestBytes int64
+
 	// Ensures that the timeDelta function is only ran once
 	// sync.Once would consider init done, even in case of error
 	// hence a good old flag
@@ -76,25 +283,40 @@ type Client struct {
 	timeDeltaDone  bool
 	timeDelta      time.Duration
 	Timeout        time.Duration
+
+	// Local wall and monotonic clocks when the time delta was computed, used
+	// to detect local clock adjustments
+	timeDeltaWall time.Time
+	timeDeltaMono time.Time
+
+	// syncTimeDelta computes the time delta when the client is created, see
+	// WithTimeDeltaSync
+	syncTimeDelta bool
+
+	// TimeDeltaSyncInterval, when set, is the maximum age of the time delta.
+	// Older deltas trigger a new /auth/time synchronization before signing.
+	// By default, the delta is only computed for the first signed request,
+	// or when the client is created with WithTimeDeltaSync.
+	TimeDeltaSyncInterval time.Duration
+
+	// ClockSkewTolerance is the maximum drift of the local wall clock, since
+	// the time delta was computed, before a new /auth/time synchronization is
+	// triggered. This happens when the local clock is adjusted (NTP step) or
+	// after the machine slept. DefaultClockSkewTolerance is used when zero,
+	// a negative value disables the detection.
+	ClockSkewTolerance time.Duration
 }
 
 // NewClient represents a new client to call the API
 func NewClient(endpoint, appKey, appSecret, consumerKey string) (*Client, error) {
-	client := Client{
-		AppKey:         appKey,
-		AppSecret:      appSecret,
-		ConsumerKey:    consumerKey,
-		Client:         &http.Client{},
-		timeDeltaMutex: &sync.Mutex{},
-		timeDeltaDone:  false,
-		Timeout:        time.Duration(DefaultTimeout),
-	}
-
-	// Get and check the configuration
-	if err := client.loadConfig(endpoint); err != nil {
-		return nil, err
-	}
-	return &client, nil
+	return NewClientWithOptions(endpoint, WithAppKey(appKey, appSecret), WithConsumerKey(consumerKey))
+}
+
+// NewClientWithConfigFile will create an API client for specified endpoint and
+// load all credentials from environment or the configuration file at "path",
+// instead of the default configuration files. The file must exist.
+func NewClientWithConfigFile(path, endpoint string) (*Client, error) {
+	return NewClientWithOptions(endpoint, WithConfigFile(path))
 }
 
 // NewEndpointClient will create an API client for specified
@@ -110,6 +332,68 @@ func NewDefaultClient() (*Client, error) {
 	return NewClient("", "", "", "")
 }
 
+// Endpoint returns the URL of the API endpoint used by the client
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// SetUserAgent sets a custom User-Agent prefix, for example "my-app/1.2", to
+// tag requests sent by this client. It takes precedence over the "user_agent"
+// configuration key. The library identifier is always appended.
+func (c *Client) SetUserAgent(ua string) {
+	c.userAgent = ua
+}
+
+// SetDefaultQuery sets a query parameter added to every request made by this
+// client. Query parameters given in a call path take precedence over the
+// defaults on key collision.
+func (c *Client) SetDefaultQuery(key, value string) {
+	if c.defaultQuery == nil {
+		c.defaultQuery = url.Values{}
+	}
+	c.defaultQuery.Set(key, value)
+}
+
+// withDefaultQuery returns path with the default query parameters which are
+// not already part of its own query string
+func (c *Client) withDefaultQuery(path string) (string, error) {
+	if len(c.defaultQuery) == 0 {
+		return path, nil
+	}
+
+	rawQuery := ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		rawQuery = path[i+1:]
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", err
+	}
+
+	defaults := url.Values{}
+	for key, values := range c.defaultQuery {
+		if _, ok := query[key]; !ok {
+			defaults[key] = values
+		}
+	}
+	if len(defaults) == 0 {
+		return path, nil
+	}
+
+	if rawQuery != "" {
+		return path + "&" + defaults.Encode(), nil
+	}
+	return strings.TrimSuffix(path, "?") + "?" + defaults.Encode(), nil
+}
+
+// getUserAgent returns the full User-Agent header value
+func (c *Client) getUserAgent() string {
+	if c.userAgent == "" {
+		return userAgent
+	}
+	return c.userAgent + " " + userAgent
+}
+
 //
 // High level helpers
 //
@@ -117,7 +401,13 @@ func NewDefaultClient() (*Client, error) {
 // Ping performs a ping to OVH API.
 // In fact, ping is just a /auth/time call, in order to check if API is up.
 func (c *Client) Ping() error {
-	_, err := c.getTime()
+	return c.PingWithContext(context.Background())
+}
+
+// PingWithContext performs a ping to OVH API.
+// In fact, ping is just a /auth/time call, in order to check if API is up.
+func (c *Client) PingWithContext(ctx context.Context) error {
+	_, err := c.getTimeWithContext(ctx)
 	return err
 }
 
@@ -127,11 +417,38 @@ func (c *Client) TimeDelta() (time.Duration, error) {
 	return c.getTimeDelta()
 }
 
+// TimeDeltaWithContext represents the delay between the machine that runs the
+// code and the OVH API. The delay shouldn't change, let's do it only once.
+func (c *Client) TimeDeltaWithContext(ctx context.Context) (time.Duration, error) {
+	return c.getTimeDeltaWithContext(ctx)
+}
+
+// RefreshTimeDelta forces a new computation of the delay between the machine
+// that runs the code and the OVH API, used to sign the next requests.
+func (c *Client) RefreshTimeDelta() (time.Duration, error) {
+	return c.RefreshTimeDeltaWithContext(context.Background())
+}
+
+// RefreshTimeDeltaWithContext forces a new computation of the delay between
+// the machine that runs the code and the OVH API, used to sign the next
+// requests.
+func (c *Client) RefreshTimeDeltaWithContext(ctx context.Context) (time.Duration, error) {
+	c.timeDeltaMutex.Lock()
+	c.timeDeltaDone = false
+	c.timeDeltaMutex.Unlock()
+	return c.getTimeDeltaWithContext(ctx)
+}
+
 // Time returns time from the OVH API, by asking GET /auth/time.
 func (c *Client) Time() (*time.Time, error) {
 	return c.getTime()
 }
 
+// TimeWithContext returns time from the OVH API, by asking GET /auth/time.
+func (c *Client) TimeWithContext(ctx context.Context) (*time.Time, error) {
+	return c.getTimeWithContext(ctx)
+}
+
 //
 // Common request wrappers
 //
@@ -216,36 +533,109 @@ func (c *Client) DeleteUnAuthWithContext(ctx context.Context, url string, resTyp
 	return c.CallAPIWithContext(ctx, "DELETE", url, nil, resType, false)
 }
 
-// timeDelta returns the time  delta between the host and the remote API
-func (c *Client) getTimeDelta() (time.Duration, error) {
+// GetJSON is a wrapper for the GET method, decoding the response as generic
+// JSON. Numbers are decoded as json.Number to preserve the precision of large
+// integer IDs.
+func (c *Client) GetJSON(url string) (interface{}, error) {
+	return c.GetJSONWithContext(context.Background(), url)
+}
 
-	if !c.timeDeltaDone {
-		// Ensure only one thread is updating
-		c.timeDeltaMutex.Lock()
+// GetJSONWithContext is a wrapper for the GET method, decoding the response
+// as generic JSON. Numbers are decoded as json.Number to preserve the
+// precision of large integer IDs.
+func (c *Client) GetJSONWithContext(ctx context.Context, url string) (interface{}, error) {
+	res := genericJSON{}
+	if err := c.CallAPIWithContext(ctx, "GET", url, nil, &res, true); err != nil {
+		return nil, err
+	}
+	return res.value, nil
+}
 
-		// Ensure that the mutex will be released on return
-		defer c.timeDeltaMutex.Unlock()
+// genericJSON decodes any JSON value, using json.Number for numbers
+type genericJSON struct {
+	value interface{}
+}
 
-		// Did we wait ? Maybe no more needed
-		if !c.timeDeltaDone {
-			ovhTime, err := c.getTime()
-			if err != nil {
-				return 0, err
-			}
+// UnmarshalJSON implements json.Unmarshaler
+func (g *genericJSON) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(&g.value)
+}
 
-			c.timeDelta = time.Since(*ovhTime)
-			c.timeDeltaDone = true
+// timeDelta returns the time  delta between the host and the remote API
+func (c *Client) getTimeDelta() (time.Duration, error) {
+	return c.getTimeDeltaWithContext(context.Background())
+}
+
+// getTimeDeltaWithContext returns the time delta between the host and the
+// remote API, synchronizing it if needed
+func (c *Client) getTimeDeltaWithContext(ctx context.Context) (time.Duration, error) {
+	// Ensure only one thread is updating, and that others see the update
+	c.timeDeltaMutex.Lock()
+	defer c.timeDeltaMutex.Unlock()
+
+	if c.timeDeltaStale() {
+		ovhTime, err := c.getTimeWithContext(ctx)
+		if err != nil {
+			return 0, err
 		}
+
+		c.timeDelta = time.Since(*ovhTime)
+		c.timeDeltaWall = getLocalTime()
+		c.timeDeltaMono = getMonotonicTime()
+		c.timeDeltaDone = true
 	}
 
 	return c.timeDelta, nil
 }
 
+// timeDeltaStale checks if the time delta must be (re)computed. The caller
+// must hold timeDeltaMutex.
+func (c *Client) timeDeltaStale() bool {
+	if !c.timeDeltaDone {
+		return true
+	}
+	if c.TimeDeltaSyncInterval > 0 && !c.timeDeltaMono.IsZero() &&
+		getMonotonicTime().Sub(c.timeDeltaMono) >= c.TimeDeltaSyncInterval {
+		return true
+	}
+	return c.clockDrifted()
+}
+
+// clockDrifted checks if the local wall clock drifted from the monotonic clock
+// by more than the tolerance since the time delta was computed, which makes
+// the time delta stale
+func (c *Client) clockDrifted() bool {
+	tolerance := c.ClockSkewTolerance
+	if tolerance == 0 {
+		tolerance = DefaultClockSkewTolerance
+	}
+	if tolerance < 0 || c.timeDeltaMono.IsZero() {
+		return false
+	}
+
+	// Round(0) strips the monotonic reading, to compare wall clocks
+	wallElapsed := getLocalTime().Round(0).Sub(c.timeDeltaWall.Round(0))
+	monoElapsed := getMonotonicTime().Sub(c.timeDeltaMono)
+	drift := wallElapsed - monoElapsed
+	return drift > tolerance || drift < -tolerance
+}
+
 // getTime t returns time from for a given api client endpoint
 func (c *Client) getTime() (*time.Time, error) {
+	return c.getTimeWithContext(context.Background())
+}
+
+// getTimeWithContext returns time from for a given api client endpoint
+func (c *Client) getTimeWithContext(ctx context.Context) (*time.Time, error) {
 	var timestamp int64
 
-	err := c.GetUnAuth("/auth/time", &timestamp)
+	// The server time is only available on the 1.0 routes
+	if c.apiVersion(ctx) != "" {
+		ctx = WithRequestAPIVersion(ctx, APIVersion1)
+	}
+	err := c.GetUnAuthWithContext(withInternalCall(ctx), "/auth/time", &timestamp)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +650,13 @@ var getLocalTime = func() time.Time {
 	return time.Now()
 }
 
+// getMonotonicTime is a function to be overwritten during the tests, it returns
+// a time carrying a reading of the local monotonic clock, which is not affected
+// by wall clock adjustments
+var getMonotonicTime = func() time.Time {
+	return time.Now()
+}
+
 // getEndpointForSignature is a function to be overwritten during the tests, it returns a
 // the endpoint
 var getEndpointForSignature = func(c *Client) string {
@@ -268,6 +665,27 @@ var getEndpointForSignature = func(c *Client) string {
 
 // NewRequest returns a new HTTP request
 func (c *Client) NewRequest(method, path string, reqBody interface{}, needAuth bool) (*http.Request, error) {
+	return c.newJSONRequest(context.Background(), method, path, reqBody, needAuth)
+}
+
+// NewSignedRequest returns a new HTTP request, signed with the client
+// credentials and ready to be sent with any http.Client or transport. The
+// body, if any, is read entirely as it is part of the signature.
+func (c *Client) NewSignedRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.newRequest(ctx, method, path, data, true)
+}
+
+// newJSONRequest returns a new HTTP request with a JSON serialized body
+func (c *Client) newJSONRequest(ctx context.Context, method, path string, reqBody interface{}, needAuth bool) (*http.Request, error) {
 	var body []byte
 	var err error
 
@@ -278,66 +696,135 @@ func (c *Client) NewRequest(method, path string, reqBody interface{}, needAuth b
 		}
 	}
 
-	target := fmt.Sprintf("%s%s", c.endpoint, path)
-	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	return c.newRequest(ctx, method, path, body, needAuth)
+}
+
+// newRequest returns a new HTTP request for a serialized body. The context is
+// used for the request itself as well as for the time synchronization needed
+// to sign it.
+func (c *Client) newRequest(ctx context.Context, method, path string, body []byte, needAuth bool) (*http.Request, error) {
+	if err := c.checkCall(ctx, method, path); err != nil {
+		return nil, err
+	}
+	req, path, err := c.buildRequest(ctx, method, path, body)
 	if err != nil {
 		return nil, err
 	}
 
+	// Inject signature. Some methods do not need authentication, especially /time,
+	// /auth and some /order methods are actually broken if authenticated.
+	if needAuth {
+		if err := c.sign(ctx, req, path, body); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+// buildRequest returns a new unsigned HTTP request, along with its path
+// including the default query parameters
+func (c *Client) buildRequest(ctx context.Context, method, path string, body []byte) (*http.Request, string, error) {
+	if err := c.checkRequestSize(method, path, body); err != nil {
+		return nil, "", err
+	}
+
+	// Default query parameters must be part of the signed URL
+	path, err := c.withDefaultQuery(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	target := fmt.Sprintf("%s%s", c.versionedEndpoint(ctx, c.endpoint), path)
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req = req.WithContext(ctx)
+
 	// Inject headers
 	if body != nil {
 		req.Header.Add("Content-Type", "application/json;charset=utf-8")
 	}
-	req.Header.Add("X-Ovh-Application", c.AppKey)
+	req.Header.Add("X-Ovh-Application", c.requestAppKey())
 	req.Header.Add("Accept", "application/json")
+	req.Header.Add("User-Agent", c.getUserAgent())
+	c.setLanguage(ctx, req)
 
-	// Inject signature. Some methods do not need authentication, especially /time,
-	// /auth and some /order methods are actually broken if authenticated.
-	if needAuth {
-		timeDelta, err := c.TimeDelta()
-		if err != nil {
-			return nil, err
-		}
+	return req, path, nil
+}
 
-		timestamp := getLocalTime().Add(-timeDelta).Unix()
+// sign injects the authentication headers in the request, path being relative
+// to the endpoint
+func (c *Client) sign(ctx context.Context, req *http.Request, path string, body []byte) error {
+	return c.signURL(ctx, req, c.versionedEndpoint(ctx, getEndpointForSignature(c))+path, body)
+}
 
-		req.Header.Add("X-Ovh-Timestamp", strconv.FormatInt(timestamp, 10))
-		req.Header.Add("X-Ovh-Consumer", c.ConsumerKey)
+// signURL injects the authentication headers in the request, for the full
+// signed url
+func (c *Client) signURL(ctx context.Context, req *http.Request, url string, body []byte) error {
+	// OAuth2 clients send a bearer token instead of a signature
+	if c.oauth2 != nil {
+		token, err := c.getAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
 
-		h := sha1.New()
-		h.Write([]byte(fmt.Sprintf("%s+%s+%s+%s%s+%s+%d",
-			c.AppSecret,
-			c.ConsumerKey,
-			method,
-			getEndpointForSignature(c),
-			path,
-			body,
-			timestamp,
-		)))
-		req.Header.Add("X-Ovh-Signature", fmt.Sprintf("$1$%x", h.Sum(nil)))
+	timeDelta, err := c.getTimeDeltaWithContext(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Send the request with requested timeout
-	c.Client.Timeout = c.Timeout
+	timestamp := getLocalTime().Add(-timeDelta).Unix()
+	keys := c.signingCredentials(req)
 
-	return req, nil
+	req.Header.Set("X-Ovh-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Ovh-Consumer", keys.consumerKey)
+
+	req.Header.Set("X-Ovh-Signature", Sign(keys.appSecret, keys.consumerKey, req.Method, url, string(body), timestamp))
+	return nil
 }
 
 // Do sends an HTTP request and returns an HTTP response
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	req = c.acceptCompression(req)
 	if c.Logger != nil {
 		c.Logger.LogRequest(req)
+		c.logRequestBody(req)
 	}
-	resp, err := c.Client.Do(req)
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	latency := time.Since(start)
 	if err != nil {
+		if logger, ok := c.Logger.(latencyLogger); ok {
+			logger.logRequestError(req, latency, err)
+		}
 		return nil, err
 	}
-	if c.Logger != nil {
+	decompress(resp)
+	if logger, ok := c.Logger.(latencyLogger); ok {
+		logger.logResponse(resp, latency)
+	} else if c.Logger != nil {
 		c.Logger.LogResponse(resp)
 	}
 	return resp, nil
 }
 
+// httpClient returns the HTTP client sending the requests, with the requested
+// Timeout. The shared HTTP client is copied rather than updated, as requests
+// may be sent concurrently.
+func (c *Client) httpClient() *http.Client {
+	if c.Client.Timeout == c.Timeout {
+		return c.Client
+	}
+	client := *c.Client
+	client.Timeout = c.Timeout
+	return &client
+}
+
 // CallAPI is the lowest level call helper. If needAuth is true,
 // inject authentication headers and sign the request.
 //
@@ -381,16 +868,162 @@ func (c *Client) CallAPI(method, path string, reqBody, resType interface{}, need
 // If everything went fine, unmarshall response into resType and return nil
 // otherwise, return the error
 func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, reqBody, resType interface{}, needAuth bool) error {
-	req, err := c.NewRequest(method, path, reqBody, needAuth)
+	response, err := c.callAPIFull(ctx, method, path, reqBody, nil, needAuth)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	response, err := c.Do(req)
+	return c.decode(method, path, response.Body, resType)
+}
+
+// send builds, signs and sends a request with the additional "header",
+// retrying it according to the client retry configuration. The call is traced
+// when a Tracer is configured.
+func (c *Client) send(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*http.Response, error) {
+	if c.Tracer != nil {
+		return c.tracedSend(ctx, method, path, reqBody, header, needAuth)
+	}
+	return c.sendAttempts(ctx, method, path, reqBody, header, needAuth)
+}
+
+// sendAttempts sends the request until it succeeds or must not be retried.
+// Each attempt is a freshly signed request.
+func (c *Client) sendAttempts(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*http.Response, error) {
+	if err := c.checkCall(ctx, method, path); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	var contentType string
+	if raw, ok := reqBody.(*RawBody); ok {
+		// Raw bodies are read once, they are part of the signature and may
+		// be sent several times
+		var err error
+		if body, err = raw.read(); err != nil {
+			return nil, err
+		}
+		contentType = raw.ContentType
+	} else if reqBody != nil {
+		var err error
+		if body, err = json.Marshal(reqBody); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, fullPath, err := c.buildRequest(ctx, method, path, body)
+		if err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		for name, values := range header {
+			req.Header[http.CanonicalHeaderKey(name)] = values
+		}
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		var generation int
+		if c.CircuitBreaker != nil {
+			if generation, err = c.CircuitBreaker.allow(); err != nil {
+				return nil, err
+			}
+		}
+		start := time.Now()
+		response, err := c.chain(c.signAndDo(ctx, req.URL.String(), fullPath, needAuth))(req)
+		if c.CircuitBreaker != nil {
+			c.CircuitBreaker.record(ctx, generation, response, err)
+		}
+		if c.RateLimiter != nil {
+			if after := retryAfter(response); after > 0 {
+				c.RateLimiter.pause(time.Now().Add(after))
+			}
+		}
+		if c.AppKeyPool != nil {
+			c.AppKeyPool.observe(req.Header.Get("X-Ovh-Application"), response)
+		}
+		if c.callLog != nil {
+			entry := CallLogEntry{Method: method, Path: path, Duration: time.Since(start)}
+			if response != nil {
+				entry.Status = response.StatusCode
+			}
+			c.callLog.add(entry)
+		}
+		c.recordHistory(start, method, path, body, contentType, response, err)
+		if c.Metrics != nil {
+			metrics := RequestMetrics{
+				Method:       method,
+				Path:         path,
+				PathTemplate: PathTemplate(path),
+				Duration:     time.Since(start),
+				Err:          err,
+			}
+			if response != nil {
+				metrics.StatusCode = response.StatusCode
+			}
+			c.Metrics.ObserveRequest(metrics)
+		}
+
+		delay, retry := c.shouldRetry(ctx, req, response, err, attempt)
+		if !retry {
+			return response, err
+		}
+		if response != nil {
+			ioutil.ReadAll(response.Body)
+			response.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// checkReadOnly returns ErrReadOnly for the calls a ReadOnly client must not
+// make. An empty method is a GET, as for http.NewRequest.
+func (c *Client) checkReadOnly(method string) error {
+	if c.ReadOnly && method != "" && !strings.EqualFold(method, "GET") {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// isMutating checks if a HTTP method may modify resources
+func isMutating(method string) bool {
+	switch strings.ToUpper(method) {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	}
+	return false
+}
+
+// readBody reads the whole response body, already decompressed by Do, and
+// enforces MaxResponseBytes on its decompressed size
+func (c *Client) readBody(response *http.Response) ([]byte, error) {
+	var reader io.Reader = response.Body
+	if c.MaxResponseBytes > 0 {
+		// Uncompressed bodies announcing their size are rejected upfront
+		if response.ContentLength > c.MaxResponseBytes {
+			return nil, c.responseTooLarge(response, response.ContentLength)
+		}
+		reader = io.LimitReader(reader, c.MaxResponseBytes+1)
+	}
+	body, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if c.MaxResponseBytes > 0 && int64(len(body)) > c.MaxResponseBytes {
+		return nil, c.responseTooLarge(response, -1)
 	}
-	return c.UnmarshalResponse(response, resType)
+
+	if c.Logger != nil {
+		c.logResponseBody(response, body)
+	}
+	return body, nil
 }
 
 // UnmarshalResponse checks the response and unmarshals it into the response
@@ -398,26 +1031,52 @@ func (c *Client) CallAPIWithContext(ctx context.Context, method, path string, re
 func (c *Client) UnmarshalResponse(response *http.Response, resType interface{}) error {
 	// Read all the response body
 	defer response.Body.Close()
-	body, err := ioutil.ReadAll(response.Body)
+	body, err := c.readBody(response)
 	if err != nil {
 		return err
 	}
 
+	if err = checkResponse(response, body); err != nil {
+		return err
+	}
+	if response.Request != nil {
+		return c.decode(response.Request.Method, response.Request.URL.Path, body, resType)
+	}
+	return decodeBody(body, resType)
+}
+
+// checkResponse returns an APIError, or a ValidationError, if the response
+// status is not a success
+func checkResponse(response *http.Response, body []byte) error {
 	// < 200 && >= 300 : API error
 	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
-		apiError := &APIError{Code: response.StatusCode}
-		if err = json.Unmarshal(body, apiError); err != nil {
+		apiError := &APIError{StatusCode: response.StatusCode, Code: response.StatusCode}
+		if err := json.Unmarshal(body, apiError); err != nil {
 			apiError.Message = string(body)
 		}
+		if apiError.Message == "" {
+			apiError.Message = apiError.Detail
+		}
+		if apiError.Message == "" {
+			apiError.Message = apiError.Title
+		}
 		apiError.QueryID = response.Header.Get("X-Ovh-QueryID")
+		apiError.Language = response.Header.Get("Content-Language")
 
-		return apiError
+		return validationError(apiError, body)
 	}
+	return nil
+}
 
+// decodeBody unmarshals a response body into the response type if needed.
+// Empty bodies, even made of whitespace, leave the response type untouched.
+func decodeBody(body []byte, resType interface{}) error {
 	// Nothing to unmarshal
-	if len(body) == 0 || resType == nil {
+	if len(bytes.TrimSpace(body)) == 0 || resType == nil {
 		return nil
 	}
 
-	return json.Unmarshal(body, &resType)
+	// Decode directly into the target so that bare JSON scalars (numbers,
+	// strings, booleans) are supported as well as objects and arrays
+	return json.Unmarshal(body, resType)
 }
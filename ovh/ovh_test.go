@@ -1,7 +1,12 @@
 package ovh
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -11,6 +16,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"unicode"
@@ -46,9 +53,12 @@ func initMockServer(InputRequest **http.Request, status int, responseBody string
 		return "http://localhost"
 	}
 
-	// Create a fake API server
+	// Create a fake API server. Slow handlers may overlap when the client
+	// gives up, hence the lock.
+	var mutex sync.Mutex
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Save input parameters
+		mutex.Lock()
 		*InputRequest = r
 		defer r.Body.Close()
 
@@ -58,6 +68,7 @@ func initMockServer(InputRequest **http.Request, status int, responseBody string
 				*requestBody = string(reqBody[:])
 			}
 		}
+		mutex.Unlock()
 
 		if handlerSleep != 0 {
 			time.Sleep(handlerSleep)
@@ -238,6 +249,9 @@ func APIMethodTester(t *testing.T, HTTPmethod string, body interface{}, expected
 		}()
 	}
 	ret := method.Call(arguments)
+
+	// Wait for the handler, which may still run after a cancelation
+	ts.Close()
 	if failureExpected && ret[0].IsNil() {
 		t.Fatal("Should have a context cancelation error, got nil")
 	} else if !failureExpected && !ret[0].IsNil() {
@@ -389,6 +403,271 @@ func TestGetResponse(t *testing.T) {
 	}
 }
 
+func TestDefaultQuery(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	client.SetDefaultQuery("tenant", "default")
+	client.SetDefaultQuery("region", "GRA")
+
+	// Test: defaults only
+	if err := client.Get("/some/resource", nil); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+	if InputRequest.URL.String() != "/some/resource?region=GRA&tenant=default" {
+		t.Fatalf("Default query should be added to request. Got %s", InputRequest.URL.String())
+	}
+
+	// Test: per-call parameters override defaults
+	if err := client.Get("/some/resource?tenant=other&page=2", nil); err != nil {
+		t.Fatalf("Unexpected error while calling API: %v", err)
+	}
+	expectedPath := "/some/resource?tenant=other&page=2&region=GRA"
+	if InputRequest.URL.String() != expectedPath {
+		t.Fatalf("Default query should be merged with call query as %s. Got %s", expectedPath, InputRequest.URL.String())
+	}
+
+	// Test: default query is signed
+	h := sha1.New()
+	h.Write([]byte(fmt.Sprintf("%s+%s+GET+http://localhost%s++%d", MockApplicationSecret, MockConsumerKey, expectedPath, MockTime)))
+	ensureHeaderPresent(t, InputRequest, "X-Ovh-Signature", fmt.Sprintf("$1$%x", h.Sum(nil)))
+}
+
+func TestGetJSON(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{"id":9007199254740993,"items":[1.5,"a"]}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	res, err := client.GetJSON("/some/resource")
+	if err != nil {
+		t.Fatalf("Client.GetJSON should not return an error. Got %v", err)
+	}
+
+	// Validate
+	object, ok := res.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Client.GetJSON should decode an object. Got %T", res)
+	}
+	id, ok := object["id"].(json.Number)
+	if !ok || id.String() != "9007199254740993" {
+		t.Fatalf("Client.GetJSON should decode id as json.Number 9007199254740993. Got %#v", object["id"])
+	}
+	if n, err := id.Int64(); err != nil || n != 9007199254740993 {
+		t.Fatalf("Client.GetJSON should not lose precision on large integers. Got %d (%v)", n, err)
+	}
+	expectedItems := []interface{}{json.Number("1.5"), "a"}
+	if !reflect.DeepEqual(object["items"], expectedItems) {
+		t.Fatalf("Client.GetJSON should decode items as %#v. Got %#v", expectedItems, object["items"])
+	}
+}
+
+func TestNewSignedRequest(t *testing.T) {
+	// Init test: a server verifying signatures
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	getEndpointForSignature = func(c *Client) string {
+		return "http://localhost"
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		h := sha1.New()
+		h.Write([]byte(fmt.Sprintf("%s+%s+%s+http://localhost%s+%s+%s",
+			MockApplicationSecret,
+			r.Header.Get("X-Ovh-Consumer"),
+			r.Method,
+			r.URL.RequestURI(),
+			body,
+			r.Header.Get("X-Ovh-Timestamp"),
+		)))
+		if r.Header.Get("X-Ovh-Signature") != fmt.Sprintf("$1$%x", h.Sum(nil)) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write(body)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	// Test
+	req, err := client.NewSignedRequest(context.Background(), "POST", "/some/resource?a=b", strings.NewReader(`{"i_val":42}`))
+	if err != nil {
+		t.Fatalf("NewSignedRequest should not return an error. Got %v", err)
+	}
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Sending a signed request should not fail. Got %v", err)
+	}
+	defer response.Body.Close()
+
+	// Validate
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("Signed request should be accepted by the server. Got status %d", response.StatusCode)
+	}
+	if body, _ := ioutil.ReadAll(response.Body); string(body) != `{"i_val":42}` {
+		t.Fatalf("Signed request should send its body. Got '%s'", body)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+	client.ReadOnly = true
+
+	// Test: reads are allowed
+	if err := client.Get("/some/resource", nil); err != nil {
+		t.Fatalf("GET should succeed on a read-only client. Got %v", err)
+	}
+	if err := client.GetUnAuth("/some/resource", nil); err != nil {
+		t.Fatalf("Unauthenticated GET should succeed on a read-only client. Got %v", err)
+	}
+
+	// Test: mutations are rejected without being sent
+	InputRequest = nil
+	if err := client.Delete("/some/resource", nil); err != ErrReadOnly {
+		t.Fatalf("DELETE should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+	if err := client.PostUnAuth("/some/resource", nil, nil); err != ErrReadOnly {
+		t.Fatalf("POST should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+	if err := client.CallAPI("PATCH", "/some/resource", nil, nil, true); err != ErrReadOnly {
+		t.Fatalf("PATCH should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+	if err := client.CallAPI("HEAD", "/some/resource", nil, nil, true); err != ErrReadOnly {
+		t.Fatalf("HEAD should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+	if InputRequest != nil {
+		t.Fatalf("Mutating calls should not be sent on a read-only client. Got %s %s", InputRequest.Method, InputRequest.URL)
+	}
+
+	// Test: mutating requests are neither built nor signed
+	if _, err := client.NewRequest("PUT", "/some/resource", nil, true); err != ErrReadOnly {
+		t.Fatalf("NewRequest should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+	if _, err := client.NewSignedRequest(context.Background(), "POST", "/some/resource", nil); err != ErrReadOnly {
+		t.Fatalf("NewSignedRequest should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+	req, _ := http.NewRequest("DELETE", ts.URL+"/some/resource", nil)
+	if err := client.SignRequest(req); err != ErrReadOnly || req.Header.Get("X-Ovh-Signature") != "" {
+		t.Fatalf("SignRequest should fail with ErrReadOnly on a read-only client. Got %v", err)
+	}
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	// Init test: a middleware turning a GET into a DELETE
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+	client, _ = NewClientWithOptions(ts.URL,
+		WithAppKey(MockApplicationKey, MockApplicationSecret),
+		WithConsumerKey(MockConsumerKey),
+		WithReadOnly(),
+	)
+	client.timeDeltaDone = true
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Method = "DELETE"
+			return next(req)
+		}
+	})
+
+	// Test
+	err := client.Get("/some/resource", nil)
+
+	// Validate
+	if err != ErrReadOnly || InputRequest != nil {
+		t.Fatalf("Requests altered by middlewares should be checked on a read-only client. Got %v", err)
+	}
+}
+
+func TestScalarResponses(t *testing.T) {
+	var InputRequest *http.Request
+
+	// Bare number
+	ts, client := initMockServer(&InputRequest, 200, `1457018875`, nil, time.Duration(0))
+	var resInt int
+	if err := client.Get("/some/resource", &resInt); err != nil {
+		t.Fatalf("Client.Get should decode a bare number. Got %v", err)
+	}
+	if resInt != 1457018875 {
+		t.Fatalf("Client.Get should decode 1457018875. Got %d", resInt)
+	}
+	ts.Close()
+
+	// Bare string
+	ts, client = initMockServer(&InputRequest, 200, `"ns12345.ip-1-2-3.eu"`, nil, time.Duration(0))
+	var resString string
+	if err := client.Get("/some/resource", &resString); err != nil {
+		t.Fatalf("Client.Get should decode a bare string. Got %v", err)
+	}
+	if resString != "ns12345.ip-1-2-3.eu" {
+		t.Fatalf("Client.Get should decode 'ns12345.ip-1-2-3.eu'. Got '%s'", resString)
+	}
+	ts.Close()
+
+	// Bare boolean
+	ts, client = initMockServer(&InputRequest, 200, `true`, nil, time.Duration(0))
+	var resBool bool
+	if err := client.Get("/some/resource", &resBool); err != nil {
+		t.Fatalf("Client.Get should decode a bare boolean. Got %v", err)
+	}
+	if !resBool {
+		t.Fatalf("Client.Get should decode true. Got %v", resBool)
+	}
+	ts.Close()
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	// Init test: a small compressed payload expanding to 1MB
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	fmt.Fprintf(writer, `"%s"`, strings.Repeat("a", 1<<20))
+	writer.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	// Test: no limit
+	var res string
+	if err := client.GetUnAuth("/some/resource", &res); err != nil {
+		t.Fatalf("Client.GetUnAuth should decode a compressed response. Got %v", err)
+	}
+	if len(res) != 1<<20 {
+		t.Fatalf("Client.GetUnAuth should decode a 1MB string. Got %d bytes", len(res))
+	}
+
+	// Test: limit is enforced on the decompressed size
+	client.MaxResponseBytes = 64 * 1024
+	if compressed.Len() >= 64*1024 {
+		t.Fatalf("Compressed payload should be smaller than the limit. Got %d bytes", compressed.Len())
+	}
+	if err := client.GetUnAuth("/some/resource", &res); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Client.GetUnAuth should fail with ErrResponseTooLarge. Got %v", err)
+	}
+
+	// Test: explicitly requested compression is decoded too
+	req, _ := client.NewRequest("GET", "/some/resource", nil, false)
+	req.Header.Set("Accept-Encoding", "gzip")
+	response, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Client.Do should not fail. Got %v", err)
+	}
+	if err := client.UnmarshalResponse(response, &res); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Client.UnmarshalResponse should fail with ErrResponseTooLarge. Got %v", err)
+	}
+}
+
 func TestConstructors(t *testing.T) {
 	// Nominal: full constructor
 	client, err := NewClient("ovh-eu", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
@@ -487,3 +766,195 @@ func TestGetTimeDelta(t *testing.T) {
 		t.Fatalf("getTimeDelta should return a delta of %d. Got %d", time.Duration(MockDelta)*time.Second, delta)
 	}
 }
+
+func TestClockSkewResync(t *testing.T) {
+	// Init test
+	var syncs int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			atomic.AddInt32(&syncs, 1)
+			fmt.Fprint(w, MockTime)
+			return
+		}
+		fmt.Fprint(w, `"success"`)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	wall := time.Unix(MockTime, 0)
+	mono := time.Unix(0, 0)
+	getLocalTime = func() time.Time { return wall }
+	getMonotonicTime = func() time.Time { return mono }
+	defer func() { getMonotonicTime = time.Now }()
+
+	// Test: first call synchronizes
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 1 {
+		t.Fatalf("First call should synchronize time. Got %d synchronizations", n)
+	}
+
+	// Test: regular time flow does not synchronize
+	wall = wall.Add(time.Hour)
+	mono = mono.Add(time.Hour)
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 1 {
+		t.Fatalf("Regular time flow should not synchronize time. Got %d synchronizations", n)
+	}
+
+	// Test: clock jump triggers a synchronization
+	wall = wall.Add(10 * time.Minute)
+	mono = mono.Add(time.Second)
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 2 {
+		t.Fatalf("Clock jump should synchronize time. Got %d synchronizations", n)
+	}
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 2 {
+		t.Fatalf("Time should only be synchronized once after a clock jump. Got %d synchronizations", n)
+	}
+
+	// Test: detection may be disabled
+	client.ClockSkewTolerance = -1
+	wall = wall.Add(-10 * time.Minute)
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 2 {
+		t.Fatalf("Disabled clock skew detection should not synchronize time. Got %d synchronizations", n)
+	}
+}
+
+func TestContextTimeSync(t *testing.T) {
+	// Init test: time synchronization hangs
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			time.Sleep(2 * time.Second)
+		}
+		fmt.Fprint(w, MockTime)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	// Test: signing honors the call context
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := client.GetWithContext(ctx, "/some/resource", nil); err == nil {
+		t.Fatalf("GetWithContext should fail when time synchronization times out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("GetWithContext should give up on context timeout. Took %v", elapsed)
+	}
+
+	// Test: ping honors the context
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := client.PingWithContext(ctx); err == nil {
+		t.Fatalf("PingWithContext should fail on context timeout")
+	}
+}
+
+func TestTimeDeltaRefresh(t *testing.T) {
+	// Init test
+	var syncs int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			atomic.AddInt32(&syncs, 1)
+			fmt.Fprint(w, MockTime)
+			return
+		}
+		fmt.Fprint(w, `"success"`)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	wall := time.Unix(MockTime, 0)
+	mono := time.Unix(0, 0)
+	getLocalTime = func() time.Time { return wall }
+	getMonotonicTime = func() time.Time { return mono }
+	defer func() { getMonotonicTime = time.Now }()
+
+	// Test: explicit refresh
+	if _, err := client.RefreshTimeDelta(); err != nil {
+		t.Fatalf("RefreshTimeDelta should not fail. Got %v", err)
+	}
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 1 {
+		t.Fatalf("Refreshed time delta should be used for signing. Got %d synchronizations", n)
+	}
+	client.RefreshTimeDelta()
+	if n := atomic.LoadInt32(&syncs); n != 2 {
+		t.Fatalf("RefreshTimeDelta should always synchronize. Got %d synchronizations", n)
+	}
+
+	// Test: periodic synchronization
+	client.TimeDeltaSyncInterval = time.Hour
+	wall = wall.Add(30 * time.Minute)
+	mono = mono.Add(30 * time.Minute)
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 2 {
+		t.Fatalf("Fresh time delta should not be synchronized. Got %d synchronizations", n)
+	}
+	wall = wall.Add(time.Hour)
+	mono = mono.Add(time.Hour)
+	client.Get("/some/resource", nil)
+	if n := atomic.LoadInt32(&syncs); n != 3 {
+		t.Fatalf("Expired time delta should be synchronized. Got %d synchronizations", n)
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	// Init test: a worker pool sharing a fresh client
+	var syncs int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			atomic.AddInt32(&syncs, 1)
+			fmt.Fprint(w, MockTime)
+			return
+		}
+		fmt.Fprint(w, `"success"`)
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.Timeout = time.Minute
+	getLocalTime = func() time.Time { return time.Unix(MockTime, 0) }
+
+	// Test: first signed calls
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var res string
+			errs <- client.Get("/some/resource", &res)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Validate
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Concurrent calls should not fail. Got %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&syncs); n != 1 {
+		t.Fatalf("Time delta should be synchronized once by concurrent calls. Got %d synchronizations", n)
+	}
+	if client.Client.Timeout == client.Timeout {
+		t.Fatalf("Timeout should be applied without updating the shared HTTP client")
+	}
+
+	// Test: refreshes concurrent with calls
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.RefreshTimeDelta()
+		}()
+		go func() {
+			defer wg.Done()
+			client.Get("/some/resource", nil)
+		}()
+	}
+	wg.Wait()
+}
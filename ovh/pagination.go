@@ -0,0 +1,153 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// DefaultPageSize is the number of items per page requested by List and by
+// pagers created with a non positive size
+const DefaultPageSize = 100
+
+// Pager iterates over the pages of a list endpoint, following the OVH
+// pagination headers. Routes which do not support pagination are returned as
+// a single page.
+//
+//	pager := client.NewPager("/domain", 50)
+//	for pager.Next(ctx) {
+//		var domains []string
+//		if err := pager.Page(&domains); err != nil {
+//			return err
+//		}
+//		...
+//	}
+//	if err := pager.Err(); err != nil {
+//		return err
+//	}
+type Pager struct {
+	client *Client
+	path   string
+	size   int
+	filter *Filter
+	number int
+	done   bool
+	page   *Response
+	err    error
+}
+
+// NewPager returns a Pager listing "path", "size" items per page
+func (c *Client) NewPager(path string, size int) *Pager {
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	return &Pager{client: c, path: path, size: size}
+}
+
+// SetFilter filters and sorts the items of the next pages, with the
+// pagination headers of the filter. Its page size, if set, replaces the size
+// of the pager.
+func (p *Pager) SetFilter(filter *Filter) {
+	p.filter = filter
+	if filter != nil && filter.pageSize > 0 {
+		p.size = filter.pageSize
+	}
+}
+
+// Next fetches the next page. It returns false when all pages were fetched or
+// on error, see Err.
+func (p *Pager) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+	p.number++
+
+	header := http.Header{}
+	if p.filter != nil {
+		header = p.filter.Header()
+	}
+	header.Set("X-Pagination-Mode", "CachedObjectList-Pages")
+	header.Set("X-Pagination-Size", strconv.Itoa(p.size))
+	header.Set("X-Pagination-Number", strconv.Itoa(p.number))
+
+	page, err := p.client.callAPIFull(ctx, "GET", p.path, nil, header, true)
+	if err != nil {
+		p.done, p.page, p.err = true, nil, err
+		return false
+	}
+
+	var items []json.RawMessage
+	if err := page.Unmarshal(&items); err != nil {
+		p.done, p.page, p.err = true, nil, fmt.Errorf("go-ovh: GET %s did not return a list: %v", p.path, err)
+		return false
+	}
+
+	// Unpaginated routes ignore the pagination headers. Otherwise, a partial
+	// page is the last one.
+	if page.Header.Get("X-Pagination-Number") == "" || len(items) < p.size {
+		p.done = true
+	}
+	if len(items) == 0 && p.number > 1 {
+		p.page = nil
+		return false
+	}
+	p.page = page
+	return true
+}
+
+// Page decodes the items of the current page into "items", typically a
+// pointer to a slice
+func (p *Pager) Page(items interface{}) error {
+	if p.page == nil {
+		return fmt.Errorf("go-ovh: no current page, Next must be called first")
+	}
+	return p.page.Unmarshal(items)
+}
+
+// Response returns the raw response of the current page, nil if there is no
+// current page
+func (p *Pager) Response() *Response {
+	return p.page
+}
+
+// Err returns the error which stopped the iteration, if any
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// List fetches all the pages of a list endpoint and appends their items to
+// "items", which must be a pointer to a slice.
+func (c *Client) List(path string, items interface{}) error {
+	return c.ListWithContext(context.Background(), path, items)
+}
+
+// ListWithContext fetches all the pages of a list endpoint and appends their
+// items to "items", which must be a pointer to a slice.
+func (c *Client) ListWithContext(ctx context.Context, path string, items interface{}) error {
+	return c.ListWithFilter(ctx, path, nil, items)
+}
+
+// ListWithFilter fetches all the pages of a list endpoint, filtered and
+// sorted by "filter", and appends their items to "items", which must be a
+// pointer to a slice. See Pager.SetFilter.
+func (c *Client) ListWithFilter(ctx context.Context, path string, filter *Filter, items interface{}) error {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("go-ovh: List expects a pointer to a slice, got %T", items)
+	}
+	list := value.Elem()
+
+	pager := c.NewPager(path, DefaultPageSize)
+	pager.SetFilter(filter)
+	for pager.Next(ctx) {
+		page := reflect.New(list.Type())
+		if err := pager.Page(page.Interface()); err != nil {
+			return err
+		}
+		list.Set(reflect.AppendSlice(list, page.Elem()))
+	}
+	return pager.Err()
+}
@@ -0,0 +1,145 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initPaginatedServer starts a server listing "count" integers, paginated
+// when requested unless "paginated" is false
+func initPaginatedServer(count int, paginated bool) (*httptest.Server, *Client, *[]string) {
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	getEndpointForSignature = func(c *Client) string {
+		return "http://localhost"
+	}
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, _ := strconv.Atoi(r.Header.Get("X-Pagination-Size"))
+		number, _ := strconv.Atoi(r.Header.Get("X-Pagination-Number"))
+		requests = append(requests, fmt.Sprintf("%s %d/%d", r.Header.Get("X-Pagination-Mode"), number, size))
+
+		first, last := 0, count
+		if paginated {
+			w.Header().Set("X-Pagination-Number", strconv.Itoa(number))
+			w.Header().Set("X-Pagination-Size", strconv.Itoa(size))
+			first = (number - 1) * size
+			if first > count {
+				first = count
+			}
+			if last = first + size; last > count {
+				last = count
+			}
+		}
+		items := []string{}
+		for i := first; i < last; i++ {
+			items = append(items, strconv.Itoa(i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	}))
+
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+	return ts, client, &requests
+}
+
+func TestPager(t *testing.T) {
+	// Init test
+	ts, client, requests := initPaginatedServer(5, true)
+	defer ts.Close()
+
+	// Test
+	var pages []string
+	pager := client.NewPager("/some/list", 2)
+	for pager.Next(context.Background()) {
+		var items []int
+		if err := pager.Page(&items); err != nil {
+			t.Fatalf("Page should not fail. Got %v", err)
+		}
+		pages = append(pages, fmt.Sprint(items))
+	}
+
+	// Validate
+	if err := pager.Err(); err != nil {
+		t.Fatalf("Pager should not fail. Got %v", err)
+	}
+	if fmt.Sprint(pages) != "[[0 1] [2 3] [4]]" {
+		t.Fatalf("Pager should return all pages. Got %v", pages)
+	}
+	if fmt.Sprint(*requests) != "[CachedObjectList-Pages 1/2 CachedObjectList-Pages 2/2 CachedObjectList-Pages 3/2]" {
+		t.Fatalf("Pager should request each page once. Got %v", *requests)
+	}
+}
+
+func TestPagerFullLastPage(t *testing.T) {
+	// Init test: the last page is only detected by an empty one
+	ts, client, requests := initPaginatedServer(4, true)
+	defer ts.Close()
+
+	// Test
+	var all []int
+	if err := client.List("/some/list", &all); err != nil {
+		t.Fatalf("List should not fail. Got %v", err)
+	}
+	pager := client.NewPager("/some/list", 2)
+	pages := 0
+	for pager.Next(context.Background()) {
+		pages++
+	}
+
+	// Validate
+	if len(all) != 4 {
+		t.Fatalf("List should return all items. Got %v", all)
+	}
+	if pages != 2 || len(*requests) != 4 {
+		t.Fatalf("Pager should skip the trailing empty page. Got %d pages in %d requests", pages, len(*requests))
+	}
+}
+
+func TestListUnpaginated(t *testing.T) {
+	// Init test
+	ts, client, requests := initPaginatedServer(150, false)
+	defer ts.Close()
+
+	// Test
+	var items []int
+	err := client.List("/some/list", &items)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("List should not fail. Got %v", err)
+	}
+	if len(items) != 150 || items[149] != 149 {
+		t.Fatalf("List should return all items. Got %d items", len(items))
+	}
+	if len(*requests) != 1 {
+		t.Fatalf("Unpaginated routes should be fetched once. Got %d requests", len(*requests))
+	}
+}
+
+func TestListErrors(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{"not":"a list"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	var items []int
+	if err := client.List("/some/list", items); err == nil {
+		t.Fatalf("List should require a pointer to a slice")
+	}
+	if err := client.List("/some/list", &items); err == nil {
+		t.Fatalf("List should fail on non list responses")
+	}
+}
@@ -0,0 +1,125 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// CallPolicy restricts the calls a client may send, whatever the rights of
+// its consumer key. Rules use the consumer key rules syntax: a method and a
+// path, relative to the API version, where '*' matches any sequence of
+// characters, for instance {"GET", "/dedicated/server/*"}.
+//
+// Calls are checked before being signed, including the requests built by
+// NewRequest and NewSignedRequest, signed by SignRequest or altered by
+// middlewares. Query strings are not part of the matched path, which is
+// unescaped and cleaned of its "." and ".." segments first. The calls of the
+// client itself, to synchronize its time and request consumer keys, are not
+// checked.
+type CallPolicy struct {
+	// Allow lists the allowed calls. All calls are allowed when empty.
+	Allow []AccessRule
+
+	// Deny lists the forbidden calls, even if they are allowed by Allow.
+	Deny []AccessRule
+}
+
+// PolicyError is returned for the calls forbidden by the client CallPolicy.
+// It matches ErrPolicyDenied with errors.Is.
+type PolicyError struct {
+	// Method and Path of the forbidden call
+	Method string
+	Path   string
+
+	// Rule of the Deny list matching the call, nil when the call is not
+	// covered by the Allow list
+	Rule *AccessRule
+}
+
+func (err *PolicyError) Error() string {
+	if err.Rule != nil {
+		return fmt.Sprintf("go-ovh: %s %s is denied by the client policy rule %s %s", err.Method, err.Path, err.Rule.Method, err.Rule.Path)
+	}
+	return fmt.Sprintf("go-ovh: %s %s is not allowed by the client policy", err.Method, err.Path)
+}
+
+// Is makes errors.Is(err, ErrPolicyDenied) true
+func (err *PolicyError) Is(target error) bool {
+	return target == ErrPolicyDenied
+}
+
+// internalCallContext is the context key marking the calls of the client
+// itself, which are not checked against the CallPolicy
+type internalCallContext struct{}
+
+// withInternalCall returns a context marking the calls made with it as calls
+// of the client itself
+func withInternalCall(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalCallContext{}, true)
+}
+
+// check returns a *PolicyError if the call is forbidden
+func (p *CallPolicy) check(method, rawPath string) error {
+	if method == "" {
+		method = "GET"
+	}
+	method = strings.ToUpper(method)
+	if i := strings.IndexByte(rawPath, '?'); i >= 0 {
+		rawPath = rawPath[:i]
+	}
+
+	// Match the path the server resolves, so that escaped characters or ".."
+	// segments do not bypass the rules
+	unescaped, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return &PolicyError{Method: method, Path: rawPath}
+	}
+	cleaned := path.Clean("/" + unescaped)
+	if strings.HasSuffix(unescaped, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	call := AccessRule{Method: method, Path: cleaned}
+
+	for i, rule := range p.Deny {
+		if rulesCover([]AccessRule{rule}, call) {
+			return &PolicyError{Method: call.Method, Path: call.Path, Rule: &p.Deny[i]}
+		}
+	}
+	if len(p.Allow) > 0 && !rulesCover(p.Allow, call) {
+		return &PolicyError{Method: call.Method, Path: call.Path}
+	}
+	return nil
+}
+
+// checkCall returns an error for the calls the client must not make, because
+// it is ReadOnly or because of its Policy. "path" is relative to the endpoint.
+func (c *Client) checkCall(ctx context.Context, method, path string) error {
+	if err := c.checkReadOnly(method); err != nil {
+		return err
+	}
+	if c.Policy != nil && ctx.Value(internalCallContext{}) == nil {
+		return c.Policy.check(method, path)
+	}
+	return nil
+}
+
+// apiPath returns the path of a full API URL, relative to its API version.
+// URLs outside of the client endpoint are returned as is.
+func (c *Client) apiPath(url string) string {
+	base := endpointBase(c.endpoint)
+	if !strings.HasPrefix(url, base+"/") {
+		return url
+	}
+	path := strings.TrimPrefix(url, base)
+	segment := strings.TrimPrefix(path, "/")
+	if i := strings.IndexAny(segment, "/?"); i >= 0 {
+		segment = segment[:i]
+	}
+	if apiVersionSegment.MatchString(segment) {
+		path = strings.TrimPrefix(path, "/"+segment)
+	}
+	return path
+}
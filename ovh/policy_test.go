@@ -0,0 +1,118 @@
+package ovh
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestPolicy(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+	WithPolicy(&CallPolicy{
+		Allow: []AccessRule{{Method: "GET", Path: "/some/*"}, {Method: "DELETE", Path: "/some/resource"}},
+		Deny:  []AccessRule{{Method: "GET", Path: "/some/secret*"}},
+	})(client)
+
+	// Test: allowed calls, query strings are ignored
+	if err := client.Get("/some/resource?filter=1", nil); err != nil {
+		t.Fatalf("Allowed GET should not fail. Got %v", err)
+	}
+	if err := client.Delete("/some/resource", nil); err != nil {
+		t.Fatalf("Allowed DELETE should not fail. Got %v", err)
+	}
+
+	// Test: calls not allowed
+	InputRequest = nil
+	err := client.Post("/some/resource", nil, nil)
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) || policyErr.Method != "POST" || policyErr.Path != "/some/resource" || policyErr.Rule != nil {
+		t.Fatalf("POST should not be allowed by the policy. Got %v", err)
+	}
+	if !errors.Is(err, ErrPolicyDenied) {
+		t.Fatalf("PolicyError should match ErrPolicyDenied. Got %v", err)
+	}
+	if InputRequest != nil {
+		t.Fatalf("Forbidden calls should not be sent")
+	}
+	if _, err := client.NewRequest("GET", "/other", nil, true); !errors.Is(err, ErrPolicyDenied) {
+		t.Fatalf("NewRequest should check the policy. Got %v", err)
+	}
+
+	// Test: denied calls, even if allowed
+	err = client.Get("/some/secret/key", nil)
+	if !errors.As(err, &policyErr) || policyErr.Rule == nil || policyErr.Rule.Path != "/some/secret*" {
+		t.Fatalf("GET should be denied by the deny rule. Got %v", err)
+	}
+
+	// Test: URLs changed by middlewares are checked
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.URL.Path = "/some/secret"
+			return next(req)
+		}
+	})
+	if err := client.Get("/some/resource", nil); !errors.Is(err, ErrPolicyDenied) {
+		t.Fatalf("Middleware URL should be checked. Got %v", err)
+	}
+
+	// Test: paths are cleaned and unescaped before being matched
+	client.middlewares = nil
+	for _, path := range []string{"/some/../me", "/some/%2E%2E/me", "/some/./secret/key"} {
+		if err := client.Get(path, nil); !errors.Is(err, ErrPolicyDenied) {
+			t.Fatalf("GET %s should be checked on its cleaned path. Got %v", path, err)
+		}
+	}
+
+	// Test: requests signed outside the client, relative to the API version
+	req, _ := http.NewRequest("DELETE", ts.URL+"/1.0/other", nil)
+	err = client.SignRequest(req)
+	if !errors.As(err, &policyErr) || policyErr.Path != "/other" {
+		t.Fatalf("SignRequest should check the policy. Got %v", err)
+	}
+}
+
+func TestPolicyInternalCalls(t *testing.T) {
+	// Init test: a fresh client, which must synchronize its time
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/auth/time":
+			fmt.Fprint(w, MockTime)
+		case "/auth/credential":
+			fmt.Fprint(w, `{"consumerKey": "ck", "state": "pendingValidation", "validationUrl": "https://example.com"}`)
+		default:
+			fmt.Fprint(w, `"success"`)
+		}
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	WithPolicy(&CallPolicy{Allow: []AccessRule{{Method: "GET", Path: "/dedicated/server/*"}}})(client)
+
+	// Test
+	err := client.Get("/dedicated/server/ns1.example.net", nil)
+	_, ckErr := client.NewCkRequest().Do()
+	otherErr := client.Get("/me", nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Time synchronization should not be checked against the policy. Got %v", err)
+	}
+	if ckErr != nil {
+		t.Fatalf("Consumer key requests should not be checked against the policy. Got %v", ckErr)
+	}
+	if !errors.Is(otherErr, ErrPolicyDenied) {
+		t.Fatalf("Calls not allowed should still be denied. Got %v", otherErr)
+	}
+	if len(paths) != 3 || paths[0] != "GET /auth/time" || paths[1] != "GET /dedicated/server/ns1.example.net" {
+		t.Fatalf("Only the allowed and internal calls should be sent. Got %v", paths)
+	}
+}
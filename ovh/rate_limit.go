@@ -0,0 +1,111 @@
+package ovh
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests sent by a
+// client, to stay below the API rate limits. It may be shared by several
+// clients using the same application.
+type RateLimiter struct {
+	mutex       sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter returns a rate limiter allowing "perSecond" requests per
+// second on average, and bursts of up to "burst" requests.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent, or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve consumes a token if one is available, otherwise it returns the
+// delay before the next one
+func (l *RateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// pause holds all requests until "until", as requested by the API
+func (l *RateLimiter) pause(until time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// retryAfter returns the delay requested by the Retry-After header of
+// throttled or unavailable responses, 0 if none
+func retryAfter(response *http.Response) time.Duration {
+	if response == nil {
+		return 0
+	}
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(getLocalTime()); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestRateLimiter(t *testing.T) {
+	// Init test: bursts of 2, then 1 request every 50ms
+	limiter := NewRateLimiter(20, 2)
+	ctx := context.Background()
+
+	// Test
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait should not fail. Got %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// Validate
+	if elapsed < 90*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("4 requests should take about 100ms. Got %v", elapsed)
+	}
+}
+
+func TestRateLimiterContext(t *testing.T) {
+	// Init test: an exhausted limiter
+	limiter := NewRateLimiter(0.1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Test
+	err := limiter.Wait(ctx)
+
+	// Validate
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Wait should stop with the context. Got %v", err)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	// Init test
+	ts, client, hits := initFlakyServer(t, 0, http.StatusOK)
+	defer ts.Close()
+	client.RateLimiter = NewRateLimiter(20, 1)
+
+	// Test
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := client.GetUnAuth("/some/resource", nil); err != nil {
+			t.Fatalf("Rate limited request should not fail. Got %v", err)
+		}
+	}
+
+	// Validate
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("3 requests should take at least 100ms. Got %v", elapsed)
+	}
+	if n := atomic.LoadInt32(hits); n != 3 {
+		t.Fatalf("All requests should be sent. Got %d", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	// Init test: a throttled first request
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"Too many requests"}`))
+			return
+		}
+		w.Write([]byte(`"success"`))
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.Retry = &RetryConfig{MaxRetries: 1, Delay: time.Millisecond}
+	client.RateLimiter = NewRateLimiter(1000, 10)
+
+	// Test
+	start := time.Now()
+	err := client.GetUnAuth("/some/resource", nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Throttled request should be retried. Got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Retry should wait for Retry-After. Got %v", elapsed)
+	}
+	if delay := client.RateLimiter.reserve(); delay != 0 {
+		t.Fatalf("Rate limiter should resume after Retry-After. Got %v", delay)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	date := time.Unix(MockTime+5, 0).UTC().Format(http.TimeFormat)
+
+	for _, test := range []struct {
+		status   int
+		header   string
+		expected time.Duration
+	}{
+		{http.StatusTooManyRequests, "3", 3 * time.Second},
+		{http.StatusServiceUnavailable, date, 5 * time.Second},
+		{http.StatusTooManyRequests, "soon", 0},
+		{http.StatusInternalServerError, "3", 0},
+	} {
+		response := &http.Response{StatusCode: test.status, Header: http.Header{"Retry-After": {test.header}}}
+		if delay := retryAfter(response); delay != test.expected {
+			t.Fatalf("Retry-After '%s' on %d should be %v. Got %v", test.header, test.status, test.expected, delay)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package ovh
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+// RawBody is a request body sent as is, instead of being serialized as JSON.
+// It may be given as "reqBody" to Post, Put, CallAPI and their variants:
+//
+//	file, _ := os.Open("payload.bin")
+//	err := client.Post(path, &ovh.RawBody{ContentType: "application/octet-stream", Data: file}, nil)
+//
+// As the body is part of the request signature, it is read entirely before
+// the request is sent.
+type RawBody struct {
+	// ContentType of the body, "application/json;charset=utf-8" when empty
+	ContentType string
+
+	// Data is the body content, no body is sent when nil
+	Data io.Reader
+}
+
+// read returns the whole body content
+func (b *RawBody) read() ([]byte, error) {
+	if b.Data == nil {
+		return nil, nil
+	}
+	return ioutil.ReadAll(b.Data)
+}
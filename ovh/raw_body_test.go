@@ -0,0 +1,82 @@
+package ovh
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestRawBody(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	var InputRequestBody string
+	ts, client := initMockServer(&InputRequest, 200, `{}`, &InputRequestBody, 0)
+	defer ts.Close()
+
+	// Test
+	err := client.Post("/some/upload", &RawBody{ContentType: "text/plain", Data: strings.NewReader("ssh-ed25519 AAAA")}, nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Post should not fail. Got %v", err)
+	}
+	if InputRequestBody != "ssh-ed25519 AAAA" {
+		t.Fatalf("Raw body should be sent as is. Got '%s'", InputRequestBody)
+	}
+	if InputRequest.Header.Get("Content-Type") != "text/plain" {
+		t.Fatalf("Content-Type should be 'text/plain'. Got '%s'", InputRequest.Header.Get("Content-Type"))
+	}
+}
+
+func TestRawBodySignature(t *testing.T) {
+	// Init test
+	ts, client := initSigningServer()
+	defer ts.Close()
+
+	// Test
+	response, err := client.Request("PUT", "/some/upload").
+		RawBody("application/octet-stream", strings.NewReader("\x00\x01binary")).
+		DoFull(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Raw body should be signed. Got %v", err)
+	}
+	if string(response.Body) != "\x00\x01binary" {
+		t.Fatalf("Raw body should be echoed. Got '%s'", response.Body)
+	}
+}
+
+func TestRawBodyRetry(t *testing.T) {
+	// Init test
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.Retry = &RetryConfig{MaxRetries: 1, Delay: time.Millisecond}
+
+	// Test
+	err := client.PutUnAuth("/some/upload", &RawBody{Data: strings.NewReader("payload")}, nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("PUT should succeed after a retry. Got %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != "payload" || bodies[1] != "payload" {
+		t.Fatalf("Raw body should be sent on each attempt. Got %q", bodies)
+	}
+}
@@ -0,0 +1,168 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultConfigWatchInterval is the delay between two checks of the
+// configuration files, used when WatchConfig is given no interval
+const DefaultConfigWatchInterval = 10 * time.Second
+
+// credentials are the application keys and OAuth2 credentials of a client
+type credentials struct {
+	appKey       string
+	appSecret    string
+	consumerKey  string
+	clientID     string
+	clientSecret string
+}
+
+// credentials returns a consistent snapshot of the client credentials, which
+// may be swapped concurrently by ReloadConfig or OnCredentialExpired
+func (c *Client) credentials() credentials {
+	c.keysMutex.RLock()
+	defer c.keysMutex.RUnlock()
+	return credentials{
+		appKey:       c.AppKey,
+		appSecret:    c.AppSecret,
+		consumerKey:  c.ConsumerKey,
+		clientID:     c.ClientID,
+		clientSecret: c.ClientSecret,
+	}
+}
+
+// ReloadConfig loads the credentials of the client again from environment,
+// configuration files, secret files and credential backends, and swaps them
+// atomically: concurrent requests are signed with either the previous or the
+// new credentials. Credentials given as parameters when creating the client
+// are kept, the endpoint and the other settings are not reloaded.
+//
+// The current credentials are kept when the configuration is invalid, or
+// changes the endpoint or the authentication mode. The consumer key is only
+// replaced when the configured one changed, so that a key obtained at
+// runtime, with a CkRequest or OnCredentialExpired, is kept.
+func (c *Client) ReloadConfig() error {
+	fresh := &Client{
+		AppKey:       c.configParams.appKey,
+		AppSecret:    c.configParams.appSecret,
+		ConsumerKey:  c.configParams.consumerKey,
+		ClientID:     c.configParams.clientID,
+		ClientSecret: c.configParams.clientSecret,
+		configFile:   c.configFile,
+		profile:      c.profile,
+		envPrefix:    c.envPrefix,
+	}
+	cfg, err := fresh.newConfig()
+	if err != nil {
+		return err
+	}
+	if err := fresh.loadConfigFrom(cfg, c.configEndpoint); err != nil {
+		return err
+	}
+	if fresh.endpoint != c.endpoint {
+		return fmt.Errorf("go-ovh: can not reload the configuration, endpoint changed from %s to %s", c.endpoint, fresh.endpoint)
+	}
+	if (fresh.oauth2 != nil) != (c.oauth2 != nil) {
+		return fmt.Errorf("go-ovh: can not reload the configuration, authentication mode changed")
+	}
+
+	// Wait for any renewal of the consumer key
+	c.credentialMutex.Lock()
+	defer c.credentialMutex.Unlock()
+	if c.oauth2 != nil {
+		c.oauth2.mutex.Lock()
+		defer c.oauth2.mutex.Unlock()
+		if fresh.ClientID != c.ClientID || fresh.ClientSecret != c.ClientSecret {
+			c.oauth2.accessToken = ""
+		}
+	}
+
+	c.keysMutex.Lock()
+	defer c.keysMutex.Unlock()
+	c.AppKey = fresh.AppKey
+	c.AppSecret = fresh.AppSecret
+	if fresh.ConsumerKey != "" && fresh.ConsumerKey != c.configConsumerKey {
+		c.ConsumerKey = fresh.ConsumerKey
+	}
+	c.configConsumerKey = fresh.ConsumerKey
+	c.ClientID = fresh.ClientID
+	c.ClientSecret = fresh.ClientSecret
+	c.secretFiles = cfg.files
+	return nil
+}
+
+// WatchConfig checks the configuration files and the secret files of the
+// client on each interval, DefaultConfigWatchInterval when zero, and reloads
+// the credentials with ReloadConfig when one of them is modified, created or
+// removed. It returns right away and watches the files until the context is
+// done.
+//
+// onReload, when set, is called after each reload with its error.
+func (c *Client) WatchConfig(ctx context.Context, interval time.Duration, onReload func(err error)) {
+	if interval <= 0 {
+		interval = DefaultConfigWatchInterval
+	}
+	state := c.configFilesState()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if current := c.configFilesState(); current == state {
+				continue
+			}
+			err := c.ReloadConfig()
+			state = c.configFilesState()
+			if onReload != nil {
+				onReload(err)
+			}
+		}
+	}()
+}
+
+// configPaths returns the configuration and secret files read by the client.
+// Drop-in directories are included, so that new files are noticed.
+func (c *Client) configPaths() []string {
+	var paths []string
+	if path := c.configFilePath(); path != "" {
+		paths = append(paths, path)
+	} else {
+		paths = append(paths, systemConfigPath, systemConfigDirPath)
+		if files, err := filepath.Glob(filepath.Join(systemConfigDirPath, "*.conf")); err == nil {
+			paths = append(paths, files...)
+		}
+		if home, err := currentUserHome(); err == nil {
+			paths = append(paths, filepath.Join(home, userConfigPath))
+		}
+		if !c.localConfigDisabled() {
+			paths = append(paths, localConfigPath)
+		}
+	}
+
+	c.keysMutex.RLock()
+	defer c.keysMutex.RUnlock()
+	return append(paths, c.secretFiles...)
+}
+
+// configFilesState describes the size and modification time of the
+// configuration files, to detect changes
+func (c *Client) configFilesState() string {
+	var state strings.Builder
+	for _, path := range c.configPaths() {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&state, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+		} else {
+			fmt.Fprintf(&state, "%s -\n", path)
+		}
+	}
+	return state.String()
+}
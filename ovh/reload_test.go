@@ -0,0 +1,148 @@
+package ovh
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestReloadConfig(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file
+consumer_key=old
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	client, err := NewClientWithOptions("ovh-eu", WithAppKey("param", ""))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions failed with: '%v'", err)
+	}
+
+	// Test: the consumer key is rotated
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file
+consumer_key=new
+`), 0660)
+	err = client.ReloadConfig()
+
+	// Validate: parameters are kept
+	if err != nil {
+		t.Fatalf("ReloadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "param" || client.AppSecret != "file" || client.ConsumerKey != "new" {
+		t.Fatalf("ReloadConfig should swap the configured credentials. Got '%s', '%s', '%s'", client.AppKey, client.AppSecret, client.ConsumerKey)
+	}
+
+	// Test: invalid configurations are ignored
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+consumer_key=invalid
+`), 0660)
+	err = client.ReloadConfig()
+
+	// Validate
+	if err == nil {
+		t.Fatalf("ReloadConfig should fail on invalid configurations")
+	}
+	if client.AppSecret != "file" || client.ConsumerKey != "new" {
+		t.Fatalf("ReloadConfig should keep the credentials on errors. Got '%s', '%s'", client.AppSecret, client.ConsumerKey)
+	}
+}
+
+func TestReloadConfigRuntimeConsumerKey(t *testing.T) {
+	// Prepare
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file
+consumer_key=configured
+`), 0660)
+	defer ioutil.WriteFile(systemConfigPath, []byte(``), 0660)
+	client, err := NewClientWithOptions("ovh-eu")
+	if err != nil {
+		t.Fatalf("NewClientWithOptions failed with: '%v'", err)
+	}
+
+	// Test: a consumer key obtained at runtime, the configuration is unchanged
+	client.ConsumerKey = "runtime"
+	if err := client.ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig failed with: '%v'", err)
+	}
+	if client.ConsumerKey != "runtime" {
+		t.Fatalf("ReloadConfig should keep the consumer key obtained at runtime. Got '%s'", client.ConsumerKey)
+	}
+
+	// Test: the configuration has no consumer key anymore
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file
+`), 0660)
+	if err := client.ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig failed with: '%v'", err)
+	}
+	if client.ConsumerKey != "runtime" {
+		t.Fatalf("ReloadConfig should keep the consumer key without configured one. Got '%s'", client.ConsumerKey)
+	}
+
+	// Test: the configured consumer key is rotated
+	ioutil.WriteFile(systemConfigPath, []byte(`
+[ovh-eu]
+application_key=file
+application_secret=file
+consumer_key=rotated
+`), 0660)
+	err = client.ReloadConfig()
+
+	// Validate
+	if err != nil || client.ConsumerKey != "rotated" {
+		t.Fatalf("ReloadConfig should swap a rotated consumer key. Got '%s', %v", client.ConsumerKey, err)
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	// Prepare: a consumer key mounted as a secret file
+	dir, err := ioutil.TempDir("", "go-ovh-secrets")
+	if err != nil {
+		t.Fatalf("TempDir failed with: '%v'", err)
+	}
+	defer os.RemoveAll(dir)
+	secret := filepath.Join(dir, "consumer_key")
+	ioutil.WriteFile(secret, []byte("old\n"), 0600)
+	os.Setenv("OVH_CONSUMER_KEY_FILE", secret)
+	defer os.Unsetenv("OVH_CONSUMER_KEY_FILE")
+
+	client, err := NewClientWithOptions("ovh-eu", WithAppKey(MockApplicationKey, MockApplicationSecret))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions failed with: '%v'", err)
+	}
+	reloads := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.WatchConfig(ctx, 10*time.Millisecond, func(err error) { reloads <- err })
+
+	// Test
+	ioutil.WriteFile(secret, []byte("rotated\n"), 0600)
+
+	// Validate
+	select {
+	case err := <-reloads:
+		if err != nil {
+			t.Fatalf("WatchConfig should reload the configuration. Got '%v'", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("WatchConfig should notice secret file changes")
+	}
+	if keys := client.credentials(); keys.consumerKey != "rotated" || keys.appKey != MockApplicationKey {
+		t.Fatalf("WatchConfig should swap the credentials. Got %+v", keys)
+	}
+}
@@ -0,0 +1,98 @@
+package ovh
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestBuilder builds an API call with additional headers and query
+// parameters. Query parameters are encoded and added to the path before the
+// request is signed. See Client.Request.
+type RequestBuilder struct {
+	client   *Client
+	method   string
+	path     string
+	body     interface{}
+	header   http.Header
+	query    url.Values
+	needAuth bool
+}
+
+// Request starts building an authenticated call to "path":
+//
+//	var ids []string
+//	err := client.Request("GET", "/domain/zone/example.com/record").
+//		Query("fieldType", "TXT").
+//		Header("Cache-Control", "no-cache").
+//		Do(ctx, &ids)
+func (c *Client) Request(method, path string) *RequestBuilder {
+	return &RequestBuilder{
+		client:   c,
+		method:   method,
+		path:     path,
+		header:   http.Header{},
+		query:    url.Values{},
+		needAuth: true,
+	}
+}
+
+// Header adds a header to the request. Authentication headers can not be
+// overridden.
+func (b *RequestBuilder) Header(name, value string) *RequestBuilder {
+	b.header.Add(name, value)
+	return b
+}
+
+// Query adds a query parameter to the request
+func (b *RequestBuilder) Query(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// Body sets the request body, serialized as JSON
+func (b *RequestBuilder) Body(reqBody interface{}) *RequestBuilder {
+	b.body = reqBody
+	return b
+}
+
+// RawBody sets a request body sent as is, with the given Content-Type, see
+// RawBody
+func (b *RequestBuilder) RawBody(contentType string, data io.Reader) *RequestBuilder {
+	b.body = &RawBody{ContentType: contentType, Data: data}
+	return b
+}
+
+// UnAuth sends the request without authentication
+func (b *RequestBuilder) UnAuth() *RequestBuilder {
+	b.needAuth = false
+	return b
+}
+
+// Path returns the path of the request, with its encoded query parameters
+func (b *RequestBuilder) Path() string {
+	if len(b.query) == 0 {
+		return b.path
+	}
+	separator := "?"
+	if strings.Contains(b.path, "?") {
+		separator = "&"
+	}
+	return b.path + separator + b.query.Encode()
+}
+
+// Do sends the request and decodes the response into resType
+func (b *RequestBuilder) Do(ctx context.Context, resType interface{}) error {
+	response, err := b.DoFull(ctx)
+	if err != nil {
+		return err
+	}
+	return b.client.decode(b.method, b.path, response.Body, resType)
+}
+
+// DoFull sends the request and returns the whole response, see CallAPIFull
+func (b *RequestBuilder) DoFull(ctx context.Context) (*Response, error) {
+	return b.client.callAPIFull(ctx, b.method, b.Path(), b.body, b.header, b.needAuth)
+}
@@ -0,0 +1,91 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestRequestBuilder(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	var InputRequestBody string
+	ts, client := initMockServer(&InputRequest, 200, `{"id":42}`, &InputRequestBody, 0)
+	defer ts.Close()
+
+	// Test
+	var res struct {
+		ID int `json:"id"`
+	}
+	err := client.Request("POST", "/domain/zone/example.com/record?fieldType=TXT").
+		Query("subDomain", "a b&c").
+		Query("subDomain", "d").
+		Header("X-Pagination-Mode", "CachedObjectList-Pages").
+		Header("Cache-Control", "no-cache").
+		Body(map[string]string{"target": "value"}).
+		Do(context.Background(), &res)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Request should not fail. Got %v", err)
+	}
+	if res.ID != 42 {
+		t.Fatalf("Response should be decoded. Got %d", res.ID)
+	}
+	if InputRequest.URL.RawQuery != "fieldType=TXT&subDomain=a+b%26c&subDomain=d" {
+		t.Fatalf("Query parameters should be encoded. Got '%s'", InputRequest.URL.RawQuery)
+	}
+	if InputRequest.Header.Get("X-Pagination-Mode") != "CachedObjectList-Pages" || InputRequest.Header.Get("Cache-Control") != "no-cache" {
+		t.Fatalf("Custom headers should be sent. Got %v", InputRequest.Header)
+	}
+	if InputRequest.Header.Get("X-Ovh-Signature") == "" {
+		t.Fatalf("Request should be signed")
+	}
+	if InputRequestBody != `{"target":"value"}` {
+		t.Fatalf("Body should be serialized. Got '%s'", InputRequestBody)
+	}
+}
+
+func TestRequestBuilderSignature(t *testing.T) {
+	// Init test
+	ts, client := initSigningServer()
+	defer ts.Close()
+
+	// Test
+	response, err := client.Request("GET", "/me/bill").
+		Query("date.from", "2020-01-01T00:00:00+01:00").
+		Header("X-Ovh-Signature", "forged").
+		DoFull(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Signature should match the encoded query. Got %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("Response should be OK. Got %d", response.StatusCode)
+	}
+}
+
+func TestRequestBuilderUnAuth(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{}`, nil, 0)
+	defer ts.Close()
+
+	// Test
+	builder := client.Request("GET", "/auth/details").UnAuth()
+	err := builder.Do(context.Background(), nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Request should not fail. Got %v", err)
+	}
+	if InputRequest.Header.Get("X-Ovh-Signature") != "" {
+		t.Fatalf("UnAuth requests should not be signed")
+	}
+	if builder.Path() != "/auth/details" {
+		t.Fatalf("Path without query should be unchanged. Got '%s'", builder.Path())
+	}
+}
@@ -0,0 +1,165 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Response holds everything about the response to an API call
+type Response struct {
+	// HTTP status code, 0 if no response was received
+	StatusCode int
+	// Response headers
+	Header http.Header
+	// Raw response body
+	Body []byte
+	// ID of the request, from the X-Ovh-QueryID header
+	QueryID string
+}
+
+// Unmarshal decodes the response body into resType. It does nothing if the
+// body is empty or resType is nil.
+func (r *Response) Unmarshal(resType interface{}) error {
+	return decodeBody(r.Body, resType)
+}
+
+// CallAPIFull is the same as CallAPI, for authenticated calls, but returns the
+// whole response instead of decoding it. The response is always returned,
+// even on error, so that its status, headers and body can be inspected.
+func (c *Client) CallAPIFull(method, path string, reqBody interface{}) (*Response, error) {
+	return c.CallAPIFullWithContext(context.Background(), method, path, reqBody)
+}
+
+// CallAPIFullWithContext is the same as CallAPIWithContext, for authenticated
+// calls, but returns the whole response instead of decoding it. The response
+// is always returned, even on error, so that its status, headers and body can
+// be inspected.
+func (c *Client) CallAPIFullWithContext(ctx context.Context, method, path string, reqBody interface{}) (*Response, error) {
+	return c.callAPIFull(ctx, method, path, reqBody, nil, true)
+}
+
+// CallAPIWithStatus is the same as CallAPI, but also returns the HTTP status
+// code of the response, for instance to tell 200 from 204 responses. The
+// result type is left untouched for responses without body.
+func (c *Client) CallAPIWithStatus(method, path string, reqBody, resType interface{}, needAuth bool) (int, error) {
+	return c.CallAPIWithStatusWithContext(context.Background(), method, path, reqBody, resType, needAuth)
+}
+
+// CallAPIWithStatusWithContext is the same as CallAPIWithContext, but also
+// returns the HTTP status code of the response, 0 if no response was
+// received. The result type is left untouched for responses without body.
+func (c *Client) CallAPIWithStatusWithContext(ctx context.Context, method, path string, reqBody, resType interface{}, needAuth bool) (int, error) {
+	response, err := c.callAPIFull(ctx, method, path, reqBody, nil, needAuth)
+	if err != nil {
+		return response.StatusCode, err
+	}
+	return response.StatusCode, c.decode(method, path, response.Body, resType)
+}
+
+// CallAPIRawJSON is the same as CallAPI, but also returns the raw JSON
+// response, for instance to keep the fields unknown to resType or archive the
+// exact response. resType may be nil to only get the raw response.
+func (c *Client) CallAPIRawJSON(method, path string, reqBody, resType interface{}, needAuth bool) (json.RawMessage, error) {
+	return c.CallAPIRawJSONWithContext(context.Background(), method, path, reqBody, resType, needAuth)
+}
+
+// CallAPIRawJSONWithContext is the same as CallAPIWithContext, but also
+// returns the raw JSON response. resType may be nil to only get the raw
+// response. The raw response is returned even if the call or its decoding
+// fails, it is then the error body, if any.
+func (c *Client) CallAPIRawJSONWithContext(ctx context.Context, method, path string, reqBody, resType interface{}, needAuth bool) (json.RawMessage, error) {
+	response, err := c.callAPIFull(ctx, method, path, reqBody, nil, needAuth)
+	raw := json.RawMessage(response.Body)
+	if err != nil {
+		return raw, err
+	}
+	return raw, c.decode(method, path, response.Body, resType)
+}
+
+// callAPIFull sends the request, with the additional "header" and its
+// idempotency key, and reads the whole response. GET responses are cached
+// when a Cache is configured.
+func (c *Client) callAPIFull(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*Response, error) {
+	if err := c.checkCall(ctx, method, path); err != nil {
+		return &Response{}, err
+	}
+	ctx, end, err := c.beginCall(ctx)
+	if err != nil {
+		return &Response{}, err
+	}
+	defer end()
+	header, done, err := c.withIdempotencyKey(ctx, method, header)
+	if err != nil {
+		return &Response{}, err
+	}
+	defer done()
+
+	ctx, cancel, wrapTimeout := c.withRequestTimeout(ctx, method, path)
+	defer cancel()
+
+	var res *Response
+	if c.Cache != nil {
+		res, err = c.callCached(ctx, method, path, reqBody, header, needAuth)
+	} else {
+		res, err = c.fetch(ctx, method, path, reqBody, header, needAuth)
+	}
+	return res, wrapTimeout(err)
+}
+
+// fetch sends the request, with the additional "header", and reads the whole
+// response. Requests rejected because of their signature or credential are
+// signed and sent again once, after synchronizing the time delta, then after
+// renewing the consumer key with OnCredentialExpired, if set.
+func (c *Client) fetch(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*Response, error) {
+	consumerKey := c.credentials().consumerKey
+	res, err := c.fetchOnce(ctx, method, path, reqBody, header, needAuth)
+	if !needAuth || c.oauth2 != nil || !isAuthError(err) {
+		return res, err
+	}
+
+	// A wrong time delta is the most common cause of signature errors. The
+	// request was rejected before being processed, it is safe to send it
+	// again, even if it is not idempotent.
+	if _, syncErr := c.RefreshTimeDeltaWithContext(ctx); syncErr != nil {
+		return res, err
+	}
+	res, err = c.fetchOnce(ctx, method, path, reqBody, header, needAuth)
+	if IsCredentialExpired(err) && c.OnCredentialExpired != nil {
+		if err := c.renewConsumerKey(ctx, consumerKey); err != nil {
+			return res, err
+		}
+		res, err = c.fetchOnce(ctx, method, path, reqBody, header, needAuth)
+	}
+	if isAuthError(err) {
+		return res, &AuthError{APIError: err.(*APIError)}
+	}
+	return res, err
+}
+
+// fetchOnce sends the request, with the additional "header", and reads the
+// whole response
+func (c *Client) fetchOnce(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*Response, error) {
+	response, err := c.send(ctx, method, path, reqBody, header, needAuth)
+	if err != nil {
+		return &Response{}, err
+	}
+	defer response.Body.Close()
+
+	res := &Response{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		QueryID:    response.Header.Get("X-Ovh-QueryID"),
+	}
+	if res.Body, err = c.readBody(response); err != nil {
+		return res, err
+	}
+	if response.StatusCode == http.StatusNoContent {
+		// No content, whatever the server sent
+		res.Body = nil
+	}
+	if err = checkResponse(response, res.Body); err != nil {
+		return res, err
+	}
+	return res, c.checkDeprecation(method, path, response)
+}
@@ -0,0 +1,176 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestCallAPIFull(t *testing.T) {
+	// Init test
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ovh-QueryID", "FR.ws-8.5860f657.4632.0180")
+		w.Header().Set("X-Custom", "custom")
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		w.Write([]byte(`{"i_val":42,"s_val":"Hello World!"}`))
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	// Test: success
+	res, err := client.CallAPIFull("GET", "/success", nil)
+	if err != nil {
+		t.Fatalf("CallAPIFull should not return an error. Got %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Response.StatusCode should be 200. Got %d", res.StatusCode)
+	}
+	if res.Header.Get("X-Custom") != "custom" {
+		t.Fatalf("Response.Header should include X-Custom. Got %v", res.Header)
+	}
+	if res.QueryID != "FR.ws-8.5860f657.4632.0180" {
+		t.Fatalf("Response.QueryID should be set. Got '%s'", res.QueryID)
+	}
+	if string(res.Body) != `{"i_val":42,"s_val":"Hello World!"}` {
+		t.Fatalf("Response.Body should be the raw body. Got '%s'", res.Body)
+	}
+	var data SomeData
+	if err = res.Unmarshal(&data); err != nil || data.IntValue != 42 {
+		t.Fatalf("Response.Unmarshal should decode the body. Got %v (%v)", data, err)
+	}
+
+	// Test: error
+	res, err = client.CallAPIFull("GET", "/error", nil)
+	if _, ok := err.(*APIError); !ok {
+		t.Fatalf("CallAPIFull should return an APIError. Got %v", err)
+	}
+	if res == nil {
+		t.Fatalf("CallAPIFull should return the response on error")
+	}
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("Response.StatusCode should be 404. Got %d", res.StatusCode)
+	}
+	if res.QueryID != "FR.ws-8.5860f657.4632.0180" {
+		t.Fatalf("Response.QueryID should be set on error. Got '%s'", res.QueryID)
+	}
+	if string(res.Body) != `{"message":"Not found"}` {
+		t.Fatalf("Response.Body should be the raw body on error. Got '%s'", res.Body)
+	}
+
+	// Test: transport error
+	client.endpoint = "https://localhost:1/does not exist"
+	res, err = client.CallAPIFull("GET", "/success", nil)
+	if err == nil || res == nil || res.StatusCode != 0 {
+		t.Fatalf("CallAPIFull should return an empty response on transport error. Got %v (%v)", res, err)
+	}
+}
+
+func TestCallAPIRawJSON(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusOK, `{"i_val":42,"s_val":"Hello World!","extra":[1,2]}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	var data SomeData
+	raw, err := client.CallAPIRawJSON("GET", "/some/resource", nil, &data, true)
+	if err != nil {
+		t.Fatalf("CallAPIRawJSON should not return an error. Got %v", err)
+	}
+	rawOnly, err := client.CallAPIRawJSONWithContext(context.Background(), "GET", "/some/resource", nil, nil, true)
+	if err != nil {
+		t.Fatalf("CallAPIRawJSONWithContext without resType should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if data.IntValue != 42 || data.StringValue != "Hello World!" {
+		t.Fatalf("CallAPIRawJSON should decode the response. Got %+v", data)
+	}
+	var extra struct {
+		Extra []int `json:"extra"`
+	}
+	if err := json.Unmarshal(raw, &extra); err != nil || len(extra.Extra) != 2 {
+		t.Fatalf("CallAPIRawJSON should return the fields unknown to resType. Got %s", raw)
+	}
+	if string(rawOnly) != string(raw) {
+		t.Fatalf("CallAPIRawJSON should return the raw response. Got %s", rawOnly)
+	}
+}
+
+func TestCallAPIRawJSONError(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusNotFound, `{"message":"Not found"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	var data SomeData
+	raw, err := client.CallAPIRawJSON("GET", "/some/resource", nil, &data, true)
+
+	// Validate
+	if _, ok := err.(*APIError); !ok {
+		t.Fatalf("CallAPIRawJSON should return an APIError. Got %v", err)
+	}
+	if string(raw) != `{"message":"Not found"}` {
+		t.Fatalf("CallAPIRawJSON should return the error body. Got %s", raw)
+	}
+}
+
+func TestCallAPIWithStatus(t *testing.T) {
+	// Init test: empty bodies, with or without a 204 status
+	for _, tc := range []struct {
+		status int
+		body   string
+	}{
+		{http.StatusNoContent, ""},
+		{http.StatusNoContent, `{"i_val":42}`},
+		{http.StatusOK, ""},
+		{http.StatusOK, "\n"},
+		{http.StatusAccepted, "  \r\n"},
+	} {
+		var InputRequest *http.Request
+		ts, client := initMockServer(&InputRequest, tc.status, tc.body, nil, time.Duration(0))
+
+		// Test
+		data := SomeData{IntValue: 1}
+		status, err := client.CallAPIWithStatus("DELETE", "/some/resource", nil, &data, true)
+		errGet := client.Get("/some/resource", &data)
+		ts.Close()
+
+		// Validate
+		if err != nil || errGet != nil {
+			t.Fatalf("Empty responses should not fail with status %d and body %q. Got %v and %v", tc.status, tc.body, err, errGet)
+		}
+		if status != tc.status {
+			t.Fatalf("CallAPIWithStatus should return status %d. Got %d", tc.status, status)
+		}
+		if data.IntValue != 1 {
+			t.Fatalf("Empty responses should leave the result untouched. Got %+v", data)
+		}
+	}
+}
+
+func TestCallAPIWithStatusError(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusConflict, `{"message":"Conflict"}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	status, err := client.CallAPIWithStatusWithContext(context.Background(), "POST", "/some/resource", nil, nil, true)
+
+	// Validate
+	if _, ok := err.(*APIError); !ok || status != http.StatusConflict {
+		t.Fatalf("CallAPIWithStatus should return the error status. Got %d and %v", status, err)
+	}
+}
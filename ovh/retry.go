@@ -0,0 +1,260 @@
+package ovh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Retry defaults, used when the corresponding RetryConfig field is not set
+const (
+	DefaultRetryDelay    = 1 * time.Second
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// DefaultRetryableStatusCodes are the HTTP status codes retried when
+// RetryConfig.RetryableStatusCodes is not set
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Backoff computes the delay before the retry number "attempt", starting at 0,
+// from the base delay
+type Backoff func(attempt int, delay time.Duration) time.Duration
+
+// ConstantBackoff always waits for the base delay
+func ConstantBackoff(attempt int, delay time.Duration) time.Duration {
+	return delay
+}
+
+// ExponentialBackoff doubles the delay after each attempt
+func ExponentialBackoff(attempt int, delay time.Duration) time.Duration {
+	if attempt > 30 {
+		attempt = 30
+	}
+	return delay << uint(attempt)
+}
+
+// RetryPolicy decides if a failed request attempt should be retried, and
+// after which delay. "attempt" is the number of the failed attempt, starting
+// at 0, and "response" is nil on network errors. Policies may classify
+// errors using PeekAPIError.
+//
+// The policy is consulted for every request, whatever its method: only
+// retry non-idempotent requests known to be safe to replay.
+type RetryPolicy interface {
+	ShouldRetry(req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool)
+}
+
+// RetryPolicyFunc is a function implementing RetryPolicy
+type RetryPolicyFunc func(req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool)
+
+// ShouldRetry calls f(req, response, err, attempt)
+func (f RetryPolicyFunc) ShouldRetry(req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+	return f(req, response, err, attempt)
+}
+
+// RetryConfig configures automatic retries of requests failing with a network
+// error or a retryable HTTP status code. Each attempt is freshly signed.
+//
+// Only idempotent requests (GET, PUT, DELETE) are retried unless
+// RetryNonIdempotent is set, as replaying a POST may, for instance, create
+// duplicate resources.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries of a single request
+	MaxRetries int
+
+	// Delay is the base delay between two attempts of a request, see Backoff.
+	// DefaultRetryDelay is used when zero.
+	Delay time.Duration
+
+	// MaxDelay caps the delay between two attempts. DefaultRetryMaxDelay is
+	// used when zero.
+	MaxDelay time.Duration
+
+	// Backoff computes the delay between two attempts. ExponentialBackoff is
+	// used when nil.
+	Backoff Backoff
+
+	// Jitter randomizes delays between half and all of their computed value,
+	// to avoid synchronized retries of many clients.
+	Jitter bool
+
+	// RetryableStatusCodes lists the HTTP status codes triggering a retry.
+	// DefaultRetryableStatusCodes is used when nil.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent enables retries of non-idempotent requests (POST,
+	// PATCH). Only enable it for calls known to be safe to replay.
+	RetryNonIdempotent bool
+}
+
+// delay returns the delay before the retry number "attempt"
+func (r *RetryConfig) delay(attempt int) time.Duration {
+	base := r.Delay
+	if base <= 0 {
+		base = DefaultRetryDelay
+	}
+	maxDelay := r.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	backoff := r.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff
+	}
+
+	delay := backoff(attempt, base)
+	if delay > maxDelay || delay < 0 {
+		delay = maxDelay
+	}
+	if r.Jitter && delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	return delay
+}
+
+// ShouldRetry implements RetryPolicy. The delay is never shorter than the
+// Retry-After header of the response.
+func (r *RetryConfig) ShouldRetry(req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= r.MaxRetries {
+		return 0, false
+	}
+	if !r.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+	if !r.isRetryable(response, err) {
+		return 0, false
+	}
+
+	// Never retry sooner than requested by the API
+	delay := r.delay(attempt)
+	if after := retryAfter(response); after > delay {
+		delay = after
+	}
+	return delay, true
+}
+
+// PeekAPIError returns the error described by an unsuccessful response, for
+// instance to classify it by ErrorCode, or nil for a successful response.
+// The response body is read and replaced, so that it can still be read.
+func PeekAPIError(response *http.Response) *APIError {
+	if response == nil || (response.StatusCode >= 200 && response.StatusCode < 300) {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var apiError *APIError
+	if errors.As(checkResponse(response, body), &apiError) {
+		return apiError
+	}
+	return nil
+}
+
+// isRetryable checks if a request attempt failed with a transient error
+func (r *RetryConfig) isRetryable(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	codes := r.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, code := range codes {
+		if response.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryBudget is a token bucket limiting the number of retries issued across
+// many requests. Each retry consumes a token, and tokens are refilled at a
+// constant rate. Once the budget is exhausted, failing requests are no longer
+// retried until it refills. This prevents a client from amplifying the load
+// on the API during a broad outage.
+type RetryBudget struct {
+	mutex      sync.Mutex
+	capacity   float64
+	refillRate float64
+	tokens     float64
+	last       time.Time
+}
+
+// NewRetryBudget returns a full retry budget allowing up to "capacity"
+// retries in a burst, refilled by "refillPerSecond" retries per second.
+func NewRetryBudget(capacity int, refillPerSecond float64) *RetryBudget {
+	return &RetryBudget{
+		capacity:   float64(capacity),
+		refillRate: refillPerSecond,
+		tokens:     float64(capacity),
+		last:       time.Now(),
+	}
+}
+
+// Take consumes a retry token. It returns false, without consuming anything,
+// when the budget is exhausted.
+func (b *RetryBudget) Take() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.refillRate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// isIdempotent checks if requests with this HTTP method may safely be replayed
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// retryPolicy returns the policy deciding retries of the client requests, if
+// any
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
+	}
+	if c.Retry != nil {
+		return c.Retry
+	}
+	return nil
+}
+
+// shouldRetry decides if a request attempt should be retried, and after which
+// delay
+func (c *Client) shouldRetry(ctx context.Context, req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+	policy := c.retryPolicy()
+	if policy == nil || ctx.Err() != nil {
+		return 0, false
+	}
+	delay, retry := policy.ShouldRetry(req, response, err, attempt)
+	if !retry {
+		return 0, false
+	}
+	if c.RetryBudget != nil && !c.RetryBudget.Take() {
+		return 0, false
+	}
+	return delay, true
+}
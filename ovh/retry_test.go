@@ -0,0 +1,201 @@
+package ovh
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initFlakyServer starts a server failing with "status" for the first "failures" requests
+func initFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *Client, *int32) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"message":"Service unavailable"}`))
+			return
+		}
+		w.Write([]byte(`"success"`))
+	}))
+
+	client, err := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	if err != nil {
+		t.Fatalf("NewClient should not fail. Got %v", err)
+	}
+	return ts, client, &hits
+}
+
+func TestRetry(t *testing.T) {
+	// Init test
+	ts, client, hits := initFlakyServer(t, 2, http.StatusServiceUnavailable)
+	defer ts.Close()
+	client.Retry = &RetryConfig{MaxRetries: 3, Delay: time.Millisecond}
+
+	// Test: transient errors are retried
+	var res string
+	if err := client.GetUnAuth("/some/resource", &res); err != nil {
+		t.Fatalf("GET should succeed after retries. Got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 3 {
+		t.Fatalf("GET should succeed on 3rd attempt. Got %d attempts", n)
+	}
+
+	// Test: non idempotent requests are not retried
+	atomic.StoreInt32(hits, 0)
+	if err := client.PostUnAuth("/some/resource", nil, &res); err == nil {
+		t.Fatalf("POST should not be retried")
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Fatalf("POST should be attempted once. Got %d attempts", n)
+	}
+}
+
+func TestRetryConfig(t *testing.T) {
+	// Test: custom retryable status codes
+	ts, client, hits := initFlakyServer(t, 1, http.StatusConflict)
+	defer ts.Close()
+	client.Retry = &RetryConfig{MaxRetries: 3, Delay: time.Millisecond}
+	if err := client.GetUnAuth("/some/resource", nil); err == nil {
+		t.Fatalf("GET should not retry 409 by default")
+	}
+	atomic.StoreInt32(hits, 0)
+	client.Retry.RetryableStatusCodes = []int{http.StatusConflict}
+	if err := client.GetUnAuth("/some/resource", nil); err != nil {
+		t.Fatalf("GET should retry configured status codes. Got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Fatalf("GET should succeed on 2nd attempt. Got %d attempts", n)
+	}
+
+	// Test: non idempotent requests opt-in
+	ts2, client2, hits2 := initFlakyServer(t, 1, http.StatusServiceUnavailable)
+	defer ts2.Close()
+	client2.Retry = &RetryConfig{MaxRetries: 3, Delay: time.Millisecond, RetryNonIdempotent: true}
+	if err := client2.PostUnAuth("/some/resource", nil, nil); err != nil {
+		t.Fatalf("POST should be retried when RetryNonIdempotent is set. Got %v", err)
+	}
+	if n := atomic.LoadInt32(hits2); n != 2 {
+		t.Fatalf("POST should succeed on 2nd attempt. Got %d attempts", n)
+	}
+
+	// Test: exponential backoff, capped
+	retry := &RetryConfig{Delay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, expected := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		if delay := retry.delay(attempt); delay != expected*time.Millisecond {
+			t.Fatalf("Retry %d should wait %v. Got %v", attempt, expected*time.Millisecond, delay)
+		}
+	}
+
+	// Test: constant backoff
+	retry.Backoff = ConstantBackoff
+	if delay := retry.delay(5); delay != 100*time.Millisecond {
+		t.Fatalf("Constant backoff should wait 100ms. Got %v", delay)
+	}
+
+	// Test: jitter
+	retry.Jitter = true
+	for i := 0; i < 100; i++ {
+		if delay := retry.delay(0); delay < 50*time.Millisecond || delay > 100*time.Millisecond {
+			t.Fatalf("Jittered delay should be between 50ms and 100ms. Got %v", delay)
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	// Init test: the API is down
+	ts, client, hits := initFlakyServer(t, 100, http.StatusServiceUnavailable)
+	defer ts.Close()
+	client.Retry = &RetryConfig{MaxRetries: 3, Delay: time.Millisecond}
+	client.RetryBudget = NewRetryBudget(2, 0)
+
+	// Test: first request consumes the whole budget
+	if err := client.GetUnAuth("/some/resource", nil); err == nil {
+		t.Fatalf("GET should fail when the API is down")
+	}
+	if n := atomic.LoadInt32(hits); n != 3 {
+		t.Fatalf("GET should be retried until the budget is exhausted. Got %d attempts", n)
+	}
+
+	// Test: subsequent requests fail fast
+	atomic.StoreInt32(hits, 0)
+	if err := client.GetUnAuth("/some/resource", nil); err == nil {
+		t.Fatalf("GET should fail when the API is down")
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Fatalf("GET should not be retried once the budget is exhausted. Got %d attempts", n)
+	}
+
+	// Test: budget refills over time
+	client.RetryBudget = NewRetryBudget(1, 1000)
+	client.RetryBudget.Take()
+	time.Sleep(10 * time.Millisecond)
+	if !client.RetryBudget.Take() {
+		t.Fatalf("RetryBudget should refill over time")
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	// Init test: the API replies with an error code, then succeeds
+	var hits int32
+	errorCode := "SERVER_UNAVAILABLE"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message":"Unavailable","errorCode":"` + errorCode + `"}`))
+			return
+		}
+		w.Write([]byte(`"success"`))
+	}))
+	defer ts.Close()
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	var codes []string
+	client.RetryPolicy = RetryPolicyFunc(func(req *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+		apiError := PeekAPIError(response)
+		if apiError == nil || attempt >= 3 {
+			return 0, false
+		}
+		codes = append(codes, req.Method+" "+apiError.ErrorCode)
+		return time.Millisecond, apiError.ErrorCode == "SERVER_UNAVAILABLE"
+	})
+
+	// Test: the policy retries the error code, even for a POST
+	var res string
+	if err := client.PostUnAuth("/some/resource", nil, &res); err != nil {
+		t.Fatalf("POST should be retried by the policy. Got %v", err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 || res != "success" {
+		t.Fatalf("POST should succeed on 2nd attempt. Got %d attempts, %q", n, res)
+	}
+	if len(codes) != 1 || codes[0] != "POST SERVER_UNAVAILABLE" {
+		t.Fatalf("Policy should see the request and API error code. Got %v", codes)
+	}
+
+	// Test: other error codes are not retried, and the body is still read
+	atomic.StoreInt32(&hits, 0)
+	errorCode = "QUOTA_EXCEEDED"
+	err := client.GetUnAuth("/some/resource", nil)
+	apiError, ok := err.(*APIError)
+	if !ok || apiError.ErrorCode != "QUOTA_EXCEEDED" {
+		t.Fatalf("GET should fail with the API error. Got %v", err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("GET should be attempted once. Got %d attempts", n)
+	}
+
+	// Test: the policy takes precedence over Retry
+	atomic.StoreInt32(&hits, 0)
+	client.Retry = &RetryConfig{MaxRetries: 3, Delay: time.Millisecond}
+	if err := client.GetUnAuth("/some/resource", nil); err == nil {
+		t.Fatalf("GET should not be retried by Retry when a policy is set")
+	}
+
+	// Test: a successful response has no API error
+	if apiError := PeekAPIError(&http.Response{StatusCode: http.StatusOK}); apiError != nil {
+		t.Fatalf("Successful response should have no API error. Got %v", apiError)
+	}
+}
@@ -0,0 +1,74 @@
+package ovh
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Sign returns the X-Ovh-Signature header value of a request: "$1$" followed
+// by the hex encoded sha1 hash of the following fields, joined by '+':
+// - application secret
+// - consumer key
+// - capitalized method
+// - full request url, including any query string argument
+// - full serialized request body
+// - server time, as a unix timestamp
+//
+// Timestamp must be the API server time, see Client.TimeDelta.
+func Sign(appSecret, consumerKey, method, url, body string, timestamp int64) string {
+	h := sha1.New()
+	h.Write([]byte(fmt.Sprintf("%s+%s+%s+%s+%s+%d",
+		appSecret,
+		consumerKey,
+		method,
+		url,
+		body,
+		timestamp,
+	)))
+	return fmt.Sprintf("$1$%x", h.Sum(nil))
+}
+
+// SignRequest injects the authentication headers of the client credentials
+// in a request built outside of the client, for instance by another HTTP
+// stack, a proxy or a test harness. The request URL must be the full API URL.
+// The body, if any, is read entirely as it is part of the signature, and
+// replaced so that the request can still be sent.
+//
+// The request context is used for the time synchronization needed to sign
+// the request.
+func (c *Client) SignRequest(req *http.Request) error {
+	if err := c.checkCall(req.Context(), req.Method, c.apiPath(req.URL.String())); err != nil {
+		return err
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	if c.oauth2 == nil {
+		req.Header.Set("X-Ovh-Application", c.requestAppKey())
+	}
+
+	// Requests to the client endpoint, whatever their API version, are
+	// signed as the client does
+	url := req.URL.String()
+	if base := endpointBase(c.endpoint); strings.HasPrefix(url, base) {
+		url = endpointBase(getEndpointForSignature(c)) + strings.TrimPrefix(url, base)
+	}
+	return c.signURL(req.Context(), req, url, body)
+}
@@ -0,0 +1,81 @@
+package ovh
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestSign(t *testing.T) {
+	// Test
+	signature := Sign(MockApplicationSecret, MockConsumerKey, "POST", "https://eu.api.ovh.com/1.0/me?a=b", `{"i_val":42}`, MockTime)
+
+	// Validate
+	h := sha1.New()
+	h.Write([]byte(fmt.Sprintf("%s+%s+POST+https://eu.api.ovh.com/1.0/me?a=b+{\"i_val\":42}+%d", MockApplicationSecret, MockConsumerKey, MockTime)))
+	if expected := fmt.Sprintf("$1$%x", h.Sum(nil)); signature != expected {
+		t.Fatalf("Sign should return %s. Got %s", expected, signature)
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	// Init test
+	ts, client := initSigningServer()
+	defer ts.Close()
+
+	req, err := http.NewRequest("PUT", ts.URL+"/some/resource?a=b", strings.NewReader(`{"i_val":42}`))
+	if err != nil {
+		t.Fatalf("NewRequest should not fail. Got %v", err)
+	}
+
+	// Test
+	if err := client.SignRequest(req); err != nil {
+		t.Fatalf("SignRequest should not return an error. Got %v", err)
+	}
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Sending a signed request should not fail. Got %v", err)
+	}
+	defer response.Body.Close()
+
+	// Validate
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("Signed request should be accepted. Got %d", response.StatusCode)
+	}
+	if body, _ := ioutil.ReadAll(response.Body); string(body) != `{"i_val":42}` {
+		t.Fatalf("Signed request body should be sent. Got %s", body)
+	}
+	if req.Header.Get("X-Ovh-Application") != MockApplicationKey {
+		t.Fatalf("Signed request should have the application key. Got %s", req.Header.Get("X-Ovh-Application"))
+	}
+}
+
+func TestSignRequestOtherURL(t *testing.T) {
+	// Init test
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0)
+	}
+	client, _ := NewClient("https://eu.api.ovh.com/1.0", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.timeDeltaDone = true
+
+	req, _ := http.NewRequest("GET", "https://proxy.example.com/me", nil)
+	req = req.WithContext(context.Background())
+
+	// Test
+	if err := client.SignRequest(req); err != nil {
+		t.Fatalf("SignRequest should not return an error. Got %v", err)
+	}
+
+	// Validate
+	expected := Sign(MockApplicationSecret, MockConsumerKey, "GET", "https://proxy.example.com/me", "", MockTime)
+	ensureHeaderPresent(t, req, "X-Ovh-Signature", expected)
+	ensureHeaderPresent(t, req, "X-Ovh-Consumer", MockConsumerKey)
+	ensureHeaderPresent(t, req, "X-Ovh-Timestamp", fmt.Sprint(MockTime))
+}
@@ -0,0 +1,89 @@
+package ovh
+
+import (
+	"context"
+	"strings"
+)
+
+// SubClient sends requests to paths relative to a prefix, for instance
+// "/dedicated/server/ns12345", sharing the credentials and transport of the
+// Client it was created from
+type SubClient struct {
+	client *Client
+	prefix string
+}
+
+// Sub returns a SubClient joining the paths of its requests to "prefix".
+// The prefix is used as is: path parameters must already be escaped.
+func (c *Client) Sub(prefix string) *SubClient {
+	return &SubClient{client: c, prefix: strings.TrimSuffix(prefix, "/")}
+}
+
+// Sub returns a SubClient joining the paths of its requests to the prefix of
+// s followed by "prefix"
+func (s *SubClient) Sub(prefix string) *SubClient {
+	return s.client.Sub(s.Path(prefix))
+}
+
+// Client returns the Client of s
+func (s *SubClient) Client() *Client {
+	return s.client
+}
+
+// Path returns the prefix of s joined with the relative "path". An empty path
+// is the prefix itself, and a path starting with "?" only adds a query
+// string.
+func (s *SubClient) Path(path string) string {
+	if path == "" || strings.HasPrefix(path, "?") {
+		return s.prefix + path
+	}
+	return s.prefix + "/" + strings.TrimPrefix(path, "/")
+}
+
+// Get is a wrapper for the GET method, see Client.Get
+func (s *SubClient) Get(path string, resType interface{}) error {
+	return s.client.Get(s.Path(path), resType)
+}
+
+// Post is a wrapper for the POST method, see Client.Post
+func (s *SubClient) Post(path string, reqBody, resType interface{}) error {
+	return s.client.Post(s.Path(path), reqBody, resType)
+}
+
+// Put is a wrapper for the PUT method, see Client.Put
+func (s *SubClient) Put(path string, reqBody, resType interface{}) error {
+	return s.client.Put(s.Path(path), reqBody, resType)
+}
+
+// Delete is a wrapper for the DELETE method, see Client.Delete
+func (s *SubClient) Delete(path string, resType interface{}) error {
+	return s.client.Delete(s.Path(path), resType)
+}
+
+// GetWithContext is a wrapper for the GET method, see Client.GetWithContext
+func (s *SubClient) GetWithContext(ctx context.Context, path string, resType interface{}) error {
+	return s.client.GetWithContext(ctx, s.Path(path), resType)
+}
+
+// PostWithContext is a wrapper for the POST method, see
+// Client.PostWithContext
+func (s *SubClient) PostWithContext(ctx context.Context, path string, reqBody, resType interface{}) error {
+	return s.client.PostWithContext(ctx, s.Path(path), reqBody, resType)
+}
+
+// PutWithContext is a wrapper for the PUT method, see Client.PutWithContext
+func (s *SubClient) PutWithContext(ctx context.Context, path string, reqBody, resType interface{}) error {
+	return s.client.PutWithContext(ctx, s.Path(path), reqBody, resType)
+}
+
+// DeleteWithContext is a wrapper for the DELETE method, see
+// Client.DeleteWithContext
+func (s *SubClient) DeleteWithContext(ctx context.Context, path string, resType interface{}) error {
+	return s.client.DeleteWithContext(ctx, s.Path(path), resType)
+}
+
+// CallAPIWithContext calls the API on a relative path, see
+// Client.CallAPIWithContext
+func (s *SubClient) CallAPIWithContext(ctx context.Context, method, path string, reqBody, resType interface{}, needAuth bool) error {
+	return s.client.CallAPIWithContext(ctx, method, s.Path(path), reqBody, resType, needAuth)
+}
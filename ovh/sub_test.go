@@ -0,0 +1,64 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestSubPath(t *testing.T) {
+	// Init test
+	client, _ := NewClient("http://api.example.invalid/1.0", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	sub := client.Sub("/dedicated/server/ns12345/")
+
+	// Validate
+	for path, expected := range map[string]string{
+		"":               "/dedicated/server/ns12345",
+		"/task":          "/dedicated/server/ns12345/task",
+		"task/42":        "/dedicated/server/ns12345/task/42",
+		"?details=true":  "/dedicated/server/ns12345?details=true",
+		"/ips?version=4": "/dedicated/server/ns12345/ips?version=4",
+	} {
+		if got := sub.Path(path); got != expected {
+			t.Fatalf("Path(%q) should be %s. Got %s", path, expected, got)
+		}
+	}
+	if got := sub.Sub("features").Path("ipmi"); got != "/dedicated/server/ns12345/features/ipmi" {
+		t.Fatalf("Nested sub clients should join their prefixes. Got %s", got)
+	}
+	if sub.Client() != client {
+		t.Fatalf("Sub clients should share their client")
+	}
+}
+
+func TestSubRequests(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	var InputRequestBody string
+	ts, client := initMockServer(&InputRequest, 200, `{"taskId": 42}`, &InputRequestBody, time.Duration(0))
+	defer ts.Close()
+	sub := client.Sub("/dedicated/server/ns12345")
+
+	// Test
+	var task struct {
+		TaskID int64 `json:"taskId"`
+	}
+	if err := sub.Get("", nil); err != nil {
+		t.Fatalf("GET should not fail. Got %v", err)
+	}
+	ensureHeaderPresent(t, InputRequest, "X-Ovh-Consumer", MockConsumerKey)
+	if InputRequest.URL.String() != "/dedicated/server/ns12345" {
+		t.Fatalf("GET should be sent to the prefix. Got %s", InputRequest.URL.String())
+	}
+	if err := sub.PostWithContext(context.Background(), "/reboot", nil, &task); err != nil {
+		t.Fatalf("POST should not fail. Got %v", err)
+	}
+
+	// Validate
+	if InputRequest.Method != "POST" || InputRequest.URL.String() != "/dedicated/server/ns12345/reboot" || task.TaskID != 42 {
+		t.Fatalf("POST should be sent to the joined path. Got %s %s", InputRequest.Method, InputRequest.URL.String())
+	}
+}
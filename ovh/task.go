@@ -0,0 +1,151 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Task polling defaults, used when the corresponding WaitTaskOptions field is
+// not set
+const (
+	DefaultTaskPollInterval    = 2 * time.Second
+	DefaultTaskPollMaxInterval = 30 * time.Second
+)
+
+// taskIDPlaceholder is replaced by the task ID in WaitTask path templates
+const taskIDPlaceholder = "{taskId}"
+
+// Final task statuses. Tasks use different status sets depending on the API
+// section, all others are considered pending.
+var (
+	taskDoneStatuses   = []string{"done"}
+	taskFailedStatuses = []string{"error", "cancelled", "canceled", "customerError", "ovhError"}
+)
+
+// WaitTaskOptions configures WaitTask
+type WaitTaskOptions struct {
+	// Interval is the delay before the second poll, doubled after each poll.
+	// DefaultTaskPollInterval is used when zero.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between two polls.
+	// DefaultTaskPollMaxInterval is used when zero.
+	MaxInterval time.Duration
+
+	// Timeout, when set, stops the polling after this delay
+	Timeout time.Duration
+
+	// DoneOnNotFound considers tasks as done when they can not be found
+	// anymore, as some API sections, like /vrack, delete completed tasks.
+	DoneOnNotFound bool
+
+	// OnProgress, when set, is called after each poll with the task status
+	// and the raw response, which may be decoded into a typed task.
+	OnProgress func(status string, response *Response)
+}
+
+// TaskError is returned by WaitTask when a task ends in a failed status
+type TaskError struct {
+	// Path of the task
+	Path string
+	// Final status of the task, like "error" or "cancelled"
+	Status string
+	// Comment of the task, if any
+	Comment string
+}
+
+func (err *TaskError) Error() string {
+	msg := fmt.Sprintf("go-ovh: task %s ended with status %s", err.Path, err.Status)
+	if err.Comment != "" {
+		msg += ": " + err.Comment
+	}
+	return msg
+}
+
+// WaitTask polls a task with GET until it is done, returning its last
+// response. "pathTemplate" is the path of the task with a "{taskId}"
+// placeholder, like "/dedicated/server/ns1.example.net/task/{taskId}". The
+// task ID is appended to the path if it has no placeholder.
+//
+// A TaskError is returned if the task fails or is cancelled, and the context
+// error if the context is done or the timeout expires first.
+func (c *Client) WaitTask(ctx context.Context, pathTemplate string, taskID interface{}, opts *WaitTaskOptions) (*Response, error) {
+	if opts == nil {
+		opts = &WaitTaskOptions{}
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	backoff := RetryConfig{
+		Delay:    opts.Interval,
+		MaxDelay: opts.MaxInterval,
+	}
+	if backoff.Delay <= 0 {
+		backoff.Delay = DefaultTaskPollInterval
+	}
+	if backoff.MaxDelay <= 0 {
+		backoff.MaxDelay = DefaultTaskPollMaxInterval
+	}
+
+	path := taskPath(pathTemplate, taskID)
+	for attempt := 0; ; attempt++ {
+		response, err := c.CallAPIFullWithContext(ctx, "GET", path, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return response, ctx.Err()
+			}
+			if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound && opts.DoneOnNotFound {
+				return response, nil
+			}
+			return response, err
+		}
+
+		var task struct {
+			Status  string `json:"status"`
+			Comment string `json:"comment"`
+		}
+		if err := response.Unmarshal(&task); err != nil {
+			return response, err
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(task.Status, response)
+		}
+
+		if hasStatus(taskDoneStatuses, task.Status) {
+			return response, nil
+		}
+		if hasStatus(taskFailedStatuses, task.Status) {
+			return response, &TaskError{Path: path, Status: task.Status, Comment: task.Comment}
+		}
+
+		select {
+		case <-time.After(backoff.delay(attempt)):
+		case <-ctx.Done():
+			return response, ctx.Err()
+		}
+	}
+}
+
+// taskPath builds the path of a task from a WaitTask template
+func taskPath(pathTemplate string, taskID interface{}) string {
+	id := fmt.Sprint(taskID)
+	if strings.Contains(pathTemplate, taskIDPlaceholder) {
+		return strings.Replace(pathTemplate, taskIDPlaceholder, id, -1)
+	}
+	return strings.TrimRight(pathTemplate, "/") + "/" + id
+}
+
+// hasStatus checks if status is one of statuses
+func hasStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
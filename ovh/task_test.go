@@ -0,0 +1,128 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initTaskServer starts a server returning the given task statuses in turn,
+// followed by 404 errors
+func initTaskServer(t *testing.T, statuses ...string) (*httptest.Server, *Client, *int32) {
+	var polls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dedicated/server/ns1/task/42" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := int(atomic.AddInt32(&polls, 1))
+		if n > len(statuses) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"The requested object (taskId = 42) does not exist"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"taskId":42,"status":"%s","comment":"Task comment"}`, statuses[n-1])
+	}))
+
+	client, err := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	if err != nil {
+		t.Fatalf("NewClient should not fail. Got %v", err)
+	}
+	client.timeDeltaDone = true
+	return ts, client, &polls
+}
+
+func TestWaitTask(t *testing.T) {
+	// Init test
+	ts, client, polls := initTaskServer(t, "init", "doing", "done")
+	defer ts.Close()
+
+	var progress []string
+	opts := &WaitTaskOptions{
+		Interval: time.Millisecond,
+		OnProgress: func(status string, response *Response) {
+			progress = append(progress, status)
+		},
+	}
+
+	// Test
+	response, err := client.WaitTask(context.Background(), "/dedicated/server/ns1/task/{taskId}", 42, opts)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("WaitTask should not fail. Got %v", err)
+	}
+	var task struct {
+		TaskID int64 `json:"taskId"`
+	}
+	if err := response.Unmarshal(&task); err != nil || task.TaskID != 42 {
+		t.Fatalf("WaitTask should return the last task response. Got %d, %v", task.TaskID, err)
+	}
+	if fmt.Sprint(progress) != "[init doing done]" || atomic.LoadInt32(polls) != 3 {
+		t.Fatalf("WaitTask should report each poll. Got %v", progress)
+	}
+}
+
+func TestWaitTaskFailed(t *testing.T) {
+	// Init test
+	ts, client, _ := initTaskServer(t, "todo", "ovhError")
+	defer ts.Close()
+
+	// Test
+	_, err := client.WaitTask(context.Background(), "/dedicated/server/ns1/task", 42, &WaitTaskOptions{Interval: time.Millisecond})
+
+	// Validate
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Status != "ovhError" || taskErr.Comment != "Task comment" {
+		t.Fatalf("WaitTask should return a TaskError. Got %v", err)
+	}
+}
+
+func TestWaitTaskNotFound(t *testing.T) {
+	// Init test: the task is deleted once done
+	ts, client, _ := initTaskServer(t, "todo")
+	defer ts.Close()
+
+	// Test
+	_, err := client.WaitTask(context.Background(), "/dedicated/server/ns1/task/{taskId}", 42, &WaitTaskOptions{Interval: time.Millisecond})
+	if err == nil {
+		t.Fatalf("WaitTask should fail on missing tasks by default")
+	}
+	_, err = client.WaitTask(context.Background(), "/dedicated/server/ns1/task/{taskId}", 42, &WaitTaskOptions{Interval: time.Millisecond, DoneOnNotFound: true})
+	if err != nil {
+		t.Fatalf("WaitTask should consider missing tasks as done. Got %v", err)
+	}
+}
+
+func TestWaitTaskTimeout(t *testing.T) {
+	// Init test: a task never done
+	statuses := make([]string, 1000)
+	for i := range statuses {
+		statuses[i] = "doing"
+	}
+	ts, client, _ := initTaskServer(t, statuses...)
+	defer ts.Close()
+
+	// Test
+	start := time.Now()
+	_, err := client.WaitTask(context.Background(), "/dedicated/server/ns1/task/{taskId}", 42, &WaitTaskOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 5 * time.Millisecond,
+		Timeout:     50 * time.Millisecond,
+	})
+
+	// Validate
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitTask should stop after the timeout. Got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("WaitTask should stop after about 50ms. Got %v", elapsed)
+	}
+}
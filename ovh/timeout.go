@@ -0,0 +1,63 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// requestTimeoutContext is the context key of the per-call timeouts
+type requestTimeoutContext struct{}
+
+// WithRequestTimeout returns a context limiting the calls made with it to
+// "timeout", whatever Client.RequestTimeout. A zero timeout disables the
+// client one, for instance for calls known to be slow.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutContext{}, timeout)
+}
+
+// TimeoutError is returned when a call does not complete within its request
+// timeout, see Client.RequestTimeout. It wraps context.DeadlineExceeded.
+type TimeoutError struct {
+	Method  string
+	Path    string
+	Timeout time.Duration
+}
+
+func (err *TimeoutError) Error() string {
+	return fmt.Sprintf("go-ovh: %s %s did not complete within %s", err.Method, err.Path, err.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded
+func (err *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// requestTimeout returns the timeout of a call, if any
+func (c *Client) requestTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(requestTimeoutContext{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.RequestTimeout
+}
+
+// withRequestTimeout returns the context of a call, limited to its request
+// timeout, and a function reporting the errors caused by the timeout as a
+// TimeoutError. The context must be released with cancel.
+func (c *Client) withRequestTimeout(ctx context.Context, method, path string) (context.Context, context.CancelFunc, func(error) error) {
+	timeout := c.requestTimeout(ctx)
+	if timeout <= 0 {
+		return ctx, func() {}, func(err error) error { return err }
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	wrap := func(err error) error {
+		// Only report the deadlines of the call, not those of the caller
+		if err != nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return &TimeoutError{Method: method, Path: path, Timeout: timeout}
+		}
+		return err
+	}
+	return callCtx, cancel, wrap
+}
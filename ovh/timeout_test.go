@@ -0,0 +1,59 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestRequestTimeout(t *testing.T) {
+	// Init test: a slow API
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, 200*time.Millisecond)
+	defer ts.Close()
+	client.RequestTimeout = 20 * time.Millisecond
+
+	// Test
+	err := client.Get("/some/resource", nil)
+
+	// Validate
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Path != "/some/resource" || timeoutErr.Timeout != client.RequestTimeout {
+		t.Fatalf("Slow calls should fail with a TimeoutError. Got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("TimeoutError should wrap context.DeadlineExceeded. Got %v", err)
+	}
+
+	// Test: per-call timeouts override the client one
+	ctx := WithRequestTimeout(context.Background(), 0)
+	err = client.GetWithContext(ctx, "/some/resource", nil)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Calls without timeout should not fail. Got %v", err)
+	}
+}
+
+func TestRequestTimeoutCallerDeadline(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, 200*time.Millisecond)
+	defer ts.Close()
+	client.RequestTimeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Test
+	err := client.GetWithContext(ctx, "/some/resource", nil)
+
+	// Validate: the caller deadline is not a request timeout
+	var timeoutErr *TimeoutError
+	if err == nil || errors.As(err, &timeoutErr) {
+		t.Fatalf("Caller deadlines should not be reported as TimeoutError. Got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer starts spans around API calls, see Client.Tracer. It is typically
+// implemented on top of OpenTelemetry, the returned context being used for
+// the call: it is available to middlewares, and to the HTTP transport, to
+// propagate the trace to the API.
+type Tracer interface {
+	// StartSpan starts a span named "METHOD /path/template", see
+	// PathTemplate, and returns its context
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// SetAttribute sets a string or int attribute on the span
+	SetAttribute(key string, value interface{})
+	// RecordError records a transport error. API errors are only reported
+	// with the "http.response.status_code" attribute.
+	RecordError(err error)
+	// End ends the span
+	End()
+}
+
+// Span attributes, following OpenTelemetry semantic conventions where they
+// exist
+const (
+	SpanAttributeMethod     = "http.request.method"
+	SpanAttributePath       = "url.path"
+	SpanAttributeEndpoint   = "server.address"
+	SpanAttributeStatusCode = "http.response.status_code"
+	SpanAttributeQueryID    = "ovh.query_id"
+)
+
+// tracedSend wraps sendAttempts in a span covering all the attempts of the
+// call
+func (c *Client) tracedSend(ctx context.Context, method, path string, reqBody interface{}, header http.Header, needAuth bool) (*http.Response, error) {
+	ctx, span := c.Tracer.StartSpan(ctx, method+" "+PathTemplate(path))
+	defer span.End()
+	span.SetAttribute(SpanAttributeMethod, method)
+	span.SetAttribute(SpanAttributePath, path)
+	span.SetAttribute(SpanAttributeEndpoint, c.endpoint)
+
+	response, err := c.sendAttempts(ctx, method, path, reqBody, header, needAuth)
+	if response != nil {
+		span.SetAttribute(SpanAttributeStatusCode, response.StatusCode)
+		if queryID := response.Header.Get("X-Ovh-QueryID"); queryID != "" {
+			span.SetAttribute(SpanAttributeQueryID, queryID)
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	return response, err
+}
@@ -0,0 +1,104 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// Common helpers are in ovh_test.go
+
+type tracingKey struct{}
+
+// mockTracer records the started spans
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+type mockSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (t *mockTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	span := &mockSpan{name: name, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, tracingKey{}, span), span
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *mockSpan) RecordError(err error)                      { s.err = err }
+func (s *mockSpan) End()                                       { s.ended = true }
+
+func TestTracer(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 404, `{"message":"not found"}`, nil, 0)
+	defer ts.Close()
+	tracer := &mockTracer{}
+	client.Tracer = tracer
+	var spanInMiddleware interface{}
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			spanInMiddleware = req.Context().Value(tracingKey{})
+			response, err := next(req)
+			if response != nil {
+				response.Header.Set("X-Ovh-QueryID", "EU.ext-1.42")
+			}
+			return response, err
+		}
+	})
+
+	// Test
+	err := client.Get("/me/bill/FR1234", nil)
+
+	// Validate
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Get should fail with an APIError. Got %v", err)
+	}
+	if len(tracer.spans) != 1 {
+		t.Fatalf("One span should be started. Got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "GET /me/bill/{id}" || !span.ended {
+		t.Fatalf("Span 'GET /me/bill/{id}' should be ended. Got '%s', %v", span.name, span.ended)
+	}
+	if spanInMiddleware != span {
+		t.Fatalf("Span context should be propagated to the request")
+	}
+	for key, expected := range map[string]interface{}{
+		SpanAttributeMethod:     "GET",
+		SpanAttributePath:       "/me/bill/FR1234",
+		SpanAttributeEndpoint:   ts.URL,
+		SpanAttributeStatusCode: 404,
+		SpanAttributeQueryID:    "EU.ext-1.42",
+	} {
+		if span.attributes[key] != expected {
+			t.Fatalf("Span attribute %s should be '%v'. Got '%v'", key, expected, span.attributes[key])
+		}
+	}
+}
+
+func TestTracerTransportError(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `{}`, nil, 0)
+	ts.Close()
+	tracer := &mockTracer{}
+	client.Tracer = tracer
+
+	// Test
+	err := client.GetUnAuth("/auth/details", nil)
+
+	// Validate
+	if err == nil || len(tracer.spans) != 1 || tracer.spans[0].err == nil {
+		t.Fatalf("Transport errors should be recorded on the span. Got %v", err)
+	}
+	if _, ok := tracer.spans[0].attributes[SpanAttributeStatusCode]; ok {
+		t.Fatalf("No status code should be set without response")
+	}
+}
@@ -0,0 +1,112 @@
+package ovh
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections kept to the API
+// by the transports of NewTransport, when TransportConfig.MaxIdleConnsPerHost
+// is not set. http.DefaultTransport only keeps 2 of them, forcing sequential
+// and concurrent calls to open new connections.
+const DefaultMaxIdleConnsPerHost = 16
+
+// defaultTransport is shared by the clients created without an HTTP client,
+// so that they reuse the same connections
+var defaultTransport = NewTransport(TransportConfig{})
+
+// TransportConfig holds the most common settings of the HTTP transport used to
+// reach the API. Zero values keep the defaults of http.DefaultTransport, with
+// DefaultMaxIdleConnsPerHost idle connections per host. HTTP/2 and keep-alives
+// are enabled unless disabled.
+type TransportConfig struct {
+	// Proxy is the URL of the HTTP proxy to use. When nil, the proxy is read
+	// from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy *url.URL
+
+	// TLSClientConfig is the TLS configuration, for instance to add custom
+	// root certificates
+	TLSClientConfig *tls.Config
+
+	// MaxIdleConns is the maximum number of idle connections to keep
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections to keep
+	// per host, DefaultMaxIdleConnsPerHost when zero. Bulk jobs should raise
+	// it to their concurrency.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is the maximum amount of time an idle connection is kept
+	IdleConnTimeout time.Duration
+
+	// TLSHandshakeTimeout is the maximum amount of time waiting for a TLS
+	// handshake
+	TLSHandshakeTimeout time.Duration
+
+	// ResponseHeaderTimeout is the maximum amount of time waiting for the
+	// response headers, once the request is sent
+	ResponseHeaderTimeout time.Duration
+
+	// DialTimeout is the maximum amount of time waiting for a connection to
+	// be established
+	DialTimeout time.Duration
+
+	// DisableHTTP2 restricts the transport to HTTP/1.1
+	DisableHTTP2 bool
+
+	// DisableKeepAlives opens a new connection for each request
+	DisableKeepAlives bool
+}
+
+// NewTransport returns an HTTP transport based on http.DefaultTransport,
+// customized with the given configuration
+func NewTransport(config TransportConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+
+	if config.Proxy != nil {
+		transport.Proxy = http.ProxyURL(config.Proxy)
+	}
+	if config.TLSClientConfig != nil {
+		transport.TLSClientConfig = config.TLSClientConfig
+	}
+	if config.MaxIdleConns != 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout != 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.TLSHandshakeTimeout != 0 {
+		transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	}
+	if config.ResponseHeaderTimeout != 0 {
+		transport.ResponseHeaderTimeout = config.ResponseHeaderTimeout
+	}
+	if config.DialTimeout != 0 {
+		dialer := &net.Dialer{Timeout: config.DialTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	if config.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	transport.DisableKeepAlives = config.DisableKeepAlives
+	return transport
+}
+
+// SetTransport sets the HTTP transport used to send requests, for instance a
+// transport from NewTransport or an instrumented http.RoundTripper. To
+// replace the whole HTTP client, set Client.Client instead.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	if c.Client == nil {
+		c.Client = &http.Client{}
+	}
+	c.Client.Transport = transport
+}
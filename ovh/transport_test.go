@@ -0,0 +1,138 @@
+package ovh
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportProxy(t *testing.T) {
+	// Init test: a proxy answering on behalf of the API
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		fmt.Fprint(w, `"proxied"`)
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	client, _ := NewClient("http://api.example.invalid/1.0", MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	client.SetTransport(NewTransport(TransportConfig{
+		Proxy:               proxyURL,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     time.Minute,
+		DialTimeout:         time.Second,
+	}))
+
+	// Test
+	var res string
+	if err := client.GetUnAuth("/some/resource", &res); err != nil {
+		t.Fatalf("Request through proxy should not fail. Got %v", err)
+	}
+
+	// Validate
+	if proxied != "http://api.example.invalid/1.0/some/resource" || res != "proxied" {
+		t.Fatalf("Request should be sent through the proxy. Got '%s' -> '%s'", proxied, res)
+	}
+	transport := client.Client.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 16 || transport.IdleConnTimeout != time.Minute {
+		t.Fatalf("Transport should be configured. Got %d, %v", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+}
+
+func TestSetTransport(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, 200, `"success"`, nil, time.Duration(0))
+	defer ts.Close()
+
+	calls := 0
+	client.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(req)
+	}))
+
+	// Test
+	if err := client.Get("/some/resource", nil); err != nil {
+		t.Fatalf("Request through custom transport should not fail. Got %v", err)
+	}
+
+	// Validate
+	if calls != 1 {
+		t.Fatalf("Custom transport should be used once. Got %d calls", calls)
+	}
+}
+
+func TestDefaultTransport(t *testing.T) {
+	// Init test: count the connections opened to the server
+	var connections int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `"success"`)
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	client, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+	other, _ := NewClient(ts.URL, MockApplicationKey, MockApplicationSecret, MockConsumerKey)
+
+	// Test
+	for i := 0; i < 10; i++ {
+		if err := client.GetUnAuth("/some/resource", nil); err != nil {
+			t.Fatalf("Request should not fail. Got %v", err)
+		}
+	}
+
+	// Validate
+	if count := atomic.LoadInt32(&connections); count != 1 {
+		t.Fatalf("Sequential calls should reuse their connection. Got %d connections", count)
+	}
+	transport, ok := client.Client.Transport.(*http.Transport)
+	if !ok || transport != other.Client.Transport || client.Client == other.Client {
+		t.Fatalf("Clients should share the default transport, not their HTTP client")
+	}
+	if !transport.ForceAttemptHTTP2 || transport.DisableKeepAlives || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Fatalf("Default transport should enable HTTP/2 and keep-alives. Got %+v", transport)
+	}
+}
+
+func TestWithTransportConfig(t *testing.T) {
+	// Init test
+	client, err := NewClientWithOptions("http://api.example.invalid/1.0",
+		WithAppKey(MockApplicationKey, MockApplicationSecret),
+		WithTransportConfig(TransportConfig{MaxIdleConnsPerHost: 64, IdleConnTimeout: time.Minute, DisableHTTP2: true}),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions should not fail. Got %v", err)
+	}
+
+	// Validate
+	transport := client.Client.Transport.(*http.Transport)
+	if transport == defaultTransport || transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != time.Minute {
+		t.Fatalf("Transport should be configured. Got %d, %v", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Fatalf("HTTP/2 should be disabled")
+	}
+	if defaultTransport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Fatalf("Default transport should not be modified")
+	}
+}
@@ -0,0 +1,121 @@
+package ovh
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError is returned when the API rejects a request with field
+// level details, mapping each invalid field to its message. Fields are
+// read from the "details" or "errors" members of the error, or from a
+// "[field] message" error message.
+//
+// The APIError may be retrieved with errors.As.
+type ValidationError struct {
+	APIError *APIError
+	Fields   map[string]string
+}
+
+func (err *ValidationError) Error() string {
+	names := make([]string, 0, len(err.Fields))
+	for name := range err.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = name + ": " + err.Fields[name]
+	}
+	return fmt.Sprintf("%v, invalid fields: %s", err.APIError, strings.Join(fields, "; "))
+}
+
+// Unwrap returns the APIError
+func (err *ValidationError) Unwrap() error {
+	return err.APIError
+}
+
+// fieldMessagePattern matches the "[field] message" error messages
+var fieldMessagePattern = regexp.MustCompile(`^\[([^\]]+)\]\s*(.+)$`)
+
+// validationError returns a ValidationError if the rejected request body
+// carries field level details, or the APIError itself
+func validationError(apiError *APIError, body []byte) error {
+	if apiError.StatusCode != http.StatusBadRequest && apiError.StatusCode != http.StatusUnprocessableEntity {
+		return apiError
+	}
+
+	fields := map[string]string{}
+	var details struct {
+		Details json.RawMessage `json:"details"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(body, &details) == nil {
+		parseFieldErrors(details.Details, fields)
+		parseFieldErrors(details.Errors, fields)
+	}
+	if len(fields) == 0 {
+		if match := fieldMessagePattern.FindStringSubmatch(apiError.Message); match != nil {
+			fields[match[1]] = match[2]
+		}
+	}
+
+	if len(fields) == 0 {
+		return apiError
+	}
+	return &ValidationError{APIError: apiError, Fields: fields}
+}
+
+// parseFieldErrors reads field errors given either as an object mapping the
+// fields to their messages, or as a list of objects naming a field and its
+// message
+func parseFieldErrors(raw json.RawMessage, fields map[string]string) {
+	if len(raw) == 0 {
+		return
+	}
+
+	var byName map[string]json.RawMessage
+	if json.Unmarshal(raw, &byName) == nil {
+		for name, message := range byName {
+			var text string
+			if json.Unmarshal(message, &text) != nil {
+				text = string(message)
+			}
+			fields[name] = text
+		}
+		return
+	}
+
+	var list []struct {
+		Field   string `json:"field"`
+		Name    string `json:"name"`
+		Path    string `json:"path"`
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+		Reason  string `json:"reason"`
+	}
+	if json.Unmarshal(raw, &list) != nil {
+		return
+	}
+	for _, item := range list {
+		name := firstNonEmpty(item.Field, item.Name, item.Path)
+		if name == "" {
+			continue
+		}
+		fields[name] = firstNonEmpty(item.Message, item.Detail, item.Reason)
+	}
+}
+
+// firstNonEmpty returns the first non empty string
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestValidationError(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body   string
+		fields map[string]string
+	}{
+		"details object": {
+			body:   `{"class":"Client::BadRequest","message":"Invalid parameters","details":{"name":"too long","ttl":"must be positive"}}`,
+			fields: map[string]string{"name": "too long", "ttl": "must be positive"},
+		},
+		"details list": {
+			body:   `{"message":"Invalid parameters","details":[{"field":"name","message":"too long"},{"path":"config.size","reason":"unknown size"}]}`,
+			fields: map[string]string{"name": "too long", "config.size": "unknown size"},
+		},
+		"problem errors": {
+			body:   `{"title":"Bad request","errors":[{"name":"region","detail":"unknown region"}]}`,
+			fields: map[string]string{"region": "unknown region"},
+		},
+		"field message": {
+			body:   `{"class":"Client::BadRequest","message":"[ovhSubsidiary] Given data (XX) does not belong to the OvhSubsidiaryEnum enumeration"}`,
+			fields: map[string]string{"ovhSubsidiary": "Given data (XX) does not belong to the OvhSubsidiaryEnum enumeration"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Init test
+			var InputRequest *http.Request
+			ts, client := initMockServer(&InputRequest, http.StatusBadRequest, tc.body, nil, time.Duration(0))
+			defer ts.Close()
+
+			// Test
+			err := client.Post("/some/resource", nil, nil)
+
+			// Validate
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Field errors should return a ValidationError. Got %T %v", err, err)
+			}
+			if !reflect.DeepEqual(validationErr.Fields, tc.fields) {
+				t.Fatalf("ValidationError should map the fields. Expected %v. Got %v", tc.fields, validationErr.Fields)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+				t.Fatalf("ValidationError should wrap the APIError. Got %v", apiErr)
+			}
+		})
+	}
+}
+
+func TestValidationErrorWithoutFields(t *testing.T) {
+	// Init test
+	var InputRequest *http.Request
+	ts, client := initMockServer(&InputRequest, http.StatusBadRequest, `{"message":"Invalid parameters","details":{}}`, nil, time.Duration(0))
+	defer ts.Close()
+
+	// Test
+	err := client.Post("/some/resource", nil, nil)
+
+	// Validate
+	if _, ok := err.(*APIError); !ok {
+		t.Fatalf("Errors without field details should be returned as APIError. Got %T %v", err, err)
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	// Init test
+	err := &ValidationError{
+		APIError: &APIError{Code: http.StatusBadRequest, Message: "Invalid parameters"},
+		Fields:   map[string]string{"ttl": "must be positive", "name": "too long"},
+	}
+
+	// Validate
+	expected := `Error 400: "Invalid parameters", invalid fields: name: too long; ttl: must be positive`
+	if err.Error() != expected {
+		t.Fatalf("Unexpected error message. Expected %s. Got %s", expected, err.Error())
+	}
+}
@@ -0,0 +1,233 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultVaultAddress is the Vault server used when VAULT_ADDR is not set
+const DefaultVaultAddress = "https://127.0.0.1:8200"
+
+// DefaultVaultTimeout bounds the requests to Vault when
+// VaultProvider.Timeout is not set
+const DefaultVaultTimeout = 10 * time.Second
+
+// vaultRenewThreshold is the remaining TTL below which the Vault token is
+// renewed before reading secrets
+const vaultRenewThreshold = time.Hour
+
+// VaultProvider is a CredentialProvider reading the credentials from a
+// HashiCorp Vault KV secret, version 1 or 2, whose keys are the configuration
+// keys, like "application_secret". It is used when the "vault_path"
+// configuration key or the OVH_VAULT_PATH environment variable is set.
+//
+// The server address, token and namespace are read from the standard
+// VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables, the
+// token falling back on ~/.vault-token.
+type VaultProvider struct {
+	// Address of the Vault server
+	Address string
+
+	// Token used to authenticate to Vault
+	Token string
+
+	// Namespace, for Vault Enterprise
+	Namespace string
+
+	// Path is the API path of the secret, for example "secret/data/ovh" for
+	// the "ovh" secret of a KV version 2 engine mounted on "secret"
+	Path string
+
+	// Client is the HTTP client used to reach Vault, http.DefaultClient when nil
+	Client *http.Client
+
+	// Timeout bounds each request to Vault, DefaultVaultTimeout when zero, so
+	// that an unreachable server does not block the client creation
+	Timeout time.Duration
+
+	// Secret read from Vault, cached after the first credential lookup
+	mutex  sync.Mutex
+	secret map[string]string
+}
+
+// NewVaultProvider returns a VaultProvider for the secret at "path",
+// configured from environment
+func NewVaultProvider(path string) *VaultProvider {
+	provider := &VaultProvider{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Path:      strings.Trim(path, "/"),
+	}
+	if provider.Address == "" {
+		provider.Address = DefaultVaultAddress
+	}
+	if provider.Token == "" {
+		if home, err := currentUserHome(); err == nil {
+			if token, err := ioutil.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				provider.Token = strings.TrimSpace(string(token))
+			}
+		}
+	}
+	return provider
+}
+
+// Credential returns a key of the Vault secret. The secret is read once.
+func (p *VaultProvider) Credential(endpoint, key string) (string, error) {
+	return p.CredentialWithContext(context.Background(), endpoint, key)
+}
+
+// CredentialWithContext returns a key of the Vault secret, reading it with
+// "ctx" if needed. See Credential.
+func (p *VaultProvider) CredentialWithContext(ctx context.Context, endpoint, key string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.secret == nil {
+		secret, err := p.readSecret(ctx)
+		if err != nil {
+			return "", err
+		}
+		p.secret = secret
+	}
+	return p.secret[key], nil
+}
+
+// readSecret renews the token if it is about to expire, then reads the secret
+func (p *VaultProvider) readSecret(ctx context.Context) (map[string]string, error) {
+	if _, err := p.renewTokenIfNeeded(ctx); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := p.call(ctx, "GET", p.Path, &response); err != nil {
+		return nil, err
+	}
+
+	// KV version 2 nests the secret, along with its metadata
+	data := response.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	secret := make(map[string]string, len(data))
+	for key, value := range data {
+		if s, ok := value.(string); ok {
+			secret[key] = s
+		}
+	}
+	return secret, nil
+}
+
+// RenewToken renews the Vault token and returns its new TTL
+func (p *VaultProvider) RenewToken(ctx context.Context) (time.Duration, error) {
+	var response struct {
+		Auth struct {
+			LeaseDuration int64 `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := p.call(ctx, "POST", "auth/token/renew-self", &response); err != nil {
+		return 0, err
+	}
+	return time.Duration(response.Auth.LeaseDuration) * time.Second, nil
+}
+
+// KeepTokenAlive renews the Vault token each time half of its TTL elapsed,
+// until the context is done or the token can not be renewed anymore. It is
+// meant to be run in its own goroutine by long running programs which keep
+// reading credentials from Vault.
+func (p *VaultProvider) KeepTokenAlive(ctx context.Context) error {
+	for {
+		ttl, err := p.renewTokenIfNeeded(ctx)
+		if err != nil {
+			return err
+		}
+		if ttl <= 0 {
+			// Tokens without TTL, like root tokens, never expire
+			return nil
+		}
+		select {
+		case <-time.After(ttl / 2):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if ttl, err = p.RenewToken(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// renewTokenIfNeeded looks the token up and renews it when it is renewable
+// and expires soon. It returns the remaining TTL.
+func (p *VaultProvider) renewTokenIfNeeded(ctx context.Context) (time.Duration, error) {
+	var response struct {
+		Data struct {
+			TTL       int64 `json:"ttl"`
+			Renewable bool  `json:"renewable"`
+		} `json:"data"`
+	}
+	if err := p.call(ctx, "GET", "auth/token/lookup-self", &response); err != nil {
+		return 0, err
+	}
+
+	ttl := time.Duration(response.Data.TTL) * time.Second
+	if response.Data.Renewable && ttl > 0 && ttl < vaultRenewThreshold {
+		return p.RenewToken(ctx)
+	}
+	return ttl, nil
+}
+
+// call sends a request to the Vault API and decodes its response
+func (p *VaultProvider) call(ctx context.Context, method, path string, resType interface{}) error {
+	if p.Token == "" {
+		return fmt.Errorf("missing Vault token, please set VAULT_TOKEN")
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultVaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(method, strings.TrimRight(p.Address, "/")+"/v1/"+path, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		json.NewDecoder(response.Body).Decode(&vaultErr)
+		return fmt.Errorf("go-ovh: Vault %s %s failed with status %d: %s", method, path, response.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+	return json.NewDecoder(response.Body).Decode(resType)
+}
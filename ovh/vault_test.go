@@ -0,0 +1,170 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+// initVaultServer starts a Vault server serving a KV version 2 secret on
+// "secret/data/ovh" and a KV version 1 secret on "kv/ovh", for the "root"
+// token, whose TTL is "ttl" seconds
+func initVaultServer(ttl int, renewals *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors":["permission denied"]}`)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/auth/token/lookup-self":
+			fmt.Fprintf(w, `{"data":{"ttl":%d,"renewable":true}}`, ttl)
+		case "POST /v1/auth/token/renew-self":
+			atomic.AddInt32(renewals, 1)
+			fmt.Fprint(w, `{"auth":{"lease_duration":7200}}`)
+		case "GET /v1/secret/data/ovh":
+			fmt.Fprint(w, `{"data":{"data":{"application_key":"vault-ak","application_secret":"vault-as","consumer_key":"vault-ck"},"metadata":{"version":1}}}`)
+		case "GET /v1/kv/ovh":
+			fmt.Fprint(w, `{"data":{"application_key":"kv1-ak","application_secret":"kv1-as"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[]}`)
+		}
+	}))
+}
+
+func TestVaultConfig(t *testing.T) {
+	// Init test: a short lived token
+	var renewals int32
+	ts := initVaultServer(60, &renewals)
+	defer ts.Close()
+
+	os.Setenv("VAULT_ADDR", ts.URL)
+	os.Setenv("VAULT_TOKEN", "root")
+	os.Setenv("OVH_VAULT_PATH", "secret/data/ovh")
+	defer os.Unsetenv("VAULT_ADDR")
+	defer os.Unsetenv("VAULT_TOKEN")
+	defer os.Unsetenv("OVH_VAULT_PATH")
+
+	// Test
+	client := Client{}
+	err := client.loadConfig("ovh-eu")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("loadConfig failed with: '%v'", err)
+	}
+	if client.AppKey != "vault-ak" || client.AppSecret != "vault-as" || client.ConsumerKey != "vault-ck" {
+		t.Fatalf("Credentials should be loaded from Vault. Got '%s', '%s', '%s'", client.AppKey, client.AppSecret, client.ConsumerKey)
+	}
+	if renewals != 1 {
+		t.Fatalf("Short lived Vault token should be renewed once. Got %d", renewals)
+	}
+}
+
+func TestVaultProviderKV1(t *testing.T) {
+	// Init test: a long lived token
+	var renewals int32
+	ts := initVaultServer(86400, &renewals)
+	defer ts.Close()
+
+	provider := &VaultProvider{Address: ts.URL, Token: "root", Path: "kv/ovh"}
+
+	// Test
+	appKey, err := provider.Credential("ovh-eu", "application_key")
+	if err != nil {
+		t.Fatalf("Vault credential should not fail. Got %v", err)
+	}
+	consumerKey, _ := provider.Credential("ovh-eu", "consumer_key")
+
+	// Validate
+	if appKey != "kv1-ak" || consumerKey != "" {
+		t.Fatalf("KV version 1 secrets should be read. Got '%s', '%s'", appKey, consumerKey)
+	}
+	if renewals != 0 {
+		t.Fatalf("Long lived Vault token should not be renewed. Got %d", renewals)
+	}
+}
+
+func TestVaultProviderErrors(t *testing.T) {
+	// Init test
+	var renewals int32
+	ts := initVaultServer(86400, &renewals)
+	defer ts.Close()
+
+	// Test: invalid token
+	provider := &VaultProvider{Address: ts.URL, Token: "invalid", Path: "kv/ovh"}
+	if _, err := provider.Credential("ovh-eu", "application_key"); err == nil {
+		t.Fatalf("Invalid Vault token should fail")
+	}
+
+	// Test: missing secret
+	provider = &VaultProvider{Address: ts.URL, Token: "root", Path: "kv/missing"}
+	if _, err := provider.Credential("ovh-eu", "application_key"); err == nil {
+		t.Fatalf("Missing Vault secret should fail")
+	}
+
+	// Test: missing token
+	provider = &VaultProvider{Address: ts.URL, Path: "kv/ovh"}
+	if _, err := provider.Credential("ovh-eu", "application_key"); err == nil {
+		t.Fatalf("Missing Vault token should fail")
+	}
+}
+
+func TestVaultProviderTimeout(t *testing.T) {
+	// Init test: an unresponsive Vault server
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	// Test
+	provider := &VaultProvider{Address: ts.URL, Token: "root", Path: "kv/ovh", Timeout: 50 * time.Millisecond}
+	start := time.Now()
+	_, timeoutErr := provider.Credential("ovh-eu", "application_key")
+	elapsed := time.Since(start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	provider = &VaultProvider{Address: ts.URL, Token: "root", Path: "kv/ovh"}
+	_, canceledErr := provider.CredentialWithContext(ctx, "ovh-eu", "application_key")
+
+	// Validate
+	if timeoutErr == nil || elapsed > time.Second {
+		t.Fatalf("Vault requests should time out. Got %v after %s", timeoutErr, elapsed)
+	}
+	if canceledErr == nil {
+		t.Fatalf("Vault requests should stop with their context")
+	}
+}
+
+func TestVaultKeepTokenAlive(t *testing.T) {
+	// Init test
+	var renewals int32
+	ts := initVaultServer(86400, &renewals)
+	defer ts.Close()
+
+	provider := &VaultProvider{Address: ts.URL, Token: "root", Path: "kv/ovh"}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Test
+	err := provider.KeepTokenAlive(ctx)
+
+	// Validate
+	if err != context.DeadlineExceeded {
+		t.Fatalf("KeepTokenAlive should run until the context is done. Got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package ovh
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook signature headers
+const (
+	WebhookSignatureHeader = "X-Ovh-Webhook-Signature"
+	WebhookTimestampHeader = "X-Ovh-Webhook-Timestamp"
+)
+
+// WebhookTolerance is the maximum accepted difference between the webhook
+// timestamp and the local time. It protects against replayed notifications.
+var WebhookTolerance = 5 * time.Minute
+
+// Webhook verification errors
+var (
+	ErrWebhookMissingSignature = errors.New("go-ovh: missing webhook signature or timestamp header")
+	ErrWebhookInvalidSignature = errors.New("go-ovh: invalid webhook signature")
+	ErrWebhookExpired          = errors.New("go-ovh: webhook timestamp is outside of the tolerance window")
+)
+
+// VerifyWebhook checks that a webhook notification was signed with "secret".
+//
+// Unlike API requests, webhook notifications are signed with an HMAC-SHA256
+// over the following fields, joined by '.':
+// - the unix timestamp from the X-Ovh-Webhook-Timestamp header
+// - the raw request body
+//
+// The hexadecimal signature is sent in the X-Ovh-Webhook-Signature header,
+// optionally prefixed by "sha256=". The timestamp must be within
+// WebhookTolerance of the local time.
+func VerifyWebhook(secret string, headers http.Header, body []byte) error {
+	signature := strings.TrimPrefix(headers.Get(WebhookSignatureHeader), "sha256=")
+	timestamp := headers.Get(WebhookTimestampHeader)
+	if signature == "" || timestamp == "" {
+		return ErrWebhookMissingSignature
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrWebhookInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrWebhookInvalidSignature
+	}
+
+	// Only trust the timestamp once the signature is known to be valid
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookInvalidSignature
+	}
+	delta := getLocalTime().Sub(time.Unix(unix, 0))
+	if delta > WebhookTolerance || delta < -WebhookTolerance {
+		return ErrWebhookExpired
+	}
+	return nil
+}
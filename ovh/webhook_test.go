@@ -0,0 +1,59 @@
+package ovh
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Common helpers are in ovh_test.go
+
+func TestVerifyWebhook(t *testing.T) {
+	const (
+		secret    = "webhook-secret"
+		body      = `{"event":"domain.zone.refreshed","zone":"example.com"}`
+		signature = "3e285e128bc94a1ac1b946d25ca1164c2d9fca542fded341d8ecb41a6ece7424"
+	)
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime+10, 0)
+	}
+
+	headers := http.Header{}
+	headers.Set(WebhookTimestampHeader, strconv.Itoa(MockTime))
+	headers.Set(WebhookSignatureHeader, "sha256="+signature)
+
+	// Nominal
+	if err := VerifyWebhook(secret, headers, []byte(body)); err != nil {
+		t.Fatalf("VerifyWebhook should accept a valid signature. Got %v", err)
+	}
+
+	// Nominal: without prefix
+	headers.Set(WebhookSignatureHeader, signature)
+	if err := VerifyWebhook(secret, headers, []byte(body)); err != nil {
+		t.Fatalf("VerifyWebhook should accept a valid signature without prefix. Got %v", err)
+	}
+
+	// Error: wrong secret
+	if err := VerifyWebhook("other-secret", headers, []byte(body)); err != ErrWebhookInvalidSignature {
+		t.Fatalf("VerifyWebhook should reject a wrong secret. Got %v", err)
+	}
+
+	// Error: tampered body
+	if err := VerifyWebhook(secret, headers, []byte(body+" ")); err != ErrWebhookInvalidSignature {
+		t.Fatalf("VerifyWebhook should reject a tampered body. Got %v", err)
+	}
+
+	// Error: expired
+	getLocalTime = func() time.Time {
+		return time.Unix(MockTime, 0).Add(WebhookTolerance + time.Second)
+	}
+	if err := VerifyWebhook(secret, headers, []byte(body)); err != ErrWebhookExpired {
+		t.Fatalf("VerifyWebhook should reject an expired notification. Got %v", err)
+	}
+
+	// Error: missing headers
+	if err := VerifyWebhook(secret, http.Header{}, []byte(body)); err != ErrWebhookMissingSignature {
+		t.Fatalf("VerifyWebhook should reject a notification without signature. Got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+// Package schema provides typed access to the description of the OVH API,
+// served by the API itself: the list of its sections at the root of the
+// endpoint, and the routes, operations and models of each section at
+// /{section}.json. It is meant for code generators and validation tools.
+// Validator checks requests against these schemas before they are sent, for
+// instance as a client middleware:
+//
+//	validator, err := schema.New(client).Validator(ctx, "me", "domain")
+//	...
+//	client.Use(validator.Middleware())
+package schema
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Client gives access to the API schemas
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a schema client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// Index lists the sections of the API
+type Index struct {
+	APIVersion string    `json:"apiVersion"`
+	BasePath   string    `json:"basePath"`
+	APIs       []Section `json:"apis"`
+}
+
+// Section is an entry of the Index
+type Section struct {
+	// Path of the section, for instance "/me"
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	// Schema is the URL of the section schema
+	Schema string   `json:"schema"`
+	Format []string `json:"format"`
+}
+
+// Schema describes the routes and models of a section
+type Schema struct {
+	APIVersion     string           `json:"apiVersion"`
+	SwaggerVersion string           `json:"swaggerVersion"`
+	BasePath       string           `json:"basePath"`
+	ResourcePath   string           `json:"resourcePath"`
+	APIs           []API            `json:"apis"`
+	Models         map[string]Model `json:"models"`
+}
+
+// API is a route of a section
+type API struct {
+	// Path of the route, with its parameters, for instance
+	// "/me/bill/{billId}"
+	Path        string      `json:"path"`
+	Description string      `json:"description"`
+	Operations  []Operation `json:"operations"`
+}
+
+// Operation is a method of a route
+type Operation struct {
+	HTTPMethod       string      `json:"httpMethod"`
+	Description      string      `json:"description"`
+	NoAuthentication bool        `json:"noAuthentication"`
+	ResponseType     string      `json:"responseType"`
+	Parameters       []Parameter `json:"parameters"`
+	APIStatus        APIStatus   `json:"apiStatus"`
+}
+
+// Parameter is a parameter of an operation
+type Parameter struct {
+	Name     string `json:"name"`
+	DataType string `json:"dataType"`
+	FullType string `json:"fullType"`
+	// ParamType is where the parameter is given: "path", "query" or "body"
+	ParamType   string `json:"paramType"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+// APIStatus is the lifecycle status of an operation
+type APIStatus struct {
+	// Value is "PRODUCTION", "BETA", "ALPHA" or "DEPRECATED"
+	Value          string `json:"value"`
+	Description    string `json:"description"`
+	DeprecatedDate string `json:"deprecatedDate,omitempty"`
+	DeletionDate   string `json:"deletionDate,omitempty"`
+	Replacement    string `json:"replacement,omitempty"`
+}
+
+// Model is a type used by operations, either an object with properties or
+// an enumeration
+type Model struct {
+	ID          string              `json:"id"`
+	Namespace   string              `json:"namespace"`
+	Description string              `json:"description"`
+	Properties  map[string]Property `json:"properties,omitempty"`
+	Enum        []string            `json:"enum,omitempty"`
+	EnumType    string              `json:"enumType,omitempty"`
+	Generics    []string            `json:"generics,omitempty"`
+}
+
+// Property is a property of a model
+type Property struct {
+	Type        string `json:"type"`
+	FullType    string `json:"fullType"`
+	CanBeNull   bool   `json:"canBeNull"`
+	ReadOnly    bool   `json:"readOnly"`
+	Description string `json:"description"`
+}
+
+// ListSchemas returns the sections of the API, with GET /
+func (c *Client) ListSchemas(ctx context.Context) (*Index, error) {
+	index := &Index{}
+	if err := c.client.GetUnAuthWithContext(ctx, "/", index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// GetSchema returns the schema of a section, with GET /{name}.json. "name"
+// is the section path, with or without its leading slash, for instance "me"
+// or "/dedicated/server".
+func (c *Client) GetSchema(ctx context.Context, name string) (*Schema, error) {
+	name = strings.TrimSuffix(strings.Trim(name, "/"), ".json")
+	schema := &Schema{}
+	if err := c.client.GetUnAuthWithContext(ctx, "/"+name+".json", schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Operation returns the operation of "method" on the route "path", as
+// written in the schema, if any
+func (s *Schema) Operation(method, path string) (*Operation, bool) {
+	for i := range s.APIs {
+		if s.APIs[i].Path != path {
+			continue
+		}
+		for j := range s.APIs[i].Operations {
+			if strings.EqualFold(s.APIs[i].Operations[j].HTTPMethod, method) {
+				return &s.APIs[i].Operations[j], true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestListSchemas(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/", http.StatusOK, `{
+		"apiVersion": "1.0",
+		"basePath": "https://eu.api.ovh.com/1.0",
+		"apis": [
+			{"path": "/me", "description": "Details about your OVH identifier", "schema": "/me.{format}", "format": ["json", "yaml"]},
+			{"path": "/dedicated/server", "description": "Operations about the HOUSING service", "schema": "/dedicated/server.{format}", "format": ["json"]}
+		]
+	}`)
+
+	// Test
+	index, err := client.ListSchemas(context.Background())
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ListSchemas should not fail. Got %v", err)
+	}
+	if index.APIVersion != "1.0" || len(index.APIs) != 2 || index.APIs[1].Path != "/dedicated/server" {
+		t.Fatalf("Index should be decoded. Got %+v", index)
+	}
+	if requests := server.Requests(); requests[0].Authenticated {
+		t.Fatalf("ListSchemas should not be authenticated")
+	}
+}
+
+func TestGetSchema(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me.json", http.StatusOK, `{
+		"apiVersion": "1.0",
+		"resourcePath": "/me",
+		"apis": [{
+			"path": "/me/bill/{billId}",
+			"description": "Details about a Bill",
+			"operations": [{
+				"httpMethod": "GET",
+				"noAuthentication": false,
+				"responseType": "billing.Bill",
+				"parameters": [{"name": "billId", "dataType": "string", "paramType": "path", "fullType": "string", "required": true}],
+				"apiStatus": {"value": "DEPRECATED", "deprecatedDate": "2024-01-01T00:00:00+01:00", "replacement": "/me/bill/{billId}/details"}
+			}]
+		}],
+		"models": {
+			"billing.Bill": {"id": "Bill", "namespace": "billing", "properties": {"billId": {"type": "string", "fullType": "string", "readOnly": true}}},
+			"billing.CategoryEnum": {"id": "CategoryEnum", "namespace": "billing", "enum": ["autorenew", "purchase"], "enumType": "string"}
+		}
+	}`)
+
+	for _, name := range []string{"me", "/me", "/me.json"} {
+		// Test
+		schema, err := client.GetSchema(context.Background(), name)
+
+		// Validate
+		if err != nil {
+			t.Fatalf("GetSchema(%q) should not fail. Got %v", name, err)
+		}
+		operation, ok := schema.Operation("get", "/me/bill/{billId}")
+		if !ok {
+			t.Fatalf("Operation GET /me/bill/{billId} should be found")
+		}
+		if operation.ResponseType != "billing.Bill" || !operation.Parameters[0].Required || operation.APIStatus.Value != "DEPRECATED" {
+			t.Fatalf("Operation should be decoded. Got %+v", operation)
+		}
+		if !schema.Models["billing.Bill"].Properties["billId"].ReadOnly || len(schema.Models["billing.CategoryEnum"].Enum) != 2 {
+			t.Fatalf("Models should be decoded. Got %+v", schema.Models)
+		}
+	}
+}
+
+func TestGetSchemaNested(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/dedicated/server.json", http.StatusOK, `{"resourcePath": "/dedicated/server"}`)
+
+	// Test
+	schema, err := client.GetSchema(context.Background(), "/dedicated/server/")
+
+	// Validate
+	if err != nil || schema.ResourcePath != "/dedicated/server" {
+		t.Fatalf("GetSchema should fetch nested sections. Got %+v, %v", schema, err)
+	}
+	if _, ok := schema.Operation("GET", "/dedicated/server"); ok {
+		t.Fatalf("Unknown operations should not be found")
+	}
+}
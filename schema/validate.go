@@ -0,0 +1,375 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// ValidationError is returned for requests not matching the schema, before
+// they are sent
+type ValidationError struct {
+	Method string
+	Path   string
+	// Problems found in the request, like "missing required field 'email'"
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("schema: invalid request %s %s: %s", err.Method, err.Path, strings.Join(err.Problems, "; "))
+}
+
+// Validator checks requests against the schemas of some API sections: the
+// route, path and query parameter types, and the body fields, their types
+// and enum values. Requests to other sections are not checked.
+type Validator struct {
+	schemas []*Schema
+}
+
+// NewValidator returns a validator of the requests to the sections of the
+// given schemas
+func NewValidator(schemas ...*Schema) *Validator {
+	return &Validator{schemas: schemas}
+}
+
+// Validator downloads the schemas of "sections", like "me" or
+// "/dedicated/server", and returns a validator of their requests
+func (c *Client) Validator(ctx context.Context, sections ...string) (*Validator, error) {
+	schemas := make([]*Schema, 0, len(sections))
+	for _, section := range sections {
+		schema, err := c.GetSchema(ctx, section)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return NewValidator(schemas...), nil
+}
+
+// Middleware returns an ovh.Middleware validating the requests before they
+// are sent, failing them with a ValidationError. It is meant to be used with
+// ovh.Client.Use.
+func (v *Validator) Middleware() ovh.Middleware {
+	return func(next ovh.Handler) ovh.Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			var body interface{}
+			if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				req.Body.Close()
+				req.Body = ioutil.NopCloser(bytes.NewReader(data))
+				if len(data) > 0 {
+					body = json.RawMessage(data)
+				}
+			}
+			if err := v.Validate(req.Method, v.relativePath(req.URL), body); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// relativePath returns the path of a request URL relative to the endpoint,
+// with its query
+func (v *Validator) relativePath(u *url.URL) string {
+	path := u.Path
+	for _, schema := range v.schemas {
+		base, err := url.Parse(schema.BasePath)
+		if err != nil || base.Path == "" {
+			continue
+		}
+		if strings.HasPrefix(path, base.Path+"/") {
+			path = strings.TrimPrefix(path, base.Path)
+			break
+		}
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path
+}
+
+// Validate checks a request, "path" being relative to the endpoint and
+// possibly including a query string, and "body" the request body, nil
+// if none. It returns a ValidationError if the request does not match the
+// schema of its section, nil if it matches or if no schema describes its
+// section.
+func (v *Validator) Validate(method, path string, body interface{}) error {
+	rawPath, rawQuery := path, ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		rawPath, rawQuery = path[:i], path[i+1:]
+	}
+	segments := strings.Split(strings.Trim(rawPath, "/"), "/")
+
+	schema := v.section(rawPath)
+	if schema == nil {
+		return nil
+	}
+	fail := func(problems ...string) error {
+		return &ValidationError{Method: method, Path: rawPath, Problems: problems}
+	}
+
+	api, values := schema.route(segments)
+	if api == nil {
+		return fail("unknown route")
+	}
+	var operation *Operation
+	for i := range api.Operations {
+		if strings.EqualFold(api.Operations[i].HTTPMethod, method) {
+			operation = &api.Operations[i]
+		}
+	}
+	if operation == nil {
+		return fail(fmt.Sprintf("method not allowed on %s", api.Path))
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fail(fmt.Sprintf("invalid query: %v", err))
+	}
+	fields, err := decodeBody(body)
+	if err != nil {
+		return fail(err.Error())
+	}
+	var problems []string
+
+	for _, param := range operation.Parameters {
+		switch param.ParamType {
+		case "path":
+			problems = append(problems, schema.checkString(param, values[param.Name], "path parameter")...)
+		case "query":
+			value, ok := query[param.Name]
+			if !ok {
+				if param.Required {
+					problems = append(problems, fmt.Sprintf("missing required query parameter '%s'", param.Name))
+				}
+				continue
+			}
+			problems = append(problems, schema.checkString(param, value[0], "query parameter")...)
+		case "body":
+			if param.Name == "" {
+				// The body is a whole model
+				if fields != nil {
+					problems = append(problems, schema.checkValue(parameterType(param), fields, "body")...)
+				}
+				fields = nil
+				continue
+			}
+			value, ok := fields[param.Name]
+			if !ok {
+				if param.Required {
+					problems = append(problems, fmt.Sprintf("missing required field '%s'", param.Name))
+				}
+				continue
+			}
+			delete(fields, param.Name)
+			problems = append(problems, schema.checkValue(parameterType(param), value, fmt.Sprintf("field '%s'", param.Name))...)
+		}
+	}
+
+	// Remaining fields are typos or unsupported ones
+	unknown := make([]string, 0, len(fields))
+	for name := range fields {
+		unknown = append(unknown, name)
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		problems = append(problems, fmt.Sprintf("unknown field '%s'", name))
+	}
+
+	if len(problems) > 0 {
+		return fail(problems...)
+	}
+	return nil
+}
+
+// section returns the schema describing the section of "path", if any. The
+// most specific section is used when they are nested.
+func (v *Validator) section(path string) *Schema {
+	var found *Schema
+	for _, schema := range v.schemas {
+		prefix := strings.TrimRight(schema.ResourcePath, "/")
+		if prefix == "" || (path != prefix && !strings.HasPrefix(path, prefix+"/")) {
+			continue
+		}
+		if found == nil || len(prefix) > len(found.ResourcePath) {
+			found = schema
+		}
+	}
+	return found
+}
+
+// route returns the route of the schema matching the path segments, with the
+// values of its placeholders. Routes with the most literal segments win, so
+// that "/me/contact/default" is preferred to "/me/contact/{contactId}".
+func (s *Schema) route(segments []string) (*API, map[string]string) {
+	var found *API
+	var foundValues map[string]string
+	best := -1
+	for i := range s.APIs {
+		route := strings.Split(strings.Trim(s.APIs[i].Path, "/"), "/")
+		if len(route) != len(segments) {
+			continue
+		}
+		literals, values := 0, map[string]string{}
+		for j, part := range route {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				value, err := url.PathUnescape(segments[j])
+				if err != nil || value == "" {
+					literals = -1
+					break
+				}
+				values[part[1:len(part)-1]] = value
+				continue
+			}
+			if part != segments[j] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > best {
+			found, foundValues, best = &s.APIs[i], values, literals
+		}
+	}
+	return found, foundValues
+}
+
+// decodeBody returns the fields of a JSON object body
+func decodeBody(body interface{}) (map[string]interface{}, error) {
+	if body == nil {
+		return map[string]interface{}{}, nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body: %v", err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.New("body is not a JSON object")
+	}
+	return fields, nil
+}
+
+// parameterType returns the type of a parameter
+func parameterType(param Parameter) string {
+	if param.FullType != "" {
+		return param.FullType
+	}
+	return param.DataType
+}
+
+// checkString checks a path or query parameter value
+func (s *Schema) checkString(param Parameter, value, kind string) []string {
+	name := fmt.Sprintf("%s '%s'", kind, param.Name)
+	fullType := parameterType(param)
+	switch fullType {
+	case "long", "int", "double", "float":
+		var number float64
+		if err := json.Unmarshal([]byte(value), &number); err != nil {
+			return []string{fmt.Sprintf("%s should be a %s. Got '%s'", name, fullType, value)}
+		}
+		return s.checkValue(fullType, number, name)
+	case "boolean":
+		if value != "true" && value != "false" {
+			return []string{fmt.Sprintf("%s should be a boolean. Got '%s'", name, value)}
+		}
+		return nil
+	}
+	return s.checkValue(fullType, value, name)
+}
+
+// checkValue checks a decoded JSON value against a schema type. Unknown types
+// are not checked.
+func (s *Schema) checkValue(fullType string, value interface{}, name string) []string {
+	if value == nil {
+		return nil
+	}
+	if strings.HasSuffix(fullType, "[]") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s should be a list", name)}
+		}
+		var problems []string
+		for i, item := range items {
+			problems = append(problems, s.checkValue(strings.TrimSuffix(fullType, "[]"), item, fmt.Sprintf("%s[%d]", name, i))...)
+		}
+		return problems
+	}
+
+	switch fullType {
+	case "long", "int":
+		if number, ok := value.(float64); !ok || number != math.Trunc(number) {
+			return []string{fmt.Sprintf("%s should be an integer. Got %v", name, value)}
+		}
+		return nil
+	case "double", "float":
+		if _, ok := value.(float64); !ok {
+			return []string{fmt.Sprintf("%s should be a number. Got %v", name, value)}
+		}
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{fmt.Sprintf("%s should be a boolean. Got %v", name, value)}
+		}
+		return nil
+	case "string", "password", "text", "date", "datetime", "time", "uuid", "ip", "ipv4", "ipv6", "ipBlock", "ipv4Block", "ipv6Block", "phoneNumber", "internationalPhoneNumber":
+		if _, ok := value.(string); !ok {
+			return []string{fmt.Sprintf("%s should be a string. Got %v", name, value)}
+		}
+		return nil
+	}
+
+	model, ok := s.Models[fullType]
+	if !ok {
+		return nil
+	}
+	if len(model.Enum) > 0 {
+		for _, allowed := range model.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s should be one of %s. Got %v", name, strings.Join(model.Enum, ", "), value)}
+	}
+	if model.Properties == nil {
+		return nil
+	}
+
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s should be an object", name)}
+	}
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	var problems []string
+	for _, field := range names {
+		property, ok := model.Properties[field]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown field '%s' in %s", field, name))
+			continue
+		}
+		propertyType := property.FullType
+		if propertyType == "" {
+			propertyType = property.Type
+		}
+		problems = append(problems, s.checkValue(propertyType, fields[field], fmt.Sprintf("%s.%s", name, field))...)
+	}
+	return problems
+}
@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// contactSchema describes a few /me routes
+const contactSchema = `{
+	"resourcePath": "/me",
+	"basePath": "https://eu.api.ovh.com/1.0",
+	"apis": [{
+		"path": "/me/contact",
+		"operations": [{
+			"httpMethod": "POST",
+			"parameters": [
+				{"name": "email", "dataType": "string", "paramType": "body", "fullType": "string", "required": true},
+				{"name": "language", "dataType": "nichandle.LanguageEnum", "paramType": "body", "fullType": "nichandle.LanguageEnum", "required": false},
+				{"name": "address", "dataType": "contact.Address", "paramType": "body", "fullType": "contact.Address", "required": false},
+				{"name": "phones", "dataType": "string[]", "paramType": "body", "fullType": "string[]", "required": false}
+			]
+		}, {
+			"httpMethod": "GET",
+			"parameters": [{"name": "limit", "dataType": "long", "paramType": "query", "fullType": "long", "required": false}]
+		}]
+	}, {
+		"path": "/me/contact/{contactId}",
+		"operations": [{
+			"httpMethod": "PUT",
+			"parameters": [
+				{"name": "contactId", "dataType": "long", "paramType": "path", "fullType": "long", "required": true},
+				{"name": "", "dataType": "contact.Contact", "paramType": "body", "fullType": "contact.Contact", "required": true}
+			]
+		}]
+	}, {
+		"path": "/me/contact/default",
+		"operations": [{"httpMethod": "GET", "parameters": []}]
+	}],
+	"models": {
+		"nichandle.LanguageEnum": {"id": "LanguageEnum", "namespace": "nichandle", "enum": ["en_GB", "fr_FR"], "enumType": "string"},
+		"contact.Address": {"id": "Address", "namespace": "contact", "properties": {
+			"city": {"type": "string", "fullType": "string"},
+			"zip": {"type": "string", "fullType": "string"}
+		}},
+		"contact.Contact": {"id": "Contact", "namespace": "contact", "properties": {
+			"email": {"type": "string", "fullType": "string"},
+			"id": {"type": "long", "fullType": "long", "readOnly": true}
+		}}
+	}
+}`
+
+func initValidator(t *testing.T) *Validator {
+	schema := &Schema{}
+	if err := json.Unmarshal([]byte(contactSchema), schema); err != nil {
+		t.Fatalf("Schema should be decoded. Got %v", err)
+	}
+	return NewValidator(schema)
+}
+
+func TestValidate(t *testing.T) {
+	// Init test
+	validator := initValidator(t)
+
+	for _, tc := range []struct {
+		method, path string
+		body         interface{}
+		problems     string
+	}{
+		{"POST", "/me/contact", map[string]interface{}{"email": "a@example.com", "language": "fr_FR", "phones": []string{"+33.1"}}, ""},
+		{"POST", "/me/contact", map[string]interface{}{"emial": "a@example.com"}, "missing required field 'email'; unknown field 'emial'"},
+		{"POST", "/me/contact", map[string]interface{}{"email": 42, "language": "fr"}, "field 'email' should be a string. Got 42; field 'language' should be one of en_GB, fr_FR. Got fr"},
+		{"POST", "/me/contact", map[string]interface{}{"email": "a@example.com", "address": map[string]string{"zipcode": "75000"}, "phones": "+33.1"}, "unknown field 'zipcode' in field 'address'; field 'phones' should be a list"},
+		{"POST", "/me/contact", json.RawMessage(`["a@example.com"]`), "body is not a JSON object"},
+		{"GET", "/me/contact?limit=10", nil, ""},
+		{"GET", "/me/contact?limit=ten", nil, "query parameter 'limit' should be a long. Got 'ten'"},
+		{"GET", "/me/contact/default", nil, ""},
+		{"PUT", "/me/contact/42", map[string]interface{}{"email": "b@example.com"}, ""},
+		{"PUT", "/me/contact/abc", map[string]interface{}{"mail": "b@example.com"}, "path parameter 'contactId' should be a long. Got 'abc'; unknown field 'mail' in body"},
+		{"DELETE", "/me/contact", nil, "method not allowed on /me/contact"},
+		{"GET", "/me/contcat", nil, "unknown route"},
+		{"GET", "/domain/zone", nil, ""},
+	} {
+		// Test
+		err := validator.Validate(tc.method, tc.path, tc.body)
+
+		// Validate
+		if tc.problems == "" {
+			if err != nil {
+				t.Errorf("%s %s should be valid. Got %v", tc.method, tc.path, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s %s should return a ValidationError. Got %v", tc.method, tc.path, err)
+			continue
+		}
+		if problems := strings.Join(validationErr.Problems, "; "); problems != tc.problems {
+			t.Errorf("%s %s should report '%s'. Got '%s'", tc.method, tc.path, tc.problems, problems)
+		}
+	}
+}
+
+func TestValidatorMiddleware(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/me.json", http.StatusOK, contactSchema)
+	server.Handle("POST", "/me/contact", http.StatusOK, `{"id": 42}`)
+	ctx := context.Background()
+
+	validator, err := client.Validator(ctx, "me")
+	if err != nil {
+		t.Fatalf("Validator should not fail. Got %v", err)
+	}
+	client.client.Use(validator.Middleware())
+
+	// Test
+	invalid := client.client.PostWithContext(ctx, "/me/contact", map[string]string{"mail": "a@example.com"}, nil)
+	valid := client.client.PostWithContext(ctx, "/me/contact", map[string]string{"email": "a@example.com"}, nil)
+
+	// Validate
+	var validationErr *ValidationError
+	if !errors.As(invalid, &validationErr) || validationErr.Path != "/me/contact" {
+		t.Fatalf("Invalid requests should fail with a ValidationError. Got %v", invalid)
+	}
+	if expected := "schema: invalid request POST /me/contact: missing required field 'email'; unknown field 'mail'"; invalid.Error() != expected {
+		t.Fatalf("ValidationError should describe the problems. Got %s", invalid.Error())
+	}
+	if valid != nil {
+		t.Fatalf("Valid requests should be sent. Got %v", valid)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 || string(requests[1].Body) != `{"email":"a@example.com"}` {
+		t.Fatalf("Only the valid request should be sent, with its body. Got %d requests", len(requests))
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LegacyService represents a service of the legacy /service routes.
+// Visit https://api.ovh.com/console/#/service/%7BserviceId%7D#GET for the full definition
+type LegacyService struct {
+	ServiceID      int64      `json:"serviceId"`
+	State          string     `json:"state"`
+	CreationDate   *time.Time `json:"creationDate"`
+	ExpirationDate *time.Time `json:"expirationDate"`
+	Route          Route      `json:"route"`
+	Resource       Resource   `json:"resource"`
+}
+
+// ServiceInfos represents the renewal details of a service, read from the
+// serviceInfos route of its product.
+// Visit https://api.ovh.com/console/#/dedicated/server/%7BserviceName%7D/serviceInfos#GET for the full definition
+type ServiceInfos struct {
+	ServiceID    int64        `json:"serviceId"`
+	Domain       string       `json:"domain"`
+	Status       string       `json:"status"`
+	Creation     string       `json:"creation"`
+	Expiration   string       `json:"expiration"`
+	RenewalType  string       `json:"renewalType"`
+	Renew        *RenewPolicy `json:"renew"`
+	ContactAdmin string       `json:"contactAdmin"`
+	ContactBill  string       `json:"contactBilling"`
+	ContactTech  string       `json:"contactTech"`
+}
+
+// RenewPolicy is the renewal policy of a service, with the serviceInfos
+// routes
+type RenewPolicy struct {
+	Automatic          bool `json:"automatic"`
+	DeleteAtExpiration bool `json:"deleteAtExpiration"`
+	Forced             bool `json:"forced"`
+	ManualPayment      bool `json:"manualPayment,omitempty"`
+	// Period is the renewal period in months, if any
+	Period *int `json:"period,omitempty"`
+}
+
+// LegacyServices lists the IDs of the services, with GET /service
+func (c *Client) LegacyServices(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, "/service", &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// LegacyService returns a service, with GET /service/{serviceId}
+func (c *Client) LegacyService(ctx context.Context, serviceID int64) (*LegacyService, error) {
+	service := &LegacyService{}
+	if err := c.client.GetWithContext(ctx, fmt.Sprintf("/service/%d", serviceID), service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// ServiceInfos returns the renewal details of a service, with
+// GET {path}/serviceInfos. "path" is the URL of the service, like
+// "/dedicated/server/ns1.example.net", see Route.URL.
+func (c *Client) ServiceInfos(ctx context.Context, path string) (*ServiceInfos, error) {
+	infos := &ServiceInfos{}
+	if err := c.client.GetWithContext(ctx, serviceInfosPath(path, "/serviceInfos"), infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// SetRenewPolicy sets the renewal policy of a service, with
+// PUT {path}/serviceInfos
+func (c *Client) SetRenewPolicy(ctx context.Context, path string, renew RenewPolicy) error {
+	body := map[string]interface{}{"renew": renew}
+	return c.client.PutWithContext(ctx, serviceInfosPath(path, "/serviceInfos"), body, nil)
+}
+
+// TerminateService asks for the termination of a service, with
+// POST {path}/terminate. The termination must be confirmed with the token
+// sent by email, see ConfirmServiceTermination.
+func (c *Client) TerminateService(ctx context.Context, path string) (string, error) {
+	var message string
+	if err := c.client.PostWithContext(ctx, serviceInfosPath(path, "/terminate"), nil, &message); err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// ConfirmServiceTermination confirms the termination of a service with the
+// token sent by email, with POST {path}/confirmTermination. "reason", like
+// "noLongerNeeded", and "commentary" are optional.
+func (c *Client) ConfirmServiceTermination(ctx context.Context, path, token, reason, commentary string) (string, error) {
+	body := map[string]string{"token": token}
+	if reason != "" {
+		body["reason"] = reason
+	}
+	if commentary != "" {
+		body["commentary"] = commentary
+	}
+	var message string
+	if err := c.client.PostWithContext(ctx, serviceInfosPath(path, "/confirmTermination"), body, &message); err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// serviceInfosPath returns the path of a route of a service URL
+func serviceInfosPath(path, route string) string {
+	return "/" + strings.Trim(path, "/") + route
+}
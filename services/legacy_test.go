@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLegacyService(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/service", http.StatusOK, `[42]`)
+	server.Handle("GET", "/service/42", http.StatusOK, `{"serviceId": 42, "state": "ok", "route": {"url": "/domain/example.com"}}`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.LegacyServices(ctx)
+	if err != nil {
+		t.Fatalf("LegacyServices should not return an error. Got %v", err)
+	}
+	service, err := client.LegacyService(ctx, ids[0])
+
+	// Validate
+	if err != nil {
+		t.Fatalf("LegacyService should not return an error. Got %v", err)
+	}
+	if service.ServiceID != 42 || service.Route.URL != "/domain/example.com" {
+		t.Fatalf("LegacyService should be decoded. Got %+v", service)
+	}
+}
+
+func TestServiceInfos(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/domain/example.com/serviceInfos", http.StatusOK, `{"serviceId": 42, "domain": "example.com", "expiration": "2025-01-01", "renew": {"automatic": true, "period": 12}}`)
+	server.Handle("PUT", "/domain/example.com/serviceInfos", http.StatusOK, `null`)
+	ctx := context.Background()
+
+	// Test
+	infos, err := client.ServiceInfos(ctx, "domain/example.com/")
+	if err != nil {
+		t.Fatalf("ServiceInfos should not return an error. Got %v", err)
+	}
+	infos.Renew.Automatic = false
+	infos.Renew.DeleteAtExpiration = true
+	err = client.SetRenewPolicy(ctx, "/domain/example.com", *infos.Renew)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("SetRenewPolicy should not return an error. Got %v", err)
+	}
+	if infos.Expiration != "2025-01-01" || *infos.Renew.Period != 12 {
+		t.Fatalf("ServiceInfos should be decoded. Got %+v", infos)
+	}
+	expected := `{"renew":{"automatic":false,"deleteAtExpiration":true,"forced":false,"period":12}}`
+	if body := string(server.Requests()[1].Body); body != expected {
+		t.Fatalf("SetRenewPolicy should send the policy. Got %s", body)
+	}
+}
+
+func TestTerminateService(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/domain/example.com/terminate", http.StatusOK, `"confirmation sent"`)
+	server.Handle("POST", "/domain/example.com/confirmTermination", http.StatusOK, `"terminated"`)
+	ctx := context.Background()
+
+	// Test
+	message, err := client.TerminateService(ctx, "/domain/example.com")
+	if err != nil {
+		t.Fatalf("TerminateService should not return an error. Got %v", err)
+	}
+	confirmed, err := client.ConfirmServiceTermination(ctx, "/domain/example.com", "TOKEN", "noLongerNeeded", "")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ConfirmServiceTermination should not return an error. Got %v", err)
+	}
+	if message != "confirmation sent" || confirmed != "terminated" {
+		t.Fatalf("Termination messages should be decoded. Got %s, %s", message, confirmed)
+	}
+	if body := string(server.Requests()[1].Body); body != `{"reason":"noLongerNeeded","token":"TOKEN"}` {
+		t.Fatalf("ConfirmServiceTermination should send the token and reason. Got %s", body)
+	}
+}
@@ -0,0 +1,195 @@
+// Package services provides typed helpers for the lifecycle of OVH
+// services: their renewal and termination, with the /services routes, and
+// the legacy /service and {path}/serviceInfos routes still used by some
+// products.
+//
+// The /services routes identify services by ID, ServiceID resolves the ID
+// of a service from its name:
+//
+//	id, err := services.New(client).ServiceID(ctx, "ns1.example.net")
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Renew modes
+const (
+	RenewModeAutomatic = "automatic"
+	RenewModeManual    = "manual"
+)
+
+// Service represents a service.
+// Visit https://api.ovh.com/console/#/services/%7BserviceId%7D#GET for the full definition
+type Service struct {
+	ServiceID int64    `json:"serviceId"`
+	Route     Route    `json:"route"`
+	Billing   Billing  `json:"billing"`
+	Resource  Resource `json:"resource"`
+}
+
+// Route locates a service in the API
+type Route struct {
+	// Path of the service routes, like "/dedicated/server/{serviceName}"
+	Path string `json:"path"`
+	// URL of the service, like "/dedicated/server/ns1.example.net"
+	URL  string     `json:"url"`
+	Vars []RouteVar `json:"vars"`
+}
+
+// RouteVar is a variable of a Route path
+type RouteVar struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Billing holds the billing details of a service
+type Billing struct {
+	Plan            Plan       `json:"plan"`
+	NextBillingDate *time.Time `json:"nextBillingDate"`
+	ExpirationDate  *time.Time `json:"expirationDate"`
+	Renew           *Renew     `json:"renew"`
+	Lifecycle       Lifecycle  `json:"lifecycle"`
+}
+
+// Plan is the commercial plan of a service
+type Plan struct {
+	Code        string `json:"code"`
+	InvoiceName string `json:"invoiceName"`
+}
+
+// Renew holds the renewal details of a service
+type Renew struct {
+	Current struct {
+		Mode     string     `json:"mode"`
+		Period   string     `json:"period"`
+		NextDate *time.Time `json:"nextDate"`
+	} `json:"current"`
+	Capacities struct {
+		Mode []string `json:"mode"`
+	} `json:"capacities"`
+}
+
+// Lifecycle holds the state of a service
+type Lifecycle struct {
+	Current struct {
+		State           string     `json:"state"`
+		CreationDate    *time.Time `json:"creationDate"`
+		TerminationDate *time.Time `json:"terminationDate"`
+		PendingActions  []string   `json:"pendingActions"`
+	} `json:"current"`
+	Capacities struct {
+		Actions []string `json:"actions"`
+	} `json:"capacities"`
+}
+
+// Resource is the resource delivered by a service
+type Resource struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	State       string `json:"state"`
+	Product     struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"product"`
+}
+
+// Termination is the answer to a termination request
+type Termination struct {
+	Message         string     `json:"message"`
+	TerminationDate *time.Time `json:"terminationDate"`
+}
+
+// Client gives access to the service routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a services client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// servicePath returns the path of a route of a service
+func servicePath(serviceID int64, format string, args ...interface{}) string {
+	return fmt.Sprintf("/services/%d", serviceID) + fmt.Sprintf(format, args...)
+}
+
+// Services lists the IDs of the services, only those of the resource
+// "resourceName" when not empty, with GET /services
+func (c *Client) Services(ctx context.Context, resourceName string) ([]int64, error) {
+	path := "/services"
+	if resourceName != "" {
+		path += "?" + url.Values{"resourceName": []string{resourceName}}.Encode()
+	}
+
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Service returns a service, with GET /services/{serviceId}
+func (c *Client) Service(ctx context.Context, serviceID int64) (*Service, error) {
+	service := &Service{}
+	if err := c.client.GetWithContext(ctx, servicePath(serviceID, ""), service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// ServiceID returns the ID of the service named "serviceName", like
+// "ns1.example.net", with GET /services?resourceName={serviceName}
+func (c *Client) ServiceID(ctx context.Context, serviceName string) (int64, error) {
+	ids, err := c.Services(ctx, serviceName)
+	if err != nil {
+		return 0, err
+	}
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("services: no service named %s", serviceName)
+	case 1:
+		return ids[0], nil
+	}
+	return 0, fmt.Errorf("services: %d services are named %s", len(ids), serviceName)
+}
+
+// SetRenewMode sets the renewal mode of a service, like RenewModeAutomatic,
+// and its period, like "P1M", kept as is when empty, with
+// PUT /services/{serviceId}
+func (c *Client) SetRenewMode(ctx context.Context, serviceID int64, mode, period string) error {
+	renew := map[string]string{"mode": mode}
+	if period != "" {
+		renew["period"] = period
+	}
+	body := map[string]interface{}{"renew": renew}
+	return c.client.PutWithContext(ctx, servicePath(serviceID, ""), body, nil)
+}
+
+// Terminate asks for the termination of a service, with
+// POST /services/{serviceId}/terminate. The termination must be confirmed
+// with the token sent by email, see ConfirmTermination.
+func (c *Client) Terminate(ctx context.Context, serviceID int64) (*Termination, error) {
+	termination := &Termination{}
+	if err := c.client.PostWithContext(ctx, servicePath(serviceID, "/terminate"), nil, termination); err != nil {
+		return nil, err
+	}
+	return termination, nil
+}
+
+// ConfirmTermination confirms the termination of a service with the token
+// sent by email, with POST /services/{serviceId}/terminate/confirm
+func (c *Client) ConfirmTermination(ctx context.Context, serviceID int64, token string) (*Termination, error) {
+	body := map[string]string{"token": token}
+	termination := &Termination{}
+	if err := c.client.PostWithContext(ctx, servicePath(serviceID, "/terminate/confirm"), body, termination); err != nil {
+		return nil, err
+	}
+	return termination, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestService(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/services/42", http.StatusOK, `{"serviceId": 42, "route": {"path": "/dedicated/server/{serviceName}", "url": "/dedicated/server/ns1.example.net"}, "billing": {"expirationDate": "2025-01-01T00:00:00+01:00", "renew": {"current": {"mode": "automatic", "period": "P1M"}, "capacities": {"mode": ["automatic", "manual"]}}, "lifecycle": {"current": {"state": "active"}}}, "resource": {"name": "ns1.example.net", "state": "ok"}}`)
+
+	// Test
+	service, err := client.Service(context.Background(), 42)
+
+	// Validate
+	if err != nil {
+		t.Fatalf("Service should not return an error. Got %v", err)
+	}
+	if service.Route.URL != "/dedicated/server/ns1.example.net" || service.Billing.ExpirationDate == nil {
+		t.Fatalf("Service should be decoded. Got %+v", service)
+	}
+	if service.Billing.Renew.Current.Mode != RenewModeAutomatic || service.Billing.Lifecycle.Current.State != "active" {
+		t.Fatalf("Service billing should be decoded. Got %+v", service.Billing)
+	}
+}
+
+func TestServiceID(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/services", http.StatusOK, `[42]`)
+	ctx := context.Background()
+
+	// Test
+	id, err := client.ServiceID(ctx, "ns1.example.net")
+
+	// Validate
+	if err != nil || id != 42 {
+		t.Fatalf("ServiceID should resolve the service. Got %d, %v", id, err)
+	}
+	if path := server.Requests()[0].Path; path != "/services?resourceName=ns1.example.net" {
+		t.Fatalf("ServiceID should filter on the resource name. Got %s", path)
+	}
+
+	// Test: unknown and ambiguous names
+	server.Handle("GET", "/services", http.StatusOK, `[]`)
+	if _, err := client.ServiceID(ctx, "unknown"); err == nil || !strings.Contains(err.Error(), "no service named unknown") {
+		t.Fatalf("ServiceID should fail on unknown services. Got %v", err)
+	}
+	server.Handle("GET", "/services", http.StatusOK, `[1, 2]`)
+	if _, err := client.ServiceID(ctx, "twice"); err == nil {
+		t.Fatalf("ServiceID should fail on ambiguous names")
+	}
+}
+
+func TestSetRenewMode(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("PUT", "/services/42", http.StatusOK, `null`)
+
+	// Test
+	err := client.SetRenewMode(context.Background(), 42, RenewModeManual, "")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("SetRenewMode should not return an error. Got %v", err)
+	}
+	if body := string(server.Requests()[0].Body); body != `{"renew":{"mode":"manual"}}` {
+		t.Fatalf("SetRenewMode should send the mode. Got %s", body)
+	}
+}
+
+func TestTerminate(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/services/42/terminate", http.StatusOK, `{"message": "confirmation sent"}`)
+	server.Handle("POST", "/services/42/terminate/confirm", http.StatusOK, `{"message": "terminated", "terminationDate": "2025-01-01T00:00:00+01:00"}`)
+	ctx := context.Background()
+
+	// Test
+	termination, err := client.Terminate(ctx, 42)
+	if err != nil {
+		t.Fatalf("Terminate should not return an error. Got %v", err)
+	}
+	confirmed, err := client.ConfirmTermination(ctx, 42, "TOKEN")
+
+	// Validate
+	if err != nil {
+		t.Fatalf("ConfirmTermination should not return an error. Got %v", err)
+	}
+	if termination.Message != "confirmation sent" || confirmed.TerminationDate == nil {
+		t.Fatalf("Terminations should be decoded. Got %+v, %+v", termination, confirmed)
+	}
+	if body := string(server.Requests()[1].Body); body != `{"token":"TOKEN"}` {
+		t.Fatalf("ConfirmTermination should send the token. Got %s", body)
+	}
+}
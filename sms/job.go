@@ -0,0 +1,154 @@
+package sms
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Job holds an SMS to send. Receivers are phone numbers in international
+// format, like "+33601020304". Sender is a sender of the account, see
+// Senders; the shortcode of the account is used when it is empty and
+// SenderForResponse is set.
+type Job struct {
+	Message           string   `json:"message"`
+	Receivers         []string `json:"receivers"`
+	Sender            string   `json:"sender,omitempty"`
+	SenderForResponse bool     `json:"senderForResponse,omitempty"`
+	// NoStopClause removes the STOP clause of the message, only allowed for
+	// non commercial messages
+	NoStopClause bool `json:"noStopClause,omitempty"`
+	// Priority is "high", "medium", "low" or "veryLow"
+	Priority string `json:"priority,omitempty"`
+	// Coding is "7bits" or "8bits", for unicode messages
+	Coding string `json:"coding,omitempty"`
+	// DifferedPeriod delays the sending, in minutes
+	DifferedPeriod int `json:"differedPeriod,omitempty"`
+	// ValidityPeriod is the maximum delivery delay, in minutes
+	ValidityPeriod int `json:"validityPeriod,omitempty"`
+	// Tag is an identifier to filter the sent SMS, see OutgoingFilter
+	Tag string `json:"tag,omitempty"`
+}
+
+// SendResult represents the result of Send
+type SendResult struct {
+	IDs                 []int64  `json:"ids"`
+	ValidReceivers      []string `json:"validReceivers"`
+	InvalidReceivers    []string `json:"invalidReceivers"`
+	TotalCreditsRemoved float64  `json:"totalCreditsRemoved"`
+}
+
+// PendingJob represents an SMS waiting to be sent.
+// Visit https://api.ovh.com/console/#/sms/%7BserviceName%7D/jobs/%7Bid%7D#GET for the full definition
+type PendingJob struct {
+	ID               int64     `json:"id"`
+	Message          string    `json:"message"`
+	Receiver         string    `json:"receiver"`
+	Sender           string    `json:"sender"`
+	CreationDatetime time.Time `json:"creationDatetime"`
+	DifferedDelivery int       `json:"differedDelivery"`
+	Credits          float64   `json:"credits"`
+	Ptt              int       `json:"ptt"`
+}
+
+// Outgoing represents a sent SMS.
+// Visit https://api.ovh.com/console/#/sms/%7BserviceName%7D/outgoing/%7Bid%7D#GET for the full definition
+type Outgoing struct {
+	ID               int64     `json:"id"`
+	Message          string    `json:"message"`
+	Receiver         string    `json:"receiver"`
+	Sender           string    `json:"sender"`
+	Tag              string    `json:"tag"`
+	CreationDatetime time.Time `json:"creationDatetime"`
+	Credits          float64   `json:"credits"`
+	// DeliveryReceipt is 1 when the SMS was delivered
+	DeliveryReceipt int `json:"deliveryReceipt"`
+	Ptt             int `json:"ptt"`
+}
+
+// OutgoingFilter filters the sent SMS listed by Outgoings. Empty fields are
+// not used as filters.
+type OutgoingFilter struct {
+	Sender   string
+	Receiver string
+	Tag      string
+	From     time.Time
+	To       time.Time
+}
+
+// Send sends an SMS, with POST /sms/{serviceName}/jobs. The receivers
+// rejected by the API are listed in the result.
+func (c *Client) Send(ctx context.Context, serviceName string, job *Job) (*SendResult, error) {
+	result := &SendResult{}
+	if err := c.client.PostWithContext(ctx, accountPath(serviceName, "/jobs"), job, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Jobs lists the IDs of the SMS waiting to be sent, with
+// GET /sms/{serviceName}/jobs
+func (c *Client) Jobs(ctx context.Context, serviceName string) ([]int64, error) {
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, accountPath(serviceName, "/jobs"), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Job returns an SMS waiting to be sent, with GET /sms/{serviceName}/jobs/{id}
+func (c *Client) Job(ctx context.Context, serviceName string, id int64) (*PendingJob, error) {
+	job := &PendingJob{}
+	if err := c.client.GetWithContext(ctx, accountPath(serviceName, "/jobs/%d", id), job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// CancelJob cancels an SMS waiting to be sent, with
+// DELETE /sms/{serviceName}/jobs/{id}
+func (c *Client) CancelJob(ctx context.Context, serviceName string, id int64) error {
+	return c.client.DeleteWithContext(ctx, accountPath(serviceName, "/jobs/%d", id), nil)
+}
+
+// Outgoings lists the IDs of the sent SMS, with GET /sms/{serviceName}/outgoing.
+// The filter may be nil.
+func (c *Client) Outgoings(ctx context.Context, serviceName string, filter *OutgoingFilter) ([]int64, error) {
+	query := url.Values{}
+	if filter != nil {
+		if filter.Sender != "" {
+			query.Set("sender", filter.Sender)
+		}
+		if filter.Receiver != "" {
+			query.Set("receiver", filter.Receiver)
+		}
+		if filter.Tag != "" {
+			query.Set("tag", filter.Tag)
+		}
+		if !filter.From.IsZero() {
+			query.Set("creationDatetime.from", filter.From.Format(time.RFC3339))
+		}
+		if !filter.To.IsZero() {
+			query.Set("creationDatetime.to", filter.To.Format(time.RFC3339))
+		}
+	}
+	path := accountPath(serviceName, "/outgoing")
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	ids := []int64{}
+	if err := c.client.GetWithContext(ctx, path, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Outgoing returns a sent SMS, with GET /sms/{serviceName}/outgoing/{id}
+func (c *Client) Outgoing(ctx context.Context, serviceName string, id int64) (*Outgoing, error) {
+	outgoing := &Outgoing{}
+	if err := c.client.GetWithContext(ctx, accountPath(serviceName, "/outgoing/%d", id), outgoing); err != nil {
+		return nil, err
+	}
+	return outgoing, nil
+}
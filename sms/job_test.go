@@ -0,0 +1,77 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSend(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("POST", "/sms/sms-ab12345-1/jobs", http.StatusOK, `{"ids": [1001], "validReceivers": ["+33601020304"], "invalidReceivers": ["+3360"], "totalCreditsRemoved": 1}`)
+	server.Handle("GET", "/sms/sms-ab12345-1/jobs", http.StatusOK, `[1001]`)
+	server.Handle("GET", "/sms/sms-ab12345-1/jobs/1001", http.StatusOK, `{"id": 1001, "message": "Disk full", "receiver": "+33601020304", "sender": "ALERTS", "creationDatetime": "2020-01-01T10:00:00+01:00", "differedDelivery": 10, "credits": 1, "ptt": 1}`)
+	server.Handle("DELETE", "/sms/sms-ab12345-1/jobs/1001", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	result, err := client.Send(ctx, "sms-ab12345-1", &Job{
+		Message:        "Disk full",
+		Receivers:      []string{"+33601020304", "+3360"},
+		Sender:         "ALERTS",
+		NoStopClause:   true,
+		DifferedPeriod: 10,
+	})
+	if err != nil {
+		t.Fatalf("Send should not return an error. Got %v", err)
+	}
+	ids, err := client.Jobs(ctx, "sms-ab12345-1")
+	if err != nil {
+		t.Fatalf("Jobs should not return an error. Got %v", err)
+	}
+	job, err := client.Job(ctx, "sms-ab12345-1", ids[0])
+	if err != nil {
+		t.Fatalf("Job should not return an error. Got %v", err)
+	}
+	if err := client.CancelJob(ctx, "sms-ab12345-1", ids[0]); err != nil {
+		t.Fatalf("CancelJob should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if result.IDs[0] != 1001 || result.InvalidReceivers[0] != "+3360" || result.TotalCreditsRemoved != 1 {
+		t.Fatalf("Send should return the result. Got %+v", result)
+	}
+	if job.Receiver != "+33601020304" || job.DifferedDelivery != 10 {
+		t.Fatalf("Job should be decoded. Got %+v", job)
+	}
+	if body := string(server.Requests()[0].Body); body != `{"message":"Disk full","receivers":["+33601020304","+3360"],"sender":"ALERTS","noStopClause":true,"differedPeriod":10}` {
+		t.Fatalf("Send should send the job. Got %s", body)
+	}
+}
+
+func TestOutgoings(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/sms/sms-ab12345-1/outgoing?creationDatetime.from=2020-01-01T00%3A00%3A00Z&tag=alerts", http.StatusOK, `[2001]`)
+	server.Handle("GET", "/sms/sms-ab12345-1/outgoing/2001", http.StatusOK, `{"id": 2001, "message": "Disk full", "receiver": "+33601020304", "sender": "ALERTS", "tag": "alerts", "creationDatetime": "2020-01-01T10:00:00+01:00", "credits": 1, "deliveryReceipt": 1, "ptt": 13}`)
+	ctx := context.Background()
+
+	// Test
+	ids, err := client.Outgoings(ctx, "sms-ab12345-1", &OutgoingFilter{Tag: "alerts", From: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Outgoings should not return an error. Got %v", err)
+	}
+	outgoing, err := client.Outgoing(ctx, "sms-ab12345-1", ids[0])
+	if err != nil {
+		t.Fatalf("Outgoing should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if outgoing.Tag != "alerts" || outgoing.DeliveryReceipt != 1 || outgoing.CreationDatetime.IsZero() {
+		t.Fatalf("Outgoing should be decoded. Got %+v", outgoing)
+	}
+}
@@ -0,0 +1,55 @@
+package sms
+
+import (
+	"context"
+	"net/url"
+)
+
+// Sender statuses
+const (
+	SenderStatusEnable            = "enable"
+	SenderStatusDisable           = "disable"
+	SenderStatusWaitingValidation = "waitingValidation"
+	SenderStatusRefused           = "refused"
+)
+
+// Sender represents a sender of an SMS account.
+// Visit https://api.ovh.com/console/#/sms/%7BserviceName%7D/senders/%7Bsender%7D#GET for the full definition
+type Sender struct {
+	Sender      string `json:"sender"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Type        string `json:"type"`
+	Comment     string `json:"comment"`
+}
+
+// Senders lists the senders of an SMS account, with GET /sms/{serviceName}/senders
+func (c *Client) Senders(ctx context.Context, serviceName string) ([]string, error) {
+	senders := []string{}
+	if err := c.client.GetWithContext(ctx, accountPath(serviceName, "/senders"), &senders); err != nil {
+		return nil, err
+	}
+	return senders, nil
+}
+
+// Sender returns a sender, with GET /sms/{serviceName}/senders/{sender}
+func (c *Client) Sender(ctx context.Context, serviceName, sender string) (*Sender, error) {
+	res := &Sender{}
+	if err := c.client.GetWithContext(ctx, accountPath(serviceName, "/senders/%s", url.PathEscape(sender)), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateSender requests a new sender, with POST /sms/{serviceName}/senders.
+// Alphanumeric senders must be validated by OVH before being used, "reason"
+// explains their use.
+func (c *Client) CreateSender(ctx context.Context, serviceName, sender, description, reason string) error {
+	body := map[string]string{"sender": sender, "description": description, "reason": reason}
+	return c.client.PostWithContext(ctx, accountPath(serviceName, "/senders"), body, nil)
+}
+
+// DeleteSender deletes a sender, with DELETE /sms/{serviceName}/senders/{sender}
+func (c *Client) DeleteSender(ctx context.Context, serviceName, sender string) error {
+	return c.client.DeleteWithContext(ctx, accountPath(serviceName, "/senders/%s", url.PathEscape(sender)), nil)
+}
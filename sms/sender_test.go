@@ -0,0 +1,42 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSenders(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/sms/sms-ab12345-1/senders", http.StatusOK, `["ALERTS"]`)
+	server.Handle("GET", "/sms/sms-ab12345-1/senders/ALERTS", http.StatusOK, `{"sender": "ALERTS", "description": "Monitoring", "status": "enable", "type": "alpha", "comment": ""}`)
+	server.Handle("POST", "/sms/sms-ab12345-1/senders", http.StatusOK, nil)
+	server.Handle("DELETE", "/sms/sms-ab12345-1/senders/ALERTS", http.StatusOK, nil)
+	ctx := context.Background()
+
+	// Test
+	if err := client.CreateSender(ctx, "sms-ab12345-1", "ALERTS", "Monitoring", "Alerts of our monitoring"); err != nil {
+		t.Fatalf("CreateSender should not return an error. Got %v", err)
+	}
+	senders, err := client.Senders(ctx, "sms-ab12345-1")
+	if err != nil {
+		t.Fatalf("Senders should not return an error. Got %v", err)
+	}
+	sender, err := client.Sender(ctx, "sms-ab12345-1", senders[0])
+	if err != nil {
+		t.Fatalf("Sender should not return an error. Got %v", err)
+	}
+	if err := client.DeleteSender(ctx, "sms-ab12345-1", "ALERTS"); err != nil {
+		t.Fatalf("DeleteSender should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if sender.Status != SenderStatusEnable || sender.Type != "alpha" {
+		t.Fatalf("Sender should be decoded. Got %+v", sender)
+	}
+	if body := string(server.Requests()[0].Body); body != `{"description":"Monitoring","reason":"Alerts of our monitoring","sender":"ALERTS"}` {
+		t.Fatalf("CreateSender should send the sender. Got %s", body)
+	}
+}
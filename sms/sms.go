@@ -0,0 +1,73 @@
+// Package sms provides typed helpers for the OVH SMS API, under /sms:
+// sending SMS, checking the credits of an account, listing the sent SMS and
+// managing the senders.
+//
+// Sending an alert is:
+//
+//	result, err := sms.New(client).Send(ctx, "sms-ab12345-1", &sms.Job{
+//		Message:   "Disk full on ns1.example.net",
+//		Receivers: []string{"+33601020304"},
+//		Sender:    "ALERTS",
+//	})
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// Account represents an SMS account.
+// Visit https://api.ovh.com/console/#/sms/%7BserviceName%7D#GET for the full definition
+type Account struct {
+	Name               string  `json:"name"`
+	Description        string  `json:"description"`
+	Status             string  `json:"status"`
+	CreditsLeft        float64 `json:"creditsLeft"`
+	CreditsHoldByQuota float64 `json:"creditsHoldByQuota"`
+	Channel            string  `json:"channel"`
+}
+
+// Client gives access to the /sms routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns an SMS client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// accountPath returns the path of a route of an SMS account
+func accountPath(serviceName, format string, args ...interface{}) string {
+	return fmt.Sprintf("/sms/%s", url.PathEscape(serviceName)) + fmt.Sprintf(format, args...)
+}
+
+// Accounts lists the names of the SMS accounts, with GET /sms
+func (c *Client) Accounts(ctx context.Context) ([]string, error) {
+	names := []string{}
+	if err := c.client.GetWithContext(ctx, "/sms", &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Account returns an SMS account, with GET /sms/{serviceName}
+func (c *Client) Account(ctx context.Context, serviceName string) (*Account, error) {
+	account := &Account{}
+	if err := c.client.GetWithContext(ctx, accountPath(serviceName, ""), account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Credits returns the credits left on an SMS account, with GET /sms/{serviceName}
+func (c *Client) Credits(ctx context.Context, serviceName string) (float64, error) {
+	account, err := c.Account(ctx, serviceName)
+	if err != nil {
+		return 0, err
+	}
+	return account.CreditsLeft, nil
+}
@@ -0,0 +1,49 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestAccount(t *testing.T) {
+	// Init test
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/sms", http.StatusOK, `["sms-ab12345-1"]`)
+	server.Handle("GET", "/sms/sms-ab12345-1", http.StatusOK, `{"name": "sms-ab12345-1", "description": "Alerts", "status": "enable", "creditsLeft": 42.5, "creditsHoldByQuota": 0, "channel": "both"}`)
+	ctx := context.Background()
+
+	// Test
+	names, err := client.Accounts(ctx)
+	if err != nil {
+		t.Fatalf("Accounts should not return an error. Got %v", err)
+	}
+	account, err := client.Account(ctx, names[0])
+	if err != nil {
+		t.Fatalf("Account should not return an error. Got %v", err)
+	}
+	credits, err := client.Credits(ctx, names[0])
+	if err != nil {
+		t.Fatalf("Credits should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if account.Description != "Alerts" || account.Status != "enable" {
+		t.Fatalf("Account should be decoded. Got %+v", account)
+	}
+	if credits != 42.5 {
+		t.Fatalf("Credits should return the credits left. Got %v", credits)
+	}
+}
@@ -0,0 +1,136 @@
+// Package vrack provides typed helpers for the OVH vRack API, under /vrack:
+// attaching dedicated servers and Public Cloud projects to private networks.
+package vrack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// VRack represents a vRack.
+// Visit https://api.ovh.com/console/#/vrack/%7BserviceName%7D#GET for the full definition
+type VRack struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Task represents an asynchronous operation on a vRack, like an attachment.
+// Visit https://api.ovh.com/console/#/vrack/%7BserviceName%7D/task/%7BtaskId%7D#GET for the full definition
+type Task struct {
+	ID           int64     `json:"id"`
+	Function     string    `json:"function"`
+	Status       string    `json:"status"`
+	ServiceName  string    `json:"serviceName"`
+	TargetDomain string    `json:"targetDomain"`
+	OrderID      int64     `json:"orderId"`
+	TodoDate     time.Time `json:"todoDate"`
+	LastUpdate   time.Time `json:"lastUpdate"`
+}
+
+// Client gives access to the /vrack routes
+type Client struct {
+	client *ovh.Client
+}
+
+// New returns a vRack client using the given OVH API client
+func New(client *ovh.Client) *Client {
+	return &Client{client: client}
+}
+
+// vrackPath returns the path of a route of a vRack
+func vrackPath(serviceName, format string, args ...interface{}) string {
+	return fmt.Sprintf("/vrack/%s", url.PathEscape(serviceName)) + fmt.Sprintf(format, args...)
+}
+
+// VRacks lists the names of the vRacks, with GET /vrack
+func (c *Client) VRacks(ctx context.Context) ([]string, error) {
+	names := []string{}
+	if err := c.client.GetWithContext(ctx, "/vrack", &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// VRack returns a vRack, with GET /vrack/{serviceName}
+func (c *Client) VRack(ctx context.Context, serviceName string) (*VRack, error) {
+	vrack := &VRack{}
+	if err := c.client.GetWithContext(ctx, vrackPath(serviceName, ""), vrack); err != nil {
+		return nil, err
+	}
+	return vrack, nil
+}
+
+// Task returns a task of a vRack, with GET /vrack/{serviceName}/task/{taskId}
+func (c *Client) Task(ctx context.Context, serviceName string, taskID int64) (*Task, error) {
+	task := &Task{}
+	if err := c.client.GetWithContext(ctx, vrackPath(serviceName, "/task/%d", taskID), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// DedicatedServers lists the dedicated servers attached to a vRack, with
+// GET /vrack/{serviceName}/dedicatedServer
+func (c *Client) DedicatedServers(ctx context.Context, serviceName string) ([]string, error) {
+	names := []string{}
+	if err := c.client.GetWithContext(ctx, vrackPath(serviceName, "/dedicatedServer"), &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// AttachDedicatedServer attaches a dedicated server to a vRack, with
+// POST /vrack/{serviceName}/dedicatedServer
+func (c *Client) AttachDedicatedServer(ctx context.Context, serviceName, server string) (*Task, error) {
+	task := &Task{}
+	body := map[string]string{"dedicatedServer": server}
+	if err := c.client.PostWithContext(ctx, vrackPath(serviceName, "/dedicatedServer"), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// DetachDedicatedServer detaches a dedicated server from a vRack, with
+// DELETE /vrack/{serviceName}/dedicatedServer/{dedicatedServer}
+func (c *Client) DetachDedicatedServer(ctx context.Context, serviceName, server string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.DeleteWithContext(ctx, vrackPath(serviceName, "/dedicatedServer/%s", url.PathEscape(server)), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CloudProjects lists the Public Cloud projects attached to a vRack, with
+// GET /vrack/{serviceName}/cloudProject
+func (c *Client) CloudProjects(ctx context.Context, serviceName string) ([]string, error) {
+	projects := []string{}
+	if err := c.client.GetWithContext(ctx, vrackPath(serviceName, "/cloudProject"), &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// AttachCloudProject attaches a Public Cloud project to a vRack, with
+// POST /vrack/{serviceName}/cloudProject
+func (c *Client) AttachCloudProject(ctx context.Context, serviceName, project string) (*Task, error) {
+	task := &Task{}
+	body := map[string]string{"project": project}
+	if err := c.client.PostWithContext(ctx, vrackPath(serviceName, "/cloudProject"), body, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// DetachCloudProject detaches a Public Cloud project from a vRack, with
+// DELETE /vrack/{serviceName}/cloudProject/{project}
+func (c *Client) DetachCloudProject(ctx context.Context, serviceName, project string) (*Task, error) {
+	task := &Task{}
+	if err := c.client.DeleteWithContext(ctx, vrackPath(serviceName, "/cloudProject/%s", url.PathEscape(project)), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
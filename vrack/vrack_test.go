@@ -0,0 +1,95 @@
+package vrack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ovh/go-ovh/govhtest"
+)
+
+func initServer(t *testing.T) (*govhtest.Server, *Client) {
+	server := govhtest.NewServer()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Client should not fail. Got %v", err)
+	}
+	return server, New(client)
+}
+
+func TestVRack(t *testing.T) {
+	// Init test
+	task := `{"id": 5, "function": "addDedicatedServerToVrack", "status": "init", "serviceName": "pn-1234", "targetDomain": "ns1.example.net", "orderId": null, "todoDate": "2020-01-01T10:00:00+01:00", "lastUpdate": "2020-01-01T10:00:00+01:00"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/vrack", http.StatusOK, `["pn-1234"]`)
+	server.Handle("GET", "/vrack/pn-1234", http.StatusOK, `{"name": "backend", "description": "Backend network"}`)
+	server.Handle("GET", "/vrack/pn-1234/task/5", http.StatusOK, task)
+	ctx := context.Background()
+
+	// Test
+	names, err := client.VRacks(ctx)
+	if err != nil {
+		t.Fatalf("VRacks should not return an error. Got %v", err)
+	}
+	vrack, err := client.VRack(ctx, names[0])
+	if err != nil {
+		t.Fatalf("VRack should not return an error. Got %v", err)
+	}
+	fetched, err := client.Task(ctx, names[0], 5)
+	if err != nil {
+		t.Fatalf("Task should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if vrack.Name != "backend" || vrack.Description != "Backend network" {
+		t.Fatalf("VRack should decode the response. Got %+v", vrack)
+	}
+	if fetched.TargetDomain != "ns1.example.net" || fetched.OrderID != 0 {
+		t.Fatalf("Task should decode the response. Got %+v", fetched)
+	}
+}
+
+func TestVRackAttachments(t *testing.T) {
+	// Init test
+	task := `{"id": 5, "function": "addDedicatedServerToVrack", "status": "init"}`
+	server, client := initServer(t)
+	defer server.Close()
+	server.Handle("GET", "/vrack/pn-1234/dedicatedServer", http.StatusOK, `["ns1.example.net"]`)
+	server.Handle("POST", "/vrack/pn-1234/dedicatedServer", http.StatusOK, task)
+	server.Handle("DELETE", "/vrack/pn-1234/dedicatedServer/ns1.example.net", http.StatusOK, task)
+	server.Handle("GET", "/vrack/pn-1234/cloudProject", http.StatusOK, `["abc123"]`)
+	server.Handle("POST", "/vrack/pn-1234/cloudProject", http.StatusOK, task)
+	server.Handle("DELETE", "/vrack/pn-1234/cloudProject/abc123", http.StatusOK, task)
+	ctx := context.Background()
+
+	// Test
+	servers, err := client.DedicatedServers(ctx, "pn-1234")
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("DedicatedServers should list the servers. Got %v, %v", servers, err)
+	}
+	if _, err := client.AttachDedicatedServer(ctx, "pn-1234", "ns1.example.net"); err != nil {
+		t.Fatalf("AttachDedicatedServer should not return an error. Got %v", err)
+	}
+	if _, err := client.DetachDedicatedServer(ctx, "pn-1234", "ns1.example.net"); err != nil {
+		t.Fatalf("DetachDedicatedServer should not return an error. Got %v", err)
+	}
+	projects, err := client.CloudProjects(ctx, "pn-1234")
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("CloudProjects should list the projects. Got %v, %v", projects, err)
+	}
+	if _, err := client.AttachCloudProject(ctx, "pn-1234", "abc123"); err != nil {
+		t.Fatalf("AttachCloudProject should not return an error. Got %v", err)
+	}
+	if _, err := client.DetachCloudProject(ctx, "pn-1234", "abc123"); err != nil {
+		t.Fatalf("DetachCloudProject should not return an error. Got %v", err)
+	}
+
+	// Validate
+	if request, _ := server.LastRequest("POST", "/vrack/pn-1234/dedicatedServer"); string(request.Body) != `{"dedicatedServer":"ns1.example.net"}` {
+		t.Fatalf("AttachDedicatedServer should send the server. Got %s", request.Body)
+	}
+	if request, _ := server.LastRequest("POST", "/vrack/pn-1234/cloudProject"); string(request.Body) != `{"project":"abc123"}` {
+		t.Fatalf("AttachCloudProject should send the project. Got %s", request.Body)
+	}
+}